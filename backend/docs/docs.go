@@ -463,6 +463,286 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/analytics/correlation": {
+            "get": {
+                "description": "Aligns the candles of several symbols on their open times, leaving out the times one of them has no candle at, and returns the Pearson and Spearman correlations of their log returns with the beta of each symbol against a benchmark. Results are cached for a minute.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get a correlation matrix",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTCUSDT,ETHUSDT,SOLUSDT\"",
+                        "description": "Comma separated symbols, from 2 to 20",
+                        "name": "symbols",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1d\"",
+                        "description": "Interval of the candles, 1d by default, any count of m, h, d, w or M such as 10m or 2w",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 90,
+                        "description": "Number of returns, 90 by default and at most 1000",
+                        "name": "lookback",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Symbol the betas are measured against, BTC in the quote asset of the first symbol by default",
+                        "name": "benchmark",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Open time in milliseconds of the last candle, the latest candle by default",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"spot\"",
+                        "description": "Market: futures (default) or spot",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with the correlation matrices and betas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseCorrelation"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing symbols or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found or not enough common candles",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/analytics/volatility": {
+            "get": {
+                "description": "Computes over the last window candles of a symbol the annualized realized volatility (close to close, Parkinson and Garman-Klass), the average true range, the maximum drawdown, the total return and rolling returns. Volatilities, returns and drawdown are in percent.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Get volatility and risk metrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1d\"",
+                        "description": "Interval of the candles, 1d by default, any count of m, h, d, w or M such as 10m or 2w",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 30,
+                        "description": "Number of candles, 30 by default and at most 1000",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 14,
+                        "description": "Period of the average true range, 14 by default",
+                        "name": "atrPeriod",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 7,
+                        "description": "Number of candles of each rolling return, 7 by default and below the window",
+                        "name": "returnPeriod",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Open time in milliseconds of the last candle, the latest candle by default",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"spot\"",
+                        "description": "Market: futures (default) or spot",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with the volatility and risk metrics",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseVolatility"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing symbol or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found or not enough history",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/arbitrage": {
+            "get": {
+                "description": "Quotes the best bid and ask of an asset on several exchanges, converts them into one currency and reports where to buy and sell, the gross spread and the spread after the taker fee of both venues",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Arbitrage"
+                ],
+                "summary": "Get cross-exchange arbitrage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTC\"",
+                        "description": "Base asset (e.g., BTC)",
+                        "name": "asset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"spot\"",
+                        "description": "Market: spot (default) or futures",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance,okx,coinbase\"",
+                        "description": "Comma separated exchanges among binance, okx, bybit and coinbase, all of them or ARBITRAGE_EXCHANGES by default",
+                        "name": "exchanges",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"USDT\"",
+                        "description": "Quote asset on every exchange, USD on Coinbase and USDT elsewhere by default",
+                        "name": "quote",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"USD\"",
+                        "description": "Fiat currency or USD stablecoin prices are compared in, USD stablecoins are taken at par",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance=0.00075,coinbase=0.004\"",
+                        "description": "Taker fees overriding the defaults and ARBITRAGE_TAKER_FEES",
+                        "name": "fees",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with the venues and spreads",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseArbitrage"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing asset or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found on any exchange",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/auth/forgot-password": {
             "post": {
                 "description": "This API allows user can forgotPassword by sends a password reset OTP to the user's email address.",
@@ -819,38 +1099,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/funding-rate": {
+        "/api/v1/depth": {
             "get": {
-                "description": "Retrieves current funding rate information for a specified trading pair from Binance Futures",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves the best bids and asks of a trading pair with spread and mid price from Binance or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Funding Rate"
+                    "Depth"
                 ],
-                "summary": "Get real-time funding rate data",
+                "summary": "Get order book depth",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"QTUMUSDT\"",
-                        "description": "Trading pair symbol (e.g., QTUMUSDT)",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
                         "name": "symbol",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"spot\"",
+                        "description": "Market: spot (default) or futures",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of levels of each side, 20 by default and at most 1000, exchanges may return fewer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response with funding rate data",
+                        "description": "Bids from the highest price and asks from the lowest",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_price-service_models.ResponseFundingRate"
+                            "$ref": "#/definitions/models.ResponseDepth"
                         }
                     },
                     "400": {
-                        "description": "Missing symbol",
+                        "description": "Invalid symbol or request parameters",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
@@ -862,7 +1159,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to fetch depth",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
@@ -870,35 +1167,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/future-price": {
+        "/api/v1/funding-rate": {
             "get": {
-                "description": "Retrieves current future price information for a specified trading pair from Binance Futures",
+                "description": "Retrieves current funding rate information for a specified trading pair from Binance Futures or the requested exchange",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Future price"
+                    "Funding Rate"
                 ],
-                "summary": "Get real-time future price data",
+                "summary": "Get real-time funding rate data",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"BTCUSDT\"",
-                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "example": "\"QTUMUSDT\"",
+                        "description": "Trading pair symbol (e.g., QTUMUSDT)",
                         "name": "symbol",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response with future price data",
+                        "description": "Successful response with funding rate data",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseFuturePrice"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_price-service_models.ResponseFundingRate"
                         }
                     },
                     "400": {
-                        "description": "Invalid symbol or request parameters",
+                        "description": "Missing symbol",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
@@ -910,7 +1217,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch price",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
@@ -918,197 +1225,289 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/payment/momo-callback": {
-            "post": {
-                "description": "Handles callback from MoMo after payment is made",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/funding-rate/history": {
+            "get": {
+                "description": "Retrieves the settled funding rates of a futures symbol, oldest first, with their mean, minimum, maximum, cumulative and annualized rate and the count of negative periods, from Binance Futures or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Funding Rate"
                 ],
-                "summary": "MoMo Payment Callback",
+                "summary": "Get funding rate history with statistics",
                 "parameters": [
                     {
-                        "description": "MoMo Callback Data",
-                        "name": "callbackData",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "type": "string",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1732147200000,
+                        "description": "Funding time in milliseconds of the first rate, endTime minus days by default",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Funding time in milliseconds of the last rate, the cursor of a previous page loads older rates",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 30,
+                        "description": "Days of rates before endTime when startTime is not set, 30 by default and at most 365",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rates, the latest of the range are kept, 1000 by default and at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx or bybit",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "MoMo callback success response",
+                        "description": "Rates oldest first with their statistics",
                         "schema": {
-                            "$ref": "#/definitions/models.MoMoResponse"
+                            "$ref": "#/definitions/models.ResponseFundingRateHistory"
                         }
                     },
                     "400": {
-                        "description": "Invalid JSON payload",
+                        "description": "Missing symbol or invalid parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
-                    "401": {
-                        "description": "Invalid signature",
+                    "404": {
+                        "description": "Symbol not found",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/payment/status": {
-            "post": {
-                "security": [
+        "/api/v1/funding-rate/screener": {
+            "get": {
+                "description": "Ranks the futures symbols of Binance or the requested exchange by their current funding rate or their average rate over the last days.\nAverages load the funding rate history of every ranked symbol, they are cached for 30 minutes.\nThe average sort ranks the 50 symbols, or the limit when it is higher, with the highest current rates in the requested order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Funding Rate"
+                ],
+                "summary": "Screen funding rates across symbols",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "example": "\"current\"",
+                        "description": "Rank by the current (default) or the average funding rate",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"desc\"",
+                        "description": "desc (default) for the highest rates first, asc for the lowest",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 7,
+                        "description": "Days of the average funding rate, 7 by default and at most 30",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of symbols, 20 by default and at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"BTCUSDT,ETHUSDT\"",
+                        "description": "Comma separated symbols to rank, every futures symbol by default",
+                        "name": "symbols",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default) or bybit",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
-                "description": "Queries the payment status and upgrades the user's VIP level if the payment is successful based on the order details",
-                "consumes": [
-                    "application/json"
-                ],
+                "responses": {
+                    "200": {
+                        "description": "Symbols ranked by funding rate",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseFundingScreener"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/future-price": {
+            "get": {
+                "description": "Retrieves current future price information for a specified trading pair from Binance Futures or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Future price"
                 ],
-                "summary": "Check payment status and upgrade user's VIP level if successful",
+                "summary": "Get real-time future price data",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Order ID from the payment gateway",
-                        "name": "statusRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.QueryPaymentRequest"
-                        }
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"VND\"",
+                        "description": "Fiat currency to convert the price into (e.g., VND, USD, EUR), USD stablecoins are taken at par",
+                        "name": "convert",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payment confirmed and VIP level upgraded",
+                        "description": "Successful response with future price data",
                         "schema": {
-                            "$ref": "#/definitions/models.ReponseQueryPaymentRequest"
+                            "$ref": "#/definitions/models.ResponseFuturePrice"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID or missing parameters",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "You do not have permission to query this order",
+                        "description": "Invalid symbol or request parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Symbol not found",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during payment confirmation",
+                        "description": "Failed to fetch price",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/payment/vip-upgrade": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Creates a MoMo payment request for upgrading the user's VIP level, validates the token, and stores the order details in the database",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/future-price/batch": {
+            "get": {
+                "description": "Retrieves the mark price of every requested futures pair, or of every pair when none is given, with one request to Binance Futures or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payment"
+                    "Future price"
                 ],
-                "summary": "Initiate MoMo payment for VIP upgrade",
+                "summary": "Get future prices of several symbols",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "example": "\"BTCUSDT,ETHUSDT\"",
+                        "description": "Comma separated trading pairs, every pair when empty (e.g., BTCUSDT,ETHUSDT)",
+                        "name": "symbols",
+                        "in": "query"
                     },
                     {
-                        "description": "Payment request data",
-                        "name": "paymentRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateVIPPaymentRequest"
-                        }
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx or bybit",
+                        "name": "exchange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"VND\"",
+                        "description": "Fiat currency to convert the prices into (e.g., VND, USD, EUR), USD stablecoins are taken at par",
+                        "name": "convert",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payment URL and Order ID",
+                        "description": "Prices by symbol, unknown symbols and symbols whose quote cannot be converted are listed in errors",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateVIPPaymentReponse"
+                            "$ref": "#/definitions/models.ResponseFuturePriceBatch"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data or missing parameters",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized: Invalid or missing authorization token",
+                        "description": "Invalid request parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during payment creation",
+                        "description": "Failed to fetch prices",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/spot-price": {
+        "/api/v1/futures/premium": {
             "get": {
-                "description": "Retrieves current spot price information for a specified trading pair from Binance Spot",
+                "description": "Retrieves the mark, index and last price of a perpetual contract with its basis versus the spot price, as an amount, a percentage and annualized, and the last funding rate annualized, both at the funding interval of the contract, from Binance Futures or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Spot price"
+                    "Future price"
                 ],
-                "summary": "Get real-time spot price data",
+                "summary": "Get mark price, index price and basis",
                 "parameters": [
                     {
                         "type": "string",
@@ -1117,13 +1516,20 @@ const docTemplate = `{
                         "name": "symbol",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx or bybit",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response with spot price data",
+                        "description": "Successful response with premium data",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSpotPrice"
+                            "$ref": "#/definitions/models.ResponsePremium"
                         }
                     },
                     "400": {
@@ -1139,7 +1545,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch price",
+                        "description": "Failed to fetch prices",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
@@ -1147,540 +1553,441 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/user/me": {
+        "/api/v1/liquidations": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "This endpoint fetches details of the currently authenticated user using the JWT token provided in the Authorization header.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves the recorded liquidations of Binance futures, oldest first, of every symbol or of one, with a notional of at least minNotional. Side is the side of the liquidated position.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Liquidation"
                 ],
-                "summary": "Retrieve current user information",
+                "summary": "Get recorded liquidations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer \u003cJWT Token\u003e",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT), every symbol when empty",
+                        "name": "symbol",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "example": 10000,
+                        "description": "Minimum notional in the quote asset",
+                        "name": "minNotional",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time in milliseconds of the first liquidation",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time in milliseconds of the last liquidation, the cursor of a previous page loads older liquidations",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of liquidations, the latest of the range are kept, 100 by default and at most 1000",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success: Returns the user's details",
+                        "description": "Liquidations oldest first",
                         "schema": {
-                            "$ref": "#/definitions/models.UserDTO"
+                            "$ref": "#/definitions/models.ResponseLiquidations"
                         }
                     },
                     "400": {
-                        "description": "Bad Request: Invalid user ID",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized: invalid token",
+                        "description": "Invalid parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
-                    "404": {
-                        "description": "Not Found: User not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Liquidations are not recorded",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "This endpoint allows the user to update their profile information such as name, username, phone, avatar, bio, and date of birth.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/liquidations/stats": {
+            "get": {
+                "description": "Sums the notional and count of the liquidated long and short positions of Binance futures over the last 1h, 4h and 24h, per symbol by 24h notional and for every symbol together. Since is the first recorded liquidation, windows reaching further back are incomplete.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Liquidation"
                 ],
-                "summary": "Update current user profile",
+                "summary": "Get liquidation stats",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "\"Bearer {token}\"",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Trading pair symbols (e.g., BTCUSDT,ETHUSDT), every liquidated symbol when empty",
+                        "name": "symbols",
+                        "in": "query"
                     },
                     {
-                        "description": "Update UserProfile Request body",
-                        "name": "UpdateUserProfileRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateUserProfileRequest"
-                        }
+                        "type": "integer",
+                        "description": "Number of symbols, 20 by default and at most 200",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Liquidations per window",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResponse"
+                            "$ref": "#/definitions/models.ResponseLiquidationStats"
                         }
                     },
                     "400": {
-                        "description": "Bad Request: Invalid input",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized: Authorization header required or invalid token",
+                        "description": "Invalid parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found: User not found",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Liquidations are not recorded",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "This endpoint allows the user to Delete the account of the currently authenticated user based on the access token.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/long-short-ratio": {
+            "get": {
+                "description": "Retrieves a long/short ratio series of a perpetual contract, oldest first, with the long and short shares from 0 to 1: the accounts (top-account) or positions (top-position) of the top traders, or every account (global-account), from Binance Futures or the requested exchange. Bybit only has global-account.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Open Interest"
                 ],
-                "summary": "Delete current user account",
+                "summary": "Get long/short ratio history",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "\"Bearer {token}\"",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"global-account\"",
+                        "description": "Ratio: global-account (default), top-account or top-position",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1h\"",
+                        "description": "Period: 5m, 15m, 30m, 1h (default), 2h, 4h, 6h, 12h or 1d, Bybit has no 2h, 6h and 12h",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time in milliseconds of the first point",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Time in milliseconds of the last point, the cursor of a previous page loads older points",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of points, the latest of the range are kept, 30 by default and at most 500 (100 on OKX)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx or bybit",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Ratios oldest first",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResponse"
+                            "$ref": "#/definitions/models.ResponseLongShortRatio"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized or token is invalid",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Missing symbol or invalid parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/user/me/change_email": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "This endpoint allows an authenticated user to change their email address.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/market-overview": {
+            "get": {
+                "description": "Retrieves the total crypto market cap and 24h volume, the BTC and ETH dominance, the largest coins by market cap and the best and worst 24h performers among the 100 largest, from CoinGecko. The overview is cached and refreshed every 5 minutes by default.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Market Stats"
                 ],
-                "summary": "Change user email",
+                "summary": "Get market overview",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "\"Bearer {token}\"",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Change Mail Request body",
-                        "name": "ChangeMailRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ChangeMailRequest"
-                        }
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Length of each rank list, from 1 to 100 (default 10)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successful response with the market overview",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResponse"
+                            "$ref": "#/definitions/models.ResponseMarketOverview"
                         }
                     },
                     "400": {
-                        "description": "Invalid email format",
+                        "description": "Invalid limit",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized or token is invalid",
+                    "429": {
+                        "description": "CoinGecko rate limit",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     },
-                    "409": {
-                        "description": "Email already exists",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "Market overview not available",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/user/me/change_password": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "This endpoint allows an authenticated user to change their password by providing the current and new passwords.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/market-stats": {
+            "get": {
+                "description": "Retrieves the CoinGecko market cap and 24h volume of a coin, given as a CoinGecko id (bitcoin), a ticker (BTC), a trading pair (BTCUSDT) or a name. A ticker shared by several coins resolves to the coin with the largest market cap. Snapshots are cached for 15 minutes.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Market Stats"
                 ],
-                "summary": "Change user password",
+                "summary": "Get market stats",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "\"Bearer {token}\"",
-                        "description": "Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "example": "\"BTC\"",
+                        "description": "CoinGecko id, ticker, trading pair or name (e.g., BTC)",
+                        "name": "symbol",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Change Password Request body",
-                        "name": "ChangePasswordRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ChangePasswordRequest"
-                        }
+                        "type": "string",
+                        "example": "\"VND\"",
+                        "description": "Fiat currency to convert the market cap and volume into (e.g., VND, EUR), USD by default",
+                        "name": "convert",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successful response with market stats",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResponse"
+                            "$ref": "#/definitions/models.FormatMarketCapResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request: Password must contain at least 8 characters, including letters, numbers, and special characters.",
+                        "description": "Missing symbol",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized: Current password is incorrect",
+                    "404": {
+                        "description": "Coin not found",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
                         }
                     },
-                    "404": {
-                        "description": "Not Found: User not found",
+                    "429": {
+                        "description": "CoinGecko rate limit",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/user/me/payment-history": {
+        "/api/v1/open-interest": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "This endpoint returns the payment history of the currently authenticated user using their JWT token.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves the current open interest of a perpetual contract in its base asset and valued at the mark price, from Binance Futures or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Open Interest"
                 ],
-                "summary": "Retrieve payment history",
+                "summary": "Get open interest",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer \u003cJWT Token\u003e",
-                        "name": "Authorization",
-                        "in": "header",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx or bybit",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Success: Returns the user's payment history",
+                        "description": "Successful response with open interest",
                         "schema": {
-                            "$ref": "#/definitions/models.PaymentHistoryUserResponse"
+                            "$ref": "#/definitions/models.ResponseOpenInterest"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized: Invalid or missing token",
+                    "400": {
+                        "description": "Missing symbol or invalid parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/users/{id}/alerts": {
+        "/api/v1/open-interest/history": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieve all alerts for a user by their ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves the open interest of a perpetual contract every period, oldest first, with times aligned to the klines of the same interval, from Binance Futures or the requested exchange. Binance keeps the last 30 days.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Open Interest"
                 ],
-                "summary": "Get user alerts",
+                "summary": "Get open interest history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of user alerts",
-                        "schema": {
-                            "$ref": "#/definitions/models.ResponseUserAlerts"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
-                        }
                     },
-                    "500": {
-                        "description": "Failed to retrieve alerts",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/users/{id}/alerts/notify": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Send a notification email to the user for their alerts",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Notify user of alerts",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "example": "\"1h\"",
+                        "description": "Period: 5m, 15m, 30m, 1h (default), 2h, 4h, 6h, 12h or 1d, Bybit has no 2h, 6h and 12h",
+                        "name": "period",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Notification sent successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.ResponseNotificationSent"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
-                        }
+                        "type": "integer",
+                        "description": "Time in milliseconds of the first point",
+                        "name": "startTime",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Failed to send notification",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/vip1/kline": {
-            "get": {
-                "description": "Fetches Kline data for a specific symbol and interval from Binance API",
-                "tags": [
-                    "Kline"
-                ],
-                "summary": "Get Kline data",
-                "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "description": "Time in milliseconds of the last point, the cursor of a previous page loads older points",
+                        "name": "endTime",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Symbol for which to fetch Kline data (e.g., BTCUSDT)",
-                        "name": "symbol",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Number of points, the latest of the range are kept, 30 by default and at most 500 (100 on OKX, 200 on Bybit)",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Interval for Kline data (e.g., 1m, 5m, 1h, 1d)",
-                        "name": "interval",
-                        "in": "query",
-                        "required": true
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx or bybit",
+                        "name": "exchange",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response with Kline data",
+                        "description": "Open interest oldest first",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseKline"
+                            "$ref": "#/definitions/models.ResponseOpenInterestHistory"
                         }
                     },
                     "400": {
-                        "description": "Missing Data",
+                        "description": "Missing symbol or invalid parameters",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponseInputMissing"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
                     "500": {
@@ -1692,14 +1999,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/vip2/alerts": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieve all alerts, optionally filter by type",
+        "/api/v1/payment/momo-callback": {
+            "post": {
+                "description": "Handles callback from MoMo after payment is made",
                 "consumes": [
                     "application/json"
                 ],
@@ -1707,55 +2009,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Payment"
                 ],
-                "summary": "Get all alerts",
+                "summary": "MoMo Payment Callback",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer Token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by alert type (e.g., new_listing, delisting)",
-                        "name": "type",
-                        "in": "query"
+                        "description": "MoMo Callback Data",
+                        "name": "callbackData",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of alerts",
+                        "description": "MoMo callback success response",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ResponseAlertList"
-                            }
+                            "$ref": "#/definitions/models.MoMoResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid JSON payload",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to retrieve alerts",
+                    "401": {
+                        "description": "Invalid signature",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/payment/status": {
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Create a new alert with the given details",
+                "description": "Queries the payment status and upgrades the user's VIP level if the payment is successful based on the order details",
                 "consumes": [
                     "application/json"
                 ],
@@ -1763,63 +2061,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Payment"
                 ],
-                "summary": "Create an alert",
+                "summary": "Check payment status and upgrade user's VIP level if successful",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
+                        "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Alert details",
-                        "name": "body",
+                        "description": "Order ID from the payment gateway",
+                        "name": "statusRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Alert"
+                            "$ref": "#/definitions/models.QueryPaymentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created alert",
+                    "200": {
+                        "description": "Payment confirmed and VIP level upgraded",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseAlertCreated"
+                            "$ref": "#/definitions/models.ReponseQueryPaymentRequest"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid order ID or missing parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "You do not have permission to query this order",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create alert",
+                        "description": "Internal server error during payment confirmation",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/vip2/alerts/symbol": {
+        "/api/v1/payment/vip-upgrade": {
             "post": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Set a new alert for symbols that are newly listed or delisted",
+                "description": "Creates a MoMo payment request for upgrading the user's VIP level, validates the token, and stores the order details in the database",
                 "consumes": [
                     "application/json"
                 ],
@@ -1827,243 +2131,324 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Payment"
                 ],
-                "summary": "Set an alert for new or delisted symbols",
+                "summary": "Initiate MoMo payment for VIP upgrade",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
+                        "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Alert details",
-                        "name": "body",
+                        "description": "Payment request data",
+                        "name": "paymentRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Alert"
+                            "$ref": "#/definitions/models.CreateVIPPaymentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created alert for symbol",
+                    "200": {
+                        "description": "Payment URL and Order ID",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseSetSymbolAlert"
+                            "$ref": "#/definitions/models.CreateVIPPaymentReponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request data or missing parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized: Invalid or missing authorization token",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create alert for symbol",
+                        "description": "Internal server error during payment creation",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/vip2/alerts/{id}": {
+        "/api/v1/spot-price": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Retrieve an alert by its unique identifier",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves current spot price information for a specified trading pair from Binance Spot or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Spot price"
                 ],
-                "summary": "Get an alert by ID",
+                "summary": "Get real-time spot price data",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Alert ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"VND\"",
+                        "description": "Fiat currency to convert the price into (e.g., VND, USD, EUR), USD stablecoins are taken at par",
+                        "name": "convert",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Alert details",
+                        "description": "Successful response with spot price data",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseAlertDetail"
+                            "$ref": "#/definitions/models.ResponseSpotPrice"
                         }
                     },
                     "400": {
-                        "description": "Invalid alert ID",
+                        "description": "Invalid symbol or request parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Symbol not found",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
                         }
                     },
-                    "404": {
-                        "description": "Alert not found",
+                    "500": {
+                        "description": "Failed to fetch price",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Delete an alert by its unique identifier",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/spot-price/batch": {
+            "get": {
+                "description": "Retrieves the spot price of every requested trading pair, or of every pair when none is given, with one request to Binance Spot or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Spot price"
                 ],
-                "summary": "Delete an alert",
+                "summary": "Get spot prices of several symbols",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "example": "\"BTCUSDT,ETHUSDT\"",
+                        "description": "Comma separated trading pairs, every pair when empty (e.g., BTCUSDT,ETHUSDT)",
+                        "name": "symbols",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Alert ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx or bybit",
+                        "name": "exchange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"VND\"",
+                        "description": "Fiat currency to convert the prices into (e.g., VND, USD, EUR), USD stablecoins are taken at par",
+                        "name": "convert",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Alert deleted successfully",
+                        "description": "Prices by symbol, unknown symbols and symbols whose quote cannot be converted are listed in errors",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseAlertDeleted"
+                            "$ref": "#/definitions/models.ResponseSpotPriceBatch"
                         }
                     },
                     "400": {
-                        "description": "Invalid alert ID",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid request parameters",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
                         }
                     },
-                    "404": {
-                        "description": "Alert not found",
+                    "500": {
+                        "description": "Failed to fetch prices",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/vip2/start-alert-checker": {
-            "post": {
-                "description": "Starts the alert checker to monitor for alerts",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/ticker/24h": {
+            "get": {
+                "description": "Retrieves the rolling 24 hour price change, high, low and volume of a trading pair from Binance or the requested exchange",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alert Running"
+                    "Ticker"
+                ],
+                "summary": "Get 24 hour ticker statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"spot\"",
+                        "description": "Market: spot (default) or futures",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Start alert checker",
                 "responses": {
                     "200": {
-                        "description": "Alert checker started successfully",
+                        "description": "Rolling 24 hour statistics, fields the exchange does not give are omitted",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseAlertCheckerStatus"
+                            "$ref": "#/definitions/models.ResponseTicker24h"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid symbol or request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to fetch ticker",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/vip2/stop-alert-checker": {
-            "post": {
-                "description": "Stops the alert checker from monitoring for alerts",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/trades": {
+            "get": {
+                "description": "Retrieves the recent trades of a trading pair, oldest first, with the VWAP, volumes and large trades of rolling windows ending at the last trade.\nSymbols recorded from the Binance aggregated trade stream are served from memory, others from Binance or the requested exchange.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Alert Running"
+                    "Trades"
+                ],
+                "summary": "Get recent trades",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"BTCUSDT\"",
+                        "description": "Trading pair symbol (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"spot\"",
+                        "description": "Market: spot (default) or futures",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of trades, 100 by default and at most 1000, exchanges may return fewer",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1m,5m,15m\"",
+                        "description": "Comma separated rolling windows from 1s to 1h, 1m,5m,15m by default",
+                        "name": "windows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "example": 100000,
+                        "description": "Quote quantity from which a trade is large, 100000 by default",
+                        "name": "largeTrade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Stop alert checker",
                 "responses": {
                     "200": {
-                        "description": "Alert checker stopped successfully",
+                        "description": "Trades oldest first with the statistics of each window",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseAlertCheckerStatus"
+                            "$ref": "#/definitions/models.ResponseTrades"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid symbol or request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to fetch trades",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/vip2/symbols-alerts": {
+        "/api/v1/user/me": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve new and delisted symbols from Binance",
+                "description": "This endpoint fetches details of the currently authenticated user using the JWT token provided in the Authorization header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2071,13 +2456,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Alerts"
+                    "Users"
                 ],
-                "summary": "Get new and delisted symbols",
+                "summary": "Retrieve current user information",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
+                        "description": "Bearer \u003cJWT Token\u003e",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
@@ -2085,34 +2470,44 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of new and delisted symbols",
+                        "description": "Success: Returns the user's details",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseNewDelistedSymbols"
+                            "$ref": "#/definitions/models.UserDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request: Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized: invalid token",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found: User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve symbols",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/vip3/indicators": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Create an alert with the given indicator type and settings",
+                "description": "This endpoint allows the user to update their profile information such as name, username, phone, avatar, bio, and date of birth.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2120,540 +2515,3015 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Indicators"
+                    "Users"
                 ],
-                "summary": "Create an advanced indicator alert",
+                "summary": "Update current user profile",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token",
+                        "format": "\"Bearer {token}\"",
+                        "description": "Bearer token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Indicator alert details",
-                        "name": "body",
+                        "description": "Update UserProfile Request body",
+                        "name": "UpdateUserProfileRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Indicator"
+                            "$ref": "#/definitions/models.UpdateUserProfileRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Indicator alert created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseIndicatorCreated"
+                            "$ref": "#/definitions/models.MessageResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or invalid indicator type",
+                        "description": "Bad Request: Invalid input",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized: Authorization header required or invalid token",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found: User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create indicator alert",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint allows the user to Delete the account of the currently authenticated user based on the access token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete current user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "\"Bearer {token}\"",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or token is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "An error occurred"
+        },
+        "/api/v1/user/me/change_email": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint allows an authenticated user to change their email address.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change user email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "\"Bearer {token}\"",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Change Mail Request body",
+                        "name": "ChangeMailRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangeMailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email format",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or token is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/me/change_password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint allows an authenticated user to change their password by providing the current and new passwords.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "\"Bearer {token}\"",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Change Password Request body",
+                        "name": "ChangePasswordRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request: Password must contain at least 8 characters, including letters, numbers, and special characters.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: Current password is incorrect",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found: User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/me/payment-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint returns the payment history of the currently authenticated user using their JWT token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Retrieve payment history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003cJWT Token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success: Returns the user's payment history",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaymentHistoryUserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/alerts/notify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Send a notification email to the user for their alerts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Notify user of alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification sent successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseNotificationSent"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to send notification",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip1/kline": {
+            "get": {
+                "description": "Fetches Kline data for a specific symbol and interval from Binance API or the requested exchange",
+                "tags": [
+                    "Kline"
+                ],
+                "summary": "Get Kline data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Symbol for which to fetch Kline data (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interval for Kline data (e.g., 1m, 5m, 1h, 1d), any count of m, h, d, w or M such as 10m or 2w is built from a finer interval",
+                        "name": "interval",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1732147200000,
+                        "description": "Open time in milliseconds of the first candle, candles go forward from it when set",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Open time in milliseconds of the last candle, the cursor of a previous page loads older candles",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of candles, 500 by default and at most 10000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"futures\"",
+                        "description": "Market: futures (default) or spot",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"VND\"",
+                        "description": "Fiat currency to convert open, high, low and close into (e.g., VND, USD, EUR) at the latest rate, volumes stay in the base asset",
+                        "name": "convert",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with Kline data",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseKline"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing Data",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseInputMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip2/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all alerts, optionally filter by type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Get all alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by alert type (e.g., new_listing, delisting)",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of alerts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ResponseAlertList"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve alerts",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new alert with the given details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Create an alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Alert details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Alert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created alert",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseAlertCreated"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create alert",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip2/alerts/symbol": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Set a new alert for symbols that are newly listed or delisted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Set an alert for new or delisted symbols",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Alert details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Alert"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created alert for symbol",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseSetSymbolAlert"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create alert for symbol",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip2/alerts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve an alert by its unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Get an alert by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alert details",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseAlertDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid alert ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Alert not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an alert by its unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Delete an alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Alert deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseAlertDeleted"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid alert ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Alert not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip2/start-alert-checker": {
+            "post": {
+                "description": "Starts the alert checker to monitor for alerts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert Running"
+                ],
+                "summary": "Start alert checker",
+                "responses": {
+                    "200": {
+                        "description": "Alert checker started successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseAlertCheckerStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip2/stop-alert-checker": {
+            "post": {
+                "description": "Stops the alert checker from monitoring for alerts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alert Running"
+                ],
+                "summary": "Stop alert checker",
+                "responses": {
+                    "200": {
+                        "description": "Alert checker stopped successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseAlertCheckerStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip2/symbols-alerts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve new and delisted symbols from Binance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Alerts"
+                ],
+                "summary": "Get new and delisted symbols",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of new and delisted symbols",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseNewDelistedSymbols"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve symbols",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip3/indicators": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create an alert with the given indicator type and settings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Indicators"
+                ],
+                "summary": "Create an advanced indicator alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Indicator alert details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Indicator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Indicator alert created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseIndicatorCreated"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or invalid indicator type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create indicator alert",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip3/indicators/series": {
+            "get": {
+                "description": "Computes technical indicators over the Kline data of a symbol and returns them aligned to its candles. The candles before the first one returned are loaded too, so that every indicator has a value from the first candle when the symbol has enough history.",
+                "tags": [
+                    "Indicators"
+                ],
+                "summary": "Get indicator series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Symbol for which to compute indicators (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interval of the candles (e.g., 1m, 5m, 1h, 1d), any count of m, h, d, w or M such as 10m or 2w is built from a finer interval",
+                        "name": "interval",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"sma:50,rsi,macd:12:26:9,bb:20:2\"",
+                        "description": "Comma separated indicators with optional parameters separated by colons: sma, ema, wma (period, 20), rsi (period, 14), macd (fast, slow, signal, 12:26:9), bb (period, deviations, 20:2), atr (period, 14), stoch (period, %K smoothing, %D period, 14:3:3), vwap (reset every UTC day) and obv",
+                        "name": "indicators",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Open time in milliseconds of the last candle, the cursor of a previous page loads older candles",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of candles, 500 by default and at most 10000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"futures\"",
+                        "description": "Market: futures (default) or spot",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with candles and indicator series",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseIndicatorSeries"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing data or invalid indicators",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseInputMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip3/patterns": {
+            "get": {
+                "description": "Searches the Kline data of a symbol for candlestick patterns: doji, hammer, shooting star, bullish and bearish engulfing, morning and evening star, three white soldiers and three black crows. Each pattern comes with the indices of its candles in kline_data and a confidence from 0 to 1.",
+                "tags": [
+                    "Patterns"
+                ],
+                "summary": "Get candlestick patterns",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Symbol to search (e.g., BTCUSDT)",
+                        "name": "symbol",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interval of the candles (e.g., 1m, 5m, 1h, 1d), any count of m, h, d, w or M such as 10m or 2w is built from a finer interval",
+                        "name": "interval",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"bullish_engulfing,hammer\"",
+                        "description": "Comma separated patterns to search, every pattern by default",
+                        "name": "patterns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Open time in milliseconds of the last candle, the cursor of a previous page loads older candles",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of candles, 100 by default and at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"futures\"",
+                        "description": "Market: futures (default) or spot",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with candles and patterns",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseCandlePatterns"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing data or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseInputMissing"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/vip3/patterns/scan": {
+            "get": {
+                "description": "Searches the latest closed candles of every symbol of a watchlist for candlestick patterns and returns the patterns that ended within the recent candles. A symbol that cannot be loaded is reported with its error.",
+                "tags": [
+                    "Patterns"
+                ],
+                "summary": "Scan a watchlist for candlestick patterns",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"BTCUSDT,ETHUSDT\"",
+                        "description": "Comma separated symbols, PATTERN_WATCHLIST by default, at most 50",
+                        "name": "symbols",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"1h\"",
+                        "description": "Interval of the candles (e.g., 15m, 1h, 4h, 1d)",
+                        "name": "interval",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Number of latest candles a pattern may end on, 3 by default and at most 30",
+                        "name": "recent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"morning_star,evening_star\"",
+                        "description": "Comma separated patterns to search, every pattern by default",
+                        "name": "patterns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"futures\"",
+                        "description": "Market: futures (default) or spot",
+                        "name": "market",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"binance\"",
+                        "description": "Exchange: binance (default), okx, bybit or coinbase",
+                        "name": "exchange",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with the recent patterns of every symbol",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponsePatternScan"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing data or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseInputMissing"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponseDataInternalServerError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_dath-241_coin-price-be-go_services_admin_service_models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "An error occurred"
+                }
+            }
+        },
+        "github_com_dath-241_coin-price-be-go_services_price-service_models.ResponseFundingRate": {
+            "type": "object",
+            "properties": {
+                "adjustedFundingRateCap": {
+                    "type": "string",
+                    "example": "0.02000000"
+                },
+                "adjustedFundingRateFloor": {
+                    "type": "string",
+                    "example": "-0.02000000"
+                },
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-11-21 08:12:13"
+                },
+                "fundingCountDown": {
+                    "type": "string",
+                    "example": "06:47:47"
+                },
+                "fundingIntervalHours": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "fundingRate": {
+                    "type": "string",
+                    "example": "0.00010000"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "QTUMUSDT"
+                }
+            }
+        },
+        "github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "An error occurred"
+                }
+            }
+        },
+        "models.Alert": {
+            "type": "object"
+        },
+        "models.ArbitrageVenue": {
+            "type": "object",
+            "properties": {
+                "ask": {
+                    "type": "number",
+                    "example": 97010.6
+                },
+                "askQuantity": {
+                    "type": "number",
+                    "example": 0.567
+                },
+                "bid": {
+                    "type": "number",
+                    "example": 97010.5
+                },
+                "bidQuantity": {
+                    "type": "number",
+                    "example": 1.234
+                },
+                "error": {
+                    "type": "string"
+                },
+                "exchange": {
+                    "type": "string",
+                    "example": "binance"
+                },
+                "quote": {
+                    "type": "string",
+                    "example": "USDT"
+                },
+                "rate": {
+                    "description": "Rate is the units of currency worth one unit of the quote asset",
+                    "type": "number",
+                    "example": 1
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                },
+                "takerFee": {
+                    "type": "number",
+                    "example": 0.001
+                },
+                "time": {
+                    "type": "integer",
+                    "example": 1733900000000
+                }
+            }
+        },
+        "models.CandlePattern": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number",
+                    "example": 0.82
+                },
+                "direction": {
+                    "type": "string",
+                    "example": "bullish"
+                },
+                "indices": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        498,
+                        499
+                    ]
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "bullish_engulfing"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "2024-11-21T00:00:00Z"
+                }
+            }
+        },
+        "models.ChangeMailRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "email mới cần cập nhật",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Conversion": {
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string",
+                    "example": "2024-12-11 00:02:31"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "USDT"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 25415.5
+                },
+                "source": {
+                    "type": "string",
+                    "example": "open.er-api.com"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "VND"
+                }
+            }
+        },
+        "models.CreateVIPPaymentReponse": {
+            "type": "object",
+            "properties": {
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateVIPPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "vip_level": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Drawdown": {
+            "type": "object",
+            "properties": {
+                "peakPrice": {
+                    "type": "number",
+                    "example": 99500
+                },
+                "peakTime": {
+                    "type": "string",
+                    "example": "2024-12-05T00:00:00Z"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": -18.4321
+                },
+                "troughPrice": {
+                    "type": "number",
+                    "example": 81160
+                },
+                "troughTime": {
+                    "type": "string",
+                    "example": "2024-12-20T00:00:00Z"
+                }
+            }
+        },
+        "models.ErrorResponseDataInternalServerError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Internal server error"
+                }
+            }
+        },
+        "models.ErrorResponseDataMissing": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Invalid symbol or request parameters"
+                }
+            }
+        },
+        "models.ErrorResponseDataNotFound": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Symbol not found"
+                }
+            }
+        },
+        "models.ErrorResponseInputMissing": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Missing data"
+                }
+            }
+        },
+        "models.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FormatMarketCapResponse": {
+            "type": "object",
+            "properties": {
+                "24h_volume": {
+                    "type": "integer",
+                    "example": 45000000000
+                },
+                "conversion": {
+                    "description": "Conversion is set when the market cap and volume were converted from USD with the convert parameter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Conversion"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bitcoin"
+                },
+                "last_updated": {
+                    "type": "string",
+                    "example": "2024-12-11T07:00:00.000Z"
+                },
+                "market_cap": {
+                    "type": "integer",
+                    "example": 1925000000000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bitcoin"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "btc"
+                }
+            }
+        },
+        "models.FundingRateStats": {
+            "type": "object",
+            "properties": {
+                "annualizedRate": {
+                    "type": "number",
+                    "example": 9.3294
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "cumulative": {
+                    "type": "number",
+                    "example": 0.007668
+                },
+                "fundingIntervalHours": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "max": {
+                    "type": "number",
+                    "example": 0.0003
+                },
+                "maxTime": {
+                    "type": "string",
+                    "example": "2024-12-05 16:00:00"
+                },
+                "mean": {
+                    "type": "number",
+                    "example": 0.0000852
+                },
+                "min": {
+                    "type": "number",
+                    "example": -0.0000321
+                },
+                "minTime": {
+                    "type": "string",
+                    "example": "2024-11-20 08:00:00"
+                },
+                "negativeCount": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.FundingScreenerEntry": {
+            "type": "object",
+            "properties": {
+                "annualizedRate": {
+                    "type": "number",
+                    "example": 10.95
+                },
+                "averageAnnualizedRate": {
+                    "type": "number",
+                    "example": 9.3294
+                },
+                "averageFundingRate": {
+                    "type": "number",
+                    "example": 0.0000852
+                },
+                "fundingIntervalHours": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "fundingRate": {
+                    "type": "number",
+                    "example": 0.0001
+                },
+                "markPrice": {
+                    "type": "number",
+                    "example": 97158.9
+                },
+                "negativeCount": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "nextFundingTime": {
+                    "type": "string",
+                    "example": "2024-12-11 16:00:00"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                }
+            }
+        },
+        "models.Indicator": {
+            "type": "object",
+            "required": [
+                "indicator",
+                "notification_method",
+                "period",
+                "symbol"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "indicator": {
+                    "type": "string"
+                },
+                "notification_method": {
+                    "type": "string"
+                },
+                "period": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IndicatorSeries": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "rsi_14"
+                },
+                "lines": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "rsi"
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "models.KLineEachData": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.KlineDataPoint": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number",
+                    "example": 94645.7
+                },
+                "high": {
+                    "type": "number",
+                    "example": 95000
+                },
+                "low": {
+                    "type": "number",
+                    "example": 94200
+                },
+                "open": {
+                    "type": "number",
+                    "example": 94288
+                },
+                "time": {
+                    "type": "string",
+                    "example": "2024-11-21T00:00:00Z"
+                },
+                "volume": {
+                    "type": "number",
+                    "example": 21752.097
+                }
+            }
+        },
+        "models.LiquidationSymbolStats": {
+            "type": "object",
+            "properties": {
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LiquidationWindowStats"
+                    }
+                }
+            }
+        },
+        "models.LiquidationWindowStats": {
+            "type": "object",
+            "properties": {
+                "longCount": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "longNotional": {
+                    "type": "number",
+                    "example": 1250000.5
+                },
+                "longShare": {
+                    "description": "LongShare is the share of the notional liquidated from long positions, from 0 to 1",
+                    "type": "number",
+                    "example": 0.7962
+                },
+                "shortCount": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "shortNotional": {
+                    "type": "number",
+                    "example": 320000.25
+                },
+                "totalNotional": {
+                    "type": "number",
+                    "example": 1570000.75
+                },
+                "window": {
+                    "type": "string",
+                    "example": "1h"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MarketOverviewCoin": {
+            "type": "object",
+            "properties": {
+                "dominance": {
+                    "description": "Dominance is the share of the total market cap in percent",
+                    "type": "number",
+                    "example": 56.12
+                },
+                "id": {
+                    "type": "string",
+                    "example": "bitcoin"
+                },
+                "marketCap": {
+                    "type": "number",
+                    "example": 1925000000000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bitcoin"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 97102.5
+                },
+                "priceChangePercent24h": {
+                    "type": "number",
+                    "example": 1.27
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BTC"
+                },
+                "volume24h": {
+                    "type": "number",
+                    "example": 45000000000
+                }
+            }
+        },
+        "models.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MoMoResponse": {
+            "type": "object",
+            "properties": {
+                "extraData": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "partnerCode": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "responseTime": {
+                    "type": "string"
+                },
+                "resultCode": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PatternScanEntry": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "patterns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CandlePattern"
+                    }
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                }
+            }
+        },
+        "models.PaymentAdmin": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Số tiền thanh toán",
+                    "type": "number"
+                },
+                "order_id": {
+                    "description": "Thông tin đơn hàng",
+                    "type": "string"
+                },
+                "order_info": {
+                    "type": "string"
+                },
+                "transaction_status": {
+                    "description": "Trạng thái giao dịch",
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PaymentDetailsAdmin": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Số tiền thanh toán",
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "Ngày tạo đơn hàng",
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "Thông tin đơn hàng",
+                    "type": "string"
+                },
+                "order_info": {
+                    "type": "string"
+                },
+                "payment_url": {
+                    "description": "URL thanh toán MoMo",
+                    "type": "string"
+                },
+                "transaction_status": {
+                    "description": "Trạng thái giao dịch",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vip_level": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PaymentDetailsUser": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Số tiền thanh toán",
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "Thời gian tạo",
+                    "type": "string"
+                },
+                "order_info": {
+                    "description": "Thông tin đơn hàng",
+                    "type": "string"
+                },
+                "transaction_status": {
+                    "description": "Trạng thái giao dịch",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Thời gian cập nhật",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PaymentHisDetailsAdminResponse": {
+            "type": "object",
+            "properties": {
+                "payment_history": {
+                    "description": "Danh sách lịch sử thanh toán",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentDetailsAdmin"
+                    }
+                }
+            }
+        },
+        "models.PaymentHistoryAdminResponse": {
+            "type": "object",
+            "properties": {
+                "payment_history": {
+                    "description": "Danh sách lịch sử thanh toán",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentAdmin"
+                    }
+                }
+            }
+        },
+        "models.PaymentHistoryUserResponse": {
+            "type": "object",
+            "properties": {
+                "payment_history": {
+                    "description": "Danh sách lịch sử thanh toán",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentDetailsUser"
+                    }
+                }
+            }
+        },
+        "models.PriceLevel": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Profile": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "example": "https://example.com/avatar.png"
+                },
+                "bio": {
+                    "type": "string",
+                    "example": "Software developer based in NY"
+                },
+                "date_of_birth": {
+                    "type": "string",
+                    "example": "1995-05-15"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "phone_number": {
+                    "description": "unique",
+                    "type": "string",
+                    "example": "+84911123456"
+                }
+            }
+        },
+        "models.QueryPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "lang": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RealizedVolatility": {
+            "type": "object",
+            "properties": {
+                "closeToClose": {
+                    "type": "number",
+                    "example": 48.1234
+                },
+                "garmanKlass": {
+                    "type": "number",
+                    "example": 44.9012
+                },
+                "parkinson": {
+                    "type": "number",
+                    "example": 42.5678
+                }
+            }
+        },
+        "models.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "description": "unique",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "hashed_password_here"
+                },
+                "profile": {
+                    "description": "Nested personal info",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Profile"
+                        }
+                    ]
+                },
+                "username": {
+                    "description": "unique",
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "models.ReponseQueryPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResetRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "otp"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "otp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResponseAlertCheckerStatus": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "Alert checker started"
+                }
+            }
+        },
+        "models.ResponseAlertCreated": {
+            "type": "object",
+            "properties": {
+                "alert_id": {
+                    "type": "string",
+                    "example": "647f1f77bcf86cd799439011"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Alert created successfully"
+                }
+            }
+        },
+        "models.ResponseAlertDeleted": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Alert deleted successfully"
+                }
+            }
+        },
+        "models.ResponseAlertDetail": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "type": "string",
+                    "example": "\u003e="
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2024-11-21T00:00:00Z"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "immediate"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "647f1f77bcf86cd799439011"
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "maxRepeatCount": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "price": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "range": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "snoozeCondition": {
+                    "type": "string",
+                    "example": "none"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2024-11-21T00:00:00Z"
+                }
+            }
+        },
+        "models.ResponseAlertList": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResponseAlertDetail"
+                    }
+                }
+            }
+        },
+        "models.ResponseArbitrage": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "type": "string",
+                    "example": "BTC"
+                },
+                "buyExchange": {
+                    "type": "string",
+                    "example": "coinbase"
+                },
+                "buyPrice": {
+                    "type": "number",
+                    "example": 96990.01
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:00"
+                },
+                "grossSpread": {
+                    "type": "number",
+                    "example": 20.49
+                },
+                "grossSpreadPercent": {
+                    "type": "number",
+                    "example": 0.0211
+                },
+                "market": {
+                    "type": "string",
+                    "example": "spot"
+                },
+                "netSpread": {
+                    "type": "number",
+                    "example": -659.61
+                },
+                "netSpreadPercent": {
+                    "type": "number",
+                    "example": -0.6801
+                },
+                "profitable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "sellExchange": {
+                    "type": "string",
+                    "example": "binance"
+                },
+                "sellPrice": {
+                    "type": "number",
+                    "example": 97010.5
+                },
+                "venues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ArbitrageVenue"
+                    }
+                }
+            }
+        },
+        "models.ResponseCandlePatterns": {
+            "type": "object",
+            "properties": {
+                "conversion": {
+                    "description": "Conversion is set when the prices were converted with the convert parameter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Conversion"
+                        }
+                    ]
+                },
+                "cursor": {
+                    "description": "Cursor is the endTime that loads the candles before this page, 0 when there is nothing older",
+                    "type": "integer"
+                },
+                "eventTime": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "kline_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.KLineEachData"
+                    }
+                },
+                "patterns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CandlePattern"
+                    }
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResponseCorrelation": {
+            "type": "object",
+            "properties": {
+                "benchmark": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                },
+                "beta": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "endTime": {
+                    "type": "string",
+                    "example": "2024-12-20T00:00:00Z"
+                },
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-20 08:37:58"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "1d"
+                },
+                "lookback": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "missing": {
+                    "description": "Missing counts the candles of each symbol left out because another symbol has no candle at that time",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "observations": {
+                    "description": "Observations is the number of returns between the candles every symbol has",
+                    "type": "integer",
+                    "example": 89
+                },
+                "pearson": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "spearman": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "startTime": {
+                    "type": "string",
+                    "example": "2024-09-22T00:00:00Z"
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "BTCUSDT",
+                        "ETHUSDT",
+                        "SOLUSDT"
+                    ]
+                }
+            }
+        },
+        "models.ResponseDepth": {
+            "type": "object",
+            "properties": {
+                "asks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceLevel"
+                    }
+                },
+                "bestAsk": {
+                    "type": "number"
+                },
+                "bestBid": {
+                    "type": "number"
+                },
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceLevel"
+                    }
+                },
+                "eventTime": {
+                    "type": "string"
+                },
+                "lastUpdateId": {
+                    "type": "integer"
+                },
+                "market": {
+                    "type": "string"
+                },
+                "midPrice": {
+                    "type": "number"
+                },
+                "spread": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_dath-241_coin-price-be-go_services_price-service_models.ResponseFundingRate": {
+        "models.ResponseFundingRateHistory": {
             "type": "object",
             "properties": {
-                "adjustedFundingRateCap": {
-                    "type": "string",
-                    "example": "0.02000000"
-                },
-                "adjustedFundingRateFloor": {
-                    "type": "string",
-                    "example": "-0.02000000"
+                "cursor": {
+                    "description": "Cursor is the endTime that loads the rates before this page, 0 when there is nothing older",
+                    "type": "integer",
+                    "example": 1733817599999
                 },
                 "eventTime": {
                     "type": "string",
-                    "example": "2024-11-21 08:12:13"
-                },
-                "fundingCountDown": {
-                    "type": "string",
-                    "example": "06:47:47"
+                    "example": "2024-12-11 08:00:05"
                 },
-                "fundingIntervalHours": {
-                    "type": "integer",
-                    "example": 8
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResponseFundingRateHistoryEach"
+                    }
                 },
-                "fundingRate": {
-                    "type": "string",
-                    "example": "0.00010000"
+                "stats": {
+                    "$ref": "#/definitions/models.FundingRateStats"
                 },
                 "symbol": {
                     "type": "string",
-                    "example": "QTUMUSDT"
+                    "example": "BTCUSDT"
                 }
             }
         },
-        "github_com_dath-241_coin-price-be-go_services_trigger-service_models.ErrorResponse": {
+        "models.ResponseFundingRateHistoryEach": {
             "type": "object",
             "properties": {
-                "message": {
+                "fundingRate": {
+                    "type": "number",
+                    "example": 0.0001
+                },
+                "fundingTime": {
                     "type": "string",
-                    "example": "An error occurred"
-                }
-            }
-        },
-        "models.Alert": {
-            "type": "object"
-        },
-        "models.ChangeMailRequest": {
-            "type": "object",
-            "required": [
-                "email"
-            ],
-            "properties": {
-                "email": {
-                    "description": "email mới cần cập nhật",
-                    "type": "string"
+                    "example": "2024-12-11 08:00:00"
+                },
+                "markPrice": {
+                    "type": "number",
+                    "example": 97158.9
                 }
             }
         },
-        "models.ChangePasswordRequest": {
+        "models.ResponseFundingScreener": {
             "type": "object",
-            "required": [
-                "current_password",
-                "new_password"
-            ],
             "properties": {
-                "current_password": {
-                    "type": "string"
+                "days": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "new_password": {
-                    "type": "string"
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 08:00:05"
+                },
+                "order": {
+                    "type": "string",
+                    "example": "desc"
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "current"
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FundingScreenerEntry"
+                    }
                 }
             }
         },
-        "models.CreateVIPPaymentReponse": {
+        "models.ResponseFuturePrice": {
             "type": "object",
             "properties": {
-                "order_id": {
+                "conversion": {
+                    "description": "Conversion is set when the prices were converted with the convert parameter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Conversion"
+                        }
+                    ]
+                },
+                "eventTime": {
                     "type": "string"
                 },
-                "payment_url": {
+                "price": {
                     "type": "string"
-                }
-            }
-        },
-        "models.CreateVIPPaymentRequest": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "integer"
                 },
-                "vip_level": {
+                "symbol": {
                     "type": "string"
                 }
             }
         },
-        "models.ErrorResponseDataInternalServerError": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Internal server error"
-                }
-            }
-        },
-        "models.ErrorResponseDataMissing": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Invalid symbol or request parameters"
-                }
-            }
-        },
-        "models.ErrorResponseDataNotFound": {
+        "models.ResponseFuturePriceBatch": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Symbol not found"
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "prices": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.ResponseFuturePrice"
+                    }
                 }
             }
         },
-        "models.ErrorResponseInputMissing": {
+        "models.ResponseIndicatorCreated": {
             "type": "object",
             "properties": {
+                "alert_id": {
+                    "description": "ID của indicator alert vừa tạo",
+                    "type": "string"
+                },
                 "message": {
-                    "type": "string",
-                    "example": "Missing data"
-                }
-            }
-        },
-        "models.ForgotPasswordRequest": {
-            "type": "object",
-            "required": [
-                "email"
-            ],
-            "properties": {
-                "email": {
                     "type": "string"
                 }
             }
         },
-        "models.Indicator": {
+        "models.ResponseIndicatorSeries": {
             "type": "object",
-            "required": [
-                "indicator",
-                "notification_method",
-                "period",
-                "symbol"
-            ],
             "properties": {
-                "id": {
-                    "type": "string"
+                "conversion": {
+                    "description": "Conversion is set when the prices were converted with the convert parameter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Conversion"
+                        }
+                    ]
                 },
-                "indicator": {
+                "cursor": {
+                    "description": "Cursor is the endTime that loads the candles before this page, 0 when there is nothing older",
+                    "type": "integer"
+                },
+                "eventTime": {
                     "type": "string"
                 },
-                "notification_method": {
+                "indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IndicatorSeries"
+                    }
+                },
+                "interval": {
                     "type": "string"
                 },
-                "period": {
-                    "type": "integer"
+                "kline_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.KLineEachData"
+                    }
                 },
                 "symbol": {
                     "type": "string"
                 }
             }
         },
-        "models.KlineDataPoint": {
+        "models.ResponseKline": {
             "type": "object",
             "properties": {
-                "close": {
-                    "type": "number",
-                    "example": 94645.7
-                },
-                "high": {
-                    "type": "number",
-                    "example": 95000
+                "conversion": {
+                    "$ref": "#/definitions/models.Conversion"
                 },
-                "low": {
-                    "type": "number",
-                    "example": 94200
+                "cursor": {
+                    "type": "integer",
+                    "example": 1732147199999
                 },
-                "open": {
-                    "type": "number",
-                    "example": 94288
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-11-21 08:37:58"
                 },
-                "time": {
+                "interval": {
                     "type": "string",
-                    "example": "2024-11-21T00:00:00Z"
+                    "example": "1m"
                 },
-                "volume": {
-                    "type": "number",
-                    "example": 21752.097
-                }
-            }
-        },
-        "models.LoginRequest": {
-            "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
-            "properties": {
-                "password": {
-                    "type": "string"
+                "kline_data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.KlineDataPoint"
+                    }
                 },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.MessageResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
                 }
             }
         },
-        "models.MoMoResponse": {
+        "models.ResponseLiquidation": {
             "type": "object",
             "properties": {
-                "extraData": {
-                    "type": "string"
+                "notional": {
+                    "type": "number",
+                    "example": 121263.13
                 },
-                "message": {
-                    "type": "string"
+                "price": {
+                    "type": "number",
+                    "example": 97010.5
                 },
-                "orderId": {
-                    "type": "string"
+                "quantity": {
+                    "type": "number",
+                    "example": 1.25
                 },
-                "partnerCode": {
-                    "type": "string"
+                "side": {
+                    "type": "string",
+                    "example": "long"
                 },
-                "requestId": {
-                    "type": "string"
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
                 },
-                "responseTime": {
-                    "type": "string"
+                "time": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:00"
+                }
+            }
+        },
+        "models.ResponseLiquidationStats": {
+            "type": "object",
+            "properties": {
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:00"
                 },
-                "resultCode": {
-                    "type": "string"
+                "since": {
+                    "type": "string",
+                    "example": "2024-12-10 07:00:00"
                 },
-                "signature": {
-                    "type": "string"
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LiquidationSymbolStats"
+                    }
+                },
+                "total": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LiquidationWindowStats"
+                    }
                 }
             }
         },
-        "models.PaymentAdmin": {
+        "models.ResponseLiquidations": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "description": "Số tiền thanh toán",
-                    "type": "number"
-                },
-                "order_id": {
-                    "description": "Thông tin đơn hàng",
-                    "type": "string"
-                },
-                "order_info": {
-                    "type": "string"
+                "cursor": {
+                    "description": "Cursor is the endTime that loads the liquidations before this page, 0 when there is nothing older",
+                    "type": "integer"
                 },
-                "transaction_status": {
-                    "description": "Trạng thái giao dịch",
-                    "type": "string"
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:00"
                 },
-                "userID": {
-                    "type": "string"
+                "liquidations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResponseLiquidation"
+                    }
                 }
             }
         },
-        "models.PaymentDetailsAdmin": {
+        "models.ResponseLongShortRatio": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "description": "Số tiền thanh toán",
-                    "type": "number"
-                },
-                "created_at": {
-                    "description": "Ngày tạo đơn hàng",
-                    "type": "string"
-                },
-                "order_id": {
-                    "description": "Thông tin đơn hàng",
-                    "type": "string"
+                "cursor": {
+                    "description": "Cursor is the endTime that loads the points before this page, 0 when the page is empty or reaches startTime",
+                    "type": "integer"
                 },
-                "order_info": {
-                    "type": "string"
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:05"
                 },
-                "payment_url": {
-                    "description": "URL thanh toán MoMo",
-                    "type": "string"
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResponseLongShortRatioEach"
+                    }
                 },
-                "transaction_status": {
-                    "description": "Trạng thái giao dịch",
-                    "type": "string"
+                "period": {
+                    "type": "string",
+                    "example": "1h"
                 },
-                "updated_at": {
-                    "type": "string"
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
                 },
-                "vip_level": {
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "example": "global-account"
                 }
             }
         },
-        "models.PaymentDetailsUser": {
+        "models.ResponseLongShortRatioEach": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "description": "Số tiền thanh toán",
-                    "type": "number"
-                },
-                "created_at": {
-                    "description": "Thời gian tạo",
-                    "type": "string"
+                "longAccount": {
+                    "type": "number",
+                    "example": 0.6444
                 },
-                "order_info": {
-                    "description": "Thông tin đơn hàng",
-                    "type": "string"
+                "longShortRatio": {
+                    "type": "number",
+                    "example": 1.8123
                 },
-                "transaction_status": {
-                    "description": "Trạng thái giao dịch",
-                    "type": "string"
+                "shortAccount": {
+                    "type": "number",
+                    "example": 0.3556
                 },
-                "updated_at": {
-                    "description": "Thời gian cập nhật",
-                    "type": "string"
+                "time": {
+                    "type": "string",
+                    "example": "2024-12-11T07:00:00Z"
                 }
             }
         },
-        "models.PaymentHisDetailsAdminResponse": {
+        "models.ResponseMarketOverview": {
             "type": "object",
             "properties": {
-                "payment_history": {
-                    "description": "Danh sách lịch sử thanh toán",
+                "activeCryptocurrencies": {
+                    "type": "integer",
+                    "example": 16231
+                },
+                "btcDominance": {
+                    "type": "number",
+                    "example": 56.12
+                },
+                "ethDominance": {
+                    "type": "number",
+                    "example": 13.05
+                },
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:05"
+                },
+                "marketCapChangePercent24h": {
+                    "type": "number",
+                    "example": 1.83
+                },
+                "topGainers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PaymentDetailsAdmin"
+                        "$ref": "#/definitions/models.MarketOverviewCoin"
+                    }
+                },
+                "topLosers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MarketOverviewCoin"
+                    }
+                },
+                "topMarketCap": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MarketOverviewCoin"
                     }
+                },
+                "totalMarketCap": {
+                    "type": "number",
+                    "example": 3430000000000
+                },
+                "totalVolume24h": {
+                    "type": "number",
+                    "example": 152000000000
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is when CoinGecko computed the overview, EventTime when it was fetched",
+                    "type": "string",
+                    "example": "2024-12-11 07:00:00"
                 }
             }
         },
-        "models.PaymentHistoryAdminResponse": {
+        "models.ResponseNewDelistedSymbols": {
             "type": "object",
             "properties": {
-                "payment_history": {
-                    "description": "Danh sách lịch sử thanh toán",
+                "delisted_symbols": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PaymentAdmin"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "[BNBUSDT]"
+                    ]
+                },
+                "new_symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[BTCUSDT",
+                        " ETHUSDT]"
+                    ]
                 }
             }
         },
-        "models.PaymentHistoryUserResponse": {
+        "models.ResponseNotificationSent": {
             "type": "object",
             "properties": {
-                "payment_history": {
-                    "description": "Danh sách lịch sử thanh toán",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.PaymentDetailsUser"
-                    }
+                "status": {
+                    "type": "string",
+                    "example": "Notification sent"
                 }
             }
         },
-        "models.Profile": {
+        "models.ResponseOpenInterest": {
             "type": "object",
             "properties": {
-                "avatar_url": {
+                "eventTime": {
                     "type": "string",
-                    "example": "https://example.com/avatar.png"
+                    "example": "2024-12-11 07:00:00"
                 },
-                "bio": {
-                    "type": "string",
-                    "example": "Software developer based in NY"
+                "markPrice": {
+                    "type": "number",
+                    "example": 97143.2
                 },
-                "date_of_birth": {
-                    "type": "string",
-                    "example": "1995-05-15"
+                "openInterest": {
+                    "type": "number",
+                    "example": 81234.567
                 },
-                "full_name": {
-                    "type": "string",
-                    "example": "John Doe"
+                "openInterestValue": {
+                    "type": "number",
+                    "example": 7891234567.89
                 },
-                "phone_number": {
-                    "description": "unique",
+                "symbol": {
                     "type": "string",
-                    "example": "+84911123456"
+                    "example": "BTCUSDT"
                 }
             }
         },
-        "models.QueryPaymentRequest": {
+        "models.ResponseOpenInterestEach": {
             "type": "object",
             "properties": {
-                "lang": {
-                    "type": "string"
+                "openInterest": {
+                    "type": "number",
+                    "example": 81234.567
                 },
-                "orderId": {
-                    "type": "string"
+                "openInterestValue": {
+                    "type": "number",
+                    "example": 7891234567.89
                 },
-                "requestId": {
-                    "type": "string"
+                "time": {
+                    "type": "string",
+                    "example": "2024-12-11T07:00:00Z"
                 }
             }
         },
-        "models.RegisterRequest": {
+        "models.ResponseOpenInterestHistory": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
-                    "description": "unique",
+                "cursor": {
+                    "description": "Cursor is the endTime that loads the points before this page, 0 when the page is empty or reaches startTime",
+                    "type": "integer"
+                },
+                "eventTime": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "2024-12-11 07:00:05"
                 },
-                "password": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ResponseOpenInterestEach"
+                    }
+                },
+                "period": {
                     "type": "string",
-                    "example": "hashed_password_here"
+                    "example": "1h"
                 },
-                "profile": {
-                    "description": "Nested personal info",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Profile"
-                        }
-                    ]
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                }
+            }
+        },
+        "models.ResponsePatternScan": {
+            "type": "object",
+            "properties": {
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:00"
                 },
-                "username": {
-                    "description": "unique",
+                "interval": {
                     "type": "string",
-                    "example": "johndoe"
+                    "example": "1h"
+                },
+                "recent": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PatternScanEntry"
+                    }
                 }
             }
         },
-        "models.ReponseQueryPaymentRequest": {
+        "models.ResponsePremium": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "annualizedBasis": {
+                    "type": "number",
+                    "example": 56.1534615
+                },
+                "annualizedFundingRate": {
+                    "type": "number",
+                    "example": 10.95
+                },
+                "basis": {
+                    "type": "number",
+                    "example": 49.8
+                },
+                "basisPercent": {
+                    "type": "number",
+                    "example": 0.0512817
+                },
+                "estimatedSettlePrice": {
+                    "type": "number",
+                    "example": 97142.3
+                },
+                "eventTime": {
+                    "type": "string",
+                    "example": "2024-12-11 07:00:01"
+                },
+                "fundingIntervalHours": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "fundingRate": {
+                    "type": "number",
+                    "example": 0.0001
+                },
+                "indexPrice": {
+                    "type": "number",
+                    "example": 97140.12
+                },
+                "interestRate": {
+                    "type": "number",
+                    "example": 0.0001
+                },
+                "lastPrice": {
+                    "type": "number",
+                    "example": 97160.4
+                },
+                "markPrice": {
+                    "type": "number",
+                    "example": 97158.9
+                },
+                "nextFundingTime": {
+                    "type": "string",
+                    "example": "2024-12-11 08:00:00"
                 },
-                "status": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.ResetRequest": {
-            "type": "object",
-            "required": [
-                "new_password",
-                "otp"
-            ],
-            "properties": {
-                "new_password": {
-                    "type": "string"
+                "spotPrice": {
+                    "type": "number",
+                    "example": 97110.6
                 },
-                "otp": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.ResponseAlertCheckerStatus": {
-            "type": "object",
-            "properties": {
-                "status": {
+                "symbol": {
                     "type": "string",
-                    "example": "Alert checker started"
+                    "example": "BTCUSDT"
                 }
             }
         },
-        "models.ResponseAlertCreated": {
+        "models.ResponseSetSymbolAlert": {
             "type": "object",
             "properties": {
                 "alert_id": {
@@ -2666,193 +5536,244 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ResponseAlertDeleted": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Alert deleted successfully"
-                }
-            }
-        },
-        "models.ResponseAlertDetail": {
+        "models.ResponseSpotPrice": {
             "type": "object",
             "properties": {
-                "condition": {
-                    "type": "string",
-                    "example": "\u003e="
-                },
-                "createdAt": {
-                    "type": "string",
-                    "example": "2024-11-21T00:00:00Z"
-                },
-                "frequency": {
-                    "type": "string",
-                    "example": "immediate"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "647f1f77bcf86cd799439011"
-                },
-                "isActive": {
-                    "type": "boolean",
-                    "example": true
+                "conversion": {
+                    "description": "Conversion is set when the prices were converted with the convert parameter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Conversion"
+                        }
+                    ]
                 },
-                "maxRepeatCount": {
-                    "type": "integer",
-                    "example": 5
+                "eventTime": {
+                    "type": "string"
                 },
                 "price": {
-                    "type": "number",
-                    "example": 50000
-                },
-                "range": {
-                    "type": "array",
-                    "items": {
-                        "type": "number"
-                    }
-                },
-                "snoozeCondition": {
-                    "type": "string",
-                    "example": "none"
+                    "type": "string"
                 },
                 "symbol": {
-                    "type": "string",
-                    "example": "BTCUSDT"
-                },
-                "updatedAt": {
-                    "type": "string",
-                    "example": "2024-11-21T00:00:00Z"
+                    "type": "string"
                 }
             }
         },
-        "models.ResponseAlertList": {
+        "models.ResponseSpotPriceBatch": {
             "type": "object",
             "properties": {
-                "alerts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ResponseAlertDetail"
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "prices": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.ResponseSpotPrice"
                     }
                 }
             }
         },
-        "models.ResponseFuturePrice": {
+        "models.ResponseTicker24h": {
             "type": "object",
             "properties": {
                 "eventTime": {
                     "type": "string"
                 },
-                "price": {
+                "highPrice": {
+                    "type": "string"
+                },
+                "lastPrice": {
+                    "type": "string"
+                },
+                "lowPrice": {
+                    "type": "string"
+                },
+                "market": {
+                    "type": "string"
+                },
+                "openPrice": {
+                    "type": "string"
+                },
+                "openTime": {
+                    "type": "string"
+                },
+                "priceChange": {
+                    "type": "string"
+                },
+                "priceChangePercent": {
+                    "type": "string"
+                },
+                "quoteVolume": {
                     "type": "string"
                 },
                 "symbol": {
                     "type": "string"
+                },
+                "tradeCount": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "string"
+                },
+                "weightedAvgPrice": {
+                    "type": "string"
                 }
             }
         },
-        "models.ResponseIndicatorCreated": {
+        "models.ResponseTrade": {
             "type": "object",
             "properties": {
-                "alert_id": {
-                    "description": "ID của indicator alert vừa tạo",
+                "id": {
                     "type": "string"
                 },
-                "message": {
+                "isLarge": {
+                    "description": "IsLarge is set when the quote quantity reaches the large trade threshold",
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "quoteQuantity": {
+                    "type": "number"
+                },
+                "side": {
+                    "type": "string"
+                },
+                "time": {
                     "type": "string"
                 }
             }
         },
-        "models.ResponseKline": {
+        "models.ResponseTrades": {
             "type": "object",
             "properties": {
                 "eventTime": {
-                    "type": "string",
-                    "example": "2024-11-21 08:37:58"
+                    "type": "string"
                 },
-                "interval": {
-                    "type": "string",
-                    "example": "1m"
+                "market": {
+                    "type": "string"
                 },
-                "kline_data": {
+                "stats": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.KlineDataPoint"
+                        "$ref": "#/definitions/models.TradeWindowStats"
                     }
                 },
                 "symbol": {
-                    "type": "string",
-                    "example": "BTCUSDT"
-                }
-            }
-        },
-        "models.ResponseNewDelistedSymbols": {
-            "type": "object",
-            "properties": {
-                "delisted_symbols": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[BNBUSDT]"
-                    ]
+                    "type": "string"
                 },
-                "new_symbols": {
+                "trades": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[BTCUSDT",
-                        " ETHUSDT]"
-                    ]
+                        "$ref": "#/definitions/models.ResponseTrade"
+                    }
                 }
             }
         },
-        "models.ResponseNotificationSent": {
+        "models.ResponseVolatility": {
             "type": "object",
             "properties": {
-                "status": {
+                "atr": {
+                    "type": "number",
+                    "example": 3421.5
+                },
+                "atrPercent": {
+                    "type": "number",
+                    "example": 3.5123
+                },
+                "atrPeriod": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "endTime": {
                     "type": "string",
-                    "example": "Notification sent"
-                }
-            }
-        },
-        "models.ResponseSetSymbolAlert": {
-            "type": "object",
-            "properties": {
-                "alert_id": {
+                    "example": "2024-12-20T00:00:00Z"
+                },
+                "eventTime": {
                     "type": "string",
-                    "example": "647f1f77bcf86cd799439011"
+                    "example": "2024-12-20 08:37:58"
                 },
-                "message": {
+                "interval": {
                     "type": "string",
-                    "example": "Alert created successfully"
+                    "example": "1d"
+                },
+                "maxDrawdown": {
+                    "$ref": "#/definitions/models.Drawdown"
+                },
+                "periodsPerYear": {
+                    "description": "PeriodsPerYear is the number of candles volatilities are annualized with",
+                    "type": "number",
+                    "example": 365
+                },
+                "rollingReturns": {
+                    "$ref": "#/definitions/models.RollingReturns"
+                },
+                "startTime": {
+                    "type": "string",
+                    "example": "2024-11-21T00:00:00Z"
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                },
+                "totalReturn": {
+                    "type": "number",
+                    "example": 5.4321
+                },
+                "volatility": {
+                    "$ref": "#/definitions/models.RealizedVolatility"
+                },
+                "window": {
+                    "type": "integer",
+                    "example": 30
                 }
             }
         },
-        "models.ResponseSpotPrice": {
+        "models.ReturnPoint": {
             "type": "object",
             "properties": {
-                "eventTime": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "string"
+                "return": {
+                    "type": "number",
+                    "example": -3.2145
                 },
-                "symbol": {
-                    "type": "string"
+                "time": {
+                    "type": "string",
+                    "example": "2024-12-20T00:00:00Z"
                 }
             }
         },
-        "models.ResponseUserAlerts": {
+        "models.RollingReturns": {
             "type": "object",
             "properties": {
-                "alerts": {
+                "latest": {
+                    "type": "number",
+                    "example": -3.2145
+                },
+                "max": {
+                    "type": "number",
+                    "example": 12.3456
+                },
+                "mean": {
+                    "type": "number",
+                    "example": 1.1042
+                },
+                "min": {
+                    "type": "number",
+                    "example": -9.8765
+                },
+                "period": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "series": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Alert"
+                        "$ref": "#/definitions/models.ReturnPoint"
                     }
                 }
             }
@@ -2868,6 +5789,35 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TradeWindowStats": {
+            "type": "object",
+            "properties": {
+                "buyVolume": {
+                    "type": "number"
+                },
+                "largeTradeCount": {
+                    "type": "integer"
+                },
+                "quoteVolume": {
+                    "type": "number"
+                },
+                "sellVolume": {
+                    "type": "number"
+                },
+                "tradeCount": {
+                    "type": "integer"
+                },
+                "volume": {
+                    "type": "number"
+                },
+                "vwap": {
+                    "type": "number"
+                },
+                "window": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateUserProfileRequest": {
             "type": "object",
             "properties": {
@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	priceRepository "github.com/dath-241/coin-price-be-go/services/price-service/repository"
 	priceRoutes "github.com/dath-241/coin-price-be-go/services/price-service/routes"
+	priceArbitrage "github.com/dath-241/coin-price-be-go/services/price-service/services/arbitrage"
+	priceKline "github.com/dath-241/coin-price-be-go/services/price-service/services/kline"
+	priceLiquidation "github.com/dath-241/coin-price-be-go/services/price-service/services/liquidation"
+	priceMarketCap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
+	priceTrades "github.com/dath-241/coin-price-be-go/services/price-service/services/trades"
 	triggerRoutes "github.com/dath-241/coin-price-be-go/services/trigger-service/routes"
 	"github.com/gin-gonic/gin"
 
@@ -56,6 +63,36 @@ func main() {
 	// Đảm bảo ngắt kết nối khi server dừng
 	defer adminConfig.DisconnectDatabase()
 
+	// Local candle store read by the kline API and kept current by the backfiller
+	candleStore := &priceRepository.MongoCandleRepository{Collection: adminConfig.DB.Collection(priceRepository.CandleCollection)}
+	if err := candleStore.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create candle indexes: %v", err)
+	}
+	priceKline.SetCandleStore(candleStore)
+	priceKline.NewBackfillerFromEnv(candleStore).Start(context.Background())
+
+	// Recent trades of the followed symbols read by the trades API
+	tradeRecorder := priceTrades.NewRecorderFromEnv()
+	priceTrades.SetRecorder(tradeRecorder)
+	tradeRecorder.Start(context.Background())
+
+	// Liquidations of every futures symbol, stored so the stats and history survive restarts
+	liquidationStore := &priceRepository.MongoLiquidationRepository{Collection: adminConfig.DB.Collection(priceRepository.LiquidationCollection)}
+	if err := liquidationStore.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("Failed to create liquidation indexes: %v", err)
+	}
+	liquidationRecorder := priceLiquidation.NewRecorder(liquidationStore)
+	priceLiquidation.SetRecorder(liquidationRecorder)
+	liquidationRecorder.Start(context.Background())
+
+	// Exchanges and taker fees of the arbitrage endpoints, read once so misconfigurations are logged at startup
+	priceArbitrage.SetConfig(priceArbitrage.NewConfigFromEnv())
+
+	// Global market overview shared by every client, refreshed every MARKET_OVERVIEW_REFRESH
+	marketOverview := priceMarketCap.NewOverviewFromEnv()
+	priceMarketCap.SetOverview(marketOverview)
+	marketOverview.Start(context.Background())
+
 	// Bắt đầu routine dọn dẹp token hết hạn
 	adminUtils.StartCleanupRoutine(10 * time.Minute)
 	adminRoutes.SetupRouter(server)
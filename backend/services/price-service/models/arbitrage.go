@@ -0,0 +1,73 @@
+package models
+
+// ArbitrageVenue is the top of the book of an asset on one exchange, prices converted into the
+// currency of the response. Error is set, and prices left out, when the exchange could not be quoted.
+type ArbitrageVenue struct {
+	Exchange    string  `json:"exchange" example:"binance"`
+	Symbol      string  `json:"symbol" example:"BTCUSDT"`
+	Quote       string  `json:"quote" example:"USDT"`
+	Bid         float64 `json:"bid,omitempty" example:"97010.5"`
+	BidQuantity float64 `json:"bidQuantity,omitempty" example:"1.234"`
+	Ask         float64 `json:"ask,omitempty" example:"97010.6"`
+	AskQuantity float64 `json:"askQuantity,omitempty" example:"0.567"`
+	// Rate is the units of currency worth one unit of the quote asset
+	Rate     float64 `json:"rate,omitempty" example:"1"`
+	TakerFee float64 `json:"takerFee" example:"0.001"`
+	Time     int64   `json:"time,omitempty" example:"1733900000000"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// ResponseArbitrage compares the quotes of an asset across exchanges: buying at the best ask of one
+// exchange and selling at the best bid of another. The net spread pays the taker fee of both venues.
+type ResponseArbitrage struct {
+	Asset              string           `json:"asset" example:"BTC"`
+	Market             string           `json:"market" example:"spot"`
+	Currency           string           `json:"currency" example:"USD"`
+	Venues             []ArbitrageVenue `json:"venues"`
+	BuyExchange        string           `json:"buyExchange" example:"coinbase"`
+	BuyPrice           float64          `json:"buyPrice" example:"96990.01"`
+	SellExchange       string           `json:"sellExchange" example:"binance"`
+	SellPrice          float64          `json:"sellPrice" example:"97010.5"`
+	GrossSpread        float64          `json:"grossSpread" example:"20.49"`
+	GrossSpreadPercent float64          `json:"grossSpreadPercent" example:"0.0211"`
+	NetSpread          float64          `json:"netSpread" example:"-659.61"`
+	NetSpreadPercent   float64          `json:"netSpreadPercent" example:"-0.6801"`
+	Profitable         bool             `json:"profitable" example:"false"`
+	EventTime          string           `json:"eventTime" example:"2024-12-11 07:00:00"`
+}
+
+// UpdateData picks among the quoted venues the pair of two different exchanges with the largest net
+// spread relative to the buy price. Spreads are relative to the buy price and stay 0 while fewer than
+// two exchanges are quoted.
+func (r *ResponseArbitrage) UpdateData(venues []ArbitrageVenue, eventTime string) {
+	r.Venues = venues
+	r.EventTime = eventTime
+
+	var buy, sell *ArbitrageVenue
+	best := 0.0
+	for i := range venues {
+		for j := range venues {
+			buyVenue, sellVenue := &venues[i], &venues[j]
+			if buyVenue.Exchange == sellVenue.Exchange || buyVenue.Error != "" || sellVenue.Error != "" ||
+				buyVenue.Ask <= 0 || sellVenue.Bid <= 0 {
+				continue
+			}
+			netSpread := (sellVenue.Bid*(1-sellVenue.TakerFee) - buyVenue.Ask*(1+buyVenue.TakerFee)) / buyVenue.Ask
+			if buy == nil || netSpread > best {
+				buy, sell, best = buyVenue, sellVenue, netSpread
+			}
+		}
+	}
+	if buy == nil {
+		return
+	}
+
+	r.BuyExchange, r.BuyPrice = buy.Exchange, buy.Ask
+	r.SellExchange, r.SellPrice = sell.Exchange, sell.Bid
+	r.GrossSpread = roundPrice(sell.Bid - buy.Ask)
+	r.GrossSpreadPercent = roundPercent(100 * (sell.Bid - buy.Ask) / buy.Ask)
+	netSpread := sell.Bid*(1-sell.TakerFee) - buy.Ask*(1+buy.TakerFee)
+	r.NetSpread = roundPrice(netSpread)
+	r.NetSpreadPercent = roundPercent(100 * netSpread / buy.Ask)
+	r.Profitable = netSpread > 0
+}
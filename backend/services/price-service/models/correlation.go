@@ -0,0 +1,20 @@
+package models
+
+// ResponseCorrelation holds the correlations of the log returns of symbols over the candles they all
+// have. Matrices follow the order of Symbols and a value is null when a series does not move.
+type ResponseCorrelation struct {
+	Symbols   []string `json:"symbols" example:"BTCUSDT,ETHUSDT,SOLUSDT"`
+	Interval  string   `json:"interval" example:"1d"`
+	Lookback  int      `json:"lookback" example:"90"`
+	Benchmark string   `json:"benchmark" example:"BTCUSDT"`
+	// Observations is the number of returns between the candles every symbol has
+	Observations int    `json:"observations" example:"89"`
+	StartTime    string `json:"startTime" example:"2024-09-22T00:00:00Z"`
+	EndTime      string `json:"endTime" example:"2024-12-20T00:00:00Z"`
+	// Missing counts the candles of each symbol left out because another symbol has no candle at that time
+	Missing   map[string]int      `json:"missing"`
+	Pearson   [][]*float64        `json:"pearson"`
+	Spearman  [][]*float64        `json:"spearman"`
+	Beta      map[string]*float64 `json:"beta"`
+	EventTime string              `json:"eventTime" example:"2024-12-20 08:37:58"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import "math"
+
+// PriceLevel is a price of one side of an order book and the quantity resting at it
+type PriceLevel struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+}
+
+// OrderBook holds the best levels of a symbol, bids from the highest and asks from the lowest price
+type OrderBook struct {
+	Symbol string
+	// LastUpdateID is the sequence of the book on the exchange, 0 when it has none
+	LastUpdateID int64
+	Bids         []PriceLevel
+	Asks         []PriceLevel
+	Time         int64
+}
+
+// DepthEvent is an update of the Binance diff depth stream. Quantities replace the ones
+// of the book and a quantity of 0 removes the level.
+type DepthEvent struct {
+	EventType     string `json:"e"`
+	EventTime     int64  `json:"E"`
+	Symbol        string `json:"s"`
+	FirstUpdateID int64  `json:"U"`
+	FinalUpdateID int64  `json:"u"`
+	// PrevFinalUpdateID is the final update id of the previous event, only sent on futures
+	PrevFinalUpdateID int64      `json:"pu"`
+	Bids              [][]string `json:"b"`
+	Asks              [][]string `json:"a"`
+}
+
+type ResponseDepth struct {
+	Symbol       string       `json:"symbol"`
+	Market       string       `json:"market"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
+	BestBid      float64      `json:"bestBid"`
+	BestAsk      float64      `json:"bestAsk"`
+	Spread       float64      `json:"spread"`
+	MidPrice     float64      `json:"midPrice"`
+	LastUpdateID int64        `json:"lastUpdateId,omitempty"`
+	EventTime    string       `json:"eventTime"`
+}
+
+// UpdateData fills the response from a book, spread and mid price are 0 while a side is empty
+func (r *ResponseDepth) UpdateData(market string, book *OrderBook, eventTime string) {
+	r.Symbol = book.Symbol
+	r.Market = market
+	r.Bids = book.Bids
+	r.Asks = book.Asks
+	r.LastUpdateID = book.LastUpdateID
+	r.EventTime = eventTime
+	if r.Bids == nil {
+		r.Bids = []PriceLevel{}
+	}
+	if r.Asks == nil {
+		r.Asks = []PriceLevel{}
+	}
+	if len(book.Bids) > 0 && len(book.Asks) > 0 {
+		r.BestBid = book.Bids[0].Price
+		r.BestAsk = book.Asks[0].Price
+		// rounded to drop the binary noise of the subtraction, no exchange quotes more than 10 decimals
+		r.Spread = roundPrice(r.BestAsk - r.BestBid)
+		r.MidPrice = roundPrice((r.BestAsk + r.BestBid) / 2)
+	}
+}
+
+func roundPrice(price float64) float64 {
+	return math.Round(price*1e10) / 1e10
+}
@@ -1,5 +1,7 @@
 package models
 
+import "strconv"
+
 type FundingRateFirst struct {
 	Symbol          string `json:"symbol"`
 	FundingRate     string `json:"lastFundingRate"`
@@ -14,6 +16,23 @@ type FundingRateSecond struct {
 	FundingIntervalHours     int    `json:"fundingIntervalHours"`
 }
 
+// FundingRateQuery describes which funding rates to request from a market data provider. Times are
+// unix milliseconds and zero values are unset: the latest rates settled up to EndTime, or now, are
+// returned, none settled before StartTime.
+type FundingRateQuery struct {
+	Symbol    string
+	StartTime int64
+	EndTime   int64
+	Limit     int
+}
+
+type FundingRateHistory struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+	MarkPrice   string `json:"markPrice"`
+}
+
 type ResponseFundingRate struct {
 	Symbol                   string `json:"symbol" example:"QTUMUSDT"`
 	FundingRate              string `json:"fundingRate" example:"0.00010000"`
@@ -43,3 +62,121 @@ type FundingRateWebSocket struct {
 		NextFundingTime int64  `json:"T"`
 	} `json:"data"`
 }
+
+// FundingRateStats summarizes settled funding rates. Rates are fractions as the exchanges give them,
+// AnnualizedRate is the mean rate paid at every funding of a year, in percent.
+type FundingRateStats struct {
+	Count                int     `json:"count" example:"90"`
+	Mean                 float64 `json:"mean" example:"0.0000852"`
+	Min                  float64 `json:"min" example:"-0.0000321"`
+	MinTime              string  `json:"minTime,omitempty" example:"2024-11-20 08:00:00"`
+	Max                  float64 `json:"max" example:"0.0003"`
+	MaxTime              string  `json:"maxTime,omitempty" example:"2024-12-05 16:00:00"`
+	Cumulative           float64 `json:"cumulative" example:"0.007668"`
+	AnnualizedRate       float64 `json:"annualizedRate" example:"9.3294"`
+	NegativeCount        int     `json:"negativeCount" example:"4"`
+	FundingIntervalHours int     `json:"fundingIntervalHours" example:"8"`
+}
+
+// UpdateData computes the statistics of rates settled oldest first. The funding interval is the most
+// common gap between two rates, 8 hours when there are fewer than two.
+func (s *FundingRateStats) UpdateData(history []FundingRateHistory, formatTime func(milliseconds int64) string) {
+	*s = FundingRateStats{Count: len(history), FundingIntervalHours: 8}
+	if len(history) == 0 {
+		return
+	}
+
+	gaps := map[int64]int{}
+	var commonGap int64
+	for i, value := range history {
+		rate, _ := strconv.ParseFloat(value.FundingRate, 64)
+		if i == 0 || rate < s.Min {
+			s.Min, s.MinTime = rate, formatTime(value.FundingTime)
+		}
+		if i == 0 || rate > s.Max {
+			s.Max, s.MaxTime = rate, formatTime(value.FundingTime)
+		}
+		if rate < 0 {
+			s.NegativeCount++
+		}
+		s.Cumulative += rate
+
+		if i > 0 {
+			// funding times may be a few milliseconds late
+			gap := (value.FundingTime - history[i-1].FundingTime + 30*60*1000) / (60 * 60 * 1000)
+			gaps[gap]++
+			if gap > 0 && (gaps[gap] > gaps[commonGap] || commonGap == 0) {
+				commonGap = gap
+			}
+		}
+	}
+	if commonGap > 0 {
+		s.FundingIntervalHours = int(commonGap)
+	}
+
+	// rounded to drop the binary noise of the sum
+	s.Cumulative = roundPrice(s.Cumulative)
+	s.Mean = roundPrice(s.Cumulative / float64(len(history)))
+	s.AnnualizedRate = AnnualizedFundingRate(s.Mean, s.FundingIntervalHours)
+}
+
+// AnnualizedFundingRate returns a rate paid at every funding of a year, in percent
+func AnnualizedFundingRate(rate float64, fundingIntervalHours int) float64 {
+	if fundingIntervalHours <= 0 {
+		fundingIntervalHours = 8
+	}
+	return roundPrice(rate * 100 * 365 * 24 / float64(fundingIntervalHours))
+}
+
+type ResponseFundingRateHistoryEach struct {
+	FundingRate float64 `json:"fundingRate" example:"0.0001"`
+	FundingTime string  `json:"fundingTime" example:"2024-12-11 08:00:00"`
+	MarkPrice   float64 `json:"markPrice,omitempty" example:"97158.9"`
+}
+
+// ResponseFundingRateHistory lists settled funding rates oldest first with their statistics
+type ResponseFundingRateHistory struct {
+	Symbol    string                           `json:"symbol" example:"BTCUSDT"`
+	History   []ResponseFundingRateHistoryEach `json:"history"`
+	Stats     FundingRateStats                 `json:"stats"`
+	EventTime string                           `json:"eventTime" example:"2024-12-11 08:00:05"`
+	// Cursor is the endTime that loads the rates before this page, 0 when there is nothing older
+	Cursor int64 `json:"cursor,omitempty" example:"1733817599999"`
+}
+
+func (r *ResponseFundingRateHistory) UpdateData(symbol string, history []FundingRateHistory, formatTime func(milliseconds int64) string, eventTime string) {
+	r.Symbol = symbol
+	r.EventTime = eventTime
+	r.History = make([]ResponseFundingRateHistoryEach, 0, len(history))
+	for _, value := range history {
+		var each ResponseFundingRateHistoryEach
+		each.FundingRate, _ = strconv.ParseFloat(value.FundingRate, 64)
+		each.MarkPrice, _ = strconv.ParseFloat(value.MarkPrice, 64)
+		each.FundingTime = formatTime(value.FundingTime)
+		r.History = append(r.History, each)
+	}
+	r.Stats.UpdateData(history, formatTime)
+}
+
+// FundingScreenerEntry is the funding of one symbol in the screener. Rates are fractions,
+// annualized rates are in percent and the averages are missing when the history failed to load.
+type FundingScreenerEntry struct {
+	Symbol                string   `json:"symbol" example:"BTCUSDT"`
+	MarkPrice             float64  `json:"markPrice" example:"97158.9"`
+	FundingRate           float64  `json:"fundingRate" example:"0.0001"`
+	AnnualizedRate        float64  `json:"annualizedRate" example:"10.95"`
+	FundingIntervalHours  int      `json:"fundingIntervalHours" example:"8"`
+	NextFundingTime       string   `json:"nextFundingTime" example:"2024-12-11 16:00:00"`
+	AverageFundingRate    *float64 `json:"averageFundingRate,omitempty" example:"0.0000852"`
+	AverageAnnualizedRate *float64 `json:"averageAnnualizedRate,omitempty" example:"9.3294"`
+	NegativeCount         *int     `json:"negativeCount,omitempty" example:"4"`
+}
+
+// ResponseFundingScreener ranks futures symbols by their current or average funding rate over Days
+type ResponseFundingScreener struct {
+	Sort      string                 `json:"sort" example:"current"`
+	Order     string                 `json:"order" example:"desc"`
+	Days      int                    `json:"days" example:"7"`
+	Symbols   []FundingScreenerEntry `json:"symbols"`
+	EventTime string                 `json:"eventTime" example:"2024-12-11 08:00:05"`
+}
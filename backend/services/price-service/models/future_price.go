@@ -29,6 +29,8 @@ type ResponseFuturePrice struct {
 	Symbol    string `json:"symbol"`
 	Price     string `json:"price"`
 	EventTime string `json:"eventTime"`
+	// Conversion is set when the prices were converted with the convert parameter
+	Conversion *Conversion `json:"conversion,omitempty"`
 }
 type ResponseBinanceFuture struct {
 	Symbol               string `json:"symbol"`
@@ -46,3 +48,9 @@ func (r *ResponseFuturePrice) UpdateData(symbol, price, eventTime string) {
 	r.Price = price
 	r.EventTime = eventTime
 }
+
+// ResponseFuturePriceBatch maps each requested symbol to its price, symbols without one are in Errors
+type ResponseFuturePriceBatch struct {
+	Prices map[string]ResponseFuturePrice `json:"prices"`
+	Errors map[string]string              `json:"errors,omitempty"`
+}
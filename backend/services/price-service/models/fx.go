@@ -0,0 +1,19 @@
+package models
+
+// FXRates are the units of each fiat currency, such as VND or EUR, worth one USD
+type FXRates struct {
+	Rates map[string]float64
+	// AsOf is when the source published the rates, in unix milliseconds
+	AsOf   int64
+	Source string
+}
+
+// Conversion tells which currency the prices of a response were converted into and at which rate,
+// the rate of AsOf applies to every price, including historical ones
+type Conversion struct {
+	From   string  `json:"from" example:"USDT"`
+	To     string  `json:"to" example:"VND"`
+	Rate   float64 `json:"rate" example:"25415.5"`
+	AsOf   string  `json:"asOf" example:"2024-12-11 00:02:31"`
+	Source string  `json:"source" example:"open.er-api.com"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "math"
+
+// IndicatorSeries holds the lines of one indicator, each with one value per candle of the response and
+// null where the indicator has no value yet. Lines are named value, or macd, signal and histogram for
+// MACD, upper, middle and lower for Bollinger Bands and k and d for the stochastic oscillator.
+type IndicatorSeries struct {
+	Key    string                `json:"key" example:"rsi_14"`
+	Name   string                `json:"name" example:"rsi"`
+	Params []float64             `json:"params"`
+	Lines  map[string][]*float64 `json:"lines"`
+}
+
+// ResponseIndicatorSeries is a kline response with indicator series aligned to its candles
+type ResponseIndicatorSeries struct {
+	KlineResponse
+	Indicators []IndicatorSeries `json:"indicators"`
+}
+
+// UpdateLine sets a line from the indicator values of the last count candles, rounded to 8 decimals
+func (s *IndicatorSeries) UpdateLine(name string, values []float64, count int) {
+	if s.Lines == nil {
+		s.Lines = map[string][]*float64{}
+	}
+	line := make([]*float64, 0, count)
+	for _, value := range values[len(values)-count:] {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			line = append(line, nil)
+			continue
+		}
+		rounded := math.Round(value*1e8) / 1e8
+		line = append(line, &rounded)
+	}
+	s.Lines[name] = line
+}
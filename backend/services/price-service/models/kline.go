@@ -5,6 +5,10 @@ type KlineResponse struct {
 	Interval  string          `json:"interval"`
 	EventTime string          `json:"eventTime"`
 	KlineData []KLineEachData `json:"kline_data"`
+	// Cursor is the endTime that loads the candles before this page, 0 when there is nothing older
+	Cursor int64 `json:"cursor,omitempty"`
+	// Conversion is set when the prices were converted with the convert parameter
+	Conversion *Conversion `json:"conversion,omitempty"`
 }
 
 func (kline *KlineResponse) UpdateKlineResponse(symbol, interval, eventTime string) {
@@ -34,7 +38,49 @@ func (klineEach *KLineEachData) UpdateKlineEachData(time string, open, high, low
 	klineEach.Volume = volume
 }
 
-// struct for KlineWebsocket
+// KlineQuery describes which candles to request from a market data provider.
+// Times are unix milliseconds and zero values are unset: without StartTime the latest
+// candles up to EndTime (or now) are returned, with it the candles from StartTime on.
+type KlineQuery struct {
+	Symbol    string
+	Interval  string
+	StartTime int64
+	EndTime   int64
+	Limit     int
+	// Market is spot or futures, futures when empty
+	Market string
+	// Timezone aligns resampled candles, providers ignore it
+	Timezone string
+	// Convert is the fiat currency the prices are converted into, providers ignore it
+	Convert string
+}
+
+// Candle is one kline as returned by a market data provider, times are unix milliseconds
+type Candle struct {
+	OpenTime  int64   `json:"openTime" bson:"openTime"`
+	CloseTime int64   `json:"closeTime" bson:"closeTime"`
+	Open      float64 `json:"open" bson:"open"`
+	High      float64 `json:"high" bson:"high"`
+	Low       float64 `json:"low" bson:"low"`
+	Close     float64 `json:"close" bson:"close"`
+	Volume    float64 `json:"volume" bson:"volume"`
+}
+
+// CandleKey identifies one candle series of the local candle store
+type CandleKey struct {
+	Exchange string `bson:"exchange"`
+	Market   string `bson:"market"`
+	Symbol   string `bson:"symbol"`
+	Interval string `bson:"interval"`
+}
+
+// StoredCandle is a closed candle of the local candle store
+type StoredCandle struct {
+	CandleKey `bson:",inline"`
+	Candle    `bson:",inline"`
+}
+
+// KlineWebsocket is a kline event of a Binance spot or futures combined stream
 type KlineWebsocket struct {
 	Data struct {
 		EventType string `json:"e"`
@@ -52,6 +98,8 @@ type KlineWebsocket struct {
 			QuoteAssetVolume    string `json:"q"`
 			TakerBuyBaseVolume  string `json:"V"`
 			TakerBuyQuoteVolume string `json:"Q"`
+			Interval            string `json:"i"`
+			IsFinal             bool   `json:"x"`
 		} `json:"k"`
 	} `json:"data"`
 }
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"math"
+	"strconv"
+)
+
+const (
+	PositionLong  = "long"
+	PositionShort = "short"
+)
+
+// Liquidation is one forced order of Binance futures. Side is the side of the liquidated position:
+// a long position is closed by a sell order and a short position by a buy order.
+type Liquidation struct {
+	Symbol   string  `json:"symbol" bson:"symbol"`
+	Side     string  `json:"side" bson:"side"`
+	Price    float64 `json:"price" bson:"price"`
+	Quantity float64 `json:"quantity" bson:"quantity"`
+	// Notional is the filled quantity at the average price, in the quote asset
+	Notional float64 `json:"notional" bson:"notional"`
+	Time     int64   `json:"time" bson:"time"`
+}
+
+// LiquidationQuery describes which stored liquidations to read. An empty Symbol means every symbol,
+// times are unix milliseconds and zero values are unset: the latest Limit liquidations of the range are read.
+type LiquidationQuery struct {
+	Symbol      string
+	MinNotional float64
+	StartTime   int64
+	EndTime     int64
+	Limit       int
+}
+
+// Matches tells whether a liquidation is in the range of the query and reaches its minimum notional
+func (q *LiquidationQuery) Matches(liquidation *Liquidation) bool {
+	return (q.Symbol == "" || liquidation.Symbol == q.Symbol) &&
+		liquidation.Notional >= q.MinNotional &&
+		(q.StartTime == 0 || liquidation.Time >= q.StartTime) &&
+		(q.EndTime == 0 || liquidation.Time <= q.EndTime)
+}
+
+// ForceOrderWebsocket is an event of the Binance futures forceOrder streams
+type ForceOrderWebsocket struct {
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+	Order     struct {
+		Symbol         string `json:"s"`
+		Side           string `json:"S"`
+		OrderType      string `json:"o"`
+		TimeInForce    string `json:"f"`
+		Quantity       string `json:"q"`
+		Price          string `json:"p"`
+		AveragePrice   string `json:"ap"`
+		Status         string `json:"X"`
+		LastFilled     string `json:"l"`
+		FilledQuantity string `json:"z"`
+		TradeTime      int64  `json:"T"`
+	} `json:"o"`
+}
+
+// Liquidation returns the liquidation of the event, valued at the average price of the filled quantity
+func (f *ForceOrderWebsocket) Liquidation() Liquidation {
+	price, _ := strconv.ParseFloat(f.Order.AveragePrice, 64)
+	quantity, _ := strconv.ParseFloat(f.Order.FilledQuantity, 64)
+	if price == 0 {
+		price, _ = strconv.ParseFloat(f.Order.Price, 64)
+	}
+	if quantity == 0 {
+		quantity, _ = strconv.ParseFloat(f.Order.Quantity, 64)
+	}
+	side := PositionLong
+	if f.Order.Side == "BUY" {
+		side = PositionShort
+	}
+	return Liquidation{
+		Symbol:   f.Order.Symbol,
+		Side:     side,
+		Price:    price,
+		Quantity: quantity,
+		Notional: roundPrice(price * quantity),
+		Time:     f.Order.TradeTime,
+	}
+}
+
+// LiquidationWindowStats sums the liquidations of a window ending now, notionals are in the quote asset
+type LiquidationWindowStats struct {
+	Window        string  `json:"window" example:"1h"`
+	LongNotional  float64 `json:"longNotional" example:"1250000.5"`
+	ShortNotional float64 `json:"shortNotional" example:"320000.25"`
+	TotalNotional float64 `json:"totalNotional" example:"1570000.75"`
+	LongCount     int     `json:"longCount" example:"42"`
+	ShortCount    int     `json:"shortCount" example:"11"`
+	// LongShare is the share of the notional liquidated from long positions, from 0 to 1
+	LongShare float64 `json:"longShare" example:"0.7962"`
+}
+
+// Add counts one liquidation in the window
+func (s *LiquidationWindowStats) Add(liquidation *Liquidation) {
+	if liquidation.Side == PositionShort {
+		s.ShortNotional = roundPrice(s.ShortNotional + liquidation.Notional)
+		s.ShortCount++
+	} else {
+		s.LongNotional = roundPrice(s.LongNotional + liquidation.Notional)
+		s.LongCount++
+	}
+	s.TotalNotional = roundPrice(s.LongNotional + s.ShortNotional)
+	if s.TotalNotional > 0 {
+		s.LongShare = math.Round(s.LongNotional/s.TotalNotional*1e4) / 1e4
+	}
+}
+
+type LiquidationSymbolStats struct {
+	Symbol  string                   `json:"symbol" example:"BTCUSDT"`
+	Windows []LiquidationWindowStats `json:"windows"`
+}
+
+// ResponseLiquidationStats holds the liquidations of every window per symbol, by total notional of the
+// longest window, and of every symbol together. Since is the time of the first recorded liquidation,
+// windows reaching further back are incomplete.
+type ResponseLiquidationStats struct {
+	Symbols   []LiquidationSymbolStats `json:"symbols"`
+	Total     []LiquidationWindowStats `json:"total"`
+	Since     string                   `json:"since,omitempty" example:"2024-12-10 07:00:00"`
+	EventTime string                   `json:"eventTime" example:"2024-12-11 07:00:00"`
+}
+
+type ResponseLiquidations struct {
+	Liquidations []ResponseLiquidation `json:"liquidations"`
+	EventTime    string                `json:"eventTime" example:"2024-12-11 07:00:00"`
+	// Cursor is the endTime that loads the liquidations before this page, 0 when there is nothing older
+	Cursor int64 `json:"cursor,omitempty"`
+}
+
+type ResponseLiquidation struct {
+	Symbol   string  `json:"symbol" example:"BTCUSDT"`
+	Side     string  `json:"side" example:"long"`
+	Price    float64 `json:"price" example:"97010.5"`
+	Quantity float64 `json:"quantity" example:"1.25"`
+	Notional float64 `json:"notional" example:"121263.13"`
+	Time     string  `json:"time" example:"2024-12-11 07:00:00"`
+}
+
+func (r *ResponseLiquidation) UpdateData(liquidation *Liquidation, time string) {
+	r.Symbol = liquidation.Symbol
+	r.Side = liquidation.Side
+	r.Price = liquidation.Price
+	r.Quantity = liquidation.Quantity
+	r.Notional = liquidation.Notional
+	r.Time = time
+}
@@ -1,7 +1,9 @@
 package models
 
 type MarketCapResponse struct {
+	ID         string `json:"id"`
 	Symbol     string `json:"symbol"`
+	Name       string `json:"name"`
 	MarketData struct {
 		MarketCap struct {
 			USD int64 `json:"usd"`
@@ -10,12 +12,18 @@ type MarketCapResponse struct {
 			USD int64 `json:"usd"`
 		} `json:"total_volume"`
 	} `json:"market_data"`
+	LastUpdated string `json:"last_updated"`
 }
 
 type FormatMarketCapResponse struct {
-	Symbol      string `json:"symbol"`
-	MarketCap   int64  `json:"market_cap"`
-	TotalVolume int64  `json:"24h_volume"`
+	ID          string `json:"id,omitempty" example:"bitcoin"`
+	Symbol      string `json:"symbol" example:"btc"`
+	Name        string `json:"name,omitempty" example:"Bitcoin"`
+	MarketCap   int64  `json:"market_cap" example:"1925000000000"`
+	TotalVolume int64  `json:"24h_volume" example:"45000000000"`
+	LastUpdated string `json:"last_updated,omitempty" example:"2024-12-11T07:00:00.000Z"`
+	// Conversion is set when the market cap and volume were converted from USD with the convert parameter
+	Conversion *Conversion `json:"conversion,omitempty"`
 }
 
 func CreateReponseFormat(symbol string, marketCap, totalVolume int64) *FormatMarketCapResponse {
@@ -25,3 +33,36 @@ func CreateReponseFormat(symbol string, marketCap, totalVolume int64) *FormatMar
 		TotalVolume: totalVolume,
 	}
 }
+
+// CoinGeckoCoin is one entry of the CoinGecko coin list
+type CoinGeckoCoin struct {
+	ID     string `json:"id"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+// CoinGeckoMarket is one entry of the CoinGecko coin markets, ranked by market cap
+type CoinGeckoMarket struct {
+	ID                       string  `json:"id"`
+	Symbol                   string  `json:"symbol"`
+	Name                     string  `json:"name"`
+	CurrentPrice             float64 `json:"current_price"`
+	MarketCap                float64 `json:"market_cap"`
+	MarketCapRank            int     `json:"market_cap_rank"`
+	TotalVolume              float64 `json:"total_volume"`
+	PriceChangePercentage24h float64 `json:"price_change_percentage_24h"`
+}
+
+// CoinGeckoGlobal is the CoinGecko overview of the whole crypto market
+type CoinGeckoGlobal struct {
+	Data struct {
+		ActiveCryptocurrencies int                `json:"active_cryptocurrencies"`
+		TotalMarketCap         map[string]float64 `json:"total_market_cap"`
+		TotalVolume            map[string]float64 `json:"total_volume"`
+		// MarketCapPercentage is the share of the total market cap of the largest coins by ticker, in percent
+		MarketCapPercentage             map[string]float64 `json:"market_cap_percentage"`
+		MarketCapChangePercentage24hUSD float64            `json:"market_cap_change_percentage_24h_usd"`
+		// UpdatedAt is in unix seconds
+		UpdatedAt int64 `json:"updated_at"`
+	} `json:"data"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "math"
+
+// MarketOverviewCoin is one coin of the market overview rank lists
+type MarketOverviewCoin struct {
+	Rank                  int     `json:"rank" example:"1"`
+	ID                    string  `json:"id" example:"bitcoin"`
+	Symbol                string  `json:"symbol" example:"BTC"`
+	Name                  string  `json:"name" example:"Bitcoin"`
+	Price                 float64 `json:"price" example:"97102.5"`
+	MarketCap             float64 `json:"marketCap" example:"1925000000000"`
+	Volume24h             float64 `json:"volume24h" example:"45000000000"`
+	PriceChangePercent24h float64 `json:"priceChangePercent24h" example:"1.27"`
+	// Dominance is the share of the total market cap in percent
+	Dominance float64 `json:"dominance" example:"56.12"`
+}
+
+// ResponseMarketOverview is the global crypto market with its largest coins, the best and the worst
+// 24h performers among them
+type ResponseMarketOverview struct {
+	TotalMarketCap            float64              `json:"totalMarketCap" example:"3430000000000"`
+	TotalVolume24h            float64              `json:"totalVolume24h" example:"152000000000"`
+	MarketCapChangePercent24h float64              `json:"marketCapChangePercent24h" example:"1.83"`
+	BTCDominance              float64              `json:"btcDominance" example:"56.12"`
+	ETHDominance              float64              `json:"ethDominance" example:"13.05"`
+	ActiveCryptocurrencies    int                  `json:"activeCryptocurrencies" example:"16231"`
+	TopMarketCap              []MarketOverviewCoin `json:"topMarketCap"`
+	TopGainers                []MarketOverviewCoin `json:"topGainers"`
+	TopLosers                 []MarketOverviewCoin `json:"topLosers"`
+	// UpdatedAt is when CoinGecko computed the overview, EventTime when it was fetched
+	UpdatedAt string `json:"updatedAt" example:"2024-12-11 07:00:00"`
+	EventTime string `json:"eventTime" example:"2024-12-11 07:00:05"`
+}
+
+func (r *ResponseMarketOverview) UpdateData(global *CoinGeckoGlobal, updatedAt, eventTime string) {
+	r.TotalMarketCap = global.Data.TotalMarketCap["usd"]
+	r.TotalVolume24h = global.Data.TotalVolume["usd"]
+	r.MarketCapChangePercent24h = roundPercent(global.Data.MarketCapChangePercentage24hUSD)
+	r.BTCDominance = roundPercent(global.Data.MarketCapPercentage["btc"])
+	r.ETHDominance = roundPercent(global.Data.MarketCapPercentage["eth"])
+	r.ActiveCryptocurrencies = global.Data.ActiveCryptocurrencies
+	r.UpdatedAt = updatedAt
+	r.EventTime = eventTime
+}
+
+// roundPercent keeps the 4 decimals CoinGecko percentages are meaningful to
+func roundPercent(percent float64) float64 {
+	return math.Round(percent*1e4) / 1e4
+}
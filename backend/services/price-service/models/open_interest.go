@@ -0,0 +1,136 @@
+package models
+
+const (
+	// RatioTopAccounts compares the accounts of the top traders with long and short positions
+	RatioTopAccounts = "top-account"
+	// RatioTopPositions compares the long and short positions of the top traders
+	RatioTopPositions = "top-position"
+	// RatioGlobalAccounts compares every account with long and short positions
+	RatioGlobalAccounts = "global-account"
+)
+
+// PositioningQuery describes which open interest or long/short ratio series to request from a
+// market data provider. Period is a Binance period such as 5m, 1h or 1d. Times are unix milliseconds
+// and zero values are unset: without StartTime the latest points up to EndTime, or now, are returned.
+type PositioningQuery struct {
+	Symbol    string
+	Period    string
+	StartTime int64
+	EndTime   int64
+	Limit     int
+}
+
+// OpenInterest is the open interest of a futures symbol in its base asset and, when the
+// exchange gives it, in its quote asset
+type OpenInterest struct {
+	Symbol            string  `json:"symbol" example:"BTCUSDT"`
+	OpenInterest      float64 `json:"openInterest" example:"81234.567"`
+	OpenInterestValue float64 `json:"openInterestValue,omitempty" example:"7891234567.89"`
+	Time              int64   `json:"time" example:"1733900000000"`
+}
+
+// LongShortRatio is one point of a long/short ratio series, LongAccount and ShortAccount are the
+// shares of the accounts, or of the positions for RatioTopPositions, from 0 to 1
+type LongShortRatio struct {
+	Symbol         string  `json:"symbol" example:"BTCUSDT"`
+	LongShortRatio float64 `json:"longShortRatio" example:"1.8123"`
+	LongAccount    float64 `json:"longAccount" example:"0.6444"`
+	ShortAccount   float64 `json:"shortAccount" example:"0.3556"`
+	Time           int64   `json:"time" example:"1733900000000"`
+}
+
+type ResponseOpenInterest struct {
+	Symbol            string  `json:"symbol" example:"BTCUSDT"`
+	OpenInterest      float64 `json:"openInterest" example:"81234.567"`
+	OpenInterestValue float64 `json:"openInterestValue" example:"7891234567.89"`
+	MarkPrice         float64 `json:"markPrice" example:"97143.2"`
+	EventTime         string  `json:"eventTime" example:"2024-12-11 07:00:00"`
+}
+
+// UpdateData values the open interest at the mark price when the exchange does not
+func (r *ResponseOpenInterest) UpdateData(openInterest *OpenInterest, markPrice float64, eventTime string) {
+	r.Symbol = openInterest.Symbol
+	r.OpenInterest = openInterest.OpenInterest
+	r.OpenInterestValue = openInterest.OpenInterestValue
+	if r.OpenInterestValue == 0 {
+		r.OpenInterestValue = roundPrice(openInterest.OpenInterest * markPrice)
+	}
+	r.MarkPrice = markPrice
+	r.EventTime = eventTime
+}
+
+type ResponseOpenInterestEach struct {
+	Time              string  `json:"time" example:"2024-12-11T07:00:00Z"`
+	OpenInterest      float64 `json:"openInterest" example:"81234.567"`
+	OpenInterestValue float64 `json:"openInterestValue,omitempty" example:"7891234567.89"`
+}
+
+// ResponseOpenInterestHistory is an open interest series oldest first, its times are the RFC 3339
+// times of the klines so both can be charted together
+type ResponseOpenInterestHistory struct {
+	Symbol    string                     `json:"symbol" example:"BTCUSDT"`
+	Period    string                     `json:"period" example:"1h"`
+	History   []ResponseOpenInterestEach `json:"history"`
+	EventTime string                     `json:"eventTime" example:"2024-12-11 07:00:05"`
+	// Cursor is the endTime that loads the points before this page, 0 when the page is empty or reaches startTime
+	Cursor int64 `json:"cursor,omitempty"`
+}
+
+func (r *ResponseOpenInterestHistory) UpdateData(symbol, period string, history []OpenInterest, formatTime func(milliseconds int64) string, eventTime string) {
+	r.Symbol = symbol
+	r.Period = period
+	r.EventTime = eventTime
+	r.History = make([]ResponseOpenInterestEach, 0, len(history))
+	for _, value := range history {
+		r.History = append(r.History, ResponseOpenInterestEach{
+			Time:              formatTime(value.Time),
+			OpenInterest:      value.OpenInterest,
+			OpenInterestValue: value.OpenInterestValue,
+		})
+	}
+}
+
+type ResponseLongShortRatioEach struct {
+	Time           string  `json:"time" example:"2024-12-11T07:00:00Z"`
+	LongShortRatio float64 `json:"longShortRatio" example:"1.8123"`
+	LongAccount    float64 `json:"longAccount" example:"0.6444"`
+	ShortAccount   float64 `json:"shortAccount" example:"0.3556"`
+}
+
+// ResponseLongShortRatio is a long/short ratio series oldest first, Type is top-account,
+// top-position or global-account
+type ResponseLongShortRatio struct {
+	Symbol    string                       `json:"symbol" example:"BTCUSDT"`
+	Type      string                       `json:"type" example:"global-account"`
+	Period    string                       `json:"period" example:"1h"`
+	History   []ResponseLongShortRatioEach `json:"history"`
+	EventTime string                       `json:"eventTime" example:"2024-12-11 07:00:05"`
+	// Cursor is the endTime that loads the points before this page, 0 when the page is empty or reaches startTime
+	Cursor int64 `json:"cursor,omitempty"`
+}
+
+func (r *ResponseLongShortRatio) UpdateData(symbol, kind, period string, history []LongShortRatio, formatTime func(milliseconds int64) string, eventTime string) {
+	r.Symbol = symbol
+	r.Type = kind
+	r.Period = period
+	r.EventTime = eventTime
+	r.History = make([]ResponseLongShortRatioEach, 0, len(history))
+	for _, value := range history {
+		r.History = append(r.History, ResponseLongShortRatioEach{
+			Time:           formatTime(value.Time),
+			LongShortRatio: value.LongShortRatio,
+			LongAccount:    value.LongAccount,
+			ShortAccount:   value.ShortAccount,
+		})
+	}
+}
+
+// ResponseOpenInterestFrame is sent on the open interest socket at every poll. Change is the
+// change of the open interest since the previous frame, the ratios are the latest 5 minute points
+// and are left out when the exchange has none.
+type ResponseOpenInterestFrame struct {
+	ResponseOpenInterest
+	Change             float64         `json:"change" example:"12.5"`
+	GlobalAccountRatio *LongShortRatio `json:"globalAccountRatio,omitempty"`
+	TopPositionRatio   *LongShortRatio `json:"topPositionRatio,omitempty"`
+}
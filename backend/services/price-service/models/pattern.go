@@ -0,0 +1,38 @@
+package models
+
+const (
+	DirectionBullish = "bullish"
+	DirectionBearish = "bearish"
+	DirectionNeutral = "neutral"
+)
+
+// CandlePattern is a candlestick pattern found over the candles at Indices, Time being the open time
+// of the last one. Confidence goes from 0 to 1 with how clearly the candles match the pattern.
+type CandlePattern struct {
+	Pattern    string  `json:"pattern" example:"bullish_engulfing"`
+	Direction  string  `json:"direction" example:"bullish"`
+	Indices    []int   `json:"indices" example:"498,499"`
+	Time       string  `json:"time" example:"2024-11-21T00:00:00Z"`
+	Confidence float64 `json:"confidence" example:"0.82"`
+}
+
+// ResponseCandlePatterns is a kline response with the patterns found over its candles, oldest first
+type ResponseCandlePatterns struct {
+	KlineResponse
+	Patterns []CandlePattern `json:"patterns"`
+}
+
+// PatternScanEntry holds the recent patterns of one symbol of a scan, or why it could not be scanned
+type PatternScanEntry struct {
+	Symbol   string          `json:"symbol" example:"BTCUSDT"`
+	Patterns []CandlePattern `json:"patterns"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// ResponsePatternScan lists the patterns that ended within the last Recent candles of every symbol
+type ResponsePatternScan struct {
+	Interval  string             `json:"interval" example:"1h"`
+	Recent    int                `json:"recent" example:"3"`
+	Symbols   []PatternScanEntry `json:"symbols"`
+	EventTime string             `json:"eventTime" example:"2024-12-11 07:00:00"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import "strconv"
+
+// MarkPriceWebsocket is an event of the Binance futures mark price stream
+type MarkPriceWebsocket struct {
+	EventType            string `json:"e"`
+	EventTime            int64  `json:"E"`
+	Symbol               string `json:"s"`
+	MarkPrice            string `json:"p"`
+	IndexPrice           string `json:"i"`
+	EstimatedSettlePrice string `json:"P"`
+	FundingRate          string `json:"r"`
+	NextFundingTime      int64  `json:"T"`
+}
+
+// PremiumIndex returns the event as the premium index of the REST API, which has no interest rate
+func (m *MarkPriceWebsocket) PremiumIndex() *ResponseBinanceFuture {
+	return &ResponseBinanceFuture{
+		Symbol:               m.Symbol,
+		MarkPrice:            m.MarkPrice,
+		IndexPrice:           m.IndexPrice,
+		EstimatedSettlePrice: m.EstimatedSettlePrice,
+		LastFundingRate:      m.FundingRate,
+		NextFundingTime:      m.NextFundingTime,
+		Time:                 m.EventTime,
+	}
+}
+
+// ResponsePremium compares the prices of a perpetual contract with the spot price of its pair.
+// Basis is the last futures price minus the spot price and BasisPercent its share of the spot price.
+// The basis of a perpetual is paid through funding, AnnualizedBasis is BasisPercent earned at every
+// funding of a year at the funding interval of the contract. It assumes the basis holds for the year
+// and is paid in full at every funding. AnnualizedFundingRate is the last funding rate paid at every funding of a year at the funding
+// interval of the contract, in percent. It assumes the rate holds for the year, which it rarely does.
+type ResponsePremium struct {
+	Symbol                string  `json:"symbol" example:"BTCUSDT"`
+	MarkPrice             float64 `json:"markPrice" example:"97158.9"`
+	IndexPrice            float64 `json:"indexPrice" example:"97140.12"`
+	EstimatedSettlePrice  float64 `json:"estimatedSettlePrice" example:"97142.3"`
+	LastPrice             float64 `json:"lastPrice" example:"97160.4"`
+	SpotPrice             float64 `json:"spotPrice" example:"97110.6"`
+	Basis                 float64 `json:"basis" example:"49.8"`
+	BasisPercent          float64 `json:"basisPercent" example:"0.0512817"`
+	AnnualizedBasis       float64 `json:"annualizedBasis" example:"56.1534615"`
+	FundingRate           float64 `json:"fundingRate" example:"0.0001"`
+	FundingIntervalHours  int     `json:"fundingIntervalHours" example:"8"`
+	AnnualizedFundingRate float64 `json:"annualizedFundingRate" example:"10.95"`
+	InterestRate          float64 `json:"interestRate" example:"0.0001"`
+	NextFundingTime       string  `json:"nextFundingTime" example:"2024-12-11 08:00:00"`
+	EventTime             string  `json:"eventTime" example:"2024-12-11 07:00:01"`
+}
+
+// UpdateData fills the response, an unknown funding interval is taken as 8 hours
+func (r *ResponsePremium) UpdateData(premiumIndex *ResponseBinanceFuture, lastPrice, spotPrice float64, fundingIntervalHours int, nextFundingTime, eventTime string) {
+	r.Symbol = premiumIndex.Symbol
+	r.MarkPrice, _ = strconv.ParseFloat(premiumIndex.MarkPrice, 64)
+	r.IndexPrice, _ = strconv.ParseFloat(premiumIndex.IndexPrice, 64)
+	r.EstimatedSettlePrice, _ = strconv.ParseFloat(premiumIndex.EstimatedSettlePrice, 64)
+	r.FundingRate, _ = strconv.ParseFloat(premiumIndex.LastFundingRate, 64)
+	r.InterestRate, _ = strconv.ParseFloat(premiumIndex.InterestRate, 64)
+	r.LastPrice = lastPrice
+	r.SpotPrice = spotPrice
+	r.NextFundingTime = nextFundingTime
+	r.EventTime = eventTime
+
+	if fundingIntervalHours <= 0 {
+		fundingIntervalHours = 8
+	}
+	r.FundingIntervalHours = fundingIntervalHours
+
+	r.Basis, r.BasisPercent, r.AnnualizedBasis = 0, 0, 0
+	if lastPrice > 0 && spotPrice > 0 {
+		r.Basis = roundPrice(lastPrice - spotPrice)
+		r.BasisPercent = roundPrice((lastPrice - spotPrice) / spotPrice * 100)
+		r.AnnualizedBasis = roundPrice((lastPrice - spotPrice) / spotPrice * 100 * 365 * 24 / float64(fundingIntervalHours))
+	}
+	r.AnnualizedFundingRate = AnnualizedFundingRate(r.FundingRate, fundingIntervalHours)
+}
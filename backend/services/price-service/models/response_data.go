@@ -15,10 +15,12 @@ type ErrorResponseInputMissing struct {
 }
 
 type ResponseKline struct {
-	Symbol    string           `json:"symbol" example:"BTCUSDT"`
-	Interval  string           `json:"interval" example:"1m"`
-	EventTime string           `json:"eventTime" example:"2024-11-21 08:37:58"`
-	KlineData []KlineDataPoint `json:"kline_data"`
+	Symbol     string           `json:"symbol" example:"BTCUSDT"`
+	Interval   string           `json:"interval" example:"1m"`
+	EventTime  string           `json:"eventTime" example:"2024-11-21 08:37:58"`
+	KlineData  []KlineDataPoint `json:"kline_data"`
+	Cursor     int64            `json:"cursor" example:"1732147199999"`
+	Conversion *Conversion      `json:"conversion,omitempty"`
 }
 
 type KlineDataPoint struct {
@@ -26,10 +26,32 @@ type SpotTickerWebSocket struct {
 	TradeCount       int    `json:"n"`
 }
 
+// Ticker24h returns the rolling window statistics of a ticker stream event, the futures
+// ticker stream has the same fields without the best bid and ask
+func (t *SpotTickerWebSocket) Ticker24h() *Ticker24h {
+	return &Ticker24h{
+		Symbol:             t.Symbol,
+		LastPrice:          t.LastPrice,
+		PriceChange:        t.PriceChange,
+		PriceChangePercent: t.PriceChangePct,
+		WeightedAvgPrice:   t.WeightedAvgPrice,
+		OpenPrice:          t.OpenPrice,
+		HighPrice:          t.HighPrice,
+		LowPrice:           t.LowPrice,
+		Volume:             t.Volume,
+		QuoteVolume:        t.QuoteVolume,
+		OpenTime:           t.OpenTime,
+		CloseTime:          t.CloseTime,
+		TradeCount:         int64(t.TradeCount),
+	}
+}
+
 type ResponseSpotPrice struct {
 	Symbol    string `json:"symbol"`
 	Price     string `json:"price"`
 	EventTime string `json:"eventTime"`
+	// Conversion is set when the prices were converted with the convert parameter
+	Conversion *Conversion `json:"conversion,omitempty"`
 }
 
 type ResponseBinance struct {
@@ -43,3 +65,9 @@ func (r *ResponseSpotPrice) UpdateData(symbol, price, eventTime string) {
 	r.Price = price
 	r.EventTime = eventTime
 }
+
+// ResponseSpotPriceBatch maps each requested symbol to its price, symbols without one are in Errors
+type ResponseSpotPriceBatch struct {
+	Prices map[string]ResponseSpotPrice `json:"prices"`
+	Errors map[string]string            `json:"errors,omitempty"`
+}
@@ -0,0 +1,4 @@
+package models
+
+// StatusCode is the HTTP status code a service function wants the handler to respond with
+type StatusCode int
@@ -0,0 +1,35 @@
+package models
+
+// StreamRequest is a frame sent by a client of the multiplexed stream,
+// e.g. {"op":"subscribe","channel":"spot","symbols":["BTCUSDT","ETHUSDT"]}
+type StreamRequest struct {
+	Op      string   `json:"op"`
+	Channel string   `json:"channel"`
+	Symbols []string `json:"symbols"`
+}
+
+// StreamAck answers every StreamRequest, Error tells why it was rejected
+type StreamAck struct {
+	Op      string   `json:"op"`
+	Channel string   `json:"channel"`
+	Symbols []string `json:"symbols"`
+	Success bool     `json:"success"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// StreamMessage carries one update of a subscribed channel and symbol.
+// A message with Error ends the subscription, the client may subscribe again.
+// A message with Status tells that the upstream feed is reconnecting or connected again.
+type StreamMessage struct {
+	Channel string      `json:"channel"`
+	Symbol  string      `json:"symbol"`
+	Data    interface{} `json:"data,omitempty"`
+	Status  string      `json:"status,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// StreamStatus is sent on the single symbol sockets when the upstream feed degrades or recovers
+type StreamStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
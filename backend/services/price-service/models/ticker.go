@@ -0,0 +1,54 @@
+package models
+
+// Ticker24h holds the statistics of a symbol over the rolling last 24 hours.
+// Prices and volumes keep the precision of the exchange, fields an exchange does not give are empty.
+type Ticker24h struct {
+	Symbol             string `json:"symbol"`
+	LastPrice          string `json:"lastPrice"`
+	PriceChange        string `json:"priceChange"`
+	PriceChangePercent string `json:"priceChangePercent"`
+	WeightedAvgPrice   string `json:"weightedAvgPrice"`
+	OpenPrice          string `json:"openPrice"`
+	HighPrice          string `json:"highPrice"`
+	LowPrice           string `json:"lowPrice"`
+	// Volume is in the base asset and QuoteVolume in the quote asset
+	Volume      string `json:"volume"`
+	QuoteVolume string `json:"quoteVolume"`
+	OpenTime    int64  `json:"openTime"`
+	CloseTime   int64  `json:"closeTime"`
+	TradeCount  int64  `json:"count"`
+}
+
+type ResponseTicker24h struct {
+	Symbol             string `json:"symbol"`
+	Market             string `json:"market"`
+	LastPrice          string `json:"lastPrice"`
+	PriceChange        string `json:"priceChange"`
+	PriceChangePercent string `json:"priceChangePercent"`
+	WeightedAvgPrice   string `json:"weightedAvgPrice,omitempty"`
+	OpenPrice          string `json:"openPrice"`
+	HighPrice          string `json:"highPrice"`
+	LowPrice           string `json:"lowPrice"`
+	Volume             string `json:"volume"`
+	QuoteVolume        string `json:"quoteVolume,omitempty"`
+	TradeCount         int64  `json:"tradeCount,omitempty"`
+	OpenTime           string `json:"openTime"`
+	EventTime          string `json:"eventTime"`
+}
+
+func (r *ResponseTicker24h) UpdateData(market string, ticker *Ticker24h, openTime, eventTime string) {
+	r.Symbol = ticker.Symbol
+	r.Market = market
+	r.LastPrice = ticker.LastPrice
+	r.PriceChange = ticker.PriceChange
+	r.PriceChangePercent = ticker.PriceChangePercent
+	r.WeightedAvgPrice = ticker.WeightedAvgPrice
+	r.OpenPrice = ticker.OpenPrice
+	r.HighPrice = ticker.HighPrice
+	r.LowPrice = ticker.LowPrice
+	r.Volume = ticker.Volume
+	r.QuoteVolume = ticker.QuoteVolume
+	r.TradeCount = ticker.TradeCount
+	r.OpenTime = openTime
+	r.EventTime = eventTime
+}
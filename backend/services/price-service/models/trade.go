@@ -0,0 +1,111 @@
+package models
+
+import "strconv"
+
+const (
+	SideBuy  = "buy"
+	SideSell = "sell"
+)
+
+// Trade is one trade as returned by a market data provider, Binance gives aggregated trades
+type Trade struct {
+	ID       string  `json:"id"`
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+	// Side is the side of the taker, buy or sell
+	Side string `json:"side"`
+	Time int64  `json:"time"`
+}
+
+// After tells whether the trade came after other, by their ids when both are numbers as on
+// Binance, OKX and Coinbase, by their times otherwise
+func (t *Trade) After(other *Trade) bool {
+	id, err := strconv.ParseInt(t.ID, 10, 64)
+	otherID, otherErr := strconv.ParseInt(other.ID, 10, 64)
+	if err != nil || otherErr != nil {
+		return t.Time > other.Time
+	}
+	return id > otherID
+}
+
+// AggTradeWebsocket is an event of the Binance aggTrade stream
+type AggTradeWebsocket struct {
+	EventType    string `json:"e"`
+	EventTime    int64  `json:"E"`
+	Symbol       string `json:"s"`
+	AggTradeID   int64  `json:"a"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	FirstTradeID int64  `json:"f"`
+	LastTradeID  int64  `json:"l"`
+	TradeTime    int64  `json:"T"`
+	IsBuyerMaker bool   `json:"m"`
+}
+
+// Trade returns the trade of the event, the taker sold when the buyer is the maker
+func (a *AggTradeWebsocket) Trade() Trade {
+	price, _ := strconv.ParseFloat(a.Price, 64)
+	quantity, _ := strconv.ParseFloat(a.Quantity, 64)
+	side := SideBuy
+	if a.IsBuyerMaker {
+		side = SideSell
+	}
+	return Trade{
+		ID:       strconv.FormatInt(a.AggTradeID, 10),
+		Price:    price,
+		Quantity: quantity,
+		Side:     side,
+		Time:     a.TradeTime,
+	}
+}
+
+// TradeWindowStats sums the trades of a rolling window ending at the last trade,
+// volumes are in the base asset and QuoteVolume in the quote asset
+type TradeWindowStats struct {
+	Window          string  `json:"window"`
+	VWAP            float64 `json:"vwap"`
+	Volume          float64 `json:"volume"`
+	QuoteVolume     float64 `json:"quoteVolume"`
+	BuyVolume       float64 `json:"buyVolume"`
+	SellVolume      float64 `json:"sellVolume"`
+	TradeCount      int     `json:"tradeCount"`
+	LargeTradeCount int     `json:"largeTradeCount"`
+}
+
+type ResponseTrade struct {
+	ID            string  `json:"id"`
+	Price         float64 `json:"price"`
+	Quantity      float64 `json:"quantity"`
+	QuoteQuantity float64 `json:"quoteQuantity"`
+	Side          string  `json:"side"`
+	// IsLarge is set when the quote quantity reaches the large trade threshold
+	IsLarge bool   `json:"isLarge"`
+	Time    string `json:"time"`
+}
+
+func (r *ResponseTrade) UpdateData(trade *Trade, isLarge bool, time string) {
+	r.ID = trade.ID
+	r.Price = trade.Price
+	r.Quantity = trade.Quantity
+	r.QuoteQuantity = trade.Price * trade.Quantity
+	r.Side = trade.Side
+	r.IsLarge = isLarge
+	r.Time = time
+}
+
+// ResponseTrades lists the recent trades of a symbol, oldest first, with the statistics of each window
+type ResponseTrades struct {
+	Symbol    string             `json:"symbol"`
+	Market    string             `json:"market"`
+	Trades    []ResponseTrade    `json:"trades"`
+	Stats     []TradeWindowStats `json:"stats"`
+	EventTime string             `json:"eventTime"`
+}
+
+// ResponseTradeFrame is sent on the trades socket for every trade
+type ResponseTradeFrame struct {
+	Symbol string             `json:"symbol"`
+	Market string             `json:"market"`
+	Trade  ResponseTrade      `json:"trade"`
+	Stats  []TradeWindowStats `json:"stats"`
+}
@@ -0,0 +1,53 @@
+package models
+
+// RealizedVolatility holds annualized volatilities in percent from three estimators: the standard deviation
+// of close to close log returns, Parkinson from the high and low and Garman-Klass from open, high, low
+// and close
+type RealizedVolatility struct {
+	CloseToClose float64 `json:"closeToClose" example:"48.1234"`
+	Parkinson    float64 `json:"parkinson" example:"42.5678"`
+	GarmanKlass  float64 `json:"garmanKlass" example:"44.9012"`
+}
+
+// Drawdown is the largest fall of the close from a previous peak within the window, in percent
+type Drawdown struct {
+	Percent     float64 `json:"percent" example:"-18.4321"`
+	PeakPrice   float64 `json:"peakPrice" example:"99500"`
+	PeakTime    string  `json:"peakTime" example:"2024-12-05T00:00:00Z"`
+	TroughPrice float64 `json:"troughPrice" example:"81160"`
+	TroughTime  string  `json:"troughTime" example:"2024-12-20T00:00:00Z"`
+}
+
+// ReturnPoint is the simple return in percent of the close over the rolling period ending at Time
+type ReturnPoint struct {
+	Time   string  `json:"time" example:"2024-12-20T00:00:00Z"`
+	Return float64 `json:"return" example:"-3.2145"`
+}
+
+// RollingReturns summarizes the returns over every Period candles of the window, in percent
+type RollingReturns struct {
+	Period int           `json:"period" example:"7"`
+	Latest float64       `json:"latest" example:"-3.2145"`
+	Mean   float64       `json:"mean" example:"1.1042"`
+	Min    float64       `json:"min" example:"-9.8765"`
+	Max    float64       `json:"max" example:"12.3456"`
+	Series []ReturnPoint `json:"series"`
+}
+
+type ResponseVolatility struct {
+	Symbol    string `json:"symbol" example:"BTCUSDT"`
+	Interval  string `json:"interval" example:"1d"`
+	Window    int    `json:"window" example:"30"`
+	StartTime string `json:"startTime" example:"2024-11-21T00:00:00Z"`
+	EndTime   string `json:"endTime" example:"2024-12-20T00:00:00Z"`
+	// PeriodsPerYear is the number of candles volatilities are annualized with
+	PeriodsPerYear float64            `json:"periodsPerYear" example:"365"`
+	Volatility     RealizedVolatility `json:"volatility"`
+	ATR            float64            `json:"atr" example:"3421.5"`
+	ATRPeriod      int                `json:"atrPeriod" example:"14"`
+	ATRPercent     float64            `json:"atrPercent" example:"3.5123"`
+	MaxDrawdown    Drawdown           `json:"maxDrawdown"`
+	TotalReturn    float64            `json:"totalReturn" example:"5.4321"`
+	RollingReturns RollingReturns     `json:"rollingReturns"`
+	EventTime      string             `json:"eventTime" example:"2024-12-20 08:37:58"`
+}
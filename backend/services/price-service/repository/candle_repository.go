@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CandleCollection is the MongoDB collection of the local candle store
+const CandleCollection = "candles"
+
+// CandleRepository stores closed candles, one document per series and open time
+type CandleRepository interface {
+	// FindCandles returns the stored candles of a series opened between startTime and endTime, oldest first
+	FindCandles(ctx context.Context, key models.CandleKey, startTime, endTime int64) ([]models.Candle, error)
+	// SaveCandles inserts the candles of a series, replacing those already stored
+	SaveCandles(ctx context.Context, key models.CandleKey, candles []models.Candle) error
+	// LatestCandle returns the last stored candle of a series, nil when there is none
+	LatestCandle(ctx context.Context, key models.CandleKey) (*models.Candle, error)
+}
+
+type MongoCandleRepository struct {
+	Collection *mongo.Collection
+}
+
+// EnsureIndexes creates the unique index the candles are looked up and upserted by
+func (r *MongoCandleRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.Collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "exchange", Value: 1},
+			{Key: "market", Value: 1},
+			{Key: "symbol", Value: 1},
+			{Key: "interval", Value: 1},
+			{Key: "openTime", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+func (r *MongoCandleRepository) FindCandles(ctx context.Context, key models.CandleKey, startTime, endTime int64) ([]models.Candle, error) {
+	filter := keyFilter(key)
+	filter["openTime"] = bson.M{"$gte": startTime, "$lte": endTime}
+
+	cursor, err := r.Collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "openTime", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stored []models.StoredCandle
+	if err := cursor.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+	candles := make([]models.Candle, 0, len(stored))
+	for _, candle := range stored {
+		candles = append(candles, candle.Candle)
+	}
+	return candles, nil
+}
+
+func (r *MongoCandleRepository) SaveCandles(ctx context.Context, key models.CandleKey, candles []models.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(candles))
+	for _, candle := range candles {
+		filter := keyFilter(key)
+		filter["openTime"] = candle.OpenTime
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(filter).
+			SetReplacement(models.StoredCandle{CandleKey: key, Candle: candle}).
+			SetUpsert(true))
+	}
+	_, err := r.Collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *MongoCandleRepository) LatestCandle(ctx context.Context, key models.CandleKey) (*models.Candle, error) {
+	var stored models.StoredCandle
+	err := r.Collection.FindOne(ctx, keyFilter(key), options.FindOne().SetSort(bson.D{{Key: "openTime", Value: -1}})).Decode(&stored)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &stored.Candle, nil
+}
+
+func keyFilter(key models.CandleKey) bson.M {
+	return bson.M{
+		"exchange": key.Exchange,
+		"market":   key.Market,
+		"symbol":   key.Symbol,
+		"interval": key.Interval,
+	}
+}
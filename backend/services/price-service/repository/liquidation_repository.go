@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LiquidationCollection is the MongoDB collection of the recorded liquidations
+const LiquidationCollection = "liquidations"
+
+// LiquidationRepository stores the liquidations of Binance futures, one document per forced order
+type LiquidationRepository interface {
+	// FindLiquidations returns the latest query.Limit liquidations matching query, oldest first,
+	// every matching liquidation when the limit is not set
+	FindLiquidations(ctx context.Context, query models.LiquidationQuery) ([]models.Liquidation, error)
+	// SaveLiquidations inserts the liquidations
+	SaveLiquidations(ctx context.Context, liquidations []models.Liquidation) error
+}
+
+type MongoLiquidationRepository struct {
+	Collection *mongo.Collection
+}
+
+// EnsureIndexes creates the indexes the liquidations are looked up by, for every symbol or for one
+func (r *MongoLiquidationRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.Collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "time", Value: 1}}},
+		{Keys: bson.D{{Key: "symbol", Value: 1}, {Key: "time", Value: 1}}},
+	})
+	return err
+}
+
+func (r *MongoLiquidationRepository) FindLiquidations(ctx context.Context, query models.LiquidationQuery) ([]models.Liquidation, error) {
+	filter := bson.M{}
+	if query.Symbol != "" {
+		filter["symbol"] = query.Symbol
+	}
+	if query.MinNotional > 0 {
+		filter["notional"] = bson.M{"$gte": query.MinNotional}
+	}
+	timeFilter := bson.M{}
+	if query.StartTime > 0 {
+		timeFilter["$gte"] = query.StartTime
+	}
+	if query.EndTime > 0 {
+		timeFilter["$lte"] = query.EndTime
+	}
+	if len(timeFilter) > 0 {
+		filter["time"] = timeFilter
+	}
+
+	// newest first so the limit keeps the latest
+	findOptions := options.Find().SetSort(bson.D{{Key: "time", Value: -1}})
+	if query.Limit > 0 {
+		findOptions.SetLimit(int64(query.Limit))
+	}
+	cursor, err := r.Collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var stored []models.Liquidation
+	if err := cursor.All(ctx, &stored); err != nil {
+		return nil, err
+	}
+	liquidations := make([]models.Liquidation, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		liquidations = append(liquidations, stored[i])
+	}
+	return liquidations, nil
+}
+
+func (r *MongoLiquidationRepository) SaveLiquidations(ctx context.Context, liquidations []models.Liquidation) error {
+	if len(liquidations) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(liquidations))
+	for _, liquidation := range liquidations {
+		documents = append(documents, liquidation)
+	}
+	_, err := r.Collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	return err
+}
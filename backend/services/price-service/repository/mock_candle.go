@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// MockCandleRepository keeps candles in memory
+type MockCandleRepository struct {
+	Candles map[models.CandleKey]map[int64]models.Candle
+	Err     error
+
+	mutex sync.Mutex
+}
+
+func NewMockCandleRepository() *MockCandleRepository {
+	return &MockCandleRepository{Candles: map[models.CandleKey]map[int64]models.Candle{}}
+}
+
+func (m *MockCandleRepository) FindCandles(ctx context.Context, key models.CandleKey, startTime, endTime int64) ([]models.Candle, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	candles := []models.Candle{}
+	for openTime, candle := range m.Candles[key] {
+		if openTime >= startTime && openTime <= endTime {
+			candles = append(candles, candle)
+		}
+	}
+	sort.Slice(candles, func(i, j int) bool { return candles[i].OpenTime < candles[j].OpenTime })
+	return candles, nil
+}
+
+func (m *MockCandleRepository) SaveCandles(ctx context.Context, key models.CandleKey, candles []models.Candle) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.Candles[key] == nil {
+		m.Candles[key] = map[int64]models.Candle{}
+	}
+	for _, candle := range candles {
+		m.Candles[key][candle.OpenTime] = candle
+	}
+	return nil
+}
+
+func (m *MockCandleRepository) LatestCandle(ctx context.Context, key models.CandleKey) (*models.Candle, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var latest *models.Candle
+	for _, candle := range m.Candles[key] {
+		if latest == nil || candle.OpenTime > latest.OpenTime {
+			latest = &candle
+		}
+	}
+	return latest, nil
+}
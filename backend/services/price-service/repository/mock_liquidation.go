@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// MockLiquidationRepository keeps liquidations in memory
+type MockLiquidationRepository struct {
+	Liquidations []models.Liquidation
+	Err          error
+
+	mutex sync.Mutex
+}
+
+func NewMockLiquidationRepository() *MockLiquidationRepository {
+	return &MockLiquidationRepository{}
+}
+
+func (m *MockLiquidationRepository) FindLiquidations(ctx context.Context, query models.LiquidationQuery) ([]models.Liquidation, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	liquidations := []models.Liquidation{}
+	for i := range m.Liquidations {
+		if query.Matches(&m.Liquidations[i]) {
+			liquidations = append(liquidations, m.Liquidations[i])
+		}
+	}
+	sort.SliceStable(liquidations, func(i, j int) bool { return liquidations[i].Time < liquidations[j].Time })
+	if query.Limit > 0 && len(liquidations) > query.Limit {
+		liquidations = liquidations[len(liquidations)-query.Limit:]
+	}
+	return liquidations, nil
+}
+
+func (m *MockLiquidationRepository) SaveLiquidations(ctx context.Context, liquidations []models.Liquidation) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.Liquidations = append(m.Liquidations, liquidations...)
+	return nil
+}
@@ -6,14 +6,24 @@ import (
 	// "github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
 	fundingrate "github.com/dath-241/coin-price-be-go/services/price-service/services/funding_rate"
 	"github.com/dath-241/coin-price-be-go/services/price-service/services/kline"
+	marketcap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
 	"github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
 	"github.com/gin-gonic/gin"
+	gorilla "github.com/gorilla/websocket"
 )
 
 func getFundingRate(context *gin.Context) {
 	fundingrate.GetFundingRate(context)
 }
 
+func getFundingRateHistory(context *gin.Context) {
+	fundingrate.GetFundingRateHistory(context)
+}
+
+func getFundingScreener(context *gin.Context) {
+	fundingrate.GetFundingScreener(context)
+}
+
 func getKline(context *gin.Context) {
 	kline.GetKline(context)
 }
@@ -30,6 +40,16 @@ func getWebsocketMarketCap(context *gin.Context) {
 	websocket.MarketCapSocket(context)
 }
 
+// getMarketStats answers the market stats snapshot, websocket upgrades still reach the socket this
+// route used to serve
+func getMarketStats(context *gin.Context) {
+	if gorilla.IsWebSocketUpgrade(context.Request) {
+		websocket.MarketCapSocket(context)
+		return
+	}
+	marketcap.GetMarketStats(context)
+}
+
 func getWebsocketSpotPrice(context *gin.Context) {
 	websocket.SpotPriceSocket(context)
 }
@@ -37,3 +57,39 @@ func getWebsocketSpotPrice(context *gin.Context) {
 func getWebsocketFuturePrice(context *gin.Context) {
 	websocket.FuturePriceSocket(context)
 }
+
+func getWebsocketStream(context *gin.Context) {
+	websocket.StreamSocket(context)
+}
+
+func getWebsocketTicker(context *gin.Context) {
+	websocket.TickerSocket(context)
+}
+
+func getWebsocketDepth(context *gin.Context) {
+	websocket.DepthSocket(context)
+}
+
+func getWebsocketTrades(context *gin.Context) {
+	websocket.TradesSocket(context)
+}
+
+func getWebsocketPremium(context *gin.Context) {
+	websocket.PremiumSocket(context)
+}
+
+func getWebsocketOpenInterest(context *gin.Context) {
+	websocket.OpenInterestSocket(context)
+}
+
+func getWebsocketLiquidations(context *gin.Context) {
+	websocket.LiquidationSocket(context)
+}
+
+func getWebsocketMarketOverview(context *gin.Context) {
+	websocket.MarketOverviewSocket(context)
+}
+
+func getWebsocketArbitrage(context *gin.Context) {
+	websocket.ArbitrageSocket(context)
+}
@@ -2,8 +2,18 @@ package routes
 
 import (
 	middlewares "github.com/dath-241/coin-price-be-go/services/admin_service/middlewares"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/analytics"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/arbitrage"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/depth"
 	"github.com/dath-241/coin-price-be-go/services/price-service/services/future_price"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/indicator"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/liquidation"
+	marketcap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
+	openinterest "github.com/dath-241/coin-price-be-go/services/price-service/services/open_interest"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/pattern"
 	"github.com/dath-241/coin-price-be-go/services/price-service/services/spot_price"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/ticker"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/trades"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,15 +30,57 @@ func RegisterRoutes(server *gin.Engine) {
 	// Funding rate
 	authenticated.GET("/v1/funding-rate", getFundingRate)
 	authenticated.GET("/v1/funding-rate/websocket", getWebsocketFundingRate)
+	authenticated.GET("/v1/funding-rate/history", getFundingRateHistory)
+	authenticated.GET("/v1/funding-rate/screener", getFundingScreener)
+	// Open interest and long/short ratios
+	authenticated.GET("/v1/open-interest", openinterest.GetOpenInterest)
+	authenticated.GET("/v1/open-interest/history", openinterest.GetOpenInterestHistory)
+	authenticated.GET("/v1/open-interest/websocket", getWebsocketOpenInterest)
+	authenticated.GET("/v1/long-short-ratio", openinterest.GetLongShortRatio)
 	// Spot price
 	authenticated.GET("/v1/spot-price", spot_price.GetSpotPrice)
+	authenticated.GET("/v1/spot-price/batch", spot_price.GetSpotPriceBatch)
 	authenticated.GET("/v1/spot-price/websocket", getWebsocketSpotPrice)
 	// Future price
 	authenticated.GET("/v1/future-price", future_price.GetFuturePrice)
+	authenticated.GET("/v1/future-price/batch", future_price.GetFuturePriceBatch)
 	authenticated.GET("/v1/future-price/websocket", getWebsocketFuturePrice)
+	// Mark price, index price and basis
+	authenticated.GET("/v1/futures/premium", future_price.GetPremium)
+	authenticated.GET("/v1/futures/premium/websocket", getWebsocketPremium)
+	// 24 hour ticker statistics
+	authenticated.GET("/v1/ticker/24h", ticker.GetTicker24h)
+	authenticated.GET("/v1/ticker/24h/websocket", getWebsocketTicker)
+	// Order book depth
+	authenticated.GET("/v1/depth", depth.GetDepth)
+	authenticated.GET("/v1/depth/websocket", getWebsocketDepth)
+	// Recent trades
+	authenticated.GET("/v1/trades", trades.GetTrades)
+	authenticated.GET("/v1/trades/websocket", getWebsocketTrades)
+	// Liquidations
+	authenticated.GET("/v1/liquidations", liquidation.GetLiquidations)
+	authenticated.GET("/v1/liquidations/stats", liquidation.GetLiquidationStats)
+	authenticated.GET("/v1/liquidations/websocket", getWebsocketLiquidations)
 	// Market stats
-	authenticated.GET("/v1/market-stats", getWebsocketMarketCap)
+	authenticated.GET("/v1/market-stats", getMarketStats)
+	authenticated.GET("/v1/market-stats/websocket", getWebsocketMarketCap)
+	authenticated.GET("/v1/market-overview", marketcap.GetMarketOverview)
+	authenticated.GET("/v1/market-overview/websocket", getWebsocketMarketOverview)
+	// Cross-exchange arbitrage
+	authenticated.GET("/v1/arbitrage", arbitrage.GetArbitrage)
+	authenticated.GET("/v1/arbitrage/websocket", getWebsocketArbitrage)
+	// Volatility and risk metrics
+	authenticated.GET("/v1/analytics/volatility", analytics.GetVolatility)
+	// Cross-asset correlation matrix
+	authenticated.GET("/v1/analytics/correlation", analytics.GetCorrelation)
+	// Multiplexed stream of every websocket channel
+	authenticated.GET("/v1/stream", getWebsocketStream)
 	// Kline
 	authenticated.GET("/v1/vip1/kline", middlewares.AuthMiddleware("VIP-1", "VIP-2", "VIP-3"), getKline)
 	authenticated.GET("/v1/vip1/kline/websocket", middlewares.AuthMiddleware("VIP-1", "VIP-2", "VIP-3"), getWebsocketKline)
+	// Technical indicators
+	authenticated.GET("/v1/vip3/indicators/series", middlewares.AuthMiddleware("VIP-3"), indicator.GetIndicatorSeries)
+	// Candlestick patterns
+	authenticated.GET("/v1/vip3/patterns", middlewares.AuthMiddleware("VIP-3"), pattern.GetPatterns)
+	authenticated.GET("/v1/vip3/patterns/scan", middlewares.AuthMiddleware("VIP-3"), pattern.GetPatternScan)
 }
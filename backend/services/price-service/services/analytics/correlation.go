@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// AlignCloses keeps the open times every series has a candle at, in order, with the close of each
+// series at those times. missing counts the candles of each series that were left out.
+func AlignCloses(series [][]models.Candle) (times []int64, closes [][]float64, missing []int) {
+	counts := map[int64]int{}
+	for _, candles := range series {
+		for _, candle := range candles {
+			counts[candle.OpenTime]++
+		}
+	}
+	for openTime, count := range counts {
+		if count == len(series) {
+			times = append(times, openTime)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	closes = make([][]float64, len(series))
+	missing = make([]int, len(series))
+	for i, candles := range series {
+		closeAt := make(map[int64]float64, len(candles))
+		for _, candle := range candles {
+			closeAt[candle.OpenTime] = candle.Close
+		}
+		closes[i] = make([]float64, len(times))
+		for j, openTime := range times {
+			closes[i][j] = closeAt[openTime]
+		}
+		missing[i] = len(closeAt) - len(times)
+	}
+	return times, closes, missing
+}
+
+// LogReturns returns the log return between each close and the next
+func LogReturns(closes []float64) []float64 {
+	returns := make([]float64, 0, max(0, len(closes)-1))
+	for i := 1; i < len(closes); i++ {
+		returns = append(returns, math.Log(closes[i]/closes[i-1]))
+	}
+	return returns
+}
+
+// Pearson returns the linear correlation of x and y, NaN when one of them does not vary
+func Pearson(x, y []float64) float64 {
+	covariance, varianceX, varianceY := moments(x, y)
+	if varianceX == 0 || varianceY == 0 {
+		return math.NaN()
+	}
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
+
+// Spearman returns the correlation of the ranks of x and y, equal values sharing their average rank
+func Spearman(x, y []float64) float64 {
+	return Pearson(ranks(x), ranks(y))
+}
+
+// Beta returns how much asset moves with benchmark, their covariance over the variance of benchmark,
+// NaN when benchmark does not vary
+func Beta(asset, benchmark []float64) float64 {
+	covariance, _, variance := moments(asset, benchmark)
+	if variance == 0 {
+		return math.NaN()
+	}
+	return covariance / variance
+}
+
+// moments returns the covariance of x and y and their variances, each times the number of values
+func moments(x, y []float64) (covariance, varianceX, varianceY float64) {
+	n := min(len(x), len(y))
+	if n == 0 {
+		return 0, 0, 0
+	}
+	// the means are taken from the first values so that a series that does not move has no deviation
+	meanX, meanY := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		meanX += (x[i] - x[0]) / float64(n)
+		meanY += (y[i] - y[0]) / float64(n)
+	}
+	meanX, meanY = x[0]+meanX, y[0]+meanY
+	for i := 0; i < n; i++ {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	return covariance, varianceX, varianceY
+}
+
+// ranks returns the rank of each value from 1, ties getting the average of their ranks
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+
+	result := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		rank := float64(start+end+1) / 2
+		for _, index := range order[start:end] {
+			result[index] = rank
+		}
+		start = end
+	}
+	return result
+}
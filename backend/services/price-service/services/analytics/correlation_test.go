@@ -0,0 +1,84 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// dailyCandles builds daily candles from 2024-12-01 closing at closes, skipping the days of gaps
+func dailyCandles(closes []float64, gaps ...int) []models.Candle {
+	skip := map[int]bool{}
+	for _, gap := range gaps {
+		skip[gap] = true
+	}
+	var candles []models.Candle
+	for i, close := range closes {
+		if skip[i] {
+			continue
+		}
+		candles = append(candles, models.Candle{OpenTime: 1733011200000 + int64(i)*86400000, Open: close, High: close, Low: close, Close: close})
+	}
+	return candles
+}
+
+func TestAlignCloses(t *testing.T) {
+	times, closes, missing := AlignCloses([][]models.Candle{
+		dailyCandles([]float64{1, 2, 3, 4, 5}),
+		dailyCandles([]float64{10, 20, 30, 40, 50}, 1),
+		dailyCandles([]float64{100, 200, 300, 400, 500}, 3, 4),
+	})
+	assert.Equal(t, []int64{1733011200000, 1733184000000}, times)
+	assert.Equal(t, [][]float64{{1, 3}, {10, 30}, {100, 300}}, closes)
+	assert.Equal(t, []int{3, 2, 1}, missing)
+
+	// no common time
+	times, closes, missing = AlignCloses([][]models.Candle{dailyCandles([]float64{1}), dailyCandles([]float64{1, 2}, 0)})
+	assert.Empty(t, times)
+	assert.Equal(t, [][]float64{{}, {}}, closes)
+	assert.Equal(t, []int{1, 1}, missing)
+}
+
+func TestLogReturns(t *testing.T) {
+	returns := LogReturns([]float64{100, 110, 99})
+	assert.Len(t, returns, 2)
+	assert.InDelta(t, math.Log(1.1), returns[0], 1e-12)
+	assert.InDelta(t, math.Log(0.9), returns[1], 1e-12)
+	assert.Empty(t, LogReturns([]float64{100}))
+}
+
+func TestCorrelation(t *testing.T) {
+	tests := []struct {
+		name             string
+		x                []float64
+		y                []float64
+		expectedPearson  float64
+		expectedSpearman float64
+		expectedBeta     float64
+	}{
+		{name: "Same moves", x: []float64{1, 3, 2, 5}, y: []float64{2, 6, 4, 10}, expectedPearson: 1, expectedSpearman: 1, expectedBeta: 0.5},
+		{name: "Opposite moves", x: []float64{1, 3, 2, 5}, y: []float64{-1, -3, -2, -5}, expectedPearson: -1, expectedSpearman: -1, expectedBeta: -1},
+		{name: "Monotonic but not linear", x: []float64{1, 4, 9, 16, 25}, y: []float64{1, 2, 3, 4, 5}, expectedPearson: 0.9811049102515929, expectedSpearman: 1, expectedBeta: 6},
+		{name: "Ties share their rank", x: []float64{1, 2, 2, 3, 5}, y: []float64{2, 1, 3, 3, 4}, expectedPearson: 0.7518094115561123, expectedSpearman: 0.7631578947368421, expectedBeta: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expectedPearson, Pearson(tt.x, tt.y), 1e-12)
+			assert.InDelta(t, tt.expectedSpearman, Spearman(tt.x, tt.y), 1e-12)
+			assert.InDelta(t, tt.expectedBeta, Beta(tt.x, tt.y), 1e-12)
+		})
+	}
+
+	// a series that does not move has no correlation, and no beta against it
+	flat := []float64{2, 2, 2}
+	assert.True(t, math.IsNaN(Pearson([]float64{1, 2, 3}, flat)))
+	assert.True(t, math.IsNaN(Spearman(flat, []float64{1, 2, 3})))
+	assert.True(t, math.IsNaN(Beta([]float64{1, 2, 3}, flat)))
+	assert.Zero(t, Beta(flat, []float64{1, 2, 3}))
+	// ties of the whole series, whose average rank is not a round number
+	assert.True(t, math.IsNaN(Spearman(make([]float64, 10), []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})))
+	assert.True(t, math.IsNaN(Pearson([]float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}, []float64{1, 2, 3, 4, 5, 6, 7})))
+}
@@ -0,0 +1,272 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/kline"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultCorrelationInterval = "1d"
+	DefaultCorrelationLookback = 90
+	MaxCorrelationLookback     = 1000
+	// MaxCorrelationSymbols bounds the symbols of one matrix
+	MaxCorrelationSymbols = 20
+
+	// correlationWorkers bounds the kline requests running at once
+	correlationWorkers = 8
+)
+
+// correlationCacheTTL is how long a matrix is reused, the same symbols are requested by many users
+var correlationCacheTTL = time.Minute
+
+// CorrelationQuery describes which symbols to correlate over which candles
+type CorrelationQuery struct {
+	Symbols   []string
+	Benchmark string
+	Interval  string
+	Market    string
+	Timezone  string
+	Lookback  int
+	EndTime   int64
+}
+
+type correlationKey struct {
+	exchange  string
+	market    string
+	interval  string
+	timezone  string
+	lookback  int
+	endTime   int64
+	symbols   string
+	benchmark string
+}
+
+type cachedCorrelation struct {
+	response *models.ResponseCorrelation
+	expires  time.Time
+}
+
+var (
+	correlationCache      = map[correlationKey]cachedCorrelation{}
+	correlationCacheMutex sync.Mutex
+)
+
+// @Summary Get a correlation matrix
+// @Description Aligns the candles of several symbols on their open times, leaving out the times one of them has no candle at, and returns the Pearson and Spearman correlations of their log returns with the beta of each symbol against a benchmark. Results are cached for a minute.
+// @Tags Analytics
+// @Produce json
+// @Param symbols query string true "Comma separated symbols, from 2 to 20" example("BTCUSDT,ETHUSDT,SOLUSDT")
+// @Param interval query string false "Interval of the candles, 1d by default, any count of m, h, d, w or M such as 10m or 2w" example("1d")
+// @Param lookback query int false "Number of returns, 90 by default and at most 1000" example(90)
+// @Param benchmark query string false "Symbol the betas are measured against, BTC in the quote asset of the first symbol by default" example("BTCUSDT")
+// @Param endTime query int false "Open time in milliseconds of the last candle, the latest candle by default"
+// @Param timezone query string false "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default"
+// @Param market query string false "Market: futures (default) or spot" example("spot")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponseCorrelation "Successful response with the correlation matrices and betas"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbols or invalid parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found or not enough common candles"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/analytics/correlation [get]
+func GetCorrelation(context *gin.Context) {
+	query := CorrelationQuery{
+		Symbols:   provider.ParseSymbols(context.QueryArray("symbols")),
+		Benchmark: provider.NormalizeSymbol(context.Query("benchmark")),
+		Interval:  context.DefaultQuery("interval", DefaultCorrelationInterval),
+		Timezone:  context.Query("timezone"),
+		Lookback:  DefaultCorrelationLookback,
+	}
+	if len(query.Symbols) < 2 {
+		utils.ShowError(http.StatusBadRequest, "At least two symbols are needed", context)
+		return
+	}
+	if len(query.Symbols) > MaxCorrelationSymbols {
+		utils.ShowError(http.StatusBadRequest, fmt.Sprintf("At most %d symbols per matrix", MaxCorrelationSymbols), context)
+		return
+	}
+	if query.Benchmark == "" {
+		query.Benchmark = "BTCUSDT"
+		if _, quote, err := provider.SplitSymbol(query.Symbols[0]); err == nil {
+			query.Benchmark = "BTC" + quote
+		}
+	}
+
+	var ok bool
+	if query.Lookback, ok = utils.QueryInt(context, "lookback", query.Lookback, 2, MaxCorrelationLookback); !ok {
+		return
+	}
+	if query.EndTime, ok = utils.QueryMilliseconds(context, "endTime"); !ok {
+		return
+	}
+	market, err := provider.NormalizeMarket(context.Query("market"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	query.Market = market
+	if _, err := kline.NewResampler(query.Interval, query.Timezone); err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	response, statusCode, err := GetCorrelationData(marketData, query)
+	if err != nil {
+		responseStatusCode := utils.ResponseStatusCode(statusCode)
+		if responseStatusCode == http.StatusInternalServerError {
+			utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+			return
+		}
+		utils.ShowError(int64(responseStatusCode), err.Error(), context)
+		return
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// GetCorrelationData returns the matrices of the query, from the cache when they were computed less
+// than correlationCacheTTL ago
+func GetCorrelationData(marketData provider.MarketDataProvider, query CorrelationQuery) (*models.ResponseCorrelation, models.StatusCode, error) {
+	key := correlationKey{
+		exchange:  marketData.Name(),
+		market:    query.Market,
+		interval:  query.Interval,
+		timezone:  query.Timezone,
+		lookback:  query.Lookback,
+		endTime:   query.EndTime,
+		symbols:   strings.Join(query.Symbols, ","),
+		benchmark: query.Benchmark,
+	}
+	now := time.Now()
+
+	correlationCacheMutex.Lock()
+	cached, ok := correlationCache[key]
+	correlationCacheMutex.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.response, http.StatusOK, nil
+	}
+
+	response, statusCode, err := computeCorrelation(marketData, query)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	correlationCacheMutex.Lock()
+	// expired matrices are dropped so that the cache only holds the last minute of requests
+	for key, cached := range correlationCache {
+		if !now.Before(cached.expires) {
+			delete(correlationCache, key)
+		}
+	}
+	correlationCache[key] = cachedCorrelation{response: response, expires: now.Add(correlationCacheTTL)}
+	correlationCacheMutex.Unlock()
+	return response, http.StatusOK, nil
+}
+
+// computeCorrelation loads the candles of every symbol and the benchmark at once and correlates them
+func computeCorrelation(marketData provider.MarketDataProvider, query CorrelationQuery) (*models.ResponseCorrelation, models.StatusCode, error) {
+	symbols := query.Symbols
+	benchmark := indexOf(symbols, query.Benchmark)
+	if benchmark < 0 {
+		symbols = append(append([]string{}, symbols...), query.Benchmark)
+		benchmark = len(symbols) - 1
+	}
+
+	series := make([][]models.Candle, len(symbols))
+	statusCodes := make([]models.StatusCode, len(symbols))
+	errs := make([]error, len(symbols))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, correlationWorkers)
+	for i, symbol := range symbols {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, symbol string) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			// one candle more than the lookback gives lookback returns
+			series[i], statusCodes[i], errs[i] = kline.LoadKlines(marketData, models.KlineQuery{
+				Symbol:   symbol,
+				Interval: query.Interval,
+				Market:   query.Market,
+				Timezone: query.Timezone,
+				EndTime:  query.EndTime,
+				Limit:    query.Lookback + 1,
+			})
+		}(i, symbol)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, statusCodes[i], fmt.Errorf("%s: %w", symbols[i], err)
+		}
+	}
+
+	times, closes, missing := AlignCloses(series)
+	if len(times) < 3 {
+		return nil, http.StatusNotFound, fmt.Errorf("Not enough common candles")
+	}
+	returns := make([][]float64, len(symbols))
+	for i := range symbols {
+		returns[i] = LogReturns(closes[i])
+	}
+
+	response := &models.ResponseCorrelation{
+		Symbols:      query.Symbols,
+		Interval:     query.Interval,
+		Lookback:     query.Lookback,
+		Benchmark:    query.Benchmark,
+		Observations: len(times) - 1,
+		StartTime:    utils.ConvertMilisecondToTimeFormatedRFC3339(times[0]),
+		EndTime:      utils.ConvertMilisecondToTimeFormatedRFC3339(times[len(times)-1]),
+		Missing:      map[string]int{},
+		Pearson:      make([][]*float64, len(query.Symbols)),
+		Spearman:     make([][]*float64, len(query.Symbols)),
+		Beta:         map[string]*float64{},
+		EventTime:    utils.GetTimeNow(),
+	}
+	for i, symbol := range query.Symbols {
+		response.Missing[symbol] = missing[i]
+		response.Beta[symbol] = coefficient(Beta(returns[i], returns[benchmark]))
+		response.Pearson[i] = make([]*float64, len(query.Symbols))
+		response.Spearman[i] = make([]*float64, len(query.Symbols))
+		for j := range query.Symbols {
+			response.Pearson[i][j] = coefficient(Pearson(returns[i], returns[j]))
+			response.Spearman[i][j] = coefficient(Spearman(returns[i], returns[j]))
+		}
+	}
+	return response, http.StatusOK, nil
+}
+
+// coefficient rounds to 6 decimals, nil when it is undefined
+func coefficient(value float64) *float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	rounded := math.Round(value*1e6) / 1e6
+	return &rounded
+}
+
+func indexOf(symbols []string, symbol string) int {
+	for i, s := range symbols {
+		if s == symbol {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,187 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupCorrelationRouter serves BTCUSDT, ETHUSDT at twice its price without the candle of 2024-12-06,
+// SOLUSDT moving the other way, USDCUSDT that does not move and OLDUSDT with a single candle
+func setupCorrelationRouter(t *testing.T) (http.Handler, *providertest.FakeExchange) {
+	eth := make([]float64, len(testCloses))
+	sol := make([]float64, len(testCloses))
+	usdc := make([]float64, len(testCloses))
+	for i, close := range testCloses {
+		eth[i], sol[i], usdc[i] = 2*close, 10000/close, 1
+	}
+	exchange := &providertest.FakeExchange{Candles: map[string][]models.Candle{
+		"BTCUSDT":  dailyCandles(testCloses),
+		"ETHUSDT":  dailyCandles(eth, 5),
+		"SOLUSDT":  dailyCandles(sol),
+		"USDCUSDT": dailyCandles(usdc),
+		"OLDUSDT":  dailyCandles(testCloses[:1]),
+	}}
+	provider.SetDefault(exchange)
+	correlationCache = map[correlationKey]cachedCorrelation{}
+	t.Cleanup(func() { provider.SetDefault(nil) })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/analytics/correlation", GetCorrelation)
+	return router, exchange
+}
+
+// latestQueries leaves out the queries of the older pages of a symbol missing candles
+func latestQueries(exchange *providertest.FakeExchange) []models.KlineQuery {
+	var queries []models.KlineQuery
+	for _, query := range exchange.Queries() {
+		if query.EndTime == 0 {
+			queries = append(queries, query)
+		}
+	}
+	return queries
+}
+
+func coefficients(values ...any) []*float64 {
+	result := make([]*float64, len(values))
+	for i, value := range values {
+		if value != nil {
+			coefficient := float64(value.(int))
+			result[i] = &coefficient
+		}
+	}
+	return result
+}
+
+func TestGetCorrelation(t *testing.T) {
+	router, exchange := setupCorrelationRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/analytics/correlation?symbols=eth-usdt,SOLUSDT&symbols=USDCUSDT&lookback=11", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	// the benchmark is loaded with the symbols, one candle more than the lookback each
+	assert.Len(t, latestQueries(exchange), 4)
+	for _, query := range latestQueries(exchange) {
+		assert.Equal(t, 12, query.Limit)
+		assert.Equal(t, "1d", query.Interval)
+	}
+
+	var response models.ResponseCorrelation
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"ETHUSDT", "SOLUSDT", "USDCUSDT"}, response.Symbols)
+	assert.Equal(t, "1d", response.Interval)
+	assert.Equal(t, 11, response.Lookback)
+	assert.Equal(t, "BTCUSDT", response.Benchmark)
+	// 11 common candles, 2024-12-06 is missing from ETHUSDT
+	assert.Equal(t, 10, response.Observations)
+	assert.Equal(t, "2024-12-01T00:00:00Z", response.StartTime)
+	assert.Equal(t, "2024-12-12T00:00:00Z", response.EndTime)
+	assert.Equal(t, map[string]int{"ETHUSDT": 0, "SOLUSDT": 1, "USDCUSDT": 1}, response.Missing)
+	// a series that does not move has no correlation
+	expected := [][]*float64{coefficients(1, -1, nil), coefficients(-1, 1, nil), coefficients(nil, nil, nil)}
+	assert.Equal(t, expected, response.Pearson)
+	assert.Equal(t, expected, response.Spearman)
+	assert.Equal(t, map[string]*float64{"ETHUSDT": coefficients(1)[0], "SOLUSDT": coefficients(-1)[0], "USDCUSDT": coefficients(0)[0]}, response.Beta)
+}
+
+func TestGetCorrelationBenchmark(t *testing.T) {
+	router, exchange := setupCorrelationRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/analytics/correlation?symbols=BTCUSDT,ETHUSDT&benchmark=solusdt", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, latestQueries(exchange), 3)
+	assert.Equal(t, 91, exchange.Queries()[0].Limit)
+
+	var response models.ResponseCorrelation
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "SOLUSDT", response.Benchmark)
+	assert.Equal(t, 90, response.Lookback)
+	assert.Equal(t, map[string]*float64{"BTCUSDT": coefficients(-1)[0], "ETHUSDT": coefficients(-1)[0]}, response.Beta)
+}
+
+func TestGetCorrelationCache(t *testing.T) {
+	router, exchange := setupCorrelationRouter(t)
+
+	request := func(query string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/analytics/correlation?"+query, nil)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request("symbols=BTCUSDT,ETHUSDT"))
+	assert.Len(t, latestQueries(exchange), 2)
+	// the same matrix is answered from the cache
+	assert.Equal(t, http.StatusOK, request("symbols=btcusdt&symbols=ethusdt"))
+	assert.Len(t, latestQueries(exchange), 2)
+	// another lookback is another matrix
+	assert.Equal(t, http.StatusOK, request("symbols=BTCUSDT,ETHUSDT&lookback=5"))
+	assert.Len(t, latestQueries(exchange), 4)
+
+	// expired matrices are computed again
+	defer func(ttl time.Duration) { correlationCacheTTL = ttl }(correlationCacheTTL)
+	correlationCacheTTL = 0
+	assert.Equal(t, http.StatusOK, request("symbols=SOLUSDT,ETHUSDT"))
+	assert.Equal(t, http.StatusOK, request("symbols=SOLUSDT,ETHUSDT"))
+	assert.Len(t, latestQueries(exchange), 10)
+
+	// errors are not cached
+	assert.Equal(t, http.StatusNotFound, request("symbols=BTCUSDT,OLDUSDT"))
+	assert.Equal(t, http.StatusNotFound, request("symbols=BTCUSDT,OLDUSDT"))
+	assert.Len(t, latestQueries(exchange), 14)
+}
+
+func TestGetCorrelationErrors(t *testing.T) {
+	router, _ := setupCorrelationRouter(t)
+
+	tooMany := make([]string, MaxCorrelationSymbols+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("COIN%dUSDT", i)
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "Missing symbols", query: "", expectedStatus: http.StatusBadRequest, expectedMessage: "At least two symbols are needed"},
+		{name: "Single symbol", query: "symbols=BTCUSDT,btc-usdt", expectedStatus: http.StatusBadRequest, expectedMessage: "At least two symbols are needed"},
+		{name: "Too many symbols", query: "symbols=" + strings.Join(tooMany, ","), expectedStatus: http.StatusBadRequest, expectedMessage: "At most 20 symbols per matrix"},
+		{name: "Lookback too short", query: "symbols=BTCUSDT,ETHUSDT&lookback=1", expectedStatus: http.StatusBadRequest, expectedMessage: "lookback must be between 2 and 1000"},
+		{name: "Invalid endTime", query: "symbols=BTCUSDT,ETHUSDT&endTime=abc", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid endTime"},
+		{name: "Invalid interval", query: "symbols=BTCUSDT,ETHUSDT&interval=1y", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid interval"},
+		{name: "Invalid market", query: "symbols=BTCUSDT,ETHUSDT&market=options", expectedStatus: http.StatusBadRequest, expectedMessage: "market options is not supported"},
+		{name: "Unknown exchange", query: "symbols=BTCUSDT,ETHUSDT&exchange=kraken", expectedStatus: http.StatusBadRequest, expectedMessage: "exchange kraken is not supported"},
+		{name: "Invalid symbol", query: "symbols=BTCUSDT,NOPEUSDT", expectedStatus: http.StatusBadRequest, expectedMessage: "NOPEUSDT: API returned status code: 400"},
+		{name: "Invalid benchmark", query: "symbols=ETHUSDT,SOLUSDT&benchmark=NOPEUSDT", expectedStatus: http.StatusBadRequest, expectedMessage: "NOPEUSDT: API returned status code: 400"},
+		{name: "Not enough common candles", query: "symbols=BTCUSDT,OLDUSDT", expectedStatus: http.StatusNotFound, expectedMessage: "Not enough common candles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/analytics/correlation?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
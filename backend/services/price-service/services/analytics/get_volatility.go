@@ -0,0 +1,195 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/indicator"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/kline"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultVolatilityInterval = "1d"
+	DefaultVolatilityWindow   = 30
+	MaxVolatilityWindow       = 1000
+	DefaultATRPeriod          = 14
+	DefaultReturnPeriod       = 7
+)
+
+// VolatilityQuery describes the window and periods of the volatility metrics
+type VolatilityQuery struct {
+	models.KlineQuery
+	Window       int
+	ATRPeriod    int
+	ReturnPeriod int
+}
+
+// @Summary Get volatility and risk metrics
+// @Description Computes over the last window candles of a symbol the annualized realized volatility (close to close, Parkinson and Garman-Klass), the average true range, the maximum drawdown, the total return and rolling returns. Volatilities, returns and drawdown are in percent.
+// @Tags Analytics
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param interval query string false "Interval of the candles, 1d by default, any count of m, h, d, w or M such as 10m or 2w" example("1d")
+// @Param window query int false "Number of candles, 30 by default and at most 1000" example(30)
+// @Param atrPeriod query int false "Period of the average true range, 14 by default" example(14)
+// @Param returnPeriod query int false "Number of candles of each rolling return, 7 by default and below the window" example(7)
+// @Param endTime query int false "Open time in milliseconds of the last candle, the latest candle by default"
+// @Param timezone query string false "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default"
+// @Param market query string false "Market: futures (default) or spot" example("spot")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponseVolatility "Successful response with the volatility and risk metrics"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbol or invalid parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found or not enough history"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/analytics/volatility [get]
+func GetVolatility(context *gin.Context) {
+	query := VolatilityQuery{
+		KlineQuery: models.KlineQuery{
+			Symbol:   provider.NormalizeSymbol(context.Query("symbol")),
+			Interval: context.DefaultQuery("interval", DefaultVolatilityInterval),
+			Market:   context.Query("market"),
+			Timezone: context.Query("timezone"),
+		},
+		Window:       DefaultVolatilityWindow,
+		ATRPeriod:    DefaultATRPeriod,
+		ReturnPeriod: DefaultReturnPeriod,
+	}
+	if query.Symbol == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing symbol", context)
+		return
+	}
+
+	var ok bool
+	if query.Window, ok = utils.QueryInt(context, "window", query.Window, 3, MaxVolatilityWindow); !ok {
+		return
+	}
+	if query.ATRPeriod, ok = utils.QueryInt(context, "atrPeriod", query.ATRPeriod, 1, indicator.MaxPeriod); !ok {
+		return
+	}
+	if query.ReturnPeriod, ok = utils.QueryInt(context, "returnPeriod", query.ReturnPeriod, 1, query.Window-1); !ok {
+		return
+	}
+	if query.EndTime, ok = utils.QueryMilliseconds(context, "endTime"); !ok {
+		return
+	}
+	market, err := provider.NormalizeMarket(query.Market)
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	query.Market = market
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	response, statusCode, err := GetVolatilityData(marketData, query)
+	if err != nil {
+		responseStatusCode := utils.ResponseStatusCode(statusCode)
+		if responseStatusCode == http.StatusInternalServerError {
+			utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+			return
+		}
+		utils.ShowError(int64(responseStatusCode), err.Error(), context)
+		return
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// GetVolatilityData loads the window and the candles the average true range needs before it, and
+// computes the metrics. A symbol with less history than the window is measured over what it has.
+func GetVolatilityData(marketData provider.MarketDataProvider, query VolatilityQuery) (*models.ResponseVolatility, models.StatusCode, error) {
+	resampler, err := kline.NewResampler(query.Interval, query.Timezone)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	query.Limit = query.Window + query.ATRPeriod
+	candles, statusCode, err := kline.LoadKlines(marketData, query.KlineQuery)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	if len(candles) <= query.ReturnPeriod || len(candles) < 3 {
+		return nil, http.StatusNotFound, fmt.Errorf("Not enough history for %s", query.Symbol)
+	}
+
+	start := max(0, len(candles)-query.Window)
+	window := candles[start:]
+	closes := make([]float64, 0, len(window)+1)
+	if start > 0 {
+		// the close before the window gives the return of its first candle
+		closes = append(closes, candles[start-1].Close)
+	}
+	for _, candle := range window {
+		closes = append(closes, candle.Close)
+	}
+	windowCloses := closes[len(closes)-len(window):]
+	last := window[len(window)-1]
+
+	response := &models.ResponseVolatility{
+		Symbol:         query.Symbol,
+		Interval:       query.Interval,
+		Window:         len(window),
+		StartTime:      utils.ConvertMilisecondToTimeFormatedRFC3339(window[0].OpenTime),
+		EndTime:        utils.ConvertMilisecondToTimeFormatedRFC3339(last.OpenTime),
+		PeriodsPerYear: resampler.PeriodsPerYear(),
+		ATRPeriod:      query.ATRPeriod,
+		TotalReturn:    percent(last.Close/window[0].Open - 1),
+		EventTime:      utils.GetTimeNow(),
+	}
+
+	annualize := math.Sqrt(response.PeriodsPerYear)
+	response.Volatility = models.RealizedVolatility{
+		CloseToClose: percent(CloseToCloseVolatility(closes) * annualize),
+		Parkinson:    percent(ParkinsonVolatility(window) * annualize),
+		GarmanKlass:  percent(GarmanKlassVolatility(window) * annualize),
+	}
+
+	if atr := indicator.ATR(candles, query.ATRPeriod); !math.IsNaN(atr[len(atr)-1]) {
+		response.ATR = math.Round(atr[len(atr)-1]*1e8) / 1e8
+		response.ATRPercent = percent(atr[len(atr)-1] / last.Close)
+	}
+
+	drawdown, peak, trough := MaxDrawdown(windowCloses)
+	response.MaxDrawdown = models.Drawdown{
+		Percent:     percent(drawdown),
+		PeakPrice:   window[peak].Close,
+		PeakTime:    utils.ConvertMilisecondToTimeFormatedRFC3339(window[peak].OpenTime),
+		TroughPrice: window[trough].Close,
+		TroughTime:  utils.ConvertMilisecondToTimeFormatedRFC3339(window[trough].OpenTime),
+	}
+
+	response.RollingReturns = models.RollingReturns{Period: query.ReturnPeriod, Series: []models.ReturnPoint{}}
+	returns := RollingReturns(windowCloses, query.ReturnPeriod)
+	for i, r := range returns {
+		candle := window[i+query.ReturnPeriod]
+		response.RollingReturns.Series = append(response.RollingReturns.Series, models.ReturnPoint{
+			Time:   utils.ConvertMilisecondToTimeFormatedRFC3339(candle.OpenTime),
+			Return: percent(r),
+		})
+		response.RollingReturns.Mean += r / float64(len(returns))
+		if i == 0 || r < response.RollingReturns.Min {
+			response.RollingReturns.Min = r
+		}
+		if i == 0 || r > response.RollingReturns.Max {
+			response.RollingReturns.Max = r
+		}
+	}
+	if len(returns) > 0 {
+		rolling := &response.RollingReturns
+		rolling.Latest = percent(returns[len(returns)-1])
+		rolling.Mean, rolling.Min, rolling.Max = percent(rolling.Mean), percent(rolling.Min), percent(rolling.Max)
+	}
+	return response, http.StatusOK, nil
+}
+
+// percent turns a fraction into a percent with 4 decimals
+func percent(fraction float64) float64 {
+	return math.Round(fraction*1e6) / 1e4
+}
@@ -0,0 +1,122 @@
+package analytics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupTestRouter serves the daily testCandles of BTCUSDT
+func setupTestRouter(t *testing.T) (*gin.Engine, *providertest.FakeExchange) {
+	exchange := &providertest.FakeExchange{Candles: map[string][]models.Candle{"BTCUSDT": testCandles()}}
+	provider.SetDefault(exchange)
+	t.Cleanup(func() { provider.SetDefault(nil) })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/analytics/volatility", GetVolatility)
+	return router, exchange
+}
+
+func TestGetVolatility(t *testing.T) {
+	router, exchange := setupTestRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/analytics/volatility?symbol=btc-usdt&window=10&atrPeriod=2&returnPeriod=3", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	// the window and the candles the average true range needs before it
+	assert.Equal(t, 12, exchange.Queries()[0].Limit)
+	assert.Equal(t, "1d", exchange.Queries()[0].Interval)
+
+	var response models.ResponseVolatility
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "BTCUSDT", response.Symbol)
+	assert.Equal(t, "1d", response.Interval)
+	assert.Equal(t, 10, response.Window)
+	assert.Equal(t, "2024-12-03T00:00:00Z", response.StartTime)
+	assert.Equal(t, "2024-12-12T00:00:00Z", response.EndTime)
+	assert.Equal(t, 365.0, response.PeriodsPerYear)
+	// the close before the window gives the return of its first candle
+	assert.Equal(t, models.RealizedVolatility{CloseToClose: 58.9534, Parkinson: 56.2123, GarmanKlass: 55.4195}, response.Volatility)
+	assert.Equal(t, 5.17431641, response.ATR)
+	assert.Equal(t, 2, response.ATRPeriod)
+	assert.Equal(t, 4.6615, response.ATRPercent)
+	assert.Equal(t, models.Drawdown{
+		Percent:     -7.619,
+		PeakPrice:   105,
+		PeakTime:    "2024-12-04T00:00:00Z",
+		TroughPrice: 97,
+		TroughTime:  "2024-12-07T00:00:00Z",
+	}, response.MaxDrawdown)
+	assert.Equal(t, 8.8235, response.TotalReturn)
+
+	rolling := response.RollingReturns
+	assert.Equal(t, 3, rolling.Period)
+	assert.Len(t, rolling.Series, 7)
+	assert.Equal(t, models.ReturnPoint{Time: "2024-12-06T00:00:00Z", Return: -1.9802}, rolling.Series[0])
+	assert.Equal(t, 6.7308, rolling.Latest)
+	assert.Equal(t, 2.5157, rolling.Mean)
+	assert.Equal(t, -7.619, rolling.Min)
+	assert.Equal(t, 11.3402, rolling.Max)
+}
+
+func TestGetVolatilityShortHistory(t *testing.T) {
+	router, _ := setupTestRouter(t)
+
+	// 12 candles for a window of 30, measured over what there is
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/analytics/volatility?symbol=BTCUSDT&interval=1w", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ResponseVolatility
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 12, response.Window)
+	assert.InDelta(t, 365.0/7, response.PeriodsPerYear, 1e-9)
+	assert.Equal(t, -7.619, response.MaxDrawdown.Percent)
+	// no average true range of 14 candles yet
+	assert.Zero(t, response.ATR)
+	assert.Len(t, response.RollingReturns.Series, 5)
+}
+
+func TestGetVolatilityErrors(t *testing.T) {
+	router, _ := setupTestRouter(t)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "Missing symbol", query: "", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing symbol"},
+		{name: "Window too short", query: "symbol=BTCUSDT&window=2", expectedStatus: http.StatusBadRequest, expectedMessage: "window must be between 3 and 1000"},
+		{name: "Invalid ATR period", query: "symbol=BTCUSDT&atrPeriod=abc", expectedStatus: http.StatusBadRequest, expectedMessage: "atrPeriod must be between 1 and 500"},
+		{name: "Return period beyond the window", query: "symbol=BTCUSDT&window=10&returnPeriod=10", expectedStatus: http.StatusBadRequest, expectedMessage: "returnPeriod must be between 1 and 9"},
+		{name: "Invalid endTime", query: "symbol=BTCUSDT&endTime=0", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid endTime"},
+		{name: "Invalid interval", query: "symbol=BTCUSDT&interval=1y", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid interval"},
+		{name: "Unknown exchange", query: "symbol=BTCUSDT&exchange=kraken", expectedStatus: http.StatusBadRequest, expectedMessage: "exchange kraken is not supported"},
+		{name: "Invalid symbol", query: "symbol=NOPEUSDT", expectedStatus: http.StatusBadRequest, expectedMessage: "API returned status code: 400"},
+		{name: "Not enough history", query: "symbol=BTCUSDT&window=20&returnPeriod=12", expectedStatus: http.StatusNotFound, expectedMessage: "Not enough history for BTCUSDT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/analytics/volatility?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
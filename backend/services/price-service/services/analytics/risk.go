@@ -0,0 +1,86 @@
+package analytics
+
+import (
+	"math"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// The volatility estimators return the standard deviation of one candle, 0 without enough candles.
+// Multiplying by the square root of the candles in a year annualizes them.
+
+// CloseToCloseVolatility returns the sample standard deviation of the log returns between closes
+func CloseToCloseVolatility(closes []float64) float64 {
+	if len(closes) < 3 {
+		return 0
+	}
+	returns := make([]float64, 0, len(closes)-1)
+	mean := 0.0
+	for i := 1; i < len(closes); i++ {
+		r := math.Log(closes[i] / closes[i-1])
+		returns = append(returns, r)
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	return math.Sqrt(variance / float64(len(returns)-1))
+}
+
+// ParkinsonVolatility estimates the volatility from the range of each candle, which uses the moves
+// within a candle that closes alone miss
+func ParkinsonVolatility(candles []models.Candle) float64 {
+	if len(candles) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, candle := range candles {
+		logRange := math.Log(candle.High / candle.Low)
+		sum += logRange * logRange
+	}
+	return math.Sqrt(sum / (4 * math.Ln2 * float64(len(candles))))
+}
+
+// GarmanKlassVolatility estimates the volatility from the range and the open to close move of each candle
+func GarmanKlassVolatility(candles []models.Candle) float64 {
+	if len(candles) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, candle := range candles {
+		logRange := math.Log(candle.High / candle.Low)
+		logMove := math.Log(candle.Close / candle.Open)
+		sum += 0.5*logRange*logRange - (2*math.Ln2-1)*logMove*logMove
+	}
+	return math.Sqrt(math.Max(0, sum/float64(len(candles))))
+}
+
+// MaxDrawdown returns the largest fall of the closes from a previous peak as a negative fraction, with
+// the indexes of the peak and the trough. It is 0 at the first index when the closes never fall.
+func MaxDrawdown(closes []float64) (drawdown float64, peak, trough int) {
+	highest := 0
+	for i, close := range closes {
+		if close > closes[highest] {
+			highest = i
+		}
+		if fall := close/closes[highest] - 1; fall < drawdown {
+			drawdown, peak, trough = fall, highest, i
+		}
+	}
+	return drawdown, peak, trough
+}
+
+// RollingReturns returns the simple return of the closes over every period ending at index period on
+func RollingReturns(closes []float64, period int) []float64 {
+	if period < 1 || len(closes) <= period {
+		return []float64{}
+	}
+	returns := make([]float64, 0, len(closes)-period)
+	for i := period; i < len(closes); i++ {
+		returns = append(returns, closes[i]/closes[i-period]-1)
+	}
+	return returns
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCloses = []float64{100, 102, 101, 105, 103, 99, 97, 100, 104, 108, 107, 111}
+
+// testCandles builds daily candles from 2024-12-01 opening at the previous close, one above and below
+// their body. Reference values were computed independently from the textbook formulas.
+func testCandles() []models.Candle {
+	candles := make([]models.Candle, len(testCloses))
+	open := 99.0
+	for i, close := range testCloses {
+		candles[i] = models.Candle{
+			OpenTime: 1733011200000 + int64(i)*86400000,
+			Open:     open,
+			High:     math.Max(open, close) + 1,
+			Low:      math.Min(open, close) - 1,
+			Close:    close,
+			Volume:   10,
+		}
+		open = close
+	}
+	return candles
+}
+
+func TestVolatilityEstimators(t *testing.T) {
+	assert.InDelta(t, 0.029473358089988046, CloseToCloseVolatility(testCloses), 1e-12)
+	assert.InDelta(t, 0.02821174372580061, ParkinsonVolatility(testCandles()), 1e-12)
+	assert.InDelta(t, 0.028081900836507332, GarmanKlassVolatility(testCandles()), 1e-12)
+
+	// constant prices do not move
+	flat := []models.Candle{{Open: 10, High: 10, Low: 10, Close: 10}, {Open: 10, High: 10, Low: 10, Close: 10}}
+	assert.Zero(t, CloseToCloseVolatility([]float64{10, 10, 10}))
+	assert.Zero(t, ParkinsonVolatility(flat))
+	assert.Zero(t, GarmanKlassVolatility(flat))
+
+	// not enough data
+	assert.Zero(t, CloseToCloseVolatility([]float64{10, 11}))
+	assert.Zero(t, ParkinsonVolatility(nil))
+	assert.Zero(t, GarmanKlassVolatility(nil))
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	tests := []struct {
+		name             string
+		closes           []float64
+		expectedDrawdown float64
+		expectedPeak     int
+		expectedTrough   int
+	}{
+		{name: "Deepest of two falls", closes: testCloses, expectedDrawdown: 97.0/105 - 1, expectedPeak: 3, expectedTrough: 6},
+		{name: "Later peak", closes: []float64{100, 90, 120, 60, 130}, expectedDrawdown: -0.5, expectedPeak: 2, expectedTrough: 3},
+		{name: "Never falls", closes: []float64{1, 2, 3}, expectedDrawdown: 0, expectedPeak: 0, expectedTrough: 0},
+		{name: "Falls from the start", closes: []float64{4, 3, 2, 1}, expectedDrawdown: -0.75, expectedPeak: 0, expectedTrough: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drawdown, peak, trough := MaxDrawdown(tt.closes)
+			assert.InDelta(t, tt.expectedDrawdown, drawdown, 1e-12)
+			assert.Equal(t, tt.expectedPeak, peak)
+			assert.Equal(t, tt.expectedTrough, trough)
+		})
+	}
+}
+
+func TestRollingReturns(t *testing.T) {
+	returns := RollingReturns(testCloses, 3)
+	assert.Len(t, returns, 9)
+	assert.InDelta(t, 0.05, returns[0], 1e-12)
+	assert.InDelta(t, 111.0/104-1, returns[8], 1e-12)
+
+	assert.Equal(t, []float64{}, RollingReturns(testCloses, 12))
+	assert.Equal(t, []float64{}, RollingReturns(testCloses, 0))
+}
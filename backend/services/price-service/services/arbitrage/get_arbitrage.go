@@ -0,0 +1,143 @@
+package arbitrage
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// depthLimit is the number of levels requested from each book, the smallest every exchange accepts
+const depthLimit = 5
+
+// @Summary Get cross-exchange arbitrage
+// @Description Quotes the best bid and ask of an asset on several exchanges, converts them into one currency and reports where to buy and sell, the gross spread and the spread after the taker fee of both venues
+// @Tags Arbitrage
+// @Produce json
+// @Param asset query string true "Base asset (e.g., BTC)" example("BTC")
+// @Param market query string false "Market: spot (default) or futures" example("spot")
+// @Param exchanges query string false "Comma separated exchanges among binance, okx, bybit and coinbase, all of them or ARBITRAGE_EXCHANGES by default" example("binance,okx,coinbase")
+// @Param quote query string false "Quote asset on every exchange, USD on Coinbase and USDT elsewhere by default" example("USDT")
+// @Param currency query string false "Fiat currency or USD stablecoin prices are compared in, USD stablecoins are taken at par" example("USD")
+// @Param fees query string false "Taker fees overriding the defaults and ARBITRAGE_TAKER_FEES" example("binance=0.00075,coinbase=0.004")
+// @Success 200 {object} models.ResponseArbitrage "Successful response with the venues and spreads"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing asset or invalid parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Asset not found on any exchange"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/arbitrage [get]
+func GetArbitrage(context *gin.Context) {
+	query, err := ParseQuery(context)
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	response, statusCode, err := GetArbitrageData(query)
+	if err != nil {
+		utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+		return
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// ParseQuery reads the asset, market, exchanges, quote, currency and fees parameters of a request
+func ParseQuery(context *gin.Context) (*Query, error) {
+	query, err := NewQuery(context.Query("asset"), context.Query("market"))
+	if err != nil {
+		return nil, err
+	}
+	if err := query.SetExchanges(context.Query("exchanges")); err != nil {
+		return nil, err
+	}
+	if err := query.SetTakerFees(context.Query("fees")); err != nil {
+		return nil, err
+	}
+	query.Quote = strings.ToUpper(strings.TrimSpace(context.Query("quote")))
+	if currency := strings.TrimSpace(context.Query("currency")); currency != "" {
+		query.Currency = strings.ToUpper(currency)
+	}
+	return query, nil
+}
+
+// GetArbitrageData quotes the asset on every exchange of the query at once. An exchange that fails is
+// reported in its venue, the query only fails when the currency is invalid or no exchange is quoted.
+func GetArbitrageData(query *Query) (*models.ResponseArbitrage, models.StatusCode, error) {
+	// rejects an unknown currency before asking any exchange
+	if _, statusCode, err := fx.FromUSD(query.Currency); err != nil {
+		return nil, statusCode, err
+	}
+
+	venues := make([]models.ArbitrageVenue, len(query.Exchanges))
+	statusCodes := make([]models.StatusCode, len(query.Exchanges))
+	var wg sync.WaitGroup
+	for i, exchange := range query.Exchanges {
+		wg.Add(1)
+		go func(i int, exchange string) {
+			defer wg.Done()
+			venues[i], statusCodes[i] = quoteVenue(query, exchange)
+		}(i, exchange)
+	}
+	wg.Wait()
+
+	quoted := false
+	for _, venue := range venues {
+		quoted = quoted || venue.Error == ""
+	}
+	if !quoted {
+		statusCode := statusCodes[0]
+		if statusCode == http.StatusBadRequest {
+			statusCode = http.StatusNotFound
+		}
+		return nil, statusCode, fmt.Errorf("%s is not quoted on any exchange", query.Asset)
+	}
+
+	response := &models.ResponseArbitrage{Asset: query.Asset, Market: query.Market, Currency: query.Currency}
+	response.UpdateData(venues, utils.ConvertMillisecondsToTimestamp(time.Now().UnixMilli()))
+	return response, http.StatusOK, nil
+}
+
+// quoteVenue reads the top of the book of the asset on an exchange and converts it into the currency
+func quoteVenue(query *Query, exchange string) (models.ArbitrageVenue, models.StatusCode) {
+	quote := query.QuoteOf(exchange)
+	venue := models.ArbitrageVenue{
+		Exchange: exchange,
+		Symbol:   query.Asset + quote,
+		Quote:    quote,
+		TakerFee: query.TakerFees[exchange],
+	}
+
+	marketData, err := provider.Get(exchange)
+	if err != nil {
+		venue.Error = err.Error()
+		return venue, http.StatusBadRequest
+	}
+	conversion, statusCode, err := fx.NewConversion(fx.Default(), quote, query.Currency)
+	if err != nil {
+		venue.Error = err.Error()
+		return venue, statusCode
+	}
+	book, statusCode, err := marketData.Depth(query.Market, venue.Symbol, depthLimit)
+	if err != nil {
+		venue.Error = err.Error()
+		return venue, statusCode
+	}
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		venue.Error = "Empty order book"
+		return venue, http.StatusNotFound
+	}
+
+	venue.Rate = conversion.Rate
+	venue.Bid = conversion.Convert(book.Bids[0].Price)
+	venue.BidQuantity = book.Bids[0].Quantity
+	venue.Ask = conversion.Convert(book.Asks[0].Price)
+	venue.AskQuantity = book.Asks[0].Quantity
+	venue.Time = book.Time
+	return venue, http.StatusOK
+}
@@ -0,0 +1,206 @@
+package arbitrage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func topOfBook(symbol string, bid, ask float64) models.OrderBook {
+	return models.OrderBook{
+		Symbol: symbol,
+		Bids:   []models.PriceLevel{{Price: bid, Quantity: 1.5}},
+		Asks:   []models.PriceLevel{{Price: ask, Quantity: 0.5}},
+		Time:   1733900000000,
+	}
+}
+
+// setupExchanges registers fake exchanges quoting BTC and ETH, Bybit lists nothing
+func setupExchanges(t *testing.T) {
+	exchanges := []*providertest.FakeExchange{
+		{ExchangeName: provider.ExchangeBinance, Books: map[string]models.OrderBook{
+			"BTCUSDT": topOfBook("BTCUSDT", 97000, 97000.5),
+			"ETHUSDT": topOfBook("ETHUSDT", 3000, 3000.5),
+		}},
+		{ExchangeName: provider.ExchangeOKX, Books: map[string]models.OrderBook{
+			"BTCUSDT": topOfBook("BTCUSDT", 97100, 97100.5),
+			"ETHUSDT": topOfBook("ETHUSDT", 2990, 3010),
+		}},
+		{ExchangeName: provider.ExchangeBybit},
+		{ExchangeName: provider.ExchangeCoinbase, SpotOnly: true, Books: map[string]models.OrderBook{"BTCUSD": topOfBook("BTCUSD", 96800, 96801)}},
+	}
+	for _, exchange := range exchanges {
+		provider.Register(exchange.ExchangeName, exchange)
+	}
+	fx.SetDefault(&fx.StaticRateSource{FXRates: models.FXRates{
+		Rates:  map[string]float64{"USD": 1, "EUR": 0.95},
+		AsOf:   1733875200000,
+		Source: "test",
+	}})
+	t.Cleanup(func() {
+		for _, exchange := range exchanges {
+			provider.Register(exchange.ExchangeName, nil)
+		}
+		fx.SetDefault(nil)
+	})
+}
+
+func setupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/arbitrage", GetArbitrage)
+	return router
+}
+
+func TestGetArbitrage(t *testing.T) {
+	setupExchanges(t)
+	router := setupTestRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/arbitrage?asset=btc", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ResponseArbitrage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "BTC", response.Asset)
+	assert.Equal(t, provider.MarketSpot, response.Market)
+	assert.Equal(t, "USD", response.Currency)
+	assert.Len(t, response.Venues, 4)
+	assert.Equal(t, models.ArbitrageVenue{
+		Exchange: "coinbase", Symbol: "BTCUSD", Quote: "USD",
+		Bid: 96800, BidQuantity: 1.5, Ask: 96801, AskQuantity: 0.5,
+		Rate: 1, TakerFee: 0.006, Time: 1733900000000,
+	}, response.Venues[3])
+	// the exchange that does not list the symbol is reported without failing the others
+	assert.Equal(t, "BTCUSDT", response.Venues[2].Symbol)
+	assert.Equal(t, "API returned status code: 400", response.Venues[2].Error)
+
+	// buying on Coinbase has the lowest ask but its fee makes Binance the better buy:
+	// 97100 * 0.999 - 97000.5 * 1.001 against 97100 * 0.999 - 96801 * 1.006
+	assert.Equal(t, "binance", response.BuyExchange)
+	assert.Equal(t, 97000.5, response.BuyPrice)
+	assert.Equal(t, "okx", response.SellExchange)
+	assert.Equal(t, 97100.0, response.SellPrice)
+	assert.Equal(t, 99.5, response.GrossSpread)
+	assert.Equal(t, 0.1026, response.GrossSpreadPercent)
+	assert.Equal(t, -94.6005, response.NetSpread)
+	assert.Equal(t, -0.0975, response.NetSpreadPercent)
+	assert.False(t, response.Profitable)
+	assert.NotEmpty(t, response.EventTime)
+}
+
+func TestGetArbitrageParameters(t *testing.T) {
+	setupExchanges(t)
+	router := setupTestRouter()
+
+	tests := []struct {
+		name                 string
+		query                string
+		expectedBuyExchange  string
+		expectedBuyPrice     float64
+		expectedSellExchange string
+		expectedSellPrice    float64
+		expectedNetSpread    float64
+		expectedProfitable   bool
+	}{
+		{
+			name:                 "Fees overridden",
+			query:                "asset=BTC&exchanges=binance,okx&fees=binance=0,okx=0",
+			expectedBuyExchange:  "binance",
+			expectedBuyPrice:     97000.5,
+			expectedSellExchange: "okx",
+			expectedSellPrice:    97100,
+			expectedNetSpread:    99.5,
+			expectedProfitable:   true,
+		},
+		{
+			name:                 "Converted into EUR",
+			query:                "asset=BTC&exchanges=binance,okx&fees=binance=0,okx=0&currency=eur",
+			expectedBuyExchange:  "binance",
+			expectedBuyPrice:     92150.475,
+			expectedSellExchange: "okx",
+			expectedSellPrice:    92245,
+			expectedNetSpread:    94.525,
+			expectedProfitable:   true,
+		},
+		{
+			// Binance has both the lowest ask and the highest bid, its own spread is not an arbitrage
+			name:                 "Two different exchanges",
+			query:                "asset=ETH&exchanges=binance,okx",
+			expectedBuyExchange:  "okx",
+			expectedBuyPrice:     3010,
+			expectedSellExchange: "binance",
+			expectedSellPrice:    3000,
+			expectedNetSpread:    -16.01,
+		},
+		{
+			name:                 "Futures without Coinbase",
+			query:                "asset=BTC&market=futures",
+			expectedBuyExchange:  "binance",
+			expectedBuyPrice:     97000.5,
+			expectedSellExchange: "okx",
+			expectedSellPrice:    97100,
+			expectedNetSpread:    -94.6005,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/arbitrage?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response models.ResponseArbitrage
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedBuyExchange, response.BuyExchange)
+			assert.Equal(t, tt.expectedBuyPrice, response.BuyPrice)
+			assert.Equal(t, tt.expectedSellExchange, response.SellExchange)
+			assert.Equal(t, tt.expectedSellPrice, response.SellPrice)
+			assert.Equal(t, tt.expectedNetSpread, response.NetSpread)
+			assert.Equal(t, tt.expectedProfitable, response.Profitable)
+		})
+	}
+}
+
+func TestGetArbitrageErrors(t *testing.T) {
+	setupExchanges(t)
+	router := setupTestRouter()
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "Missing asset", query: "", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing asset"},
+		{name: "Invalid market", query: "asset=BTC&market=options", expectedStatus: http.StatusBadRequest, expectedMessage: "market options is not supported"},
+		{name: "One exchange", query: "asset=BTC&exchanges=binance", expectedStatus: http.StatusBadRequest, expectedMessage: "At least two exchanges are needed"},
+		{name: "Unknown exchange", query: "asset=BTC&exchanges=binance,kraken", expectedStatus: http.StatusBadRequest, expectedMessage: "exchange kraken is not supported"},
+		{name: "Invalid fee", query: "asset=BTC&fees=binance=2", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid fee of binance, expected a fraction between 0 and 1"},
+		{name: "Unsupported currency", query: "asset=BTC&currency=XYZ", expectedStatus: http.StatusBadRequest, expectedMessage: "Unsupported currency XYZ"},
+		{name: "Asset not listed", query: "asset=NOPE", expectedStatus: http.StatusNotFound, expectedMessage: "NOPE is not quoted on any exchange"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/arbitrage?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
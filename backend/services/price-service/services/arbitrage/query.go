@@ -0,0 +1,207 @@
+package arbitrage
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+)
+
+// DefaultCurrency is the currency prices are compared in when none is requested
+const DefaultCurrency = "USD"
+
+// DefaultTakerFees are the base tier taker fees of each exchange, ARBITRAGE_TAKER_FEES overrides them
+var DefaultTakerFees = map[string]float64{
+	provider.ExchangeBinance:  0.001,
+	provider.ExchangeOKX:      0.001,
+	provider.ExchangeBybit:    0.001,
+	provider.ExchangeCoinbase: 0.006,
+}
+
+// defaultExchanges are compared when neither the request nor ARBITRAGE_EXCHANGES names any
+var defaultExchanges = []string{provider.ExchangeBinance, provider.ExchangeOKX, provider.ExchangeBybit, provider.ExchangeCoinbase}
+
+// Config holds the exchanges and taker fees every query starts from
+type Config struct {
+	// Exchanges are at least two supported exchange names
+	Exchanges []string
+	// TakerFees are the taker fees of the exchanges as fractions, e.g. 0.001 for 0.1%
+	TakerFees map[string]float64
+}
+
+var (
+	config      = defaultConfig()
+	configMutex sync.RWMutex
+)
+
+func defaultConfig() *Config {
+	takerFees := make(map[string]float64, len(DefaultTakerFees))
+	for exchange, fee := range DefaultTakerFees {
+		takerFees[exchange] = fee
+	}
+	return &Config{Exchanges: defaultExchanges, TakerFees: takerFees}
+}
+
+// NewConfigFromEnv reads ARBITRAGE_EXCHANGES, a comma separated list such as binance,okx, and
+// ARBITRAGE_TAKER_FEES, a list such as binance=0.001,coinbase=0.006 overriding DefaultTakerFees.
+// An invalid value is logged and leaves the defaults.
+func NewConfigFromEnv() *Config {
+	c := defaultConfig()
+	if value := os.Getenv("ARBITRAGE_EXCHANGES"); strings.TrimSpace(value) != "" {
+		exchanges, err := parseExchanges(value)
+		switch {
+		case err != nil:
+			log.Printf("Invalid ARBITRAGE_EXCHANGES %s, comparing the default exchanges: %v", value, err)
+		case len(exchanges) < 2:
+			log.Printf("Invalid ARBITRAGE_EXCHANGES %s, at least two exchanges are needed, comparing the default exchanges", value)
+		default:
+			c.Exchanges = exchanges
+		}
+	}
+	fees, err := parseFees(os.Getenv("ARBITRAGE_TAKER_FEES"))
+	if err != nil {
+		log.Printf("Invalid ARBITRAGE_TAKER_FEES, using the default taker fees: %v", err)
+	}
+	for exchange, fee := range fees {
+		c.TakerFees[exchange] = fee
+	}
+	return c
+}
+
+// SetConfig makes the queries start from c, nil restores the defaults
+func SetConfig(c *Config) {
+	configMutex.Lock()
+	defer configMutex.Unlock()
+	if c == nil {
+		c = defaultConfig()
+	}
+	config = c
+}
+
+func getConfig() *Config {
+	configMutex.RLock()
+	defer configMutex.RUnlock()
+	return config
+}
+
+// Query describes which asset to compare across which exchanges
+type Query struct {
+	Asset  string
+	Market string
+	// Exchanges are at least two supported exchange names
+	Exchanges []string
+	// Quote is the quote asset of every exchange, empty for USD on Coinbase and USDT elsewhere
+	Quote string
+	// Currency is the fiat currency or USD stablecoin prices are converted into
+	Currency string
+	// TakerFees are the taker fees of the exchanges as fractions, e.g. 0.001 for 0.1%
+	TakerFees map[string]float64
+}
+
+// NewQuery returns the query of an asset on a market with the exchanges and fees set by SetConfig.
+// An empty market means spot.
+func NewQuery(asset, market string) (*Query, error) {
+	asset = provider.NormalizeSymbol(asset)
+	if asset == "" {
+		return nil, fmt.Errorf("Missing asset")
+	}
+	if strings.TrimSpace(market) == "" {
+		market = provider.MarketSpot
+	}
+	market, err := provider.NormalizeMarket(market)
+	if err != nil {
+		return nil, err
+	}
+
+	c := getConfig()
+	// the fees are copied since a request may override them
+	takerFees := make(map[string]float64, len(c.TakerFees))
+	for exchange, fee := range c.TakerFees {
+		takerFees[exchange] = fee
+	}
+
+	return &Query{Asset: asset, Market: market, Exchanges: c.Exchanges, Currency: DefaultCurrency, TakerFees: takerFees}, nil
+}
+
+// SetExchanges replaces the exchanges of the query by a comma separated list, empty keeps them
+func (q *Query) SetExchanges(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	exchanges, err := parseExchanges(value)
+	if err != nil {
+		return err
+	}
+	if len(exchanges) < 2 {
+		return fmt.Errorf("At least two exchanges are needed")
+	}
+	q.Exchanges = exchanges
+	return nil
+}
+
+// SetTakerFees overrides taker fees with a list such as binance=0.001,coinbase=0.006
+func (q *Query) SetTakerFees(value string) error {
+	fees, err := parseFees(value)
+	if err != nil {
+		return err
+	}
+	for exchange, fee := range fees {
+		q.TakerFees[exchange] = fee
+	}
+	return nil
+}
+
+// QuoteOf returns the quote asset the asset is traded against on an exchange
+func (q *Query) QuoteOf(exchange string) string {
+	switch {
+	case q.Quote != "":
+		return q.Quote
+	case exchange == provider.ExchangeCoinbase:
+		return "USD"
+	}
+	return "USDT"
+}
+
+func parseExchanges(value string) ([]string, error) {
+	var exchanges []string
+	seen := map[string]bool{}
+	for _, exchange := range strings.Split(value, ",") {
+		exchange = strings.ToLower(strings.TrimSpace(exchange))
+		if exchange == "" || seen[exchange] {
+			continue
+		}
+		if _, ok := DefaultTakerFees[exchange]; !ok {
+			return nil, fmt.Errorf("exchange %s is not supported", exchange)
+		}
+		seen[exchange] = true
+		exchanges = append(exchanges, exchange)
+	}
+	return exchanges, nil
+}
+
+func parseFees(value string) (map[string]float64, error) {
+	fees := map[string]float64{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		exchange, rate, ok := strings.Cut(pair, "=")
+		exchange = strings.ToLower(strings.TrimSpace(exchange))
+		if !ok {
+			return nil, fmt.Errorf("Invalid fee %s, expected exchange=fee", strings.TrimSpace(pair))
+		}
+		if _, ok := DefaultTakerFees[exchange]; !ok {
+			return nil, fmt.Errorf("exchange %s is not supported", exchange)
+		}
+		fee, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if err != nil || fee < 0 || fee >= 1 {
+			return nil, fmt.Errorf("Invalid fee of %s, expected a fraction between 0 and 1", exchange)
+		}
+		fees[exchange] = fee
+	}
+	return fees, nil
+}
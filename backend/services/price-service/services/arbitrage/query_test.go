@@ -0,0 +1,96 @@
+package arbitrage
+
+import (
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQuery(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		query, err := NewQuery("eth", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "ETH", query.Asset)
+		assert.Equal(t, provider.MarketSpot, query.Market)
+		assert.Equal(t, defaultExchanges, query.Exchanges)
+		assert.Equal(t, DefaultCurrency, query.Currency)
+		assert.Equal(t, DefaultTakerFees, query.TakerFees)
+		assert.Equal(t, "USD", query.QuoteOf(provider.ExchangeCoinbase))
+		assert.Equal(t, "USDT", query.QuoteOf(provider.ExchangeOKX))
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		SetConfig(&Config{Exchanges: []string{"okx", "coinbase"}, TakerFees: map[string]float64{"okx": 0.001, "coinbase": 0.004}})
+		defer SetConfig(nil)
+		query, err := NewQuery("BTC", "futures")
+		assert.NoError(t, err)
+		assert.Equal(t, provider.MarketFutures, query.Market)
+		assert.Equal(t, []string{"okx", "coinbase"}, query.Exchanges)
+		assert.Equal(t, 0.004, query.TakerFees[provider.ExchangeCoinbase])
+
+		// a request overriding a fee leaves the config untouched
+		assert.NoError(t, query.SetTakerFees("coinbase=0"))
+		assert.Equal(t, 0.004, getConfig().TakerFees[provider.ExchangeCoinbase])
+	})
+
+	t.Run("Quote override", func(t *testing.T) {
+		query, err := NewQuery("BTC", "")
+		assert.NoError(t, err)
+		query.Quote = "USDC"
+		assert.Equal(t, "USDC", query.QuoteOf(provider.ExchangeCoinbase))
+	})
+}
+
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv("ARBITRAGE_EXCHANGES", "OKX, coinbase")
+		t.Setenv("ARBITRAGE_TAKER_FEES", "coinbase=0.004")
+		c := NewConfigFromEnv()
+		assert.Equal(t, []string{"okx", "coinbase"}, c.Exchanges)
+		assert.Equal(t, 0.004, c.TakerFees[provider.ExchangeCoinbase])
+		assert.Equal(t, 0.001, c.TakerFees[provider.ExchangeBinance])
+		// the defaults are left untouched
+		assert.Equal(t, 0.006, DefaultTakerFees[provider.ExchangeCoinbase])
+	})
+
+	t.Run("Invalid environment falls back to the defaults", func(t *testing.T) {
+		t.Setenv("ARBITRAGE_EXCHANGES", "binance,kraken")
+		t.Setenv("ARBITRAGE_TAKER_FEES", "binance")
+		c := NewConfigFromEnv()
+		assert.Equal(t, defaultExchanges, c.Exchanges)
+		assert.Equal(t, DefaultTakerFees, c.TakerFees)
+	})
+
+	t.Run("A single exchange falls back to the defaults", func(t *testing.T) {
+		t.Setenv("ARBITRAGE_EXCHANGES", "binance")
+		assert.Equal(t, defaultExchanges, NewConfigFromEnv().Exchanges)
+	})
+}
+
+func TestParseFees(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectedFees  map[string]float64
+		expectedError string
+	}{
+		{name: "Empty", value: "", expectedFees: map[string]float64{}},
+		{name: "Several exchanges", value: "Binance=0.00075, coinbase=0.004", expectedFees: map[string]float64{"binance": 0.00075, "coinbase": 0.004}},
+		{name: "Missing fee", value: "binance", expectedError: "Invalid fee binance, expected exchange=fee"},
+		{name: "Unknown exchange", value: "kraken=0.001", expectedError: "exchange kraken is not supported"},
+		{name: "Negative fee", value: "okx=-0.1", expectedError: "Invalid fee of okx, expected a fraction between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fees, err := parseFees(tt.value)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedFees, fees)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package depth
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+)
+
+// ApplyResult tells what Book.Apply did with an event
+type ApplyResult int
+
+const (
+	// Applied means the event updated the book
+	Applied ApplyResult = iota
+	// Stale means the event is older than the book and was ignored
+	Stale
+	// Gap means updates were missed, the book is cleared until the next Reset
+	Gap
+)
+
+// Book is an order book kept up to date by the Binance diff depth stream on top of a REST snapshot,
+// following the rules Binance gives for managing a local order book on each market.
+type Book struct {
+	Symbol string
+	Market string
+
+	bids map[float64]float64
+	asks map[float64]float64
+	// lastUpdateID is the id of the snapshot, then the final update id of the last applied event
+	lastUpdateID int64
+	loaded       bool
+	// synced is set once an event following the snapshot was applied
+	synced bool
+}
+
+func NewBook(market, symbol string) *Book {
+	return &Book{Symbol: symbol, Market: market}
+}
+
+// Loaded tells whether the book holds a snapshot, a book without one needs a Reset before Apply
+func (b *Book) Loaded() bool {
+	return b.loaded
+}
+
+// Reset replaces the book with a REST snapshot, which must carry the update id of the exchange
+func (b *Book) Reset(snapshot *models.OrderBook) {
+	b.bids = make(map[float64]float64, len(snapshot.Bids))
+	for _, level := range snapshot.Bids {
+		b.bids[level.Price] = level.Quantity
+	}
+	b.asks = make(map[float64]float64, len(snapshot.Asks))
+	for _, level := range snapshot.Asks {
+		b.asks[level.Price] = level.Quantity
+	}
+	b.lastUpdateID = snapshot.LastUpdateID
+	b.loaded = true
+	b.synced = false
+}
+
+// Apply updates the book with a diff depth event. The first event after the snapshot must cover
+// the snapshot update id, then spot events must follow each other without a hole in the update ids
+// while futures events must point to the previous event with pu.
+func (b *Book) Apply(event *models.DepthEvent) (ApplyResult, error) {
+	if !b.loaded {
+		return Gap, nil
+	}
+	futures := b.Market == provider.MarketFutures
+
+	if !b.synced {
+		// futures start with the event holding the snapshot id, spot with the one right after it
+		first := b.lastUpdateID + 1
+		if futures {
+			first = b.lastUpdateID
+		}
+		if event.FinalUpdateID < first {
+			return Stale, nil
+		}
+		if event.FirstUpdateID > first {
+			return b.clear(), nil
+		}
+	} else if futures && event.PrevFinalUpdateID != b.lastUpdateID || !futures && event.FirstUpdateID != b.lastUpdateID+1 {
+		return b.clear(), nil
+	}
+
+	if err := applyLevels(b.bids, event.Bids); err != nil {
+		return b.clear(), err
+	}
+	if err := applyLevels(b.asks, event.Asks); err != nil {
+		return b.clear(), err
+	}
+	b.lastUpdateID = event.FinalUpdateID
+	b.synced = true
+	return Applied, nil
+}
+
+// Top returns at most limit of the best bids and asks
+func (b *Book) Top(limit int) *models.OrderBook {
+	return &models.OrderBook{
+		Symbol:       b.Symbol,
+		LastUpdateID: b.lastUpdateID,
+		Bids:         topLevels(b.bids, limit, func(a, b float64) bool { return a > b }),
+		Asks:         topLevels(b.asks, limit, func(a, b float64) bool { return a < b }),
+	}
+}
+
+func (b *Book) clear() ApplyResult {
+	b.bids, b.asks = nil, nil
+	b.lastUpdateID = 0
+	b.loaded = false
+	b.synced = false
+	return Gap
+}
+
+// applyLevels sets the quantity of every [price, quantity] level, a quantity of 0 removes the level
+func applyLevels(side map[float64]float64, levels [][]string) error {
+	for _, level := range levels {
+		if len(level) < 2 {
+			return fmt.Errorf("price level has %d fields", len(level))
+		}
+		price, err := strconv.ParseFloat(level[0], 64)
+		if err != nil {
+			return fmt.Errorf("invalid price %q", level[0])
+		}
+		quantity, err := strconv.ParseFloat(level[1], 64)
+		if err != nil {
+			return fmt.Errorf("invalid quantity %q", level[1])
+		}
+		if quantity == 0 {
+			delete(side, price)
+		} else {
+			side[price] = quantity
+		}
+	}
+	return nil
+}
+
+func topLevels(side map[float64]float64, limit int, better func(a, b float64) bool) []models.PriceLevel {
+	prices := make([]float64, 0, len(side))
+	for price := range side {
+		prices = append(prices, price)
+	}
+	sort.Slice(prices, func(i, j int) bool { return better(prices[i], prices[j]) })
+	if len(prices) > limit {
+		prices = prices[:limit]
+	}
+
+	levels := make([]models.PriceLevel, 0, len(prices))
+	for _, price := range prices {
+		levels = append(levels, models.PriceLevel{Price: price, Quantity: side[price]})
+	}
+	return levels
+}
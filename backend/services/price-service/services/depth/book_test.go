@@ -0,0 +1,142 @@
+package depth
+
+import (
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBook(market string) *Book {
+	book := NewBook(market, "BTCUSDT")
+	book.Reset(&models.OrderBook{
+		LastUpdateID: 100,
+		Bids:         []models.PriceLevel{{Price: 99, Quantity: 1}, {Price: 98, Quantity: 2}},
+		Asks:         []models.PriceLevel{{Price: 101, Quantity: 1}, {Price: 102, Quantity: 2}},
+	})
+	return book
+}
+
+func TestBookApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		market   string
+		events   []models.DepthEvent
+		expected []ApplyResult
+	}{
+		{
+			name:   "spot drops events of the snapshot then follows update ids",
+			market: provider.MarketSpot,
+			events: []models.DepthEvent{
+				{FirstUpdateID: 95, FinalUpdateID: 100},
+				{FirstUpdateID: 99, FinalUpdateID: 103},
+				{FirstUpdateID: 104, FinalUpdateID: 104},
+			},
+			expected: []ApplyResult{Stale, Applied, Applied},
+		},
+		{
+			name:     "spot first event after the snapshot",
+			market:   provider.MarketSpot,
+			events:   []models.DepthEvent{{FirstUpdateID: 102, FinalUpdateID: 103}},
+			expected: []ApplyResult{Gap},
+		},
+		{
+			name:   "spot hole in update ids",
+			market: provider.MarketSpot,
+			events: []models.DepthEvent{
+				{FirstUpdateID: 101, FinalUpdateID: 101},
+				{FirstUpdateID: 103, FinalUpdateID: 104},
+				{FirstUpdateID: 105, FinalUpdateID: 105},
+			},
+			expected: []ApplyResult{Applied, Gap, Gap},
+		},
+		{
+			name:   "futures starts with the event holding the snapshot id and follows pu",
+			market: provider.MarketFutures,
+			events: []models.DepthEvent{
+				{FirstUpdateID: 90, FinalUpdateID: 99},
+				{FirstUpdateID: 97, FinalUpdateID: 100, PrevFinalUpdateID: 96},
+				{FirstUpdateID: 108, FinalUpdateID: 110, PrevFinalUpdateID: 100},
+				{FirstUpdateID: 115, FinalUpdateID: 120, PrevFinalUpdateID: 110},
+			},
+			expected: []ApplyResult{Stale, Applied, Applied, Applied},
+		},
+		{
+			name:   "futures broken pu",
+			market: provider.MarketFutures,
+			events: []models.DepthEvent{
+				{FirstUpdateID: 100, FinalUpdateID: 105, PrevFinalUpdateID: 99},
+				{FirstUpdateID: 112, FinalUpdateID: 115, PrevFinalUpdateID: 108},
+			},
+			expected: []ApplyResult{Applied, Gap},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := newTestBook(tt.market)
+			for i, event := range tt.events {
+				result, err := book.Apply(&event)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected[i], result, "event %d", i)
+			}
+		})
+	}
+}
+
+func TestBookLevels(t *testing.T) {
+	book := newTestBook(provider.MarketSpot)
+
+	result, err := book.Apply(&models.DepthEvent{
+		FirstUpdateID: 101,
+		FinalUpdateID: 102,
+		Bids:          [][]string{{"99.5", "3"}, {"98", "0"}},
+		Asks:          [][]string{{"101", "0.000"}, {"103", "4"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Applied, result)
+	assert.Equal(t, &models.OrderBook{
+		Symbol:       "BTCUSDT",
+		LastUpdateID: 102,
+		Bids:         []models.PriceLevel{{Price: 99.5, Quantity: 3}, {Price: 99, Quantity: 1}},
+		Asks:         []models.PriceLevel{{Price: 102, Quantity: 2}, {Price: 103, Quantity: 4}},
+	}, book.Top(5))
+	assert.Len(t, book.Top(1).Bids, 1)
+
+	t.Run("Gap clears the book", func(t *testing.T) {
+		result, err := book.Apply(&models.DepthEvent{FirstUpdateID: 110, FinalUpdateID: 111})
+		assert.NoError(t, err)
+		assert.Equal(t, Gap, result)
+		assert.False(t, book.Loaded())
+		assert.Empty(t, book.Top(5).Bids)
+
+		result, _ = book.Apply(&models.DepthEvent{FirstUpdateID: 112, FinalUpdateID: 112})
+		assert.Equal(t, Gap, result)
+	})
+
+	t.Run("Invalid level", func(t *testing.T) {
+		book := newTestBook(provider.MarketSpot)
+		_, err := book.Apply(&models.DepthEvent{FirstUpdateID: 101, FinalUpdateID: 101, Bids: [][]string{{"abc", "1"}}})
+		assert.EqualError(t, err, `invalid price "abc"`)
+		assert.False(t, book.Loaded())
+	})
+}
+
+func TestResponseDepth(t *testing.T) {
+	var response models.ResponseDepth
+	response.UpdateData(provider.MarketSpot, &models.OrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   []models.PriceLevel{{Price: 97123.4, Quantity: 0.8}},
+		Asks:   []models.PriceLevel{{Price: 97123.5, Quantity: 1.2}},
+	}, "2024-12-11 07:00:00")
+	assert.Equal(t, 97123.4, response.BestBid)
+	assert.Equal(t, 97123.5, response.BestAsk)
+	assert.Equal(t, 0.1, response.Spread)
+	assert.Equal(t, 97123.45, response.MidPrice)
+
+	response = models.ResponseDepth{}
+	response.UpdateData(provider.MarketSpot, &models.OrderBook{Symbol: "BTCUSDT"}, "")
+	assert.Equal(t, []models.PriceLevel{}, response.Bids)
+	assert.Zero(t, response.Spread)
+}
@@ -0,0 +1,80 @@
+package depth
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultLimit is the number of levels of each side when the client asks for none
+	DefaultLimit = 20
+	// MaxLimit bounds the levels of each side, it is also the size of the snapshot of the depth socket
+	MaxLimit = 1000
+)
+
+// @Summary Get order book depth
+// @Description Retrieves the best bids and asks of a trading pair with spread and mid price from Binance or the requested exchange
+// @Tags Depth
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param market query string false "Market: spot (default) or futures" example("spot")
+// @Param limit query int false "Number of levels of each side, 20 by default and at most 1000, exchanges may return fewer"
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponseDepth "Bids from the highest price and asks from the lowest"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid symbol or request parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Failed to fetch depth"
+// @Router /api/v1/depth [get]
+func GetDepth(ctx *gin.Context) {
+	symbol := ctx.Query("symbol")
+	if symbol == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "symbol cannot be empty"})
+		return
+	}
+
+	market, err := provider.NormalizeMarket(ctx.DefaultQuery("market", provider.MarketSpot))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, err := ParseLimit(ctx.Query("limit"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	marketData, err := provider.Get(ctx.Query("exchange"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, statusCode, err := marketData.Depth(market, provider.NormalizeSymbol(symbol), limit)
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+
+	var response models.ResponseDepth
+	response.UpdateData(market, book, utils.ConvertMillisecondsToTimestamp(book.Time))
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ParseLimit reads the number of levels of each side, DefaultLimit when value is empty
+func ParseLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+	return limit, nil
+}
@@ -0,0 +1,108 @@
+package depth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDepth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var requestedPath, requestedLimit string
+	binance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		requestedPath, requestedLimit = r.URL.Path, r.URL.Query().Get("limit")
+		w.Write([]byte(`{"lastUpdateId":1027024,"bids":[["50000.00","1.5"],["49999.90","0.2"]],"asks":[["50000.10","0.7"],["50001.00","2.25"]]}`))
+	}))
+	defer binance.Close()
+	provider.SetDefault(provider.NewBinanceProvider(binance.URL, binance.URL))
+	defer provider.SetDefault(nil)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedPath   string
+		expectedLimit  string
+		expectedError  string
+	}{
+		{
+			name:           "spot by default",
+			query:          "symbol=btcusdt",
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/api/v3/depth",
+			expectedLimit:  "20",
+		},
+		{
+			name:           "futures",
+			query:          "symbol=BTCUSDT&market=futures&limit=2",
+			expectedStatus: http.StatusOK,
+			expectedPath:   "/fapi/v1/depth",
+			expectedLimit:  "5",
+		},
+		{
+			name:           "empty symbol",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "symbol cannot be empty",
+		},
+		{
+			name:           "invalid limit",
+			query:          "symbol=BTCUSDT&limit=5000",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "limit must be between 1 and 1000",
+		},
+		{
+			name:           "unknown market",
+			query:          "symbol=BTCUSDT&market=options",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "market options is not supported",
+		},
+		{
+			name:           "invalid symbol",
+			query:          "symbol=NOPEUSDT",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "API returned status code: 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/depth?"+tt.query, nil)
+
+			GetDepth(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response["error"])
+				return
+			}
+
+			assert.Equal(t, tt.expectedPath, requestedPath)
+			assert.Equal(t, tt.expectedLimit, requestedLimit)
+			var response models.ResponseDepth
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "BTCUSDT", response.Symbol)
+			assert.Equal(t, []models.PriceLevel{{Price: 50000, Quantity: 1.5}, {Price: 49999.9, Quantity: 0.2}}, response.Bids)
+			assert.Equal(t, 50000.1, response.BestAsk)
+			assert.Equal(t, 0.1, response.Spread)
+			assert.Equal(t, 50000.05, response.MidPrice)
+			assert.Equal(t, int64(1027024), response.LastUpdateID)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package fundingrate
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultHistoryDays is how far back the history goes without startTime
+	DefaultHistoryDays = 30
+	// MaxHistoryDays bounds the days parameter
+	MaxHistoryDays = 365
+	// MaxHistoryLimit bounds one page of rates, older rates are loaded with the cursor
+	MaxHistoryLimit = 1000
+)
+
+// @Summary Get funding rate history with statistics
+// @Description Retrieves the settled funding rates of a futures symbol, oldest first, with their mean, minimum, maximum, cumulative and annualized rate and the count of negative periods, from Binance Futures or the requested exchange
+// @Tags Funding Rate
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param startTime query int false "Funding time in milliseconds of the first rate, endTime minus days by default" example(1732147200000)
+// @Param endTime query int false "Funding time in milliseconds of the last rate, the cursor of a previous page loads older rates"
+// @Param days query int false "Days of rates before endTime when startTime is not set, 30 by default and at most 365" example(30)
+// @Param limit query int false "Number of rates, the latest of the range are kept, 1000 by default and at most 1000"
+// @Param exchange query string false "Exchange: binance (default), okx or bybit" example("binance")
+// @Success 200 {object} models.ResponseFundingRateHistory "Rates oldest first with their statistics"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbol or invalid parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/funding-rate/history [get]
+func GetFundingRateHistory(context *gin.Context) {
+	symbol := context.Query("symbol")
+	if symbol == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing symbol", context)
+		return
+	}
+	query := models.FundingRateQuery{Symbol: provider.NormalizeSymbol(symbol), Limit: MaxHistoryLimit}
+
+	var ok bool
+	if query.StartTime, ok = utils.QueryMilliseconds(context, "startTime"); !ok {
+		return
+	}
+	if query.EndTime, ok = utils.QueryMilliseconds(context, "endTime"); !ok {
+		return
+	}
+	days, ok := utils.QueryInt(context, "days", DefaultHistoryDays, 1, MaxHistoryDays)
+	if !ok {
+		return
+	}
+	if query.Limit, ok = utils.QueryInt(context, "limit", MaxHistoryLimit, 1, MaxHistoryLimit); !ok {
+		return
+	}
+	if query.StartTime == 0 {
+		endTime := query.EndTime
+		if endTime == 0 {
+			endTime = time.Now().UnixMilli()
+		}
+		query.StartTime = endTime - int64(days)*24*time.Hour.Milliseconds()
+	}
+	if query.EndTime > 0 && query.StartTime > query.EndTime {
+		utils.ShowError(http.StatusBadRequest, "startTime must not be after endTime", context)
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	history, statusCode, err := FetchFundingRateHistory(marketData, query)
+	if err != nil {
+		utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+		return
+	}
+
+	var response models.ResponseFundingRateHistory
+	response.UpdateData(query.Symbol, history, utils.ConvertMillisecondsToTimestamp, utils.GetTimeNow())
+	if len(history) == query.Limit {
+		response.Cursor = history[0].FundingTime - 1
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// FetchFundingRateHistory returns the latest query.Limit rates of the range, oldest first, paging
+// backward from EndTime, or now, through the per request cap of the exchange
+func FetchFundingRateHistory(marketData provider.MarketDataProvider, query models.FundingRateQuery) ([]models.FundingRateHistory, models.StatusCode, error) {
+	var history []models.FundingRateHistory
+	page := query
+	for len(history) < query.Limit {
+		page.Limit = query.Limit - len(history)
+		result, statusCode, err := marketData.FundingRateHistory(page)
+		if err != nil {
+			return nil, statusCode, err
+		}
+
+		// keep only rates before the previous page, so a page repeating itself ends the loop
+		older := make([]models.FundingRateHistory, 0, len(result))
+		for _, rate := range result {
+			if len(history) == 0 || rate.FundingTime < history[0].FundingTime {
+				older = append(older, rate)
+			}
+		}
+		if len(older) == 0 {
+			break
+		}
+		history = append(older, history...)
+		page.EndTime = history[0].FundingTime - 1
+		if page.EndTime < query.StartTime {
+			break
+		}
+	}
+
+	if len(history) > query.Limit {
+		history = history[len(history)-query.Limit:]
+	}
+	return history, http.StatusOK, nil
+}
@@ -0,0 +1,176 @@
+package fundingrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	eightHours = int64(8 * 60 * 60 * 1000)
+	// lastFundingTime is the funding time of the last rate of the fake history
+	lastFundingTime = int64(1733875200000)
+)
+
+// fakeRates are the rates of the fake history, oldest first and 8 hours apart
+var fakeRates = []string{"0.0001", "-0.0002", "0.0003", "0.0001", "0.0002"}
+
+// setupHistoryServer serves fakeRates for BTCUSDT two at a time, the latest up to endTime, like a
+// Binance with a lower cap, and counts the requests
+func setupHistoryServer(t *testing.T) *int {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/fapi/v1/fundingRate", r.URL.Path)
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		requests++
+		endTime := lastFundingTime
+		if r.URL.Query().Get("endTime") != "" {
+			endTime, _ = strconv.ParseInt(r.URL.Query().Get("endTime"), 10, 64)
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var records []string
+		for i := len(fakeRates) - 1; i >= 0 && len(records) < min(limit, 2); i-- {
+			fundingTime := lastFundingTime - int64(len(fakeRates)-1-i)*eightHours
+			if fundingTime <= endTime {
+				records = append([]string{fmt.Sprintf(`{"symbol":"BTCUSDT","fundingRate":"%s","fundingTime":%d,"markPrice":"97000.5"}`, fakeRates[i], fundingTime)}, records...)
+			}
+		}
+		w.Write([]byte("[" + strings.Join(records, ",") + "]"))
+	}))
+	provider.SetDefault(provider.NewBinanceProvider(server.URL, server.URL))
+	t.Cleanup(func() {
+		provider.SetDefault(nil)
+		server.Close()
+	})
+	return &requests
+}
+
+func TestGetFundingRateHistory(t *testing.T) {
+	router := setupTestRouter()
+	router.GET("/funding-rate/history", GetFundingRateHistory)
+
+	firstTime := lastFundingTime - 4*eightHours
+	tests := []struct {
+		name             string
+		query            string
+		expectedStatus   int
+		expectedRates    []float64
+		expectedCursor   int64
+		expectedRequests int
+		expectedMessage  string
+	}{
+		{
+			name:             "every rate of the range",
+			query:            fmt.Sprintf("symbol=btcusdt&startTime=%d&endTime=%d", firstTime, lastFundingTime),
+			expectedStatus:   http.StatusOK,
+			expectedRates:    []float64{0.0001, -0.0002, 0.0003, 0.0001, 0.0002},
+			expectedRequests: 3,
+		},
+		{
+			name:             "latest rates of a page",
+			query:            fmt.Sprintf("symbol=BTCUSDT&startTime=%d&limit=3", firstTime),
+			expectedStatus:   http.StatusOK,
+			expectedRates:    []float64{0.0003, 0.0001, 0.0002},
+			expectedCursor:   lastFundingTime - 2*eightHours - 1,
+			expectedRequests: 2,
+		},
+		{
+			name:             "older page from the cursor",
+			query:            fmt.Sprintf("symbol=BTCUSDT&startTime=%d&endTime=%d&limit=3", firstTime, lastFundingTime-2*eightHours-1),
+			expectedStatus:   http.StatusOK,
+			expectedRates:    []float64{0.0001, -0.0002},
+			expectedRequests: 1,
+		},
+		{
+			name:            "missing symbol",
+			query:           "",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "Missing symbol",
+		},
+		{
+			name:            "invalid days",
+			query:           "symbol=BTCUSDT&days=400",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "days must be between 1 and 365",
+		},
+		{
+			name:            "start after end",
+			query:           "symbol=BTCUSDT&startTime=2000&endTime=1000",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "startTime must not be after endTime",
+		},
+		{
+			name:            "invalid symbol",
+			query:           "symbol=NOPEUSDT",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "API returned status code: 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := setupHistoryServer(t)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/funding-rate/history?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				assert.JSONEq(t, fmt.Sprintf(`{"message":%q}`, tt.expectedMessage), w.Body.String())
+				return
+			}
+
+			var response models.ResponseFundingRateHistory
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "BTCUSDT", response.Symbol)
+			var rates []float64
+			for _, rate := range response.History {
+				rates = append(rates, rate.FundingRate)
+			}
+			assert.Equal(t, tt.expectedRates, rates)
+			assert.Equal(t, 97000.5, response.History[0].MarkPrice)
+			assert.Equal(t, tt.expectedCursor, response.Cursor)
+			assert.Equal(t, tt.expectedRequests, *requests)
+		})
+	}
+}
+
+func TestFundingRateStats(t *testing.T) {
+	var history []models.FundingRateHistory
+	for i, rate := range fakeRates {
+		history = append(history, models.FundingRateHistory{Symbol: "BTCUSDT", FundingRate: rate, FundingTime: lastFundingTime + int64(i)*eightHours + int64(i)})
+	}
+
+	var stats models.FundingRateStats
+	stats.UpdateData(history, func(milliseconds int64) string { return strconv.FormatInt(milliseconds, 10) })
+	assert.Equal(t, models.FundingRateStats{
+		Count:                5,
+		Mean:                 0.0001,
+		Min:                  -0.0002,
+		MinTime:              strconv.FormatInt(lastFundingTime+eightHours+1, 10),
+		Max:                  0.0003,
+		MaxTime:              strconv.FormatInt(lastFundingTime+2*eightHours+2, 10),
+		Cumulative:           0.0005,
+		AnnualizedRate:       10.95,
+		NegativeCount:        1,
+		FundingIntervalHours: 8,
+	}, stats)
+
+	// an hourly history
+	stats.UpdateData([]models.FundingRateHistory{{FundingRate: "0.0001", FundingTime: 0}, {FundingRate: "0.0001", FundingTime: 3600000}}, func(int64) string { return "" })
+	assert.Equal(t, 1, stats.FundingIntervalHours)
+	assert.Equal(t, 87.6, stats.AnnualizedRate)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,6 +47,7 @@ func setupTestRouter() *gin.Engine {
 }
 
 func TestGetFundingRate(t *testing.T) {
+	setupMockServer(t)
 	router := setupTestRouter()
 	router.GET("/funding-rate", GetFundingRate)
 
@@ -87,23 +89,35 @@ func TestGetFundingRate(t *testing.T) {
 	}
 }
 
-func setupMockServer(t *testing.T, firstEndpoint bool) *httptest.Server {
-	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+func setupMockServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "GET", r.Method)
 
-		if firstEndpoint {
-			assert.Contains(t, r.URL.Path, "/fapi/v1/premiumIndex")
+		switch r.URL.Path {
+		case "/fapi/v1/premiumIndex":
+			if r.URL.Query().Get("symbol") != MockFundingRateFirst.Symbol {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
 			json.NewEncoder(w).Encode(MockFundingRateFirst)
-		} else {
-			assert.Contains(t, r.URL.Path, "/fapi/v1/fundingInfo")
-			json.NewEncoder(w).Encode([]models.FundingRateSecond{MockFundingRateSecond})
+		case "/fapi/v1/fundingInfo":
+			qtum := MockFundingRateSecond
+			qtum.Symbol = "QTUMUSDT"
+			json.NewEncoder(w).Encode([]models.FundingRateSecond{MockFundingRateSecond, qtum})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
 		}
 	}))
+	provider.SetDefault(provider.NewBinanceProvider(server.URL, server.URL))
+	t.Cleanup(func() {
+		provider.SetDefault(nil)
+		server.Close()
+	})
+	return server
 }
 
 func TestGetDataFundingFirst(t *testing.T) {
-	server := setupMockServer(t, true)
-	defer server.Close()
+	setupMockServer(t)
 
 	tests := []struct {
 		name         string
@@ -127,7 +141,7 @@ func TestGetDataFundingFirst(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response, statusCode, err := GetDataFundingFirst(tt.symbol)
+			response, statusCode, err := GetDataFundingFirst(provider.Default(), tt.symbol)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -145,8 +159,7 @@ func TestGetDataFundingFirst(t *testing.T) {
 }
 
 func TestGetDataFundingSecond(t *testing.T) {
-	server := setupMockServer(t, false)
-	defer server.Close()
+	setupMockServer(t)
 
 	tests := []struct {
 		name         string
@@ -167,7 +180,7 @@ func TestGetDataFundingSecond(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response, statusCode := GetDataFundingSecond(tt.symbol)
+			response, statusCode := GetDataFundingSecond(provider.Default(), tt.symbol)
 
 			assert.Equal(t, tt.expectedCode, statusCode)
 			if statusCode == http.StatusOK {
@@ -0,0 +1,245 @@
+package fundingrate
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	SortCurrent = "current"
+	SortAverage = "average"
+
+	// DefaultScreenerLimit is the number of symbols returned when the client asks for none
+	DefaultScreenerLimit = 20
+	// MaxScreenerLimit bounds the returned symbols
+	MaxScreenerLimit = 200
+	// DefaultScreenerDays is how many days the average funding rate covers when the client asks for none
+	DefaultScreenerDays = 7
+	// MaxScreenerDays bounds the days of the average funding rate
+	MaxScreenerDays = 30
+
+	// averageCacheTTL is how long the average rate of a symbol is reused, rates settle every few hours
+	averageCacheTTL = 30 * time.Minute
+	// screenerWorkers bounds the funding rate history requests running at once
+	screenerWorkers = 8
+)
+
+// averageCandidates is how many symbols, those with the highest current rates in the requested
+// order, the average sort loads the history of. Every perpetual would take hundreds of requests.
+var averageCandidates = 50
+
+type averageKey struct {
+	exchange string
+	symbol   string
+	days     int
+}
+
+type cachedAverage struct {
+	stats   models.FundingRateStats
+	expires time.Time
+}
+
+var (
+	averageCache      = map[averageKey]cachedAverage{}
+	averageCacheMutex sync.Mutex
+)
+
+// @Summary Screen funding rates across symbols
+// @Description Ranks the futures symbols of Binance or the requested exchange by their current funding rate or their average rate over the last days.
+// @Description Averages load the funding rate history of every ranked symbol, they are cached for 30 minutes.
+// @Description The average sort ranks the 50 symbols, or the limit when it is higher, with the highest current rates in the requested order.
+// @Tags Funding Rate
+// @Produce json
+// @Param sort query string false "Rank by the current (default) or the average funding rate" example("current")
+// @Param order query string false "desc (default) for the highest rates first, asc for the lowest" example("desc")
+// @Param days query int false "Days of the average funding rate, 7 by default and at most 30" example(7)
+// @Param limit query int false "Number of symbols, 20 by default and at most 200"
+// @Param symbols query string false "Comma separated symbols to rank, every futures symbol by default" example("BTCUSDT,ETHUSDT")
+// @Param exchange query string false "Exchange: binance (default) or bybit" example("binance")
+// @Success 200 {object} models.ResponseFundingScreener "Symbols ranked by funding rate"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid parameters"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/funding-rate/screener [get]
+func GetFundingScreener(context *gin.Context) {
+	sortBy := context.DefaultQuery("sort", SortCurrent)
+	if sortBy != SortCurrent && sortBy != SortAverage {
+		utils.ShowError(http.StatusBadRequest, "sort must be current or average", context)
+		return
+	}
+	order := context.DefaultQuery("order", "desc")
+	if order != "desc" && order != "asc" {
+		utils.ShowError(http.StatusBadRequest, "order must be desc or asc", context)
+		return
+	}
+	days, ok := utils.QueryInt(context, "days", DefaultScreenerDays, 1, MaxScreenerDays)
+	if !ok {
+		return
+	}
+	limit, ok := utils.QueryInt(context, "limit", DefaultScreenerLimit, 1, MaxScreenerLimit)
+	if !ok {
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	entries, statusCode, err := screenerEntries(marketData, provider.ParseSymbols(context.QueryArray("symbols")))
+	if err != nil {
+		utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+		return
+	}
+
+	descending := order == "desc"
+	if sortBy == SortAverage {
+		sortEntries(entries, descending, func(entry *models.FundingScreenerEntry) (float64, bool) {
+			return entry.FundingRate, true
+		})
+		entries = entries[:min(max(limit, averageCandidates), len(entries))]
+		addAverages(marketData, entries, days)
+		sortEntries(entries, descending, func(entry *models.FundingScreenerEntry) (float64, bool) {
+			if entry.AverageFundingRate == nil {
+				return 0, false
+			}
+			return *entry.AverageFundingRate, true
+		})
+		entries = entries[:min(limit, len(entries))]
+	} else {
+		sortEntries(entries, descending, func(entry *models.FundingScreenerEntry) (float64, bool) {
+			return entry.FundingRate, true
+		})
+		entries = entries[:min(limit, len(entries))]
+		addAverages(marketData, entries, days)
+	}
+
+	context.JSON(http.StatusOK, models.ResponseFundingScreener{
+		Sort:      sortBy,
+		Order:     order,
+		Days:      days,
+		Symbols:   entries,
+		EventTime: utils.GetTimeNow(),
+	})
+}
+
+// screenerEntries reads the current funding rate of every futures symbol, or of symbols when it is not empty
+func screenerEntries(marketData provider.MarketDataProvider, symbols []string) ([]models.FundingScreenerEntry, models.StatusCode, error) {
+	indexes, statusCode, err := marketData.PremiumIndexes()
+	if err != nil {
+		return nil, statusCode, err
+	}
+	// symbols missing from the funding info have the 8 hour interval
+	intervals := provider.FundingIntervals(marketData)
+	wanted := map[string]bool{}
+	for _, symbol := range symbols {
+		wanted[symbol] = true
+	}
+
+	entries := make([]models.FundingScreenerEntry, 0, len(indexes))
+	hasFunding := false
+	for _, index := range indexes {
+		if index.LastFundingRate == "" {
+			continue
+		}
+		hasFunding = true
+		if len(wanted) > 0 && !wanted[index.Symbol] {
+			continue
+		}
+		interval := intervals[index.Symbol]
+		if interval <= 0 {
+			interval = 8
+		}
+		entry := models.FundingScreenerEntry{
+			Symbol:               index.Symbol,
+			FundingIntervalHours: interval,
+			NextFundingTime:      utils.ConvertMillisecondsToTimestamp(index.NextFundingTime),
+		}
+		entry.MarkPrice, _ = strconv.ParseFloat(index.MarkPrice, 64)
+		entry.FundingRate, _ = strconv.ParseFloat(index.LastFundingRate, 64)
+		entry.AnnualizedRate = models.AnnualizedFundingRate(entry.FundingRate, interval)
+		entries = append(entries, entry)
+	}
+	if len(indexes) > 0 && !hasFunding {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s has no funding rate of every symbol: %w", marketData.Name(), provider.ErrNotSupported)
+	}
+	return entries, http.StatusOK, nil
+}
+
+// addAverages sets the average rate over days of every entry whose history loads
+func addAverages(marketData provider.MarketDataProvider, entries []models.FundingScreenerEntry, days int) {
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, screenerWorkers)
+	for i := range entries {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(entry *models.FundingScreenerEntry) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			stats, ok := averageStats(marketData, entry.Symbol, days)
+			if !ok {
+				return
+			}
+			entry.AverageFundingRate = &stats.Mean
+			entry.AverageAnnualizedRate = &stats.AnnualizedRate
+			entry.NegativeCount = &stats.NegativeCount
+		}(&entries[i])
+	}
+	wg.Wait()
+}
+
+// averageStats returns the statistics of the rates of the last days of a symbol, from the cache when they are recent
+func averageStats(marketData provider.MarketDataProvider, symbol string, days int) (models.FundingRateStats, bool) {
+	key := averageKey{exchange: marketData.Name(), symbol: symbol, days: days}
+	now := time.Now()
+
+	averageCacheMutex.Lock()
+	cached, ok := averageCache[key]
+	averageCacheMutex.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.stats, true
+	}
+
+	// one rate an hour is the shortest funding interval
+	history, _, err := FetchFundingRateHistory(marketData, models.FundingRateQuery{
+		Symbol:    symbol,
+		StartTime: now.Add(-time.Duration(days) * 24 * time.Hour).UnixMilli(),
+		Limit:     days * 24,
+	})
+	if err != nil || len(history) == 0 {
+		return models.FundingRateStats{}, false
+	}
+
+	var stats models.FundingRateStats
+	stats.UpdateData(history, utils.ConvertMillisecondsToTimestamp)
+	averageCacheMutex.Lock()
+	averageCache[key] = cachedAverage{stats: stats, expires: now.Add(averageCacheTTL)}
+	averageCacheMutex.Unlock()
+	return stats, true
+}
+
+// sortEntries orders entries by the rate of value, entries without one go last
+func sortEntries(entries []models.FundingScreenerEntry, descending bool, value func(entry *models.FundingScreenerEntry) (float64, bool)) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, aOK := value(&entries[i])
+		b, bOK := value(&entries[j])
+		if aOK != bOK {
+			return aOK
+		}
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+}
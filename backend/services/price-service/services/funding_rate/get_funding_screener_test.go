@@ -0,0 +1,184 @@
+package fundingrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupScreenerServer serves the current rates of three symbols and a history whose average ranks
+// them the other way round, ETHUSDT has no history. It counts the history requests, loading a
+// history takes two, the second one returning nothing older.
+func setupScreenerServer(t *testing.T) *int32 {
+	averageCacheMutex.Lock()
+	averageCache = map[averageKey]cachedAverage{}
+	averageCacheMutex.Unlock()
+
+	var historyRequests int32
+	averages := map[string]string{"BTCUSDT": "0.0003", "SOLUSDT": "-0.0001"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","markPrice":"97000","lastFundingRate":"0.0001","nextFundingTime":1733904000000},` +
+				`{"symbol":"ETHUSDT","markPrice":"3900","lastFundingRate":"0.0002","nextFundingTime":1733904000000},` +
+				`{"symbol":"SOLUSDT","markPrice":"230","lastFundingRate":"0.0004","nextFundingTime":1733889600000}]`))
+		case "/fapi/v1/fundingInfo":
+			w.Write([]byte(`[{"symbol":"SOLUSDT","fundingIntervalHours":4}]`))
+		case "/fapi/v1/fundingRate":
+			atomic.AddInt32(&historyRequests, 1)
+			rate, ok := averages[r.URL.Query().Get("symbol")]
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			now := time.Now().UnixMilli()
+			w.Write([]byte(fmt.Sprintf(`[{"fundingRate":"%s","fundingTime":%d},{"fundingRate":"%s","fundingTime":%d}]`,
+				rate, now-2*eightHours, rate, now-eightHours)))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	provider.SetDefault(provider.NewBinanceProvider(server.URL, server.URL))
+	t.Cleanup(func() {
+		provider.SetDefault(nil)
+		server.Close()
+	})
+	return &historyRequests
+}
+
+func TestGetFundingScreener(t *testing.T) {
+	router := setupTestRouter()
+	router.GET("/funding-rate/screener", GetFundingScreener)
+
+	tests := []struct {
+		name             string
+		query            string
+		candidates       int
+		expectedStatus   int
+		expectedSymbols  []string
+		expectedRequests int32
+		expectedMessage  string
+	}{
+		{
+			name:             "highest current rates",
+			query:            "limit=2",
+			expectedStatus:   http.StatusOK,
+			expectedSymbols:  []string{"SOLUSDT", "ETHUSDT"},
+			expectedRequests: 3,
+		},
+		{
+			name:             "highest average rates, symbols without history last",
+			query:            "sort=average",
+			expectedStatus:   http.StatusOK,
+			expectedSymbols:  []string{"BTCUSDT", "SOLUSDT", "ETHUSDT"},
+			expectedRequests: 5,
+		},
+		{
+			name:             "highest average rates of the highest current rates",
+			query:            "sort=average&limit=1",
+			candidates:       2,
+			expectedStatus:   http.StatusOK,
+			expectedSymbols:  []string{"SOLUSDT"},
+			expectedRequests: 3,
+		},
+		{
+			name:             "lowest current rates of some symbols",
+			query:            "order=asc&symbols=solusdt,BTCUSDT",
+			expectedStatus:   http.StatusOK,
+			expectedSymbols:  []string{"BTCUSDT", "SOLUSDT"},
+			expectedRequests: 4,
+		},
+		{
+			name:            "invalid sort",
+			query:           "sort=volume",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "sort must be current or average",
+		},
+		{
+			name:            "invalid days",
+			query:           "days=31",
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "days must be between 1 and 30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := setupScreenerServer(t)
+			if tt.candidates > 0 {
+				defer func(candidates int) { averageCandidates = candidates }(averageCandidates)
+				averageCandidates = tt.candidates
+			}
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/funding-rate/screener?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				assert.JSONEq(t, fmt.Sprintf(`{"message":%q}`, tt.expectedMessage), w.Body.String())
+				return
+			}
+
+			var response models.ResponseFundingScreener
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			var symbols []string
+			for _, entry := range response.Symbols {
+				symbols = append(symbols, entry.Symbol)
+			}
+			assert.Equal(t, tt.expectedSymbols, symbols)
+			assert.Equal(t, tt.expectedRequests, atomic.LoadInt32(requests))
+		})
+	}
+}
+
+func TestGetFundingScreenerEntries(t *testing.T) {
+	requests := setupScreenerServer(t)
+	router := setupTestRouter()
+	router.GET("/funding-rate/screener", GetFundingScreener)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/funding-rate/screener?symbols=SOLUSDT", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response models.ResponseFundingScreener
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, DefaultScreenerDays, response.Days)
+		entry := response.Symbols[0]
+		assert.Equal(t, 4, entry.FundingIntervalHours)
+		assert.Equal(t, 87.6, entry.AnnualizedRate)
+		assert.Equal(t, -0.0001, *entry.AverageFundingRate)
+		assert.Equal(t, -10.95, *entry.AverageAnnualizedRate)
+		assert.Equal(t, 2, *entry.NegativeCount)
+	}
+	// the history ends with a page of nothing new, the second average comes from the cache
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func TestGetFundingScreenerWithoutFunding(t *testing.T) {
+	okx := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","markPx":"97000","ts":"1733900000000"}]}`))
+	}))
+	defer okx.Close()
+	provider.Register(provider.ExchangeOKX, provider.NewOKXProvider(okx.URL))
+	defer provider.Register(provider.ExchangeOKX, nil)
+
+	router := setupTestRouter()
+	router.GET("/funding-rate/screener", GetFundingScreener)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/funding-rate/screener?exchange=okx", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.True(t, strings.Contains(w.Body.String(), "okx has no funding rate of every symbol"))
+}
@@ -1,37 +1,51 @@
 package fundingrate
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
-	"net/url"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
 	"github.com/gin-gonic/gin"
 )
 
 // @Summary Get real-time funding rate data
-// @Description Retrieves current funding rate information for a specified trading pair from Binance Futures
+// @Description Retrieves current funding rate information for a specified trading pair from Binance Futures or the requested exchange
 // @Tags Funding Rate
 // @Accept json
 // @Produce json
 // @Param symbol query string true "Trading pair symbol (e.g., QTUMUSDT)" example("QTUMUSDT")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
 // @Success 200 {object} models.ResponseFundingRate "Successful response with funding rate data"
 // @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbol"
 // @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
 // @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
 // @Router /api/v1/funding-rate [get]
-func GetFundingRateRealTime(symbol string, context *gin.Context) {
+func GetFundingRate(context *gin.Context) {
+	symbol := context.Query("symbol")
+	if symbol == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing symbol", context)
+		return
+	}
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	GetFundingRateRealTime(marketData, symbol, context)
+}
+
+func GetFundingRateRealTime(marketData provider.MarketDataProvider, symbol string, context *gin.Context) {
 	var responseApi models.ResponseFundingRate
 	// get symbol, funding rate, eventTime, countdown
-	response1, statusCode, err := GetDataFundingFirst(symbol)
+	response1, statusCode, err := GetDataFundingFirst(marketData, symbol)
 	if err != nil {
 		utils.ShowError(int64(statusCode), err.Error(), context)
 		return
 	}
 	// get adjustedFundingRateCap, adjustedFundingRateFloor, fundingInterval if exist
-	response2, statusCode := GetDataFundingSecond(symbol)
+	response2, statusCode := GetDataFundingSecond(marketData, symbol)
 	if statusCode != http.StatusOK {
 		response2 = &models.FundingRateSecond{
 			Symbol:                   symbol,
@@ -44,61 +58,33 @@ func GetFundingRateRealTime(symbol string, context *gin.Context) {
 	context.JSON(http.StatusOK, responseApi)
 }
 
-func GetDataFundingFirst(symbol string) (*models.FundingRateFirst, models.StatusCode, error) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", "https://fapi.binance.com/fapi/v1/premiumIndex", nil)
+func GetDataFundingFirst(marketData provider.MarketDataProvider, symbol string) (*models.FundingRateFirst, models.StatusCode, error) {
+	premiumIndex, statusCode, err := marketData.PremiumIndex(symbol)
 	if err != nil {
-		return nil, http.StatusInternalServerError, errors.New("error from request api.")
-	}
-
-	q := url.Values{}
-	q.Add("symbol", symbol)
-	req.URL.RawQuery = q.Encode()
-	resp, err := client.Do(req)
-
-	if err != nil {
-		return nil, http.StatusInternalServerError, errors.New("error sending request to server.")
-	}
-	defer resp.Body.Close()
-
-	respStatusCode := resp.StatusCode
-	if respStatusCode == http.StatusBadRequest {
-		return nil, http.StatusBadRequest, errors.New("Error information.")
-	} else if respStatusCode != http.StatusOK {
+		if errors.Is(err, provider.ErrNotSupported) {
+			return nil, http.StatusBadRequest, err
+		}
+		if statusCode == http.StatusBadRequest {
+			return nil, http.StatusBadRequest, errors.New("Error information.")
+		}
 		return nil, http.StatusInternalServerError, errors.New("Server error.")
 	}
 
-	var response models.FundingRateFirst
-	err = json.NewDecoder(resp.Body).Decode(&response)
-	if err != nil {
-		return nil, http.StatusInternalServerError, errors.New("Error decoding response.")
+	response := models.FundingRateFirst{
+		Symbol:          premiumIndex.Symbol,
+		FundingRate:     premiumIndex.LastFundingRate,
+		NextFundingTime: premiumIndex.NextFundingTime,
+		EventTime:       premiumIndex.Time,
 	}
 	return &response, http.StatusOK, nil
 }
 
-func GetDataFundingSecond(symbol string) (*models.FundingRateSecond, models.StatusCode) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", "https://fapi.binance.com/fapi/v1/fundingInfo", nil)
-	if err != nil {
-		return nil, http.StatusInternalServerError
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, http.StatusInternalServerError
-	}
-	defer resp.Body.Close()
-
-	respStatusCode := resp.StatusCode
-	if respStatusCode == http.StatusBadRequest {
-		return nil, http.StatusBadRequest
-	} else if respStatusCode != http.StatusOK {
-		return nil, http.StatusInternalServerError
-	}
-
-	var response []models.FundingRateSecond
-	err = json.NewDecoder(resp.Body).Decode(&response)
+func GetDataFundingSecond(marketData provider.MarketDataProvider, symbol string) (*models.FundingRateSecond, models.StatusCode) {
+	response, statusCode, err := marketData.FundingInfo()
 	if err != nil {
+		if statusCode == http.StatusBadRequest {
+			return nil, http.StatusBadRequest
+		}
 		return nil, http.StatusInternalServerError
 	}
 	// find trading exist
@@ -0,0 +1,84 @@
+package future_price
+
+import (
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get future prices of several symbols
+// @Description Retrieves the mark price of every requested futures pair, or of every pair when none is given, with one request to Binance Futures or the requested exchange
+// @Tags Future price
+// @Produce json
+// @Param symbols query string false "Comma separated trading pairs, every pair when empty (e.g., BTCUSDT,ETHUSDT)" example("BTCUSDT,ETHUSDT")
+// @Param exchange query string false "Exchange: binance (default), okx or bybit" example("binance")
+// @Param convert query string false "Fiat currency to convert the prices into (e.g., VND, USD, EUR), USD stablecoins are taken at par" example("VND")
+// @Success 200 {object} models.ResponseFuturePriceBatch "Prices by symbol, unknown symbols and symbols whose quote cannot be converted are listed in errors"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid request parameters"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Failed to fetch prices"
+// @Router /api/v1/future-price/batch [get]
+func GetFuturePriceBatch(ctx *gin.Context) {
+	marketData, err := provider.Get(ctx.Query("exchange"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// rejects an unknown currency before asking the exchange
+	currency := ctx.Query("convert")
+	if _, statusCode, err := fx.FromUSD(currency); err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+
+	premiumIndexes, statusCode, err := marketData.PremiumIndexes()
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+
+	bySymbol := make(map[string]models.ResponseBinanceFuture, len(premiumIndexes))
+	for _, premiumIndex := range premiumIndexes {
+		bySymbol[premiumIndex.Symbol] = premiumIndex
+	}
+
+	symbols := provider.ParseSymbols(ctx.QueryArray("symbols"))
+	if len(symbols) == 0 {
+		for symbol := range bySymbol {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	response := &models.ResponseFuturePriceBatch{Prices: make(map[string]models.ResponseFuturePrice, len(symbols))}
+	for _, symbol := range symbols {
+		premiumIndex, ok := bySymbol[symbol]
+		if !ok {
+			if response.Errors == nil {
+				response.Errors = map[string]string{}
+			}
+			response.Errors[symbol] = "Symbol not found"
+			continue
+		}
+		conversion, _, err := fx.ForSymbol(premiumIndex.Symbol, currency)
+		if err != nil {
+			if response.Errors == nil {
+				response.Errors = map[string]string{}
+			}
+			response.Errors[symbol] = err.Error()
+			continue
+		}
+		var price models.ResponseFuturePrice
+		price.UpdateData(premiumIndex.Symbol, premiumIndex.MarkPrice, utils.ConvertMillisecondsToTimestamp(premiumIndex.Time))
+		if conversion != nil {
+			price.Price = conversion.ConvertPrice(price.Price)
+			price.Conversion = conversion.Response()
+		}
+		response.Prices[symbol] = price
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,127 @@
+package future_price
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFuturePriceBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	originalClient := http.DefaultClient
+	defer func() { http.DefaultClient = originalClient }()
+
+	tests := []struct {
+		name           string
+		query          string
+		mockStatusCode int
+		expectedStatus int
+		expectedPrices map[string]string
+		expectedErrors map[string]string
+	}{
+		{
+			name:           "requested symbols",
+			query:          "symbols=btcusdt,NOPEUSDT",
+			mockStatusCode: http.StatusOK,
+			expectedStatus: http.StatusOK,
+			expectedPrices: map[string]string{"BTCUSDT": "50000.00"},
+			expectedErrors: map[string]string{"NOPEUSDT": "Symbol not found"},
+		},
+		{
+			name:           "repeated symbols parameter",
+			query:          "symbols=BTCUSDT&symbols=ETH-USDT",
+			mockStatusCode: http.StatusOK,
+			expectedStatus: http.StatusOK,
+			expectedPrices: map[string]string{"BTCUSDT": "50000.00", "ETHUSDT": "3000.00"},
+		},
+		{
+			name:           "every symbol",
+			mockStatusCode: http.StatusOK,
+			expectedStatus: http.StatusOK,
+			expectedPrices: map[string]string{"BTCUSDT": "50000.00", "ETHUSDT": "3000.00"},
+		},
+		{
+			name:           "binance api error",
+			mockStatusCode: http.StatusInternalServerError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			var upstreamCalls int
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				upstreamCalls++
+				assert.Empty(t, r.URL.Query().Get("symbol"))
+				w.WriteHeader(tt.mockStatusCode)
+				w.Write([]byte(`[{"symbol":"BTCUSDT","markPrice":"50000.00","time":1700000000000},{"symbol":"ETHUSDT","markPrice":"3000.00","time":1700000000000}]`))
+			}))
+			defer mockServer.Close()
+			http.DefaultClient = &http.Client{Transport: &mockTransport{mockServer: mockServer}}
+
+			c.Request, _ = http.NewRequest(http.MethodGet, "/batch?"+tt.query, nil)
+			GetFuturePriceBatch(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, 1, upstreamCalls)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response models.ResponseFuturePriceBatch
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Len(t, response.Prices, len(tt.expectedPrices))
+			for symbol, price := range tt.expectedPrices {
+				assert.Equal(t, symbol, response.Prices[symbol].Symbol)
+				assert.Equal(t, price, response.Prices[symbol].Price)
+				assert.NotEmpty(t, response.Prices[symbol].EventTime)
+			}
+			assert.Equal(t, tt.expectedErrors, response.Errors)
+		})
+	}
+}
+
+func TestGetFuturePriceBatchConvert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"symbol":"BTCUSDT","markPrice":"50000.00","time":1700000000000},{"symbol":"ETHBTC","markPrice":"0.06","time":1700000000000}]`))
+	}))
+	defer mockServer.Close()
+	provider.SetDefault(provider.NewBinanceProvider(mockServer.URL, mockServer.URL))
+	defer provider.SetDefault(nil)
+	fx.SetDefault(&fx.StaticRateSource{FXRates: models.FXRates{Rates: map[string]float64{"USD": 1, "VND": 25400}, AsOf: 1733875200000, Source: "test"}})
+	defer fx.SetDefault(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/batch?symbols=BTCUSDT,ETHBTC&convert=vnd", nil)
+	GetFuturePriceBatch(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ResponseFuturePriceBatch
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "1270000000", response.Prices["BTCUSDT"].Price)
+	assert.Equal(t, "USDT", response.Prices["BTCUSDT"].Conversion.From)
+	assert.Equal(t, "VND", response.Prices["BTCUSDT"].Conversion.To)
+	// a quote without a rate is reported with the symbol
+	assert.Equal(t, map[string]string{"ETHBTC": "Cannot convert prices quoted in BTC"}, response.Errors)
+
+	// an unknown currency fails the whole request
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/batch?convert=XYZ", nil)
+	GetFuturePriceBatch(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"Unsupported currency XYZ"}`, w.Body.String())
+}
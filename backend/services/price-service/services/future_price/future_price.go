@@ -1,20 +1,22 @@
 package future_price
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
 	"github.com/gin-gonic/gin"
 )
 
 // @Summary Get real-time future price data
-// @Description Retrieves current future price information for a specified trading pair from Binance Futures
+// @Description Retrieves current future price information for a specified trading pair from Binance Futures or the requested exchange
 // @Tags Future price
 // @Produce json
 // @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Param convert query string false "Fiat currency to convert the price into (e.g., VND, USD, EUR), USD stablecoins are taken at par" example("VND")
 // @Success 200 {object} models.ResponseFuturePrice "Successful response with future price data"
 // @Failure 400 {object} models.ErrorResponseDataMissing "Invalid symbol or request parameters"
 // @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
@@ -27,38 +29,38 @@ func GetFuturePrice(ctx *gin.Context) {
 		return
 	}
 
-	// Construct the Binance Futures API URL
-	url := fmt.Sprintf("https://fapi.binance.com/fapi/v1/premiumIndex?symbol=%s", symbol)
-
-	// Make the HTTP request
-	resp, err := http.Get(url)
+	marketData, err := provider.Get(ctx.Query("exchange"))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to fetch future price: %v", err)})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check if the response status is not OK
-	if resp.StatusCode != http.StatusOK {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("API returned status code: %d", resp.StatusCode)})
+	premiumIndex, statusCode, err := marketData.PremiumIndex(symbol)
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Decode the Binance response
-	var binanceResp models.ResponseBinanceFuture
-	if err := json.NewDecoder(resp.Body).Decode(&binanceResp); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to decode response: %v", err)})
+	// Convert the price into the requested fiat currency
+	conversion, statusCode, err := fx.ForSymbol(premiumIndex.Symbol, ctx.Query("convert"))
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
 		return
 	}
+	price := premiumIndex.MarkPrice
+	if conversion != nil {
+		price = conversion.ConvertPrice(price)
+	}
 
 	// Convert timestamp to formatted date string
-	eventTime := time.Unix(binanceResp.Time/1000, 0).Format("2006-01-02 15:04:05")
+	eventTime := utils.ConvertMillisecondsToTimestamp(premiumIndex.Time)
 
 	// Create our response structure
 	response := &models.ResponseFuturePrice{
-		EventTime: eventTime,
-		Price:     binanceResp.MarkPrice,
-		Symbol:    binanceResp.Symbol,
+		EventTime:  eventTime,
+		Price:      price,
+		Symbol:     premiumIndex.Symbol,
+		Conversion: conversion.Response(),
 	}
 
 	ctx.JSON(http.StatusOK, response)
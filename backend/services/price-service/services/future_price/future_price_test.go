@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -142,3 +144,26 @@ func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// Use the mock server's client to do the actual request
 	return t.mockServer.Client().Do(newReq)
 }
+
+func TestGetFuturePriceConvert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"50000.00","indexPrice":"49990.00","time":1677721200000}`))
+	}))
+	defer mockServer.Close()
+	provider.SetDefault(provider.NewBinanceProvider(mockServer.URL, mockServer.URL))
+	defer provider.SetDefault(nil)
+	fx.SetDefault(&fx.StaticRateSource{FXRates: models.FXRates{Rates: map[string]float64{"USD": 1, "VND": 25400}, AsOf: 1733875200000, Source: "test"}})
+	defer fx.SetDefault(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/?symbol=BTCUSDT&convert=VND", nil)
+	GetFuturePrice(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.ResponseFuturePrice
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "1270000000", response.Price)
+	assert.Equal(t, &models.Conversion{From: "USDT", To: "VND", Rate: 25400, AsOf: response.Conversion.AsOf, Source: "test"}, response.Conversion)
+}
@@ -0,0 +1,67 @@
+package future_price
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get mark price, index price and basis
+// @Description Retrieves the mark, index and last price of a perpetual contract with its basis versus the spot price, as an amount, a percentage and annualized, and the last funding rate annualized, both at the funding interval of the contract, from Binance Futures or the requested exchange
+// @Tags Future price
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param exchange query string false "Exchange: binance (default), okx or bybit" example("binance")
+// @Success 200 {object} models.ResponsePremium "Successful response with premium data"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid symbol or request parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Failed to fetch prices"
+// @Router /api/v1/futures/premium [get]
+func GetPremium(ctx *gin.Context) {
+	symbol := ctx.Query("symbol")
+	if symbol == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "symbol cannot be empty"})
+		return
+	}
+
+	marketData, err := provider.Get(ctx.Query("exchange"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, statusCode, err := GetPremiumData(marketData, provider.NormalizeSymbol(symbol))
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetPremiumData reads the premium index, the last futures price and the spot price of a symbol
+func GetPremiumData(marketData provider.MarketDataProvider, symbol string) (*models.ResponsePremium, models.StatusCode, error) {
+	premiumIndex, statusCode, err := marketData.PremiumIndex(symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	futuresTicker, statusCode, err := marketData.FuturesTicker(symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	spotTicker, statusCode, err := marketData.SpotTicker(symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	lastPrice, _ := strconv.ParseFloat(futuresTicker.Price, 64)
+	spotPrice, _ := strconv.ParseFloat(spotTicker.Price, 64)
+	var response models.ResponsePremium
+	response.UpdateData(premiumIndex, lastPrice, spotPrice, provider.FundingIntervals(marketData)[symbol],
+		utils.ConvertMillisecondsToTimestamp(premiumIndex.NextFundingTime),
+		utils.ConvertMillisecondsToTimestamp(premiumIndex.Time))
+	return &response, http.StatusOK, nil
+}
@@ -0,0 +1,87 @@
+package future_price
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPremium(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	binance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fapi/v1/fundingInfo" {
+			w.Write([]byte(`[{"symbol":"BTCUSDT","adjustedFundingRateCap":"0.02","adjustedFundingRateFloor":"-0.02","fundingIntervalHours":4}]`))
+			return
+		}
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"50010.00","indexPrice":"49995.00","estimatedSettlePrice":"49998.00","lastFundingRate":"0.0001","interestRate":"0.0001","nextFundingTime":1733904000000,"time":1733900000000}`))
+		case "/fapi/v1/ticker/24hr":
+			w.Write([]byte(`{"symbol":"BTCUSDT","lastPrice":"50025.00","closeTime":1733900000000}`))
+		case "/api/v3/ticker/price":
+			w.Write([]byte(`{"symbol":"BTCUSDT","price":"50000.00"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer binance.Close()
+	provider.SetDefault(provider.NewBinanceProvider(binance.URL, binance.URL))
+	defer provider.SetDefault(nil)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "premium and basis", query: "symbol=btc-usdt", expectedStatus: http.StatusOK},
+		{name: "empty symbol", query: "", expectedStatus: http.StatusBadRequest, expectedError: "symbol cannot be empty"},
+		{name: "unknown exchange", query: "symbol=BTCUSDT&exchange=kraken", expectedStatus: http.StatusBadRequest, expectedError: "exchange kraken is not supported"},
+		{name: "invalid symbol", query: "symbol=NOPEUSDT", expectedStatus: http.StatusBadRequest, expectedError: "API returned status code: 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/futures/premium?"+tt.query, nil)
+
+			GetPremium(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response["error"])
+				return
+			}
+
+			var response models.ResponsePremium
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "BTCUSDT", response.Symbol)
+			assert.Equal(t, 50010.0, response.MarkPrice)
+			assert.Equal(t, 49995.0, response.IndexPrice)
+			assert.Equal(t, 50025.0, response.LastPrice)
+			assert.Equal(t, 50000.0, response.SpotPrice)
+			assert.Equal(t, 25.0, response.Basis)
+			assert.Equal(t, 0.05, response.BasisPercent)
+			// funded every 4 hours
+			assert.Equal(t, 4, response.FundingIntervalHours)
+			assert.Equal(t, 109.5, response.AnnualizedBasis)
+			assert.Equal(t, 21.9, response.AnnualizedFundingRate)
+			assert.NotEmpty(t, response.NextFundingTime)
+		})
+	}
+}
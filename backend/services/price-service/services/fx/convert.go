@@ -0,0 +1,112 @@
+package fx
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+)
+
+// stablecoins are taken at par with USD
+var stablecoins = map[string]bool{"USDT": true, "USDC": true, "BUSD": true, "TUSD": true, "FDUSD": true}
+
+// Conversion turns prices quoted in From into To
+type Conversion struct {
+	From   string
+	To     string
+	Rate   float64
+	AsOf   int64
+	Source string
+}
+
+// NewConversion returns the conversion of prices quoted in from into to, both being fiat currencies or
+// USD stablecoins. Conversions between USD and its stablecoins are at par and need no rates.
+func NewConversion(source RateSource, from, to string) (*Conversion, models.StatusCode, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(strings.TrimSpace(to))
+	if isUSD(from) && isUSD(to) {
+		return &Conversion{From: from, To: to, Rate: 1, AsOf: time.Now().UnixMilli(), Source: "par"}, http.StatusOK, nil
+	}
+	rates, statusCode, err := source.Rates()
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	toRate, ok := usdRate(rates, to)
+	if !ok {
+		return nil, http.StatusBadRequest, fmt.Errorf("Unsupported currency %s", to)
+	}
+	fromRate, ok := usdRate(rates, from)
+	if !ok {
+		return nil, http.StatusBadRequest, fmt.Errorf("Cannot convert prices quoted in %s", from)
+	}
+
+	return &Conversion{From: from, To: to, Rate: toRate / fromRate, AsOf: rates.AsOf, Source: rates.Source}, http.StatusOK, nil
+}
+
+func isUSD(currency string) bool {
+	return currency == "USD" || stablecoins[currency]
+}
+
+// usdRate returns the units of currency worth one USD
+func usdRate(rates *models.FXRates, currency string) (float64, bool) {
+	if isUSD(currency) {
+		return 1, true
+	}
+	rate, ok := rates.Rates[currency]
+	return rate, ok && rate > 0
+}
+
+// ForSymbol returns the conversion of the prices of a trading pair such as BTCUSDT into currency,
+// nil without error when currency is empty
+func ForSymbol(symbol, currency string) (*Conversion, models.StatusCode, error) {
+	if currency == "" {
+		return nil, http.StatusOK, nil
+	}
+	_, quote, err := provider.SplitSymbol(symbol)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return NewConversion(Default(), quote, currency)
+}
+
+// FromUSD returns the conversion of USD values into currency, nil without error when currency is empty
+func FromUSD(currency string) (*Conversion, models.StatusCode, error) {
+	if currency == "" {
+		return nil, http.StatusOK, nil
+	}
+	return NewConversion(Default(), "USD", currency)
+}
+
+// Convert converts a value, rounded to drop the binary noise of the multiplication
+func (c *Conversion) Convert(value float64) float64 {
+	return math.Round(value*c.Rate*1e8) / 1e8
+}
+
+// ConvertPrice converts a price string, prices that are not numbers are kept
+func (c *Conversion) ConvertPrice(price string) string {
+	value, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return price
+	}
+	return strconv.FormatFloat(c.Convert(value), 'f', -1, 64)
+}
+
+// Response describes the conversion in a response, nil for a nil conversion
+func (c *Conversion) Response() *models.Conversion {
+	if c == nil {
+		return nil
+	}
+	return &models.Conversion{
+		From:   c.From,
+		To:     c.To,
+		Rate:   c.Rate,
+		AsOf:   utils.ConvertMillisecondsToTimestamp(c.AsOf),
+		Source: c.Source,
+	}
+}
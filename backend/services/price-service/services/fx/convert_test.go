@@ -0,0 +1,81 @@
+package fx
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRates = &StaticRateSource{FXRates: models.FXRates{
+	Rates:  map[string]float64{"USD": 1, "VND": 25400, "EUR": 0.95, "TRY": 35},
+	AsOf:   1733875200000,
+	Source: "test",
+}}
+
+func TestNewConversion(t *testing.T) {
+	tests := []struct {
+		name               string
+		from               string
+		to                 string
+		expectedRate       float64
+		expectedStatusCode models.StatusCode
+		expectedError      string
+	}{
+		{name: "Stablecoin at par", from: "USDT", to: "vnd", expectedRate: 25400, expectedStatusCode: http.StatusOK},
+		{name: "Fiat cross rate", from: "EUR", to: "VND", expectedRate: 25400 / 0.95, expectedStatusCode: http.StatusOK},
+		{name: "Same currency", from: "USD", to: "USD", expectedRate: 1, expectedStatusCode: http.StatusOK},
+		{name: "Stablecoin target", from: "EUR", to: "USDC", expectedRate: 1 / 0.95, expectedStatusCode: http.StatusOK},
+		{name: "Unknown currency", from: "USDT", to: "XYZ", expectedStatusCode: http.StatusBadRequest, expectedError: "Unsupported currency XYZ"},
+		{name: "Crypto quote", from: "BTC", to: "VND", expectedStatusCode: http.StatusBadRequest, expectedError: "Cannot convert prices quoted in BTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conversion, statusCode, err := NewConversion(testRates, tt.from, tt.to)
+			assert.Equal(t, tt.expectedStatusCode, statusCode)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expectedRate, conversion.Rate, 1e-9)
+		})
+	}
+}
+
+func TestForSymbol(t *testing.T) {
+	SetDefault(testRates)
+	defer SetDefault(nil)
+
+	conversion, _, err := ForSymbol("BTCUSDT", "")
+	assert.NoError(t, err)
+	assert.Nil(t, conversion)
+	assert.Nil(t, conversion.Response())
+
+	conversion, _, err = ForSymbol("BTCTRY", "VND")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRY", conversion.From)
+	assert.Equal(t, "7257.14285714", conversion.ConvertPrice("10"))
+
+	_, statusCode, err := ForSymbol("NOPE", "VND")
+	assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+	assert.EqualError(t, err, "unknown quote asset in symbol NOPE")
+
+	conversion, _, err = FromUSD("EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, 95.0, conversion.Convert(100))
+	assert.Equal(t, "n/a", conversion.ConvertPrice("n/a"))
+	assert.Equal(t, &models.Conversion{From: "USD", To: "EUR", Rate: 0.95, AsOf: conversion.Response().AsOf, Source: "test"}, conversion.Response())
+}
+
+func TestNewConversionAtPar(t *testing.T) {
+	// USD and its stablecoins convert without asking the source
+	conversion, statusCode, err := NewConversion(&flakySource{err: errors.New("down")}, "USDT", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+	assert.Equal(t, 1.0, conversion.Rate)
+	assert.Equal(t, "par", conversion.Source)
+}
@@ -0,0 +1,190 @@
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	DefaultExchangeRateBaseURL = "https://open.er-api.com"
+
+	// DefaultCacheTTL is how long rates are used before they are fetched again, the default source
+	// publishes new rates once a day
+	DefaultCacheTTL = time.Hour
+
+	// sourceTimeout bounds a request of the default client
+	sourceTimeout = 10 * time.Second
+	// fetchRetry is how long a cached source waits after a failed fetch before fetching again
+	fetchRetry = time.Minute
+)
+
+// defaultClient is used by the sources without a client
+var defaultClient = &http.Client{Timeout: sourceTimeout}
+
+// RateSource provides the USD exchange rates of fiat currencies
+type RateSource interface {
+	Rates() (*models.FXRates, models.StatusCode, error)
+}
+
+// ExchangeRateAPISource reads the daily rates of the ExchangeRate-API open access endpoint
+type ExchangeRateAPISource struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+func NewExchangeRateAPISource(baseURL string) *ExchangeRateAPISource {
+	return &ExchangeRateAPISource{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewExchangeRateAPISourceFromEnv reads FX_BASE_URL, DefaultExchangeRateBaseURL when it is not set
+func NewExchangeRateAPISourceFromEnv() *ExchangeRateAPISource {
+	baseURL := os.Getenv("FX_BASE_URL")
+	if baseURL == "" {
+		baseURL = DefaultExchangeRateBaseURL
+	}
+	return NewExchangeRateAPISource(baseURL)
+}
+
+func (s *ExchangeRateAPISource) Rates() (*models.FXRates, models.StatusCode, error) {
+	client := s.Client
+	if client == nil {
+		client = defaultClient
+	}
+	resp, err := client.Get(s.BaseURL + "/v6/latest/USD")
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, http.StatusBadGateway, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	}
+
+	var data struct {
+		Result             string             `json:"result"`
+		ErrorType          string             `json:"error-type"`
+		TimeLastUpdateUnix int64              `json:"time_last_update_unix"`
+		Rates              map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to decode response: %v", err)
+	}
+	if data.Result != "success" {
+		return nil, http.StatusBadGateway, fmt.Errorf("exchange rate error: %s", data.ErrorType)
+	}
+
+	source := s.BaseURL
+	if u, err := url.Parse(s.BaseURL); err == nil && u.Host != "" {
+		source = u.Host
+	}
+	return &models.FXRates{Rates: data.Rates, AsOf: data.TimeLastUpdateUnix * 1000, Source: source}, http.StatusOK, nil
+}
+
+// StaticRateSource always returns the same rates, e.g. rates set by hand or a fake source in tests
+type StaticRateSource struct {
+	FXRates models.FXRates
+}
+
+func (s *StaticRateSource) Rates() (*models.FXRates, models.StatusCode, error) {
+	rates := s.FXRates
+	return &rates, http.StatusOK, nil
+}
+
+// CachedSource keeps the rates of Source for TTL. Rates that could not be fetched again are kept
+// until the source answers, their as-of time shows how old they are.
+type CachedSource struct {
+	Source RateSource
+	TTL    time.Duration
+
+	flight singleflight.Group
+
+	mutex     sync.Mutex
+	rates     *models.FXRates
+	fetchedAt time.Time
+	// failedAt is the time of the last failed fetch, the next one waits for fetchRetry
+	failedAt   time.Time
+	failStatus models.StatusCode
+	failErr    error
+}
+
+func NewCachedSource(source RateSource, ttl time.Duration) *CachedSource {
+	return &CachedSource{Source: source, TTL: ttl}
+}
+
+// Rates returns the cached rates. Expired rates are returned while they are fetched again in the
+// background, without rates concurrent callers wait for one fetch. The error of a failed fetch is
+// answered for fetchRetry before trying again.
+func (c *CachedSource) Rates() (*models.FXRates, models.StatusCode, error) {
+	c.mutex.Lock()
+	rates, fetchedAt, failedAt, failStatus, failErr := c.rates, c.fetchedAt, c.failedAt, c.failStatus, c.failErr
+	c.mutex.Unlock()
+	if rates != nil && time.Since(fetchedAt) < c.TTL {
+		return rates, http.StatusOK, nil
+	}
+	if failErr != nil && time.Since(failedAt) < fetchRetry {
+		if rates != nil {
+			return rates, http.StatusOK, nil
+		}
+		return nil, failStatus, failErr
+	}
+
+	if rates != nil {
+		c.flight.DoChan("rates", c.fetch)
+		return rates, http.StatusOK, nil
+	}
+	result, err, _ := c.flight.Do("rates", c.fetch)
+	fetched := result.(fetchResult)
+	return fetched.rates, fetched.statusCode, err
+}
+
+// fetchResult is what a fetch shared by concurrent callers returns to each of them
+type fetchResult struct {
+	rates      *models.FXRates
+	statusCode models.StatusCode
+}
+
+// fetch fetches the rates from Source and caches them, or the error
+func (c *CachedSource) fetch() (interface{}, error) {
+	rates, statusCode, err := c.Source.Rates()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err != nil {
+		c.failedAt, c.failStatus, c.failErr = time.Now(), statusCode, err
+		return fetchResult{statusCode: statusCode}, err
+	}
+	c.rates, c.fetchedAt, c.failErr = rates, time.Now(), nil
+	return fetchResult{rates: rates, statusCode: http.StatusOK}, nil
+}
+
+var (
+	source      RateSource
+	sourceMutex sync.Mutex
+)
+
+// Default returns the rate source used by the convert parameter, the cached ExchangeRate-API source
+// unless SetDefault replaced it
+func Default() RateSource {
+	sourceMutex.Lock()
+	defer sourceMutex.Unlock()
+	if source == nil {
+		source = NewCachedSource(NewExchangeRateAPISourceFromEnv(), DefaultCacheTTL)
+	}
+	return source
+}
+
+// SetDefault replaces the rate source, e.g. with a StaticRateSource in tests.
+// A nil source restores the one created from the environment.
+func SetDefault(s RateSource) {
+	sourceMutex.Lock()
+	defer sourceMutex.Unlock()
+	source = s
+}
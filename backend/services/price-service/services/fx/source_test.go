@@ -0,0 +1,169 @@
+package fx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRateAPISource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v6/latest/USD", r.URL.Path)
+		w.Write([]byte(`{"result":"success","time_last_update_unix":1733875351,"base_code":"USD","rates":{"USD":1,"VND":25415.5,"EUR":0.951}}`))
+	}))
+	defer server.Close()
+
+	rates, statusCode, err := NewExchangeRateAPISource(server.URL + "/").Rates()
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+	assert.Equal(t, map[string]float64{"USD": 1, "VND": 25415.5, "EUR": 0.951}, rates.Rates)
+	assert.Equal(t, int64(1733875351000), rates.AsOf)
+	assert.Equal(t, server.Listener.Addr().String(), rates.Source)
+}
+
+func TestExchangeRateAPISourceErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"result":"error","error-type":"unsupported-code"}`))
+	}))
+	defer server.Close()
+
+	_, statusCode, err := NewExchangeRateAPISource(server.URL).Rates()
+	assert.Equal(t, models.StatusCode(http.StatusBadGateway), statusCode)
+	assert.EqualError(t, err, "exchange rate error: unsupported-code")
+
+	_, statusCode, err = NewExchangeRateAPISource("http://127.0.0.1:0").Rates()
+	assert.Equal(t, models.StatusCode(http.StatusBadGateway), statusCode)
+	assert.Error(t, err)
+}
+
+// flakySource counts its calls and fails when err is set
+type flakySource struct {
+	mutex sync.Mutex
+	calls int
+	err   error
+}
+
+func (s *flakySource) Rates() (*models.FXRates, models.StatusCode, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls++
+	if s.err != nil {
+		return nil, http.StatusBadGateway, s.err
+	}
+	return &models.FXRates{Rates: map[string]float64{"VND": 25000 + float64(s.calls)}, AsOf: int64(s.calls)}, http.StatusOK, nil
+}
+
+func (s *flakySource) fail(err error) {
+	s.mutex.Lock()
+	s.err = err
+	s.mutex.Unlock()
+}
+
+func (s *flakySource) callCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+func TestCachedSource(t *testing.T) {
+	source := &flakySource{}
+	cached := NewCachedSource(source, 20*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		rates, _, err := cached.Rates()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rates.AsOf)
+	}
+	assert.Equal(t, 1, source.callCount())
+
+	// expired rates are answered while they are fetched again
+	time.Sleep(30 * time.Millisecond)
+	rates, _, _ := cached.Rates()
+	assert.Equal(t, int64(1), rates.AsOf)
+	assert.Eventually(t, func() bool {
+		rates, _, _ := cached.Rates()
+		return rates.AsOf == 2
+	}, time.Second, time.Millisecond)
+
+	// rates that cannot be fetched again are kept, the source is not asked again for fetchRetry
+	time.Sleep(30 * time.Millisecond)
+	source.fail(errors.New("down"))
+	cached.Rates()
+	assert.Eventually(t, func() bool { return source.callCount() == 3 }, time.Second, time.Millisecond)
+	source.fail(nil)
+	for i := 0; i < 3; i++ {
+		rates, statusCode, err := cached.Rates()
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+		assert.Equal(t, int64(2), rates.AsOf)
+	}
+	assert.Equal(t, 3, source.callCount())
+}
+
+// blockedSource holds every fetch until release is closed
+type blockedSource struct {
+	flakySource
+	release chan struct{}
+}
+
+func (s *blockedSource) Rates() (*models.FXRates, models.StatusCode, error) {
+	<-s.release
+	return s.flakySource.Rates()
+}
+
+func TestCachedSourceFetchesOnce(t *testing.T) {
+	source := &blockedSource{release: make(chan struct{})}
+	cached := NewCachedSource(source, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rates, _, err := cached.Rates()
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), rates.AsOf)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(source.release)
+	wg.Wait()
+	assert.Equal(t, 1, source.callCount())
+}
+
+func TestCachedSourceErrors(t *testing.T) {
+	source := &flakySource{err: errors.New("down")}
+	cached := NewCachedSource(source, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		_, statusCode, err := cached.Rates()
+		assert.Equal(t, models.StatusCode(http.StatusBadGateway), statusCode)
+		assert.EqualError(t, err, "down")
+	}
+	// the error is answered until fetchRetry
+	assert.Equal(t, 1, source.callCount())
+
+	source.fail(nil)
+	cached.failedAt = time.Now().Add(-fetchRetry)
+	rates, _, err := cached.Rates()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rates.AsOf)
+}
+
+func TestDefault(t *testing.T) {
+	static := &StaticRateSource{}
+	SetDefault(static)
+	assert.Same(t, static, Default())
+	SetDefault(nil)
+	assert.IsType(t, &CachedSource{}, Default())
+}
@@ -0,0 +1,117 @@
+package indicator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/kline"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get indicator series
+// @Description Computes technical indicators over the Kline data of a symbol and returns them aligned to its candles. The candles before the first one returned are loaded too, so that every indicator has a value from the first candle when the symbol has enough history.
+// @Tags Indicators
+// @Param Authorization header string true "Authorization token"
+// @Param symbol query string true "Symbol for which to compute indicators (e.g., BTCUSDT)"
+// @Param interval query string true "Interval of the candles (e.g., 1m, 5m, 1h, 1d), any count of m, h, d, w or M such as 10m or 2w is built from a finer interval"
+// @Param indicators query string true "Comma separated indicators with optional parameters separated by colons: sma, ema, wma (period, 20), rsi (period, 14), macd (fast, slow, signal, 12:26:9), bb (period, deviations, 20:2), atr (period, 14), stoch (period, %K smoothing, %D period, 14:3:3), vwap (reset every UTC day) and obv" example("sma:50,rsi,macd:12:26:9,bb:20:2")
+// @Param timezone query string false "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default"
+// @Param endTime query int false "Open time in milliseconds of the last candle, the cursor of a previous page loads older candles"
+// @Param limit query int false "Number of candles, 500 by default and at most 10000"
+// @Param market query string false "Market: futures (default) or spot" example("futures")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponseIndicatorSeries "Successful response with candles and indicator series"
+// @Failure 400 {object} models.ErrorResponseInputMissing "Missing data or invalid indicators"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/vip3/indicators/series [get]
+func GetIndicatorSeries(context *gin.Context) {
+	query := models.KlineQuery{
+		Symbol:   context.Query("symbol"),
+		Interval: context.Query("interval"),
+		Market:   context.Query("market"),
+		Timezone: context.Query("timezone"),
+		Limit:    kline.DefaultKlineLimit,
+	}
+	if query.Symbol == "" || query.Interval == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing data", context)
+		return
+	}
+	specs, err := ParseSpecs(context.Query("indicators"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	if context.Query("endTime") != "" {
+		endTime, err := strconv.ParseInt(context.Query("endTime"), 10, 64)
+		if err != nil || endTime <= 0 {
+			utils.ShowError(http.StatusBadRequest, "Invalid endTime", context)
+			return
+		}
+		query.EndTime = endTime
+	}
+	if context.Query("limit") != "" {
+		limit, err := strconv.Atoi(context.Query("limit"))
+		if err != nil || limit < 1 || limit > kline.MaxKlineLimit {
+			utils.ShowError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", kline.MaxKlineLimit), context)
+			return
+		}
+		query.Limit = limit
+	}
+	if query.Market, err = provider.NormalizeMarket(query.Market); err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	response, statusCode, err := GetIndicatorSeriesData(marketData, query, specs)
+	if err != nil {
+		responseStatusCode := utils.ResponseStatusCode(statusCode)
+		if responseStatusCode == http.StatusInternalServerError {
+			utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+			return
+		}
+		utils.ShowError(int64(responseStatusCode), err.Error(), context)
+		return
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// GetIndicatorSeriesData loads query.Limit candles, and the candles the indicators need before them,
+// and computes every indicator over them
+func GetIndicatorSeriesData(marketData provider.MarketDataProvider, query models.KlineQuery, specs []Spec) (*models.ResponseIndicatorSeries, models.StatusCode, error) {
+	count := query.Limit
+	lookback := 0
+	for _, spec := range specs {
+		lookback = max(lookback, spec.Lookback())
+	}
+	query.Limit += lookback
+
+	candles, statusCode, err := kline.LoadKlines(marketData, query)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	count = min(count, len(candles))
+
+	response := &models.ResponseIndicatorSeries{
+		KlineResponse: kline.NewKlineResponse(query.Symbol, query.Interval, candles[len(candles)-count:], nil),
+		Indicators:    make([]models.IndicatorSeries, 0, len(specs)),
+	}
+	for _, spec := range specs {
+		series := models.IndicatorSeries{Key: spec.Key(), Name: spec.Name, Params: spec.Params}
+		for name, values := range spec.Compute(candles) {
+			series.UpdateLine(name, values, count)
+		}
+		response.Indicators = append(response.Indicators, series)
+	}
+	return response, http.StatusOK, nil
+}
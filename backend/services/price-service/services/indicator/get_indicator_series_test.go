@@ -0,0 +1,128 @@
+package indicator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupTestRouter serves the hourly testCandles of BTCUSDT
+func setupTestRouter(t *testing.T) (*gin.Engine, *providertest.FakeExchange) {
+	exchange := &providertest.FakeExchange{Candles: map[string][]models.Candle{"BTCUSDT": testCandles()}}
+	provider.SetDefault(exchange)
+	t.Cleanup(func() { provider.SetDefault(nil) })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/indicators/series", GetIndicatorSeries)
+	return router, exchange
+}
+
+func getSeries(t *testing.T, router *gin.Engine, query string) (*httptest.ResponseRecorder, models.ResponseIndicatorSeries) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/indicators/series?"+query, nil)
+	router.ServeHTTP(w, req)
+	var response models.ResponseIndicatorSeries
+	if w.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w, response
+}
+
+func TestGetIndicatorSeries(t *testing.T) {
+	router, exchange := setupTestRouter(t)
+
+	w, response := getSeries(t, router, "symbol=BTCUSDT&interval=1h&limit=10&indicators=sma:5,macd:3:6:4,obv")
+	assert.Equal(t, http.StatusOK, w.Code)
+	// the 8 candles before the first one returned warm up the MACD signal
+	assert.Equal(t, 18, exchange.Queries()[0].Limit)
+
+	assert.Equal(t, "BTCUSDT", response.Symbol)
+	assert.Equal(t, "1h", response.Interval)
+	assert.Len(t, response.KlineData, 10)
+	assert.Equal(t, "2024-12-11T16:00:00Z", response.KlineData[0].Time)
+	assert.Equal(t, 22.17, response.KlineData[9].Close)
+	assert.Equal(t, int64(1733932800000-1), response.Cursor)
+
+	assert.Len(t, response.Indicators, 3)
+	sma := response.Indicators[0]
+	assert.Equal(t, "sma_5", sma.Key)
+	assert.Equal(t, "sma", sma.Name)
+	assert.Equal(t, []float64{5}, sma.Params)
+	assert.Len(t, sma.Lines["value"], 10)
+	// the warm-up candles are not returned, the simple average matches the whole series
+	assert.Equal(t, 22.736, *sma.Lines["value"][9])
+
+	macd := response.Indicators[1]
+	assert.Equal(t, "macd_3_6_4", macd.Key)
+	for _, name := range []string{"macd", "signal", "histogram"} {
+		assert.Len(t, macd.Lines[name], 10)
+		for _, value := range macd.Lines[name] {
+			assert.NotNil(t, value)
+		}
+	}
+
+	// on-balance volume counts from the first candle loaded
+	assert.Equal(t, []float64{}, response.Indicators[2].Params)
+	assert.NotNil(t, response.Indicators[2].Lines["value"][0])
+}
+
+func TestGetIndicatorSeriesShortHistory(t *testing.T) {
+	router, _ := setupTestRouter(t)
+
+	// fewer candles than asked for, the first ones have no value yet
+	w, response := getSeries(t, router, "symbol=BTCUSDT&interval=1h&indicators=rsi:5&endTime=1733871600000")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, response.KlineData, 4)
+	assert.Equal(t, "2024-12-10T20:00:00Z", response.KlineData[0].Time)
+	for _, value := range response.Indicators[0].Lines["value"] {
+		assert.Nil(t, value)
+	}
+
+	w, response = getSeries(t, router, "symbol=BTCUSDT&interval=1h&indicators=sma:5")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, response.KlineData, 30)
+	line := response.Indicators[0].Lines["value"]
+	assert.Nil(t, line[3])
+	assert.Equal(t, 22.178, *line[4])
+}
+
+func TestGetIndicatorSeriesErrors(t *testing.T) {
+	router, _ := setupTestRouter(t)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "Missing symbol", query: "interval=1h&indicators=rsi", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing data"},
+		{name: "Missing indicators", query: "symbol=BTCUSDT&interval=1h", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing indicators"},
+		{name: "Unknown indicator", query: "symbol=BTCUSDT&interval=1h&indicators=foo", expectedStatus: http.StatusBadRequest, expectedMessage: "Unknown indicator foo"},
+		{name: "Invalid limit", query: "symbol=BTCUSDT&interval=1h&indicators=rsi&limit=0", expectedStatus: http.StatusBadRequest, expectedMessage: "limit must be between 1 and 10000"},
+		{name: "Invalid endTime", query: "symbol=BTCUSDT&interval=1h&indicators=rsi&endTime=abc", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid endTime"},
+		{name: "Invalid market", query: "symbol=BTCUSDT&interval=1h&indicators=rsi&market=options", expectedStatus: http.StatusBadRequest, expectedMessage: "market options is not supported"},
+		{name: "Invalid interval", query: "symbol=BTCUSDT&interval=7x&indicators=rsi", expectedStatus: http.StatusBadRequest},
+		{name: "Unknown exchange", query: "symbol=BTCUSDT&interval=1h&indicators=rsi&exchange=kraken", expectedStatus: http.StatusBadRequest, expectedMessage: "exchange kraken is not supported"},
+		{name: "Invalid symbol", query: "symbol=NOPEUSDT&interval=1h&indicators=rsi", expectedStatus: http.StatusBadRequest, expectedMessage: "API returned status code: 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := getSeries(t, router, tt.query)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMessage, response["message"])
+			}
+		})
+	}
+}
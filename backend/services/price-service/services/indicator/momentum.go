@@ -0,0 +1,71 @@
+package indicator
+
+import (
+	"math"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// RSI returns the relative strength index of values over period, from 0 to 100, with the gains and
+// losses smoothed the way of Wilder
+func RSI(values []float64, period int) []float64 {
+	result := undefined(len(values))
+	if len(values) < 2 {
+		return result
+	}
+
+	gains, losses := undefined(len(values)), undefined(len(values))
+	for i := 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		gains[i], losses[i] = math.Max(change, 0), math.Max(-change, 0)
+	}
+	averageGains, averageLosses := wilder(gains, period), wilder(losses, period)
+	for i := range values {
+		if math.IsNaN(averageGains[i]) {
+			continue
+		}
+		if averageLosses[i] == 0 {
+			result[i] = 100
+			if averageGains[i] == 0 {
+				result[i] = 50
+			}
+			continue
+		}
+		result[i] = 100 - 100/(1+averageGains[i]/averageLosses[i])
+	}
+	return result
+}
+
+// MACD returns the difference of the fast and slow EMAs of values, its EMA over signal periods and the
+// histogram of their difference
+func MACD(values []float64, fast, slow, signal int) (macd, signalLine, histogram []float64) {
+	fastEMA, slowEMA := EMA(values, fast), EMA(values, slow)
+	macd = make([]float64, len(values))
+	for i := range values {
+		macd[i] = fastEMA[i] - slowEMA[i]
+	}
+	signalLine = EMA(macd, signal)
+	histogram = make([]float64, len(values))
+	for i := range values {
+		histogram[i] = macd[i] - signalLine[i]
+	}
+	return macd, signalLine, histogram
+}
+
+// Stochastic returns the stochastic oscillator of candles: %K places the close in the range of the last
+// period candles, from 0 to 100, averaged over smoothK candles, and %D is the average of %K over smoothD
+func Stochastic(candles []models.Candle, period, smoothK, smoothD int) (k, d []float64) {
+	raw := undefined(len(candles))
+	for i := period - 1; period > 0 && i < len(candles); i++ {
+		highest, lowest := candles[i].High, candles[i].Low
+		for _, candle := range candles[i-period+1 : i] {
+			highest, lowest = math.Max(highest, candle.High), math.Min(lowest, candle.Low)
+		}
+		raw[i] = 50
+		if highest > lowest {
+			raw[i] = 100 * (candles[i].Close - lowest) / (highest - lowest)
+		}
+	}
+	k = SMA(raw, smoothK)
+	return k, SMA(k, smoothD)
+}
@@ -0,0 +1,65 @@
+package indicator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSI(t *testing.T) {
+	// the closes and 14-day RSI of the StockCharts example
+	closes := []float64{
+		44.3389, 44.0902, 44.1497, 43.6124, 44.3278, 44.8264, 45.0955, 45.4245, 45.8433, 46.0826, 45.8931,
+		46.0328, 45.6140, 46.2820, 46.2820, 46.0028, 46.0328, 46.4116, 46.2222, 45.6439, 46.2122, 46.2521,
+		45.7137, 46.4515, 45.7835, 45.3548, 44.0288, 44.1783, 44.2181, 44.5672, 43.4205, 42.6628, 43.1314,
+	}
+	expected := []float64{
+		70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+		54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+	}
+	values := RSI(closes, 14)
+	assertSeries(t, values, 14, nil, 0)
+	for i, value := range expected {
+		assert.InDelta(t, value, values[i+14], 0.005, "value %d", i+14)
+	}
+}
+
+func TestRSIBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		closes   []float64
+		expected float64
+	}{
+		{name: "Only gains", closes: []float64{1, 2, 3, 4}, expected: 100},
+		{name: "Only losses", closes: []float64{4, 3, 2, 1}, expected: 0},
+		{name: "Flat", closes: []float64{1, 1, 1, 1}, expected: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := RSI(tt.closes, 3)
+			assertSeries(t, values, 3, map[int]float64{3: tt.expected}, 1e-9)
+		})
+	}
+	assert.True(t, math.IsNaN(RSI([]float64{1}, 3)[0]))
+}
+
+func TestMACD(t *testing.T) {
+	macd, signal, histogram := MACD(emaCloses, 3, 6, 4)
+	assertSeries(t, macd, 5, map[int]float64{5: -0.01625, 29: -0.2782980108}, 1e-9)
+	assertSeries(t, signal, 8, map[int]float64{8: 0.0166375501, 29: -0.2071103979}, 1e-9)
+	assertSeries(t, histogram, 8, map[int]float64{8: 0.0070236197, 29: -0.0711876129}, 1e-9)
+}
+
+func TestStochastic(t *testing.T) {
+	k, d := Stochastic(testCandles(), 5, 3, 3)
+	assertSeries(t, k, 6, map[int]float64{6: 50.7751937984, 29: 28.9839390227}, 1e-9)
+	assertSeries(t, d, 8, map[int]float64{8: 52.6010487597, 29: 32.5114123422}, 1e-9)
+
+	// a candle range of zero places the close in the middle
+	flat := []models.Candle{{High: 1, Low: 1, Close: 1}, {High: 1, Low: 1, Close: 1}}
+	k, _ = Stochastic(flat, 2, 1, 1)
+	assertSeries(t, k, 1, map[int]float64{1: 50}, 1e-9)
+}
@@ -0,0 +1,96 @@
+package indicator
+
+import "math"
+
+// Every indicator returns one value per input, NaN while there are not enough values yet.
+// Leading NaN inputs, such as the warm-up of another indicator, are skipped.
+
+// SMA returns the simple moving average of values over period
+func SMA(values []float64, period int) []float64 {
+	result := undefined(len(values))
+	start := firstDefined(values)
+	if period < 1 || start+period > len(values) {
+		return result
+	}
+
+	sum := 0.0
+	for i := start; i < len(values); i++ {
+		sum += values[i]
+		if i >= start+period {
+			sum -= values[i-period]
+		}
+		if i >= start+period-1 {
+			result[i] = sum / float64(period)
+		}
+	}
+	return result
+}
+
+// EMA returns the exponential moving average of values over period, weighting the latest value by
+// 2/(period+1). It starts from the simple average of the first period values.
+func EMA(values []float64, period int) []float64 {
+	return smooth(values, period, 2/float64(period+1))
+}
+
+// WMA returns the linearly weighted moving average of values over period, the latest value weighing period
+func WMA(values []float64, period int) []float64 {
+	result := undefined(len(values))
+	start := firstDefined(values)
+	if period < 1 || start+period > len(values) {
+		return result
+	}
+
+	weights := float64(period*(period+1)) / 2
+	for i := start + period - 1; i < len(values); i++ {
+		sum := 0.0
+		for j := 0; j < period; j++ {
+			sum += values[i-j] * float64(period-j)
+		}
+		result[i] = sum / weights
+	}
+	return result
+}
+
+// smooth is the exponential smoothing shared by EMA and the Wilder averages of RSI and ATR
+func smooth(values []float64, period int, alpha float64) []float64 {
+	result := undefined(len(values))
+	start := firstDefined(values)
+	if period < 1 || start+period > len(values) {
+		return result
+	}
+
+	average := 0.0
+	for i := start; i < start+period; i++ {
+		average += values[i]
+	}
+	average /= float64(period)
+	result[start+period-1] = average
+	for i := start + period; i < len(values); i++ {
+		average += alpha * (values[i] - average)
+		result[i] = average
+	}
+	return result
+}
+
+// wilder is the smoothing of Welles Wilder, an exponential average weighting the latest value by 1/period
+func wilder(values []float64, period int) []float64 {
+	return smooth(values, period, 1/float64(period))
+}
+
+func undefined(length int) []float64 {
+	result := make([]float64, length)
+	for i := range result {
+		result[i] = math.NaN()
+	}
+	return result
+}
+
+// firstDefined returns the index of the first value that is not NaN, len(values) when there is none
+func firstDefined(values []float64) int {
+	for i, value := range values {
+		if !math.IsNaN(value) {
+			return i
+		}
+	}
+	return len(values)
+}
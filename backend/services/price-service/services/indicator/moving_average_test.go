@@ -0,0 +1,95 @@
+package indicator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// emaCloses are the closes of the StockCharts 10-day EMA example
+var emaCloses = []float64{
+	22.27, 22.19, 22.08, 22.17, 22.18, 22.13, 22.23, 22.43, 22.24, 22.29,
+	22.15, 22.39, 22.38, 22.61, 23.36, 24.05, 23.75, 23.83, 23.95, 23.63,
+	23.82, 23.87, 23.65, 23.19, 23.10, 23.33, 22.68, 23.10, 22.40, 22.17,
+}
+
+// testCandles builds hourly candles from 2024-12-10T20:00:00Z around emaCloses, the fifth one opening
+// a new UTC day. Reference values were computed independently from the textbook definitions.
+func testCandles() []models.Candle {
+	candles := make([]models.Candle, len(emaCloses))
+	for i, close := range emaCloses {
+		candles[i] = models.Candle{
+			OpenTime: 1733860800000 + int64(i)*3600000,
+			High:     close + 0.3 + float64(i%3)*0.1,
+			Low:      close - 0.25 - float64(i%4)*0.05,
+			Close:    close,
+			Volume:   float64(1000 + (i*37)%200),
+		}
+	}
+	return candles
+}
+
+// assertSeries checks the values expected at some indexes and that every value before the first
+// expected index is NaN
+func assertSeries(t *testing.T, values []float64, first int, expected map[int]float64, delta float64) {
+	t.Helper()
+	for i := 0; i < first; i++ {
+		assert.True(t, math.IsNaN(values[i]), "value %d should be NaN, got %v", i, values[i])
+	}
+	assert.False(t, math.IsNaN(values[first]), "value %d should be set", first)
+	for i, value := range expected {
+		assert.InDelta(t, value, values[i], delta, "value %d", i)
+	}
+}
+
+func TestSMA(t *testing.T) {
+	assertSeries(t, SMA(emaCloses, 5), 4, map[int]float64{4: 22.178, 10: 22.268, 29: 22.736}, 1e-9)
+	assertSeries(t, SMA(emaCloses, 10), 9, map[int]float64{9: 22.221, 29: 23.131}, 1e-9)
+}
+
+func TestEMA(t *testing.T) {
+	// the StockCharts table, which rounds its intermediate values
+	expected := []float64{
+		22.22, 22.21, 22.24, 22.27, 22.33, 22.52, 22.80, 22.97, 23.13, 23.28, 23.34,
+		23.43, 23.51, 23.53, 23.47, 23.40, 23.39, 23.26, 23.23, 23.08, 22.92,
+	}
+	values := EMA(emaCloses, 10)
+	for i, value := range expected {
+		assert.InDelta(t, value, values[i+9], 0.005, "value %d", i+9)
+	}
+	assertSeries(t, values, 9, nil, 0)
+}
+
+func TestWMA(t *testing.T) {
+	assertSeries(t, WMA([]float64{1, 2, 3, 4}, 3), 2, map[int]float64{2: 14.0 / 6, 3: 20.0 / 6}, 1e-9)
+	assertSeries(t, WMA(emaCloses, 5), 4, map[int]float64{4: 22.1646666667, 10: 22.248, 29: 22.5626666667}, 1e-9)
+}
+
+func TestMovingAveragesEdgeCases(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		period int
+	}{
+		{name: "Fewer values than the period", values: []float64{1, 2}, period: 3},
+		{name: "No values", values: nil, period: 3},
+		{name: "Invalid period", values: []float64{1, 2, 3}, period: 0},
+		{name: "Only NaN", values: []float64{math.NaN(), math.NaN()}, period: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, values := range [][]float64{SMA(tt.values, tt.period), EMA(tt.values, tt.period), WMA(tt.values, tt.period)} {
+				assert.Len(t, values, len(tt.values))
+				for _, value := range values {
+					assert.True(t, math.IsNaN(value))
+				}
+			}
+		})
+	}
+
+	// leading NaN are skipped
+	assertSeries(t, SMA([]float64{math.NaN(), 1, 2, 3}, 2), 2, map[int]float64{2: 1.5, 3: 2.5}, 1e-9)
+}
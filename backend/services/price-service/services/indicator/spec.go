@@ -0,0 +1,201 @@
+package indicator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	// MaxIndicators bounds the indicators of one request
+	MaxIndicators = 10
+	// MaxPeriod bounds every period parameter
+	MaxPeriod = 500
+)
+
+// definition describes an indicator: its default parameters, of which the first periods are whole
+// numbers of candles, how many candles come before its first value and how its lines are computed
+type definition struct {
+	defaults []float64
+	periods  int
+	lookback func(params []int) int
+	compute  func(candles []models.Candle, params []int, values []float64) map[string][]float64
+}
+
+var definitions = map[string]definition{
+	"sma": {
+		defaults: []float64{20}, periods: 1,
+		lookback: func(p []int) int { return p[0] - 1 },
+		compute: func(c []models.Candle, p []int, _ []float64) map[string][]float64 {
+			return map[string][]float64{"value": SMA(closes(c), p[0])}
+		},
+	},
+	"ema": {
+		defaults: []float64{20}, periods: 1,
+		lookback: func(p []int) int { return p[0] - 1 },
+		compute: func(c []models.Candle, p []int, _ []float64) map[string][]float64 {
+			return map[string][]float64{"value": EMA(closes(c), p[0])}
+		},
+	},
+	"wma": {
+		defaults: []float64{20}, periods: 1,
+		lookback: func(p []int) int { return p[0] - 1 },
+		compute: func(c []models.Candle, p []int, _ []float64) map[string][]float64 {
+			return map[string][]float64{"value": WMA(closes(c), p[0])}
+		},
+	},
+	"rsi": {
+		defaults: []float64{14}, periods: 1,
+		lookback: func(p []int) int { return p[0] },
+		compute: func(c []models.Candle, p []int, _ []float64) map[string][]float64 {
+			return map[string][]float64{"value": RSI(closes(c), p[0])}
+		},
+	},
+	"macd": {
+		defaults: []float64{12, 26, 9}, periods: 3,
+		lookback: func(p []int) int { return max(p[0], p[1]) + p[2] - 2 },
+		compute: func(c []models.Candle, p []int, _ []float64) map[string][]float64 {
+			macd, signal, histogram := MACD(closes(c), p[0], p[1], p[2])
+			return map[string][]float64{"macd": macd, "signal": signal, "histogram": histogram}
+		},
+	},
+	"bb": {
+		defaults: []float64{20, 2}, periods: 1,
+		lookback: func(p []int) int { return p[0] - 1 },
+		compute: func(c []models.Candle, p []int, values []float64) map[string][]float64 {
+			upper, middle, lower := BollingerBands(closes(c), p[0], values[1])
+			return map[string][]float64{"upper": upper, "middle": middle, "lower": lower}
+		},
+	},
+	"atr": {
+		defaults: []float64{14}, periods: 1,
+		lookback: func(p []int) int { return p[0] - 1 },
+		compute: func(c []models.Candle, p []int, _ []float64) map[string][]float64 {
+			return map[string][]float64{"value": ATR(c, p[0])}
+		},
+	},
+	"stoch": {
+		defaults: []float64{14, 3, 3}, periods: 3,
+		lookback: func(p []int) int { return p[0] + p[1] + p[2] - 3 },
+		compute: func(c []models.Candle, p []int, _ []float64) map[string][]float64 {
+			k, d := Stochastic(c, p[0], p[1], p[2])
+			return map[string][]float64{"k": k, "d": d}
+		},
+	},
+	"vwap": {
+		lookback: func([]int) int { return 0 },
+		compute: func(c []models.Candle, _ []int, _ []float64) map[string][]float64 {
+			return map[string][]float64{"value": VWAP(c)}
+		},
+	},
+	"obv": {
+		lookback: func([]int) int { return 0 },
+		compute: func(c []models.Candle, _ []int, _ []float64) map[string][]float64 {
+			return map[string][]float64{"value": OBV(c)}
+		},
+	},
+}
+
+// aliases are the other names accepted for an indicator
+var aliases = map[string]string{"bollinger": "bb", "stochastic": "stoch"}
+
+// Spec is an indicator with its parameters, e.g. macd:12:26:9
+type Spec struct {
+	Name   string
+	Params []float64
+}
+
+// ParseSpecs reads a comma separated list of indicators such as sma:50,rsi,bb:20:2.5. Missing parameters
+// take their default: sma, ema and wma 20, rsi 14, macd 12:26:9, bb 20:2, atr 14 and stoch 14:3:3.
+func ParseSpecs(value string) ([]Spec, error) {
+	var specs []Spec
+	seen := map[string]bool{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			continue
+		}
+		spec, err := parseSpec(item)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[spec.Key()] {
+			seen[spec.Key()] = true
+			specs = append(specs, spec)
+		}
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("Missing indicators")
+	}
+	if len(specs) > MaxIndicators {
+		return nil, fmt.Errorf("At most %d indicators per request", MaxIndicators)
+	}
+	return specs, nil
+}
+
+func parseSpec(item string) (Spec, error) {
+	fields := strings.Split(item, ":")
+	name := fields[0]
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	definition, ok := definitions[name]
+	if !ok {
+		return Spec{}, fmt.Errorf("Unknown indicator %s", fields[0])
+	}
+	if len(fields)-1 > len(definition.defaults) {
+		return Spec{}, fmt.Errorf("%s takes at most %d parameters", name, len(definition.defaults))
+	}
+
+	spec := Spec{Name: name, Params: append([]float64{}, definition.defaults...)}
+	for i, field := range fields[1:] {
+		param, err := strconv.ParseFloat(field, 64)
+		switch {
+		case err != nil || param <= 0 || math.IsInf(param, 0):
+			return Spec{}, fmt.Errorf("Invalid parameter %s of %s", field, name)
+		case i < definition.periods && (param != math.Trunc(param) || param > MaxPeriod):
+			return Spec{}, fmt.Errorf("Periods of %s must be whole numbers from 1 to %d", name, MaxPeriod)
+		}
+		spec.Params[i] = param
+	}
+	return spec, nil
+}
+
+// Key names the series of a spec, e.g. macd_12_26_9
+func (s Spec) Key() string {
+	parts := []string{s.Name}
+	for _, param := range s.Params {
+		parts = append(parts, strconv.FormatFloat(param, 'f', -1, 64))
+	}
+	return strings.Join(parts, "_")
+}
+
+// Lookback returns the number of candles before the first one with every line of the indicator
+func (s Spec) Lookback() int {
+	return definitions[s.Name].lookback(s.periods())
+}
+
+// Compute returns the lines of the indicator over candles, one value per candle
+func (s Spec) Compute(candles []models.Candle) map[string][]float64 {
+	return definitions[s.Name].compute(candles, s.periods(), s.Params)
+}
+
+func (s Spec) periods() []int {
+	periods := make([]int, definitions[s.Name].periods)
+	for i := range periods {
+		periods[i] = int(s.Params[i])
+	}
+	return periods
+}
+
+// closes returns the close of every candle
+func closes(candles []models.Candle) []float64 {
+	values := make([]float64, len(candles))
+	for i, candle := range candles {
+		values[i] = candle.Close
+	}
+	return values
+}
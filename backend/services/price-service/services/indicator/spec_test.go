@@ -0,0 +1,72 @@
+package indicator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpecs(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expectedKeys  []string
+		expectedError string
+	}{
+		{name: "Defaults", value: "sma,ema,wma,rsi,macd,bb,atr,stoch,vwap,obv", expectedKeys: []string{"sma_20", "ema_20", "wma_20", "rsi_14", "macd_12_26_9", "bb_20_2", "atr_14", "stoch_14_3_3", "vwap", "obv"}},
+		{name: "Parameters", value: "SMA:50, bollinger:20:2.5,macd:5", expectedKeys: []string{"sma_50", "bb_20_2.5", "macd_5_26_9"}},
+		{name: "Duplicates", value: "rsi,rsi:14,rsi:7", expectedKeys: []string{"rsi_14", "rsi_7"}},
+		{name: "Missing", value: " , ", expectedError: "Missing indicators"},
+		{name: "Unknown", value: "sma,ichimoku", expectedError: "Unknown indicator ichimoku"},
+		{name: "Too many parameters", value: "rsi:14:2", expectedError: "rsi takes at most 1 parameters"},
+		{name: "Parameters of an indicator without any", value: "obv:3", expectedError: "obv takes at most 0 parameters"},
+		{name: "Invalid parameter", value: "ema:abc", expectedError: "Invalid parameter abc of ema"},
+		{name: "Negative parameter", value: "bb:20:-1", expectedError: "Invalid parameter -1 of bb"},
+		{name: "Fractional period", value: "sma:2.5", expectedError: "Periods of sma must be whole numbers from 1 to 500"},
+		{name: "Period too long", value: "ema:501", expectedError: "Periods of ema must be whole numbers from 1 to 500"},
+		{name: "Too many indicators", value: "sma:1,sma:2,sma:3,sma:4,sma:5,sma:6,sma:7,sma:8,sma:9,sma:10,sma:11", expectedError: "At most 10 indicators per request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := ParseSpecs(tt.value)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			var keys []string
+			for _, spec := range specs {
+				keys = append(keys, spec.Key())
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestSpecLookback(t *testing.T) {
+	candles := testCandles()
+	specs, err := ParseSpecs("sma:5,ema:4,rsi:5,macd:3:6:4,macd:6:3:4,bb:10,atr:5,stoch:5:3:3,vwap,obv")
+	assert.NoError(t, err)
+	more, err := ParseSpecs("wma:3")
+	assert.NoError(t, err)
+	specs = append(specs, more...)
+
+	// the lookback is the index of the first candle where every line has a value
+	for _, spec := range specs {
+		t.Run(spec.Key(), func(t *testing.T) {
+			lines := spec.Compute(candles)
+			assert.NotEmpty(t, lines)
+			first := 0
+			for _, values := range lines {
+				assert.Len(t, values, len(candles))
+				first = max(first, firstDefined(values))
+			}
+			assert.Equal(t, spec.Lookback(), first)
+			for _, values := range lines {
+				assert.False(t, math.IsNaN(values[len(values)-1]))
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package indicator
+
+import (
+	"math"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// BollingerBands returns the SMA of values over period as the middle band, with the upper and lower bands
+// deviations population standard deviations away from it
+func BollingerBands(values []float64, period int, deviations float64) (upper, middle, lower []float64) {
+	middle = SMA(values, period)
+	upper, lower = undefined(len(values)), undefined(len(values))
+	for i := range values {
+		if math.IsNaN(middle[i]) {
+			continue
+		}
+		variance := 0.0
+		for _, value := range values[i-period+1 : i+1] {
+			variance += (value - middle[i]) * (value - middle[i])
+		}
+		width := deviations * math.Sqrt(variance/float64(period))
+		upper[i], lower[i] = middle[i]+width, middle[i]-width
+	}
+	return upper, middle, lower
+}
+
+// ATR returns the average true range of candles over period, smoothed the way of Wilder. The true range
+// of the first candle is its high minus its low.
+func ATR(candles []models.Candle, period int) []float64 {
+	trueRanges := make([]float64, len(candles))
+	for i, candle := range candles {
+		trueRanges[i] = candle.High - candle.Low
+		if i > 0 {
+			previousClose := candles[i-1].Close
+			trueRanges[i] = math.Max(trueRanges[i], math.Max(math.Abs(candle.High-previousClose), math.Abs(candle.Low-previousClose)))
+		}
+	}
+	return wilder(trueRanges, period)
+}
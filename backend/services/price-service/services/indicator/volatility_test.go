@@ -0,0 +1,19 @@
+package indicator
+
+import "testing"
+
+func TestBollingerBands(t *testing.T) {
+	upper, middle, lower := BollingerBands(emaCloses, 10, 2)
+	assertSeries(t, upper, 9, map[int]float64{9: 22.4050543398, 20: 24.6328041249, 29: 24.2258040921}, 1e-9)
+	assertSeries(t, middle, 9, map[int]float64{9: 22.221, 20: 23.377, 29: 23.131}, 1e-9)
+	assertSeries(t, lower, 9, map[int]float64{9: 22.0369456602, 20: 22.1211958751, 29: 22.0361959079}, 1e-9)
+
+	// a constant series has no width
+	upper, _, lower = BollingerBands([]float64{5, 5, 5}, 3, 2)
+	assertSeries(t, upper, 2, map[int]float64{2: 5}, 1e-9)
+	assertSeries(t, lower, 2, map[int]float64{2: 5}, 1e-9)
+}
+
+func TestATR(t *testing.T) {
+	assertSeries(t, ATR(testCandles(), 5), 4, map[int]float64{4: 0.69, 5: 0.712, 29: 0.8027495313}, 1e-9)
+}
@@ -0,0 +1,45 @@
+package indicator
+
+import (
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// VWAP returns the volume weighted average of the typical price, (high + low + close) / 3, of candles
+// since the start of their UTC day
+func VWAP(candles []models.Candle) []float64 {
+	result := undefined(len(candles))
+	day := int64(24 * time.Hour / time.Millisecond)
+
+	var session int64 = -1
+	var priceVolume, volume float64
+	for i, candle := range candles {
+		if candle.OpenTime/day != session {
+			session = candle.OpenTime / day
+			priceVolume, volume = 0, 0
+		}
+		priceVolume += (candle.High + candle.Low + candle.Close) / 3 * candle.Volume
+		volume += candle.Volume
+		if volume > 0 {
+			result[i] = priceVolume / volume
+		}
+	}
+	return result
+}
+
+// OBV returns the on-balance volume of candles, adding the volume of a candle closing up and subtracting
+// the volume of one closing down, from 0 at the first candle
+func OBV(candles []models.Candle) []float64 {
+	result := make([]float64, len(candles))
+	for i := 1; i < len(candles); i++ {
+		result[i] = result[i-1]
+		switch {
+		case candles[i].Close > candles[i-1].Close:
+			result[i] += candles[i].Volume
+		case candles[i].Close < candles[i-1].Close:
+			result[i] -= candles[i].Volume
+		}
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package indicator
+
+import (
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVWAP(t *testing.T) {
+	values := VWAP(testCandles())
+	// the fifth candle opens a new UTC day and starts over from its own typical price
+	assertSeries(t, values, 0, map[int]float64{0: 22.2866666667, 3: 22.1917858835, 4: 22.23, 5: 22.2130690099, 29: 22.3414619883}, 1e-9)
+
+	// no volume yet, no price
+	values = VWAP([]models.Candle{{High: 2, Low: 1, Close: 1.5}, {High: 2, Low: 1, Close: 1.5, Volume: 1}})
+	assertSeries(t, values, 1, map[int]float64{1: 1.5}, 1e-9)
+}
+
+func TestOBV(t *testing.T) {
+	values := OBV(testCandles())
+	assert.Equal(t, []float64{0, -1037, -2111, -1000}, values[:4])
+	assert.Equal(t, 1245.0, values[29])
+
+	// an unchanged close keeps the balance
+	values = OBV([]models.Candle{{Close: 1, Volume: 5}, {Close: 1, Volume: 7}})
+	assert.Equal(t, []float64{0, 0}, values)
+}
@@ -0,0 +1,185 @@
+package kline
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/repository"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
+)
+
+// DefaultBackfillHistory is how many candles are loaded for a series the store does not have yet
+const DefaultBackfillHistory = 1000
+
+// Backfiller keeps the candle store current for a set of Binance futures symbols. It fills the gap
+// since the last stored candle from the REST API, then stores every candle closed on the kline
+// stream, filling the gap again whenever the stream reconnects.
+type Backfiller struct {
+	Store      repository.CandleRepository
+	MarketData provider.MarketDataProvider
+	Hub        *websocket.Hub
+	Symbols    []string
+	Intervals  []string
+	History    int
+}
+
+// NewBackfillerFromEnv follows the symbols of CANDLE_BACKFILL_SYMBOLS, a comma separated list such as
+// BTCUSDT,ETHUSDT, at the intervals of CANDLE_BACKFILL_INTERVALS, 1m when it is not set
+func NewBackfillerFromEnv(store repository.CandleRepository) *Backfiller {
+	intervals := splitList(os.Getenv("CANDLE_BACKFILL_INTERVALS"))
+	if len(intervals) == 0 {
+		intervals = []string{"1m"}
+	}
+	symbols := splitList(os.Getenv("CANDLE_BACKFILL_SYMBOLS"))
+	for i, symbol := range symbols {
+		symbols[i] = provider.NormalizeSymbol(symbol)
+	}
+
+	return &Backfiller{
+		Store:      store,
+		MarketData: provider.Default(),
+		Hub:        websocket.DefaultHub,
+		Symbols:    symbols,
+		Intervals:  intervals,
+		History:    DefaultBackfillHistory,
+	}
+}
+
+// Start follows every symbol and interval in the background until ctx is done
+func (b *Backfiller) Start(ctx context.Context) {
+	for _, symbol := range b.Symbols {
+		for _, interval := range b.Intervals {
+			if !provider.IsValidInterval(interval) {
+				log.Println("Backfill skips invalid interval ", interval)
+				continue
+			}
+			go b.follow(ctx, symbol, interval)
+		}
+	}
+}
+
+func (b *Backfiller) follow(ctx context.Context, symbol, interval string) {
+	key := CandleKeyOf(b.MarketData, provider.MarketFutures, symbol, interval)
+	url := websocket.KlineStreamURL(symbol, provider.MarketFutures, interval)
+
+	subscription := b.Hub.Subscribe(url)
+	defer func() { subscription.Close() }()
+	b.fill(key)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case message, ok := <-subscription.C:
+			if !ok {
+				// the hub dropped this subscriber, candles may have closed meanwhile
+				subscription = b.Hub.Subscribe(url)
+				b.fill(key)
+				continue
+			}
+			if message.Status == websocket.StatusConnected {
+				b.fill(key)
+				continue
+			}
+			if message.Status != "" {
+				continue
+			}
+
+			candle, final, err := closedCandle(message.Data)
+			if err != nil {
+				log.Println("JSON unmarshal error: ", err)
+				continue
+			}
+			if final {
+				b.save(key, []models.Candle{candle})
+			}
+		}
+	}
+}
+
+// fill stores the candles closed since the last stored one, or the last History candles
+func (b *Backfiller) fill(key models.CandleKey) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	latest, err := b.Store.LatestCandle(ctx, key)
+	cancel()
+	if err != nil {
+		log.Println("Candle store error: ", err)
+		return
+	}
+
+	now := time.Now().UnixMilli()
+	startTime := provider.RangeStart(now, key.Interval, b.History)
+	if latest != nil {
+		startTime = latest.OpenTime + 1
+	}
+	for {
+		candles, _, err := FetchKlines(b.MarketData, models.KlineQuery{
+			Symbol:    key.Symbol,
+			Interval:  key.Interval,
+			Market:    key.Market,
+			StartTime: startTime,
+			Limit:     MaxKlineLimit,
+		})
+		if err != nil {
+			log.Println("Backfill error: ", err)
+			return
+		}
+
+		closed := make([]models.Candle, 0, len(candles))
+		for _, candle := range candles {
+			if provider.CandleCloseTime(candle.OpenTime, key.Interval) < now {
+				closed = append(closed, candle)
+			}
+		}
+		b.save(key, closed)
+
+		// a full page means the store was further behind than one request
+		if len(candles) < MaxKlineLimit {
+			return
+		}
+		startTime = candles[len(candles)-1].OpenTime + 1
+	}
+}
+
+func (b *Backfiller) save(key models.CandleKey, candles []models.Candle) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := b.Store.SaveCandles(ctx, key, candles); err != nil {
+		log.Println("Candle store error: ", err)
+	}
+}
+
+// closedCandle parses a kline stream event, final tells whether its candle is closed
+func closedCandle(message []byte) (candle models.Candle, final bool, err error) {
+	var event models.KlineWebsocket
+	if err := json.Unmarshal(message, &event); err != nil {
+		return models.Candle{}, false, err
+	}
+	k := event.Data.KData
+	return models.Candle{
+		OpenTime:  k.StartTime,
+		CloseTime: k.CloseTime,
+		Open:      ChangeToFloat(k.OpenPrice),
+		High:      ChangeToFloat(k.HighPrice),
+		Low:       ChangeToFloat(k.LowPrice),
+		Close:     ChangeToFloat(k.ClosePrice),
+		Volume:    ChangeToFloat(k.BaseAssetVolume),
+	}, k.IsFinal, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
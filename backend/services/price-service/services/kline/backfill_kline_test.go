@@ -0,0 +1,87 @@
+package kline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/repository"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockKlineStream sends an open then a closed event for the candle opened at openTime
+func newMockKlineStream(t *testing.T, openTime int64) *httptest.Server {
+	upgrader := gorilla.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for _, final := range []bool{false, true} {
+			event := fmt.Sprintf(`{"stream":"btcusdt@kline_1h","data":{"e":"kline","s":"BTCUSDT","k":{"t":%d,"T":%d,"i":"1h","o":"100","c":"%s","h":"120","l":"90","v":"10","x":%t}}}`,
+				openTime, openTime+hour-1, map[bool]string{false: "105", true: "110"}[final], final)
+			conn.WriteMessage(gorilla.TextMessage, []byte(event))
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBackfiller(t *testing.T) {
+	exchange, last := newRecentExchange()
+	store := repository.NewMockCandleRepository()
+	key := CandleKeyOf(exchange, "", "BTCUSDT", "1h")
+
+	// a candle the exchange has not served yet, so it can only come from the stream
+	streamed := last + 5*hour
+	server := newMockKlineStream(t, streamed)
+	t.Setenv("BINANCE_FUTURES_WS_URL", "ws"+strings.TrimPrefix(server.URL, "http"))
+
+	backfiller := &Backfiller{
+		Store:      store,
+		MarketData: exchange,
+		Hub:        websocket.NewHub(),
+		Symbols:    []string{"BTCUSDT"},
+		Intervals:  []string{"1h"},
+		History:    10,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backfiller.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		candles, _ := store.FindCandles(context.Background(), key, 0, streamed)
+		return len(candles) == 10
+	}, 2*time.Second, 10*time.Millisecond)
+
+	candles, _ := store.FindCandles(context.Background(), key, 0, streamed)
+	// the gap since the last stored candle comes from the REST API, without the open candle
+	assert.Equal(t, last-9*hour, candles[0].OpenTime)
+	assert.Equal(t, last-hour, candles[8].OpenTime)
+	// the stream only adds closed candles
+	assert.Equal(t, streamed, candles[9].OpenTime)
+	assert.Equal(t, 110.0, candles[9].Close)
+}
+
+func TestNewBackfillerFromEnv(t *testing.T) {
+	t.Setenv("CANDLE_BACKFILL_SYMBOLS", "btcusdt, ETH-USDT,")
+	t.Setenv("CANDLE_BACKFILL_INTERVALS", "")
+
+	backfiller := NewBackfillerFromEnv(repository.NewMockCandleRepository())
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, backfiller.Symbols)
+	assert.Equal(t, []string{"1m"}, backfiller.Intervals)
+	assert.Equal(t, DefaultBackfillHistory, backfiller.History)
+}
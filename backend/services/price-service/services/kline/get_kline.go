@@ -1,83 +1,106 @@
 package kline
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
-	"net/url"
 	"strconv"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
 	"github.com/gin-gonic/gin"
 )
 
 // @Summary Get Kline data
-// @Description Fetches Kline data for a specific symbol and interval from Binance API
+// @Description Fetches Kline data for a specific symbol and interval from Binance API or the requested exchange
 // @Tags Kline
 // @Param Authorization header string true "Authorization token"
 // @Param symbol query string true "Symbol for which to fetch Kline data (e.g., BTCUSDT)"
-// @Param interval query string true "Interval for Kline data (e.g., 1m, 5m, 1h, 1d)"
+// @Param interval query string true "Interval for Kline data (e.g., 1m, 5m, 1h, 1d), any count of m, h, d, w or M such as 10m or 2w is built from a finer interval"
+// @Param timezone query string false "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default"
+// @Param startTime query int false "Open time in milliseconds of the first candle, candles go forward from it when set" example(1732147200000)
+// @Param endTime query int false "Open time in milliseconds of the last candle, the cursor of a previous page loads older candles"
+// @Param limit query int false "Number of candles, 500 by default and at most 10000"
+// @Param market query string false "Market: futures (default) or spot" example("futures")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Param convert query string false "Fiat currency to convert open, high, low and close into (e.g., VND, USD, EUR) at the latest rate, volumes stay in the base asset" example("VND")
 // @Success 200 {object} models.ResponseKline "Successful response with Kline data"
 // @Failure 400 {object} models.ErrorResponseInputMissing "Missing Data"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
 // @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
 // @Router /api/v1/vip1/kline [get]
 func GetKline(context *gin.Context) {
-	symbol := context.Query("symbol")
-	interval := context.Query("interval")
-	GetKlineData(symbol, interval, context)
-}
+	query := models.KlineQuery{
+		Symbol:   context.Query("symbol"),
+		Interval: context.Query("interval"),
+		Market:   context.Query("market"),
+		Timezone: context.Query("timezone"),
+		Convert:  context.Query("convert"),
+	}
+	var ok bool
+	if query.StartTime, ok = utils.QueryMilliseconds(context, "startTime"); !ok {
+		return
+	}
+	if query.EndTime, ok = utils.QueryMilliseconds(context, "endTime"); !ok {
+		return
+	}
+	if context.Query("limit") != "" {
+		limit, err := strconv.Atoi(context.Query("limit"))
+		if err != nil || limit < 1 || limit > MaxKlineLimit {
+			utils.ShowError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", MaxKlineLimit), context)
+			return
+		}
+		query.Limit = limit
+	}
 
-func GetKlineData(symbol, interval string, context *gin.Context) {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", "https://fapi.binance.com/fapi/v1/klines", nil)
+	marketData, err := provider.Get(context.Query("exchange"))
 	if err != nil {
-		utils.ShowError(http.StatusInternalServerError, err.Error(), context)
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
 		return
 	}
+	GetKlineData(marketData, query, context)
+}
 
-	if symbol == "" || interval == "" {
+func GetKlineData(marketData provider.MarketDataProvider, query models.KlineQuery, context *gin.Context) {
+	if query.Symbol == "" || query.Interval == "" {
 		utils.ShowError(http.StatusBadRequest, "Missing data", context)
 		return
 	}
-
-	q := url.Values{}
-	q.Add("symbol", symbol)
-	q.Add("interval", interval)
-	req.URL.RawQuery = q.Encode()
-	resp, err := client.Do(req)
+	market, err := provider.NormalizeMarket(query.Market)
 	if err != nil {
-		utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
 		return
 	}
-	defer resp.Body.Close()
-
-	respStatusCode := resp.StatusCode
-	if respStatusCode != http.StatusOK {
-		utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+	query.Market = market
+	conversion, statusCode, err := fx.ForSymbol(query.Symbol, query.Convert)
+	if err != nil {
+		utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
 		return
 	}
+	if query.StartTime > 0 && query.EndTime > 0 {
+		if query.StartTime > query.EndTime {
+			utils.ShowError(http.StatusBadRequest, "startTime must not be after endTime", context)
+			return
+		}
+		// a closed range is returned whole, up to MaxKlineLimit
+		if query.Limit == 0 {
+			query.Limit = MaxKlineLimit
+		}
+	}
 
-	var data [][]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&data)
+	candles, statusCode, err := LoadKlines(marketData, query)
 	if err != nil {
-		utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+		responseStatusCode := utils.ResponseStatusCode(statusCode)
+		if responseStatusCode == http.StatusInternalServerError {
+			utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+			return
+		}
+		utils.ShowError(int64(responseStatusCode), err.Error(), context)
 		return
 	}
 
-	var response models.KlineResponse
-	response.UpdateKlineResponse(symbol, interval, utils.GetTimeNow())
-	for _, value := range data {
-		var kline models.KLineEachData
-		timeData := int64(value[0].(float64))
-		timeKline := utils.ConvertMilisecondToTimeFormatedRFC3339(timeData)
-		open := ChangeToFloat(value[1])
-		high := ChangeToFloat(value[2])
-		low := ChangeToFloat(value[3])
-		close := ChangeToFloat(value[4])
-		volume := ChangeToFloat(value[5])
-		kline.UpdateKlineEachData(timeKline, open, high, low, close, volume)
-		response.UpdateKlineResponseData(&kline)
-	}
+	response := NewKlineResponse(query.Symbol, query.Interval, candles, conversion)
 	// data response
 	// [
 	// "symbol": "BTCUSDT",
@@ -99,10 +122,56 @@ func GetKlineData(symbol, interval string, context *gin.Context) {
 	//         "low": 30261.4,
 	//         "close": 30608.4,
 	//         "volume": 298904.747
-	//     },]
+	//     },],
+	// "cursor": 1689033599999
 
 	context.JSON(http.StatusOK, response)
 }
+
+// NewKlineResponse returns the response of candles, converted when conversion is not nil, with the
+// cursor that loads the candles before them
+func NewKlineResponse(symbol, interval string, candles []models.Candle, conversion *fx.Conversion) models.KlineResponse {
+	var response models.KlineResponse
+	response.UpdateKlineResponse(symbol, interval, utils.GetTimeNow())
+	response.Conversion = conversion.Response()
+	response.KlineData = make([]models.KLineEachData, 0, len(candles))
+	if len(candles) > 0 {
+		response.Cursor = candles[0].OpenTime - 1
+	}
+	for _, candle := range candles {
+		var kline models.KLineEachData
+		timeKline := utils.ConvertMilisecondToTimeFormatedRFC3339(candle.OpenTime)
+		if conversion != nil {
+			candle.Open, candle.High = conversion.Convert(candle.Open), conversion.Convert(candle.High)
+			candle.Low, candle.Close = conversion.Convert(candle.Low), conversion.Convert(candle.Close)
+		}
+		kline.UpdateKlineEachData(timeKline, candle.Open, candle.High, candle.Low, candle.Close, candle.Volume)
+		response.UpdateKlineResponseData(&kline)
+	}
+	return response
+}
+
+// LoadKlines returns the candles of a query, resampled from a finer interval when the exchange does not
+// offer the interval. It answers 400 for an invalid interval or timezone.
+func LoadKlines(marketData provider.MarketDataProvider, query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	resampler, err := NewResampler(query.Interval, query.Timezone)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if resampler.Native() {
+		return fetchKlines(marketData, query)
+	}
+	return FetchResampledKlines(marketData, query, resampler)
+}
+
+// fetchKlines reads closed candles from the candle store when there is one
+func fetchKlines(marketData provider.MarketDataProvider, query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	if store := getCandleStore(); store != nil {
+		return FetchStoredKlines(store, marketData, query)
+	}
+	return FetchKlines(marketData, query)
+}
+
 func ChangeToFloat(data interface{}) float64 {
 	if strVal, ok := data.(string); ok {
 		result, _ := strconv.ParseFloat(strVal, 64)
@@ -7,6 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,6 +19,7 @@ type KlineResponse struct {
 	Interval  string          `json:"interval"`
 	EventTime string          `json:"eventTime"`
 	KlineData []KLineEachData `json:"kline_data"`
+	Cursor    int64           `json:"cursor"`
 }
 
 type KLineEachData struct {
@@ -46,6 +50,28 @@ var mockKlineData = [][]interface{}{
 	},
 }
 
+// setupMockBinance points the market data provider at a local server returning mockKlineData
+func setupMockBinance(t *testing.T) *httptest.Server {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Query().Get("symbol")
+		interval := r.URL.Query().Get("interval")
+
+		if symbol == "" || interval == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(mockKlineData)
+	}))
+	provider.SetDefault(provider.NewBinanceProvider(mockServer.URL, mockServer.URL))
+	t.Cleanup(func() {
+		provider.SetDefault(nil)
+		mockServer.Close()
+	})
+	return mockServer
+}
+
 func setupTestRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -53,6 +79,7 @@ func setupTestRouter() *gin.Engine {
 }
 
 func TestGetKline(t *testing.T) {
+	setupMockBinance(t)
 	router := setupTestRouter()
 	router.GET("/kline", GetKline)
 
@@ -69,17 +96,57 @@ func TestGetKline(t *testing.T) {
 		{
 			name:           "Missing Symbol",
 			queryParams:    "interval=1d",
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "Missing Interval",
 			queryParams:    "symbol=BTCUSDT",
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "Empty Parameters",
 			queryParams:    "",
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Valid Range",
+			queryParams:    "symbol=BTCUSDT&interval=1d&startTime=1689033600000&endTime=1689120000000&limit=2",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Interval",
+			queryParams:    "symbol=BTCUSDT&interval=7s",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Resampled Interval",
+			queryParams:    "symbol=BTCUSDT&interval=1w&timezone=UTC%2B7",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Timezone",
+			queryParams:    "symbol=BTCUSDT&interval=1d&timezone=Mars/Olympus",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Market",
+			queryParams:    "symbol=BTCUSDT&interval=1d&market=options",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Start Time",
+			queryParams:    "symbol=BTCUSDT&interval=1d&startTime=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Start Time After End Time",
+			queryParams:    "symbol=BTCUSDT&interval=1d&startTime=1689120000000&endTime=1689033600000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Limit Too Large",
+			queryParams:    "symbol=BTCUSDT&interval=1d&limit=10001",
+			expectedStatus: http.StatusBadRequest,
 		},
 	}
 
@@ -94,20 +161,7 @@ func TestGetKline(t *testing.T) {
 }
 
 func TestGetKlineData(t *testing.T) {
-	// Create a mock server
-	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		symbol := r.URL.Query().Get("symbol")
-		interval := r.URL.Query().Get("interval")
-
-		if symbol == "" || interval == "" {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(mockKlineData)
-	}))
-	defer mockServer.Close()
+	setupMockBinance(t)
 
 	tests := []struct {
 		name           string
@@ -127,14 +181,14 @@ func TestGetKlineData(t *testing.T) {
 			name:           "Empty Symbol",
 			symbol:         "",
 			interval:       "1d",
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusBadRequest,
 			checkResponse:  false,
 		},
 		{
 			name:           "Empty Interval",
 			symbol:         "BTCUSDT",
 			interval:       "",
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusBadRequest,
 			checkResponse:  false,
 		},
 	}
@@ -144,7 +198,7 @@ func TestGetKlineData(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
-			GetKlineData(tt.symbol, tt.interval, c)
+			GetKlineData(provider.Default(), models.KlineQuery{Symbol: tt.symbol, Interval: tt.interval}, c)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
 
@@ -174,6 +228,7 @@ func TestGetKlineData(t *testing.T) {
 					assert.Equal(t, 30396.9, firstKline.Close)
 					assert.Equal(t, 429115.537, firstKline.Volume)
 				}
+				assert.Equal(t, int64(1689033599999), response.Cursor)
 			}
 		})
 	}
@@ -276,3 +331,29 @@ func TestKlineResponseStructure(t *testing.T) {
 		assert.Equal(t, expectedKline.Volume, actualKline.Volume)
 	}
 }
+
+func TestGetKlineConvert(t *testing.T) {
+	setupMockBinance(t)
+	fx.SetDefault(&fx.StaticRateSource{FXRates: models.FXRates{Rates: map[string]float64{"USD": 1, "VND": 25000}, AsOf: 1733875200000, Source: "test"}})
+	defer fx.SetDefault(nil)
+	router := setupTestRouter()
+	router.GET("/kline", GetKline)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/kline?symbol=BTCUSDT&interval=1d&convert=VND", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.KlineResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.KLineEachData{Time: "2023-07-11T00:00:00Z", Open: 753695000, High: 776000000, Low: 748220000, Close: 759922500, Volume: 429115.537}, response.KlineData[0])
+	assert.Equal(t, "VND", response.Conversion.To)
+	assert.Equal(t, 25000.0, response.Conversion.Rate)
+
+	// the quote asset of ETHBTC is not a fiat currency
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/kline?symbol=ETHBTC&interval=1d&convert=VND", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"message":"Cannot convert prices quoted in BTC"}`, w.Body.String())
+}
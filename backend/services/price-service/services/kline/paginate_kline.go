@@ -0,0 +1,96 @@
+package kline
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+)
+
+const (
+	// DefaultKlineLimit is the number of candles returned without limit, the same as Binance
+	DefaultKlineLimit = 500
+	// MaxKlineLimit bounds one response, older candles are loaded with the cursor
+	MaxKlineLimit = 10000
+)
+
+// FetchKlines returns up to query.Limit candles of the range, oldest first, paging through the
+// per request cap of the exchange. Pages go forward from StartTime when it is set and backward
+// from EndTime, or now, otherwise.
+func FetchKlines(marketData provider.MarketDataProvider, query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	if query.Limit <= 0 {
+		query.Limit = DefaultKlineLimit
+	}
+	if query.StartTime > 0 {
+		return fetchKlinesForward(marketData, query)
+	}
+	return fetchKlinesBackward(marketData, query)
+}
+
+func fetchKlinesForward(marketData provider.MarketDataProvider, query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	var candles []models.Candle
+	page := query
+	for len(candles) < query.Limit {
+		page.Limit = min(query.Limit-len(candles), provider.BinanceMaxKlines)
+		result, statusCode, err := marketData.Klines(page)
+		if err != nil {
+			return nil, statusCode, err
+		}
+
+		// keep only candles after the previous page, so a page repeating itself ends the loop
+		fresh := result[:0]
+		for _, candle := range result {
+			if (len(candles) == 0 || candle.OpenTime > candles[len(candles)-1].OpenTime) &&
+				(query.EndTime == 0 || candle.OpenTime <= query.EndTime) {
+				fresh = append(fresh, candle)
+			}
+		}
+		if len(fresh) == 0 {
+			break
+		}
+		candles = append(candles, fresh...)
+
+		last := candles[len(candles)-1]
+		closeTime := provider.CandleCloseTime(last.OpenTime, query.Interval)
+		if (query.EndTime > 0 && closeTime >= query.EndTime) || closeTime >= time.Now().UnixMilli() {
+			break
+		}
+		page.StartTime = last.OpenTime + 1
+	}
+
+	if len(candles) > query.Limit {
+		candles = candles[:query.Limit]
+	}
+	return candles, http.StatusOK, nil
+}
+
+func fetchKlinesBackward(marketData provider.MarketDataProvider, query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	var candles []models.Candle
+	page := query
+	for len(candles) < query.Limit {
+		page.Limit = min(query.Limit-len(candles), provider.BinanceMaxKlines)
+		result, statusCode, err := marketData.Klines(page)
+		if err != nil {
+			return nil, statusCode, err
+		}
+
+		// keep only candles before the previous page, so a page repeating itself ends the loop
+		older := make([]models.Candle, 0, len(result))
+		for _, candle := range result {
+			if len(candles) == 0 || candle.OpenTime < candles[0].OpenTime {
+				older = append(older, candle)
+			}
+		}
+		if len(older) == 0 {
+			break
+		}
+		candles = append(older, candles...)
+		page.EndTime = candles[0].OpenTime - 1
+	}
+
+	if len(candles) > query.Limit {
+		candles = candles[len(candles)-query.Limit:]
+	}
+	return candles, http.StatusOK, nil
+}
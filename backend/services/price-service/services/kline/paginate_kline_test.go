@@ -0,0 +1,111 @@
+package kline
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+const hour = int64(time.Hour / time.Millisecond)
+
+// newHourlyExchange serves count hourly candles of BTCUSDT from first, at most pageSize per request
+func newHourlyExchange(first int64, count, pageSize int) *providertest.FakeExchange {
+	return &providertest.FakeExchange{
+		Candles:  map[string][]models.Candle{"BTCUSDT": providertest.HourlyCandles(first, count)},
+		PageSize: pageSize,
+	}
+}
+
+func TestFetchKlines(t *testing.T) {
+	first := int64(1700000000000) / hour * hour
+
+	tests := []struct {
+		name          string
+		query         models.KlineQuery
+		pageSize      int
+		expectedFirst int64
+		expectedCount int
+		expectedPages int
+	}{
+		{
+			name:          "Latest candles in one page",
+			query:         models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", Limit: 100},
+			pageSize:      1500,
+			expectedFirst: first + 3900*hour,
+			expectedCount: 100,
+			expectedPages: 1,
+		},
+		{
+			name:          "Default limit",
+			query:         models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h"},
+			pageSize:      1500,
+			expectedFirst: first + 3500*hour,
+			expectedCount: DefaultKlineLimit,
+			expectedPages: 1,
+		},
+		{
+			name:          "Backward over the Binance cap",
+			query:         models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", Limit: 3500},
+			pageSize:      1500,
+			expectedFirst: first + 500*hour,
+			expectedCount: 3500,
+			expectedPages: 3,
+		},
+		{
+			name:          "Backward over a smaller exchange cap",
+			query:         models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", Limit: 700},
+			pageSize:      300,
+			expectedFirst: first + 3300*hour,
+			expectedCount: 700,
+			expectedPages: 3,
+		},
+		{
+			name:          "Backward until the oldest candle",
+			query:         models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", EndTime: first + 999*hour, Limit: 2000},
+			pageSize:      1500,
+			expectedFirst: first,
+			expectedCount: 1000,
+			expectedPages: 2,
+		},
+		{
+			name:          "Forward range",
+			query:         models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", StartTime: first + 10*hour, EndTime: first + 2009*hour, Limit: MaxKlineLimit},
+			pageSize:      1500,
+			expectedFirst: first + 10*hour,
+			expectedCount: 2000,
+			expectedPages: 2,
+		},
+		{
+			name:          "Forward until the latest candle",
+			query:         models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", StartTime: first + 3800*hour, Limit: 1000},
+			pageSize:      150,
+			expectedFirst: first + 3800*hour,
+			expectedCount: 200,
+			expectedPages: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := newHourlyExchange(first, 4000, tt.pageSize)
+
+			candles, statusCode, err := FetchKlines(exchange, tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+			assert.Len(t, candles, tt.expectedCount)
+			assert.Equal(t, tt.expectedFirst, candles[0].OpenTime)
+			assert.Len(t, exchange.Queries(), tt.expectedPages)
+			for i := 1; i < len(candles); i++ {
+				assert.Equal(t, candles[i-1].OpenTime+hour, candles[i].OpenTime)
+			}
+			for _, query := range exchange.Queries() {
+				assert.LessOrEqual(t, query.Limit, provider.BinanceMaxKlines)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package kline
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// the alpine image has no zoneinfo
+	_ "time/tzdata"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+)
+
+var (
+	errInvalidInterval = errors.New("Invalid interval")
+	errInvalidTimezone = errors.New("Invalid timezone")
+
+	intervalPattern = regexp.MustCompile(`^([1-9][0-9]{0,3})(m|h|d|w|M)$`)
+	offsetPattern   = regexp.MustCompile(`^(?:UTC|GMT)?([+-])([0-9]{1,2})(?::?([0-9]{2}))?$`)
+)
+
+// baseIntervals are the Binance intervals a custom interval is built from, largest first
+var baseIntervals = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1d", 24 * time.Hour},
+	{"12h", 12 * time.Hour},
+	{"8h", 8 * time.Hour},
+	{"6h", 6 * time.Hour},
+	{"4h", 4 * time.Hour},
+	{"2h", 2 * time.Hour},
+	{"1h", time.Hour},
+	{"30m", 30 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"5m", 5 * time.Minute},
+	{"3m", 3 * time.Minute},
+	{"1m", time.Minute},
+}
+
+// Resampler builds candles of any count of minutes, hours, days, weeks or months from a Binance
+// interval. Minute and hour candles are aligned to the unix epoch in Location, day, week and month
+// candles open at midnight in Location, weeks on Monday.
+type Resampler struct {
+	Interval string
+	Location *time.Location
+	// Base is the Binance interval the candles are built from, Interval when no resampling is needed
+	Base string
+
+	count int
+	unit  string
+	// baseDuration is the length of a Base candle, 0 for 1M
+	baseDuration time.Duration
+}
+
+// NewResampler returns the resampler of an interval such as 10m, 2h or 1w in a timezone such as
+// UTC+7, +05:30 or Asia/Ho_Chi_Minh. An empty timezone is UTC.
+func NewResampler(interval, timezone string) (*Resampler, error) {
+	match := intervalPattern.FindStringSubmatch(interval)
+	if match == nil {
+		return nil, errInvalidInterval
+	}
+	location, err := ParseTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	count, _ := strconv.Atoi(match[1])
+	r := &Resampler{Interval: interval, Location: location, count: count, unit: match[2]}
+	offsets := zoneOffsets(location)
+
+	// a Binance interval keeps its own candles when they open on the same boundaries
+	if provider.IsValidInterval(interval) && r.alignedWithUTC(offsets) {
+		r.Base = interval
+		return r, nil
+	}
+
+	// the base candles must not straddle a boundary, so their length divides the
+	// length of the interval, or of a day, and every offset of the timezone
+	step := r.fixedDuration()
+	if step == 0 {
+		step = 24 * time.Hour
+	}
+	for _, offset := range offsets {
+		step = gcd(step, offset)
+	}
+	for _, base := range baseIntervals {
+		if step%base.duration == 0 {
+			r.Base, r.baseDuration = base.name, base.duration
+			break
+		}
+	}
+	if r.Base == "" {
+		return nil, errInvalidTimezone
+	}
+	if r.perBucket() > MaxKlineLimit {
+		return nil, errInvalidInterval
+	}
+	return r, nil
+}
+
+// ParseTimezone accepts an IANA name, UTC, or an offset such as UTC+7, UTC-03:30 or +05:30
+func ParseTimezone(timezone string) (*time.Location, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" || strings.EqualFold(timezone, "UTC") {
+		return time.UTC, nil
+	}
+	if match := offsetPattern.FindStringSubmatch(strings.ToUpper(timezone)); match != nil {
+		hours, _ := strconv.Atoi(match[2])
+		minutes, _ := strconv.Atoi(match[3])
+		if hours > 14 || minutes >= 60 {
+			return nil, errInvalidTimezone
+		}
+		offset := hours*3600 + minutes*60
+		if match[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(timezone, offset), nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errInvalidTimezone
+	}
+	return location, nil
+}
+
+// Native tells whether the interval is served by the exchange as is
+func (r *Resampler) Native() bool {
+	return r.Base == r.Interval
+}
+
+// PeriodsPerYear returns how many candles of the interval make a year of 365 days, markets trading
+// around the clock
+func (r *Resampler) PeriodsPerYear() float64 {
+	var perYear float64
+	switch r.unit {
+	case "m":
+		perYear = 365 * 24 * 60
+	case "h":
+		perYear = 365 * 24
+	case "d":
+		perYear = 365
+	case "w":
+		perYear = 365.0 / 7
+	case "M":
+		perYear = 12
+	}
+	return perYear / float64(r.count)
+}
+
+// Resample aggregates base candles, oldest first, into candles of the interval
+func (r *Resampler) Resample(candles []models.Candle) []models.Candle {
+	var result []models.Candle
+	for _, candle := range candles {
+		openTime := r.bucketStart(candle.OpenTime)
+		if n := len(result); n > 0 && result[n-1].OpenTime == openTime {
+			last := &result[n-1]
+			last.High = max(last.High, candle.High)
+			last.Low = min(last.Low, candle.Low)
+			last.Close = candle.Close
+			last.Volume += candle.Volume
+			continue
+		}
+		result = append(result, models.Candle{
+			OpenTime:  openTime,
+			CloseTime: r.bucketEnd(openTime) - 1,
+			Open:      candle.Open,
+			High:      candle.High,
+			Low:       candle.Low,
+			Close:     candle.Close,
+			Volume:    candle.Volume,
+		})
+	}
+	return result
+}
+
+// FetchResampledKlines returns the candles of query in the interval of r, built from base candles.
+// A response is limited to the candles MaxKlineLimit base candles make, the cursor loads the rest.
+func FetchResampledKlines(marketData provider.MarketDataProvider, query models.KlineQuery, r *Resampler) ([]models.Candle, models.StatusCode, error) {
+	if query.Limit <= 0 {
+		query.Limit = DefaultKlineLimit
+	}
+
+	baseQuery := models.KlineQuery{
+		Symbol:   query.Symbol,
+		Interval: r.Base,
+		Market:   query.Market,
+		Limit:    min((query.Limit+1)*r.perBucket(), MaxKlineLimit),
+	}
+	if query.StartTime > 0 {
+		// the first candle opens at or after startTime
+		baseQuery.StartTime = r.bucketStart(query.StartTime)
+		if baseQuery.StartTime < query.StartTime {
+			baseQuery.StartTime = r.bucketEnd(baseQuery.StartTime)
+		}
+	}
+	if query.EndTime > 0 {
+		// the last candle is the one open at endTime, whole
+		baseQuery.EndTime = r.bucketEnd(r.bucketStart(query.EndTime)) - 1
+	}
+
+	base, statusCode, err := fetchKlines(marketData, baseQuery)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	candles := r.Resample(base)
+
+	// when the base limit is reached the candle at the cut is missing base candles
+	if len(base) == baseQuery.Limit && len(candles) > 0 {
+		if query.StartTime > 0 {
+			last := base[len(base)-1]
+			if provider.CandleCloseTime(last.OpenTime, r.Base) != candles[len(candles)-1].CloseTime {
+				candles = candles[:len(candles)-1]
+			}
+		} else if base[0].OpenTime != candles[0].OpenTime {
+			candles = candles[1:]
+		}
+	}
+
+	if len(candles) > query.Limit {
+		if query.StartTime > 0 {
+			candles = candles[:query.Limit]
+		} else {
+			candles = candles[len(candles)-query.Limit:]
+		}
+	}
+	return candles, http.StatusOK, nil
+}
+
+// alignedWithUTC tells whether the candles open on the same boundaries in UTC and at the offsets
+func (r *Resampler) alignedWithUTC(offsets []time.Duration) bool {
+	for _, offset := range offsets {
+		if fixed := r.fixedDuration(); fixed > 0 && offset%fixed != 0 || fixed == 0 && offset != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// fixedDuration returns the length of a minute or hour candle, 0 for days, weeks and months
+func (r *Resampler) fixedDuration() time.Duration {
+	switch r.unit {
+	case "m":
+		return time.Duration(r.count) * time.Minute
+	case "h":
+		return time.Duration(r.count) * time.Hour
+	}
+	return 0
+}
+
+// perBucket returns the most base candles one candle of the interval is made of
+func (r *Resampler) perBucket() int {
+	if r.baseDuration == 0 {
+		return 1
+	}
+	var longest time.Duration
+	switch r.unit {
+	case "m", "h":
+		longest = r.fixedDuration()
+	case "d":
+		// a day is 25 hours when daylight saving time ends
+		longest = time.Duration(r.count) * 25 * time.Hour
+	case "w":
+		longest = time.Duration(r.count) * (7*24 + 1) * time.Hour
+	case "M":
+		longest = time.Duration(r.count) * (31*24 + 1) * time.Hour
+	}
+	return int((longest + r.baseDuration - 1) / r.baseDuration)
+}
+
+// bucketStart returns the open time of the candle holding the unix milliseconds ms
+func (r *Resampler) bucketStart(ms int64) int64 {
+	t := time.UnixMilli(ms).In(r.Location)
+	switch r.unit {
+	case "m", "h":
+		_, offset := t.Zone()
+		shift := int64(offset) * 1000
+		d := r.fixedDuration().Milliseconds()
+		return floorDiv(ms+shift, d)*d - shift
+	case "d":
+		days := localDays(t)
+		return r.localMidnight(days - mod(days, int64(r.count)))
+	case "w":
+		// 1970-01-05 is the first Monday of the epoch
+		days := localDays(t) - 4
+		return r.localMidnight(days - mod(days, int64(7*r.count)) + 4)
+	default:
+		months := int64(t.Year()-1970)*12 + int64(t.Month()) - 1
+		months -= mod(months, int64(r.count))
+		return time.Date(1970, time.January+time.Month(months), 1, 0, 0, 0, 0, r.Location).UnixMilli()
+	}
+}
+
+// bucketEnd returns the open time of the candle after the one opened at openTime
+func (r *Resampler) bucketEnd(openTime int64) int64 {
+	t := time.UnixMilli(openTime).In(r.Location)
+	year, month, day := t.Date()
+	switch r.unit {
+	case "m", "h":
+		return openTime + r.fixedDuration().Milliseconds()
+	case "d":
+		return time.Date(year, month, day+r.count, 0, 0, 0, 0, r.Location).UnixMilli()
+	case "w":
+		return time.Date(year, month, day+7*r.count, 0, 0, 0, 0, r.Location).UnixMilli()
+	default:
+		return time.Date(year, month+time.Month(r.count), 1, 0, 0, 0, 0, r.Location).UnixMilli()
+	}
+}
+
+func (r *Resampler) localMidnight(days int64) int64 {
+	return time.Date(1970, time.January, 1+int(days), 0, 0, 0, 0, r.Location).UnixMilli()
+}
+
+// localDays returns the days from 1970-01-01 to the local date of t
+func localDays(t time.Time) int64 {
+	year, month, day := t.Date()
+	return floorDiv(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix(), 24*3600)
+}
+
+// zoneOffsets returns the UTC offsets of a location in winter and in summer
+func zoneOffsets(location *time.Location) []time.Duration {
+	year := time.Now().Year()
+	var offsets []time.Duration
+	for _, month := range []time.Month{time.January, time.July} {
+		_, offset := time.Date(year, month, 1, 0, 0, 0, 0, location).Zone()
+		offsets = append(offsets, time.Duration(offset)*time.Second)
+	}
+	return offsets
+}
+
+func gcd(a, b time.Duration) time.Duration {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func floorDiv(a, b int64) int64 {
+	return (a - mod(a, b)) / b
+}
+
+func mod(a, b int64) int64 {
+	return (a%b + b) % b
+}
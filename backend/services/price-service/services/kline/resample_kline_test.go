@@ -0,0 +1,164 @@
+package kline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResampler(t *testing.T) {
+	tests := []struct {
+		name         string
+		interval     string
+		timezone     string
+		expectedBase string
+		expectedErr  error
+	}{
+		{"Binance interval", "1h", "", "1h", nil},
+		{"Hours in a whole hour timezone", "1h", "UTC+7", "1h", nil},
+		{"Binance week", "1w", "UTC", "1w", nil},
+		{"Minutes", "10m", "", "5m", nil},
+		{"Quarter hours", "45m", "", "15m", nil},
+		{"Two days", "2d", "", "1d", nil},
+		{"Two months", "2M", "", "1d", nil},
+		{"Day in UTC+7", "1d", "UTC+7", "1h", nil},
+		{"Week in UTC+7", "1w", "+07:00", "1h", nil},
+		{"Three days in an IANA timezone", "3d", "Asia/Ho_Chi_Minh", "1h", nil},
+		{"Half hour timezone", "2h", "UTC+5:30", "30m", nil},
+		{"Daylight saving time", "1d", "America/New_York", "1h", nil},
+		{"Seconds", "7s", "", "", errInvalidInterval},
+		{"Zero count", "0m", "", "", errInvalidInterval},
+		{"Too long", "9999d", "", "", errInvalidInterval},
+		{"Offset out of range", "1d", "UTC+15", "", errInvalidTimezone},
+		{"Unknown timezone", "1d", "Mars/Olympus", "", errInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resampler, err := NewResampler(tt.interval, tt.timezone)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, tt.expectedBase, resampler.Base)
+			}
+		})
+	}
+}
+
+func TestResamplerBuckets(t *testing.T) {
+	at := func(value string) int64 {
+		parsed, err := time.Parse(time.RFC3339, value)
+		assert.NoError(t, err)
+		return parsed.UnixMilli()
+	}
+
+	tests := []struct {
+		name          string
+		interval      string
+		timezone      string
+		time          string
+		expectedStart string
+		expectedEnd   string
+	}{
+		{"Ten minutes", "10m", "", "2024-11-20T12:34:56Z", "2024-11-20T12:30:00Z", "2024-11-20T12:40:00Z"},
+		{"Week from Monday in UTC+7", "1w", "UTC+7", "2024-11-20T10:00:00Z", "2024-11-17T17:00:00Z", "2024-11-24T17:00:00Z"},
+		{"Monday morning in UTC+7", "1w", "UTC+7", "2024-11-24T18:00:00Z", "2024-11-24T17:00:00Z", "2024-12-01T17:00:00Z"},
+		{"Day in UTC+7", "1d", "UTC+7", "2024-11-20T16:59:59Z", "2024-11-19T17:00:00Z", "2024-11-20T17:00:00Z"},
+		{"Day ending daylight saving time", "1d", "America/New_York", "2024-11-03T12:00:00Z", "2024-11-03T04:00:00Z", "2024-11-04T05:00:00Z"},
+		{"Three days", "3d", "", "2024-11-20T10:00:00Z", "2024-11-19T00:00:00Z", "2024-11-22T00:00:00Z"},
+		{"Month in UTC+7", "1M", "UTC+7", "2024-11-30T18:00:00Z", "2024-11-30T17:00:00Z", "2024-12-31T17:00:00Z"},
+		{"Quarter", "3M", "", "2024-11-20T10:00:00Z", "2024-10-01T00:00:00Z", "2025-01-01T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resampler, err := NewResampler(tt.interval, tt.timezone)
+			assert.NoError(t, err)
+			start := resampler.bucketStart(at(tt.time))
+			assert.Equal(t, at(tt.expectedStart), start)
+			assert.Equal(t, at(tt.expectedEnd), resampler.bucketEnd(start))
+		})
+	}
+}
+
+func TestResample(t *testing.T) {
+	resampler, err := NewResampler("10m", "")
+	assert.NoError(t, err)
+
+	minute := int64(time.Minute / time.Millisecond)
+	base := []models.Candle{
+		{OpenTime: 0, Open: 100, High: 110, Low: 95, Close: 105, Volume: 1},
+		{OpenTime: 5 * minute, Open: 105, High: 120, Low: 100, Close: 115, Volume: 2},
+		{OpenTime: 10 * minute, Open: 115, High: 118, Low: 90, Close: 92, Volume: 3},
+	}
+
+	assert.Equal(t, []models.Candle{
+		{OpenTime: 0, CloseTime: 10*minute - 1, Open: 100, High: 120, Low: 95, Close: 115, Volume: 3},
+		{OpenTime: 10 * minute, CloseTime: 20*minute - 1, Open: 115, High: 118, Low: 90, Close: 92, Volume: 3},
+	}, resampler.Resample(base))
+}
+
+func TestPeriodsPerYear(t *testing.T) {
+	tests := []struct {
+		interval string
+		expected float64
+	}{
+		{interval: "1m", expected: 525600},
+		{interval: "10m", expected: 52560},
+		{interval: "4h", expected: 2190},
+		{interval: "1d", expected: 365},
+		{interval: "2w", expected: 365.0 / 14},
+		{interval: "3M", expected: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.interval, func(t *testing.T) {
+			resampler, err := NewResampler(tt.interval, "")
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, resampler.PeriodsPerYear(), 1e-9)
+		})
+	}
+}
+
+func TestFetchResampledKlines(t *testing.T) {
+	first := int64(1700000000000) / hour * hour
+	resampler, err := NewResampler("4h", "UTC+7")
+	assert.NoError(t, err)
+	offset := 7 * hour
+
+	t.Run("Latest candles", func(t *testing.T) {
+		exchange := newHourlyExchange(first, 4000, 1500)
+		candles, _, err := FetchResampledKlines(exchange, models.KlineQuery{Symbol: "BTCUSDT", Interval: "4h", EndTime: first + 3999*hour, Limit: 5}, resampler)
+		assert.NoError(t, err)
+		assert.Len(t, candles, 5)
+		for _, candle := range candles[:4] {
+			assert.Equal(t, int64(0), (candle.OpenTime+offset)%(4*hour))
+			assert.Equal(t, 4.0, candle.Volume)
+		}
+		assert.Equal(t, "1h", exchange.Queries()[0].Interval)
+	})
+
+	t.Run("From an unaligned start time", func(t *testing.T) {
+		exchange := newHourlyExchange(first, 4000, 1500)
+		startTime := first + 10*hour + 1
+		candles, _, err := FetchResampledKlines(exchange, models.KlineQuery{Symbol: "BTCUSDT", Interval: "4h", StartTime: startTime, Limit: 3}, resampler)
+		assert.NoError(t, err)
+		assert.Len(t, candles, 3)
+		assert.GreaterOrEqual(t, candles[0].OpenTime, startTime)
+		assert.Less(t, candles[0].OpenTime, startTime+4*hour)
+		for _, candle := range candles {
+			assert.Equal(t, 4.0, candle.Volume)
+		}
+	})
+
+	t.Run("Cut by the base limit", func(t *testing.T) {
+		exchange := newHourlyExchange(first, 20000, 1500)
+		candles, _, err := FetchResampledKlines(exchange, models.KlineQuery{Symbol: "BTCUSDT", Interval: "4h", EndTime: first + 19999*hour, Limit: 4000}, resampler)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, candles)
+		assert.LessOrEqual(t, len(candles), MaxKlineLimit/4)
+		// the candle at the cut would miss base candles, so it is left to the next page
+		assert.Equal(t, 4.0, candles[0].Volume)
+	})
+}
@@ -0,0 +1,148 @@
+package kline
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/repository"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+)
+
+// storeTimeout bounds every read and write of the candle store
+const storeTimeout = 5 * time.Second
+
+var (
+	candleStore      repository.CandleRepository
+	candleStoreMutex sync.RWMutex
+)
+
+// SetCandleStore makes kline requests read closed candles from store first, nil turns the store off
+func SetCandleStore(store repository.CandleRepository) {
+	candleStoreMutex.Lock()
+	defer candleStoreMutex.Unlock()
+	candleStore = store
+}
+
+func getCandleStore() repository.CandleRepository {
+	candleStoreMutex.RLock()
+	defer candleStoreMutex.RUnlock()
+	return candleStore
+}
+
+// CandleKeyOf returns the store key of the candles of a provider on a market, futures when empty
+func CandleKeyOf(marketData provider.MarketDataProvider, market, symbol, interval string) models.CandleKey {
+	if market == "" {
+		market = provider.MarketFutures
+	}
+	return models.CandleKey{Exchange: marketData.Name(), Market: market, Symbol: symbol, Interval: interval}
+}
+
+// FetchStoredKlines returns the same candles as FetchKlines, reading the closed ones from store and
+// fetching only the missing ranges upstream. The closed candles fetched upstream are stored.
+// The store is skipped, with a log, when it cannot be read.
+func FetchStoredKlines(store repository.CandleRepository, marketData provider.MarketDataProvider, query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	if query.Limit <= 0 {
+		query.Limit = DefaultKlineLimit
+	}
+	now := time.Now().UnixMilli()
+	startTime, endTime := storeWindow(query, now)
+	if startTime > endTime {
+		return FetchKlines(marketData, query)
+	}
+
+	key := CandleKeyOf(marketData, query.Market, query.Symbol, query.Interval)
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	stored, err := store.FindCandles(ctx, key, startTime, endTime)
+	if err != nil {
+		log.Println("Candle store error: ", err)
+		return FetchKlines(marketData, query)
+	}
+
+	candles := stored
+	var closed []models.Candle
+	for _, gap := range candleGaps(stored, query.Interval, startTime, endTime, now) {
+		fetched, statusCode, err := FetchKlines(marketData, models.KlineQuery{
+			Symbol:    query.Symbol,
+			Interval:  query.Interval,
+			Market:    query.Market,
+			StartTime: gap[0],
+			EndTime:   gap[1],
+			Limit:     MaxKlineLimit,
+		})
+		if err != nil {
+			return nil, statusCode, err
+		}
+		for _, candle := range fetched {
+			if candle.OpenTime < gap[0] || candle.OpenTime > gap[1] {
+				continue
+			}
+			candles = append(candles, candle)
+			if provider.CandleCloseTime(candle.OpenTime, query.Interval) < now {
+				closed = append(closed, candle)
+			}
+		}
+	}
+	if len(closed) > 0 {
+		if err := store.SaveCandles(ctx, key, closed); err != nil {
+			log.Println("Candle store error: ", err)
+		}
+	}
+
+	sort.Slice(candles, func(i, j int) bool { return candles[i].OpenTime < candles[j].OpenTime })
+	if len(candles) > query.Limit {
+		if query.StartTime > 0 {
+			candles = candles[:query.Limit]
+		} else {
+			candles = candles[len(candles)-query.Limit:]
+		}
+	}
+	return candles, http.StatusOK, nil
+}
+
+// storeWindow returns the open times of the first and the last candle query can return
+func storeWindow(query models.KlineQuery, now int64) (startTime, endTime int64) {
+	if query.StartTime > 0 {
+		startTime = query.StartTime
+		endTime = provider.RangeEnd(startTime, query.Interval, query.Limit)
+		if query.EndTime > 0 && query.EndTime < endTime {
+			endTime = query.EndTime
+		}
+	} else {
+		endTime = query.EndTime
+		if endTime == 0 || endTime > now {
+			endTime = now
+		}
+		startTime = provider.RangeStart(endTime, query.Interval, query.Limit)
+	}
+	return startTime, min(endTime, now)
+}
+
+// candleGaps returns the ranges of the window not covered by the stored candles. A gap shorter than
+// one candle cannot hold one and is skipped, unless it reaches now where the open candle is.
+// Candles an exchange never had, e.g. during maintenance, are asked for again on every request.
+func candleGaps(stored []models.Candle, interval string, startTime, endTime, now int64) [][2]int64 {
+	var gaps [][2]int64
+	add := func(from, to int64) {
+		if from > to {
+			return
+		}
+		if provider.CandleCloseTime(from, interval) > to && to < now {
+			return
+		}
+		gaps = append(gaps, [2]int64{from, to})
+	}
+
+	next := startTime
+	for _, candle := range stored {
+		add(next, candle.OpenTime-1)
+		next = provider.CandleCloseTime(candle.OpenTime, interval) + 1
+	}
+	add(next, endTime)
+	return gaps
+}
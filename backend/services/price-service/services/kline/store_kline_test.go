@@ -0,0 +1,81 @@
+package kline
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/repository"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRecentExchange serves hourly candles up to the one open now, whose open time it returns
+func newRecentExchange() (*providertest.FakeExchange, int64) {
+	current := time.Now().UnixMilli() / hour * hour
+	return newHourlyExchange(current-3999*hour, 4000, 1500), current
+}
+
+func TestFetchStoredKlines(t *testing.T) {
+	t.Run("Stores closed candles and reads them back", func(t *testing.T) {
+		exchange, last := newRecentExchange()
+		store := repository.NewMockCandleRepository()
+		query := models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", Limit: 100}
+		key := CandleKeyOf(exchange, "", "BTCUSDT", "1h")
+
+		candles, statusCode, err := FetchStoredKlines(store, exchange, query)
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+		assert.Len(t, candles, 100)
+		assert.Equal(t, last, candles[99].OpenTime)
+		// the open candle is not stored
+		assert.Len(t, store.Candles[key], 99)
+		assert.NotContains(t, store.Candles[key], last)
+
+		// only the open candle is fetched again
+		exchange.ResetQueries()
+		again, _, err := FetchStoredKlines(store, exchange, query)
+		assert.NoError(t, err)
+		assert.Equal(t, candles, again)
+		assert.Len(t, exchange.Queries(), 1)
+		assert.Equal(t, last, exchange.Queries()[0].StartTime)
+	})
+
+	t.Run("Fetches only the missing range", func(t *testing.T) {
+		exchange, last := newRecentExchange()
+		store := repository.NewMockCandleRepository()
+		startTime := last - 3899*hour
+		query := models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", StartTime: startTime, EndTime: startTime + 49*hour, Limit: 50}
+		key := CandleKeyOf(exchange, "", "BTCUSDT", "1h")
+
+		_, _, err := FetchStoredKlines(store, exchange, query)
+		assert.NoError(t, err)
+		assert.Len(t, store.Candles[key], 50)
+		delete(store.Candles[key], startTime+20*hour)
+		delete(store.Candles[key], startTime+21*hour)
+
+		exchange.ResetQueries()
+		candles, _, err := FetchStoredKlines(store, exchange, query)
+		assert.NoError(t, err)
+		assert.Len(t, candles, 50)
+		assert.Equal(t, startTime, candles[0].OpenTime)
+		assert.Len(t, exchange.Queries(), 1)
+		assert.Equal(t, startTime+20*hour, exchange.Queries()[0].StartTime)
+		assert.Equal(t, startTime+22*hour-1, exchange.Queries()[0].EndTime)
+		assert.Len(t, store.Candles[key], 50)
+	})
+
+	t.Run("Falls back to the exchange when the store fails", func(t *testing.T) {
+		exchange, last := newRecentExchange()
+		store := repository.NewMockCandleRepository()
+		store.Err = errors.New("connection refused")
+
+		candles, statusCode, err := FetchStoredKlines(store, exchange, models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+		assert.Len(t, candles, 10)
+		assert.Equal(t, last, candles[9].OpenTime)
+	})
+}
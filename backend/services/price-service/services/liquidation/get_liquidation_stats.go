@@ -0,0 +1,104 @@
+package liquidation
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultStatsLimit and MaxStatsLimit bound the number of symbols of the stats
+	DefaultStatsLimit = 20
+	MaxStatsLimit     = 200
+)
+
+// @Summary Get liquidation stats
+// @Description Sums the notional and count of the liquidated long and short positions of Binance futures over the last 1h, 4h and 24h, per symbol by 24h notional and for every symbol together. Since is the first recorded liquidation, windows reaching further back are incomplete.
+// @Tags Liquidation
+// @Produce json
+// @Param symbols query []string false "Trading pair symbols (e.g., BTCUSDT,ETHUSDT), every liquidated symbol when empty" collectionFormat(csv)
+// @Param limit query int false "Number of symbols, 20 by default and at most 200"
+// @Success 200 {object} models.ResponseLiquidationStats "Liquidations per window"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid parameters"
+// @Failure 503 {object} models.ErrorResponseDataInternalServerError "Liquidations are not recorded"
+// @Router /api/v1/liquidations/stats [get]
+func GetLiquidationStats(context *gin.Context) {
+	limit := DefaultStatsLimit
+	if value := context.Query("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxStatsLimit {
+			utils.ShowError(http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(MaxStatsLimit), context)
+			return
+		}
+	}
+
+	recorder := getRecorder()
+	if recorder == nil {
+		utils.ShowError(http.StatusServiceUnavailable, "Liquidations are not recorded", context)
+		return
+	}
+
+	response := Stats(recorder.Recent(models.LiquidationQuery{}), provider.ParseSymbols(context.QueryArray("symbols")), time.Now())
+	if len(response.Symbols) > limit {
+		response.Symbols = response.Symbols[:limit]
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// Stats sums the liquidations, oldest first, of the symbols, or of every symbol when empty, over every
+// window ending at now
+func Stats(liquidations []models.Liquidation, symbols []string, now time.Time) *models.ResponseLiquidationStats {
+	wanted := map[string]bool{}
+	for _, symbol := range symbols {
+		wanted[symbol] = true
+	}
+	newWindows := func() []models.LiquidationWindowStats {
+		windows := make([]models.LiquidationWindowStats, len(Windows))
+		for i, window := range Windows {
+			windows[i].Window = window.Name
+		}
+		return windows
+	}
+
+	response := &models.ResponseLiquidationStats{
+		Symbols:   []models.LiquidationSymbolStats{},
+		Total:     newWindows(),
+		EventTime: utils.ConvertMillisecondsToTimestamp(now.UnixMilli()),
+	}
+	if len(liquidations) > 0 {
+		response.Since = utils.ConvertMillisecondsToTimestamp(liquidations[0].Time)
+	}
+
+	index := map[string]int{}
+	for i := range liquidations {
+		liquidation := &liquidations[i]
+		if len(wanted) > 0 && !wanted[liquidation.Symbol] {
+			continue
+		}
+		position, ok := index[liquidation.Symbol]
+		if !ok {
+			position = len(response.Symbols)
+			index[liquidation.Symbol] = position
+			response.Symbols = append(response.Symbols, models.LiquidationSymbolStats{Symbol: liquidation.Symbol, Windows: newWindows()})
+		}
+		for w, window := range Windows {
+			if liquidation.Time >= now.Add(-window.Duration).UnixMilli() {
+				response.Symbols[position].Windows[w].Add(liquidation)
+				response.Total[w].Add(liquidation)
+			}
+		}
+	}
+
+	longest := len(Windows) - 1
+	sort.SliceStable(response.Symbols, func(i, j int) bool {
+		return response.Symbols[i].Windows[longest].TotalNotional > response.Symbols[j].Windows[longest].TotalNotional
+	})
+	return response
+}
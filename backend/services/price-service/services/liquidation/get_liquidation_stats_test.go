@@ -0,0 +1,89 @@
+package liquidation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	now := time.UnixMilli(1733900000000)
+	ago := func(duration time.Duration) int64 { return now.Add(-duration).UnixMilli() }
+	liquidations := []models.Liquidation{
+		{Symbol: "ETHUSDT", Side: models.PositionShort, Notional: 300000, Time: ago(20 * time.Hour)},
+		{Symbol: "BTCUSDT", Side: models.PositionLong, Notional: 50000, Time: ago(3 * time.Hour)},
+		{Symbol: "BTCUSDT", Side: models.PositionShort, Notional: 10000, Time: ago(30 * time.Minute)},
+		{Symbol: "BTCUSDT", Side: models.PositionLong, Notional: 30000, Time: ago(time.Minute)},
+	}
+
+	stats := Stats(liquidations, nil, now)
+	assert.Equal(t, "ETHUSDT", stats.Symbols[0].Symbol)
+	assert.Equal(t, "BTCUSDT", stats.Symbols[1].Symbol)
+	assert.Equal(t, []models.LiquidationWindowStats{
+		{Window: "1h", LongNotional: 30000, ShortNotional: 10000, TotalNotional: 40000, LongCount: 1, ShortCount: 1, LongShare: 0.75},
+		{Window: "4h", LongNotional: 80000, ShortNotional: 10000, TotalNotional: 90000, LongCount: 2, ShortCount: 1, LongShare: 0.8889},
+		{Window: "24h", LongNotional: 80000, ShortNotional: 10000, TotalNotional: 90000, LongCount: 2, ShortCount: 1, LongShare: 0.8889},
+	}, stats.Symbols[1].Windows)
+	assert.Equal(t, models.LiquidationWindowStats{Window: "24h", LongNotional: 80000, ShortNotional: 310000, TotalNotional: 390000, LongCount: 2, ShortCount: 2, LongShare: 0.2051}, stats.Total[2])
+	assert.Equal(t, time.UnixMilli(ago(20*time.Hour)).Format("2006-01-02 15:04:05"), stats.Since)
+
+	stats = Stats(liquidations, []string{"BTCUSDT"}, now)
+	assert.Len(t, stats.Symbols, 1)
+	assert.Equal(t, 90000.0, stats.Total[2].TotalNotional)
+}
+
+func TestGetLiquidationStats(t *testing.T) {
+	now := time.Now().UnixMilli()
+	recorder := &Recorder{liquidations: []models.Liquidation{
+		{Symbol: "BTCUSDT", Side: models.PositionLong, Notional: 50000, Time: now - 1000},
+		{Symbol: "ETHUSDT", Side: models.PositionShort, Notional: 20000, Time: now - 500},
+	}}
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	router := setupTestRouter()
+	router.GET("/liquidations/stats", GetLiquidationStats)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedSymbols []string
+		expectedMessage string
+	}{
+		{name: "every symbol by notional", query: "", expectedStatus: http.StatusOK, expectedSymbols: []string{"BTCUSDT", "ETHUSDT"}},
+		{name: "requested symbols", query: "symbols=ethusdt,SOLUSDT", expectedStatus: http.StatusOK, expectedSymbols: []string{"ETHUSDT"}},
+		{name: "top symbols", query: "limit=1", expectedStatus: http.StatusOK, expectedSymbols: []string{"BTCUSDT"}},
+		{name: "invalid limit", query: "limit=0", expectedStatus: http.StatusBadRequest, expectedMessage: "limit must be between 1 and 200"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/liquidations/stats?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMessage, response["message"])
+				return
+			}
+
+			var response models.ResponseLiquidationStats
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			symbols := make([]string, 0, len(response.Symbols))
+			for _, stats := range response.Symbols {
+				symbols = append(symbols, stats.Symbol)
+			}
+			assert.Equal(t, tt.expectedSymbols, symbols)
+			assert.Len(t, response.Total, len(Windows))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package liquidation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultLimit and MaxLimit bound the number of liquidations of one page
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// @Summary Get recorded liquidations
+// @Description Retrieves the recorded liquidations of Binance futures, oldest first, of every symbol or of one, with a notional of at least minNotional. Side is the side of the liquidated position.
+// @Tags Liquidation
+// @Produce json
+// @Param symbol query string false "Trading pair symbol (e.g., BTCUSDT), every symbol when empty" example("BTCUSDT")
+// @Param minNotional query number false "Minimum notional in the quote asset" example(10000)
+// @Param startTime query int false "Time in milliseconds of the first liquidation"
+// @Param endTime query int false "Time in milliseconds of the last liquidation, the cursor of a previous page loads older liquidations"
+// @Param limit query int false "Number of liquidations, the latest of the range are kept, 100 by default and at most 1000"
+// @Success 200 {object} models.ResponseLiquidations "Liquidations oldest first"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid parameters"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Failure 503 {object} models.ErrorResponseDataInternalServerError "Liquidations are not recorded"
+// @Router /api/v1/liquidations [get]
+func GetLiquidations(context *gin.Context) {
+	query := models.LiquidationQuery{Symbol: provider.NormalizeSymbol(context.Query("symbol")), Limit: DefaultLimit}
+
+	var ok bool
+	if query.MinNotional, ok = queryMinNotional(context); !ok {
+		return
+	}
+	if query.StartTime, ok = utils.QueryMilliseconds(context, "startTime"); !ok {
+		return
+	}
+	if query.EndTime, ok = utils.QueryMilliseconds(context, "endTime"); !ok {
+		return
+	}
+	if query.EndTime > 0 && query.StartTime > query.EndTime {
+		utils.ShowError(http.StatusBadRequest, "startTime must not be after endTime", context)
+		return
+	}
+	if value := context.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			utils.ShowError(http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(MaxLimit), context)
+			return
+		}
+		query.Limit = limit
+	}
+
+	recorder := getRecorder()
+	if recorder == nil {
+		utils.ShowError(http.StatusServiceUnavailable, "Liquidations are not recorded", context)
+		return
+	}
+	liquidations, err := recorder.History(context.Request.Context(), query)
+	if err != nil {
+		utils.ShowError(http.StatusInternalServerError, err.Error(), context)
+		return
+	}
+
+	response := models.ResponseLiquidations{
+		Liquidations: make([]models.ResponseLiquidation, len(liquidations)),
+		EventTime:    utils.GetTimeNow(),
+	}
+	for i := range liquidations {
+		response.Liquidations[i].UpdateData(&liquidations[i], utils.ConvertMillisecondsToTimestamp(liquidations[i].Time))
+	}
+	if len(liquidations) == query.Limit {
+		response.Cursor = liquidations[0].Time - 1
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// queryMinNotional reads the optional minNotional parameter, 0 when it is missing.
+// It answers 400 and returns false when the parameter is not a number of at least 0.
+func queryMinNotional(context *gin.Context) (float64, bool) {
+	if context.Query("minNotional") == "" {
+		return 0, true
+	}
+	minNotional, err := strconv.ParseFloat(context.Query("minNotional"), 64)
+	if err != nil || minNotional < 0 {
+		utils.ShowError(http.StatusBadRequest, "minNotional must be a number of at least 0", context)
+		return 0, false
+	}
+	return minNotional, true
+}
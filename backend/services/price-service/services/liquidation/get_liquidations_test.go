@@ -0,0 +1,95 @@
+package liquidation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// storedLiquidations are the liquidations of the mock store, oldest first
+var storedLiquidations = []models.Liquidation{
+	{Symbol: "BTCUSDT", Side: models.PositionLong, Price: 100000, Quantity: 0.05, Notional: 5000, Time: 1733900000000},
+	{Symbol: "ETHUSDT", Side: models.PositionShort, Price: 4000, Quantity: 10, Notional: 40000, Time: 1733900001000},
+	{Symbol: "BTCUSDT", Side: models.PositionShort, Price: 100100, Quantity: 1, Notional: 100100, Time: 1733900002000},
+}
+
+func setupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	return gin.New()
+}
+
+func TestGetLiquidations(t *testing.T) {
+	store := repository.NewMockLiquidationRepository()
+	store.Liquidations = storedLiquidations
+	SetRecorder(&Recorder{Store: store})
+	defer SetRecorder(nil)
+
+	router := setupTestRouter()
+	router.GET("/liquidations", GetLiquidations)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedTimes   []int64
+		expectedCursor  int64
+		expectedMessage string
+	}{
+		{name: "every symbol", query: "", expectedStatus: http.StatusOK, expectedTimes: []int64{1733900000000, 1733900001000, 1733900002000}},
+		{name: "one symbol", query: "symbol=btc-usdt", expectedStatus: http.StatusOK, expectedTimes: []int64{1733900000000, 1733900002000}},
+		{name: "minimum notional", query: "minNotional=10000", expectedStatus: http.StatusOK, expectedTimes: []int64{1733900001000, 1733900002000}},
+		{name: "latest page", query: "limit=1", expectedStatus: http.StatusOK, expectedTimes: []int64{1733900002000}, expectedCursor: 1733900001999},
+		{name: "older page", query: "endTime=1733900001999&limit=1", expectedStatus: http.StatusOK, expectedTimes: []int64{1733900001000}, expectedCursor: 1733900000999},
+		{name: "invalid minimum notional", query: "minNotional=-1", expectedStatus: http.StatusBadRequest, expectedMessage: "minNotional must be a number of at least 0"},
+		{name: "invalid limit", query: "limit=1001", expectedStatus: http.StatusBadRequest, expectedMessage: "limit must be between 1 and 1000"},
+		{name: "start after end", query: "startTime=2&endTime=1", expectedStatus: http.StatusBadRequest, expectedMessage: "startTime must not be after endTime"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/liquidations?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMessage, response["message"])
+				return
+			}
+
+			var response models.ResponseLiquidations
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCursor, response.Cursor)
+			assert.Len(t, response.Liquidations, len(tt.expectedTimes))
+			for i, liquidation := range response.Liquidations {
+				for _, stored := range storedLiquidations {
+					if stored.Time == tt.expectedTimes[i] {
+						assert.Equal(t, stored.Symbol, liquidation.Symbol)
+						assert.Equal(t, stored.Notional, liquidation.Notional)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGetLiquidationsNotRecorded(t *testing.T) {
+	SetRecorder(nil)
+	router := setupTestRouter()
+	router.GET("/liquidations", GetLiquidations)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/liquidations", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"message":"Liquidations are not recorded"}`, w.Body.String())
+}
@@ -0,0 +1,210 @@
+package liquidation
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/repository"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
+)
+
+const (
+	// storeTimeout bounds every read and write of the liquidation store
+	storeTimeout = 5 * time.Second
+	// storeQueueSize bounds the liquidations waiting for the store, more are dropped from the store only
+	storeQueueSize = 4096
+	// storeBatchSize bounds the liquidations saved at once
+	storeBatchSize = 500
+)
+
+// Window is a rolling window of the liquidation stats
+type Window struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Windows are the windows of the liquidation stats, the recorder keeps the liquidations of the longest in memory
+var Windows = []Window{
+	{Name: "1h", Duration: time.Hour},
+	{Name: "4h", Duration: 4 * time.Hour},
+	{Name: "24h", Duration: 24 * time.Hour},
+}
+
+// Recorder follows the liquidations of every Binance futures symbol. It keeps those of the longest
+// window in memory for the stats and saves every one to Store, from which it reloads them on start so
+// that a restart does not empty the windows. Binance streams at most one liquidation per symbol
+// every second, the latest, so the sums are a lower bound. The liquidations are saved in the background
+// so that a slow store does not hold up the stream.
+type Recorder struct {
+	Hub *websocket.Hub
+	// Store keeps the liquidations across restarts, nil keeps them in memory only
+	Store repository.LiquidationRepository
+
+	mutex        sync.RWMutex
+	liquidations []models.Liquidation
+	// saves queues the liquidations to save, nil without a store
+	saves chan models.Liquidation
+}
+
+var (
+	recorder      *Recorder
+	recorderMutex sync.RWMutex
+)
+
+// SetRecorder makes the liquidation requests read the recorder, nil turns them off
+func SetRecorder(r *Recorder) {
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+	recorder = r
+}
+
+func getRecorder() *Recorder {
+	recorderMutex.RLock()
+	defer recorderMutex.RUnlock()
+	return recorder
+}
+
+func NewRecorder(store repository.LiquidationRepository) *Recorder {
+	return &Recorder{Hub: websocket.DefaultHub, Store: store}
+}
+
+// Start reloads the liquidations of the longest window from the store and follows the stream in the
+// background until ctx is done
+func (r *Recorder) Start(ctx context.Context) {
+	if r.Store != nil {
+		storeCtx, cancel := context.WithTimeout(ctx, storeTimeout)
+		stored, err := r.Store.FindLiquidations(storeCtx, models.LiquidationQuery{StartTime: windowStart(time.Now())})
+		cancel()
+		if err != nil {
+			log.Println("Liquidation store error: ", err)
+		}
+		r.mutex.Lock()
+		r.liquidations = stored
+		r.mutex.Unlock()
+
+		r.saves = make(chan models.Liquidation, storeQueueSize)
+		go r.save(ctx)
+	}
+	go r.follow(ctx)
+}
+
+func (r *Recorder) follow(ctx context.Context) {
+	url := websocket.ForceOrderStreamURL()
+	subscription := r.Hub.Subscribe(url)
+	defer func() { subscription.Close() }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case message, ok := <-subscription.C:
+			if !ok {
+				// the hub dropped this subscriber, liquidations may have been missed meanwhile
+				subscription = r.Hub.Subscribe(url)
+				continue
+			}
+			if message.Status != "" {
+				continue
+			}
+
+			var event models.ForceOrderWebsocket
+			if err := json.Unmarshal(message.Data, &event); err != nil {
+				log.Println("JSON unmarshal error: ", err)
+				continue
+			}
+			r.record(event.Liquidation())
+		}
+	}
+}
+
+// record keeps a liquidation in memory, drops those older than the longest window and queues it for the store
+func (r *Recorder) record(liquidation models.Liquidation) {
+	r.mutex.Lock()
+	r.liquidations = append(r.liquidations, liquidation)
+	start := windowStart(time.Now())
+	expired := 0
+	for expired < len(r.liquidations) && r.liquidations[expired].Time < start {
+		expired++
+	}
+	// reslicing drops the expired liquidations without copying the others, append copies them only
+	// when it grows the array
+	r.liquidations = r.liquidations[expired:]
+	r.mutex.Unlock()
+
+	if r.saves != nil {
+		select {
+		case r.saves <- liquidation:
+		default:
+			log.Println("Liquidation store queue full, a liquidation is not saved")
+		}
+	}
+}
+
+// save writes the queued liquidations to the store until ctx is done, those queued during a write
+// in the next one
+func (r *Recorder) save(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case liquidation := <-r.saves:
+			storeCtx, cancel := context.WithTimeout(ctx, storeTimeout)
+			if err := r.Store.SaveLiquidations(storeCtx, r.queued(liquidation)); err != nil {
+				log.Println("Liquidation store error: ", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// queued returns first followed by the liquidations already queued, at most storeBatchSize
+func (r *Recorder) queued(first models.Liquidation) []models.Liquidation {
+	batch := []models.Liquidation{first}
+	for len(batch) < storeBatchSize {
+		select {
+		case liquidation := <-r.saves:
+			batch = append(batch, liquidation)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// Recent returns the liquidations of the longest window matching query, oldest first
+func (r *Recorder) Recent(query models.LiquidationQuery) []models.Liquidation {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var liquidations []models.Liquidation
+	for i := range r.liquidations {
+		if query.Matches(&r.liquidations[i]) {
+			liquidations = append(liquidations, r.liquidations[i])
+		}
+	}
+	if query.Limit > 0 && len(liquidations) > query.Limit {
+		liquidations = liquidations[len(liquidations)-query.Limit:]
+	}
+	return liquidations
+}
+
+// History returns the liquidations matching query from the store, or from memory without a store
+func (r *Recorder) History(ctx context.Context, query models.LiquidationQuery) ([]models.Liquidation, error) {
+	if r.Store == nil {
+		return r.Recent(query), nil
+	}
+	storeCtx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	return r.Store.FindLiquidations(storeCtx, query)
+}
+
+// windowStart returns the start of the longest window ending at now
+func windowStart(now time.Time) int64 {
+	return now.Add(-Windows[len(Windows)-1].Duration).UnixMilli()
+}
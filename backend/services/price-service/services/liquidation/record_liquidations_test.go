@@ -0,0 +1,148 @@
+package liquidation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/repository"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// forceOrder returns a forceOrder event of the all market stream, filled at price
+func forceOrder(symbol, side, price, quantity string, tradeTime int64) string {
+	return fmt.Sprintf(`{"e":"forceOrder","E":%d,"o":{"s":"%s","S":"%s","o":"LIMIT","f":"IOC","q":"%s","p":"%s","ap":"%s","X":"FILLED","l":"%s","z":"%s","T":%d}}`,
+		tradeTime+1, symbol, side, quantity, price, price, quantity, quantity, tradeTime)
+}
+
+// newMockForceOrderStream sends events to every client and keeps it open
+func newMockForceOrderStream(t *testing.T, events ...string) {
+	upgrader := gorilla.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ws/!forceOrder@arr", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, event := range events {
+			conn.WriteMessage(gorilla.TextMessage, []byte(event))
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("BINANCE_FUTURES_WS_URL", "ws"+strings.TrimPrefix(server.URL, "http"))
+}
+
+func TestRecorder(t *testing.T) {
+	now := time.Now().UnixMilli()
+	newMockForceOrderStream(t,
+		forceOrder("BTCUSDT", "SELL", "100000", "0.5", now-1000),
+		forceOrder("ETHUSDT", "BUY", "4000", "2.5", now-500),
+	)
+
+	store := repository.NewMockLiquidationRepository()
+	store.Liquidations = []models.Liquidation{
+		{Symbol: "BTCUSDT", Side: models.PositionShort, Price: 90000, Quantity: 1, Notional: 90000, Time: now - 25*time.Hour.Milliseconds()},
+		{Symbol: "BTCUSDT", Side: models.PositionLong, Price: 95000, Quantity: 1, Notional: 95000, Time: now - 2*time.Hour.Milliseconds()},
+	}
+	recorder := &Recorder{Hub: websocket.NewHub(), Store: store}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	recorder.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return len(recorder.Recent(models.LiquidationQuery{})) == 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// the liquidation older than the longest window is not reloaded
+	assert.Equal(t, []models.Liquidation{
+		{Symbol: "BTCUSDT", Side: models.PositionLong, Price: 95000, Quantity: 1, Notional: 95000, Time: now - 2*time.Hour.Milliseconds()},
+		{Symbol: "BTCUSDT", Side: models.PositionLong, Price: 100000, Quantity: 0.5, Notional: 50000, Time: now - 1000},
+		{Symbol: "ETHUSDT", Side: models.PositionShort, Price: 4000, Quantity: 2.5, Notional: 10000, Time: now - 500},
+	}, recorder.Recent(models.LiquidationQuery{}))
+	assert.Equal(t, []models.Liquidation{
+		{Symbol: "BTCUSDT", Side: models.PositionLong, Price: 100000, Quantity: 0.5, Notional: 50000, Time: now - 1000},
+	}, recorder.Recent(models.LiquidationQuery{MinNotional: 20000, StartTime: now - time.Hour.Milliseconds()}))
+
+	// every streamed liquidation is saved and the history reads the store
+	assert.Eventually(t, func() bool {
+		history, err := recorder.History(ctx, models.LiquidationQuery{})
+		return err == nil && len(history) == 4
+	}, 2*time.Second, 10*time.Millisecond)
+	history, err := recorder.History(ctx, models.LiquidationQuery{Symbol: "BTCUSDT", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{now - 2*time.Hour.Milliseconds(), now - 1000}, []int64{history[0].Time, history[1].Time})
+}
+
+func TestRecorderWithoutStore(t *testing.T) {
+	now := time.Now().UnixMilli()
+	newMockForceOrderStream(t, forceOrder("BTCUSDT", "BUY", "100000", "0.1", now))
+
+	recorder := &Recorder{Hub: websocket.NewHub()}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	recorder.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		history, err := recorder.History(ctx, models.LiquidationQuery{Symbol: "BTCUSDT"})
+		return err == nil && len(history) == 1 && history[0].Side == models.PositionShort
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// blockedStore holds every save until release is closed
+type blockedStore struct {
+	*repository.MockLiquidationRepository
+	saving  chan struct{}
+	release chan struct{}
+	batches chan int
+}
+
+func (s *blockedStore) SaveLiquidations(ctx context.Context, liquidations []models.Liquidation) error {
+	s.saving <- struct{}{}
+	<-s.release
+	err := s.MockLiquidationRepository.SaveLiquidations(ctx, liquidations)
+	s.batches <- len(liquidations)
+	return err
+}
+
+func TestRecorderSavesInBackground(t *testing.T) {
+	store := &blockedStore{MockLiquidationRepository: repository.NewMockLiquidationRepository(), saving: make(chan struct{}, 10), release: make(chan struct{}), batches: make(chan int, 10)}
+	recorder := &Recorder{Store: store, saves: make(chan models.Liquidation, storeQueueSize)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go recorder.save(ctx)
+
+	// the store does not hold up the recorder
+	now := time.Now().UnixMilli()
+	recorder.record(models.Liquidation{Symbol: "BTCUSDT", Time: now})
+	<-store.saving
+	for i := int64(1); i < 5; i++ {
+		recorder.record(models.Liquidation{Symbol: "BTCUSDT", Time: now + i})
+	}
+	assert.Len(t, recorder.Recent(models.LiquidationQuery{}), 5)
+
+	// the liquidations queued during the first save are saved together
+	close(store.release)
+	assert.Equal(t, 1, <-store.batches)
+	assert.Equal(t, 4, <-store.batches)
+	assert.Len(t, store.Liquidations, 5)
+}
+
+func TestRecorderDropsExpired(t *testing.T) {
+	now := time.Now()
+	recorder := &Recorder{liquidations: []models.Liquidation{{Time: windowStart(now) - 1000}, {Time: now.UnixMilli() - 1000}}}
+	recorder.record(models.Liquidation{Time: now.UnixMilli()})
+	assert.Equal(t, []models.Liquidation{{Time: now.UnixMilli() - 1000}, {Time: now.UnixMilli()}}, recorder.Recent(models.LiquidationQuery{}))
+}
@@ -0,0 +1,72 @@
+package marketcap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const DefaultCoinGeckoBaseURL = "https://api.coingecko.com/api/v3"
+
+// baseURL returns the CoinGecko API base URL, COINGECKO_BASE_URL when it is set
+func baseURL() string {
+	baseURL := os.Getenv("COINGECKO_BASE_URL")
+	if baseURL == "" {
+		baseURL = DefaultCoinGeckoBaseURL
+	}
+	return strings.TrimRight(baseURL, "/")
+}
+
+// getJSON decodes the CoinGecko answer of path into out and returns the status code of CoinGecko,
+// the status code is 0 when CoinGecko could not be reached
+func getJSON(path string, query url.Values, out interface{}) (int, error) {
+	req, err := http.NewRequest("GET", baseURL()+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// FetchMarketCap returns the market cap of a coin id such as bitcoin and the status code of CoinGecko,
+// the status code is 0 when CoinGecko could not be reached
+func FetchMarketCap(coinID string) (*models.FormatMarketCapResponse, int, error) {
+	q := url.Values{}
+	q.Add("localization", "false")
+	q.Add("tickers", "false")
+	q.Add("community_data", "false")
+
+	var marketCapResponse models.MarketCapResponse
+	statusCode, err := getJSON("/coins/"+url.PathEscape(strings.ToLower(coinID)), q, &marketCapResponse)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	response := models.CreateReponseFormat(
+		marketCapResponse.Symbol,
+		marketCapResponse.MarketData.MarketCap.USD,
+		marketCapResponse.MarketData.TotalVolume.USD,
+	)
+	response.ID = marketCapResponse.ID
+	response.Name = marketCapResponse.Name
+	response.LastUpdated = marketCapResponse.LastUpdated
+	return response, statusCode, nil
+}
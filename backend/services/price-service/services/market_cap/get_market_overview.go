@@ -0,0 +1,39 @@
+package marketcap
+
+import (
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get market overview
+// @Description Retrieves the total crypto market cap and 24h volume, the BTC and ETH dominance, the largest coins by market cap and the best and worst 24h performers among the 100 largest, from CoinGecko. The overview is cached and refreshed every 5 minutes by default.
+// @Tags Market Stats
+// @Produce json
+// @Param limit query int false "Length of each rank list, from 1 to 100 (default 10)" example(10)
+// @Success 200 {object} models.ResponseMarketOverview "Successful response with the market overview"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid limit"
+// @Failure 429 {object} models.ErrorResponseDataInternalServerError "CoinGecko rate limit"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Failure 503 {object} models.ErrorResponseDataInternalServerError "Market overview not available"
+// @Router /api/v1/market-overview [get]
+func GetMarketOverview(context *gin.Context) {
+	limit, err := ParseOverviewLimit(context.Query("limit"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	overview := CurrentOverview()
+	if overview == nil {
+		utils.ShowError(http.StatusServiceUnavailable, "Market overview is not available", context)
+		return
+	}
+
+	latest, statusCode, err := overview.Latest()
+	if err != nil {
+		showError(statusCode, err, context)
+		return
+	}
+	context.JSON(http.StatusOK, latest.Response(limit))
+}
@@ -0,0 +1,65 @@
+package marketcap
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMarketOverview(t *testing.T) {
+	setupOverviewCoinGecko(t)
+	SetOverview(NewOverview(time.Hour))
+	defer SetOverview(nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/market-overview", GetMarketOverview)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedTop     []string
+		expectedMessage string
+	}{
+		{name: "Default limit", query: "", expectedStatus: http.StatusOK, expectedTop: []string{"bitcoin", "ethereum", "tether", "solana"}},
+		{name: "Limit", query: "limit=1", expectedStatus: http.StatusOK, expectedTop: []string{"bitcoin"}},
+		{name: "Invalid limit", query: "limit=101", expectedStatus: http.StatusBadRequest, expectedMessage: "limit must be between 1 and 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/market-overview?"+tt.query, nil))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				assert.JSONEq(t, `{"message":"`+tt.expectedMessage+`"}`, w.Body.String())
+				return
+			}
+
+			var response models.ResponseMarketOverview
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedTop, coinIDs(response.TopMarketCap))
+			assert.Equal(t, 56.1235, response.BTCDominance)
+			assert.NotEmpty(t, response.EventTime)
+		})
+	}
+}
+
+func TestGetMarketOverviewNotAvailable(t *testing.T) {
+	SetOverview(nil)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/market-overview", GetMarketOverview)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/market-overview", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"message":"Market overview is not available"}`, w.Body.String())
+}
@@ -0,0 +1,60 @@
+package marketcap
+
+import (
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get market stats
+// @Description Retrieves the CoinGecko market cap and 24h volume of a coin, given as a CoinGecko id (bitcoin), a ticker (BTC), a trading pair (BTCUSDT) or a name. A ticker shared by several coins resolves to the coin with the largest market cap. Snapshots are cached for 15 minutes.
+// @Tags Market Stats
+// @Produce json
+// @Param symbol query string true "CoinGecko id, ticker, trading pair or name (e.g., BTC)" example("BTC")
+// @Param convert query string false "Fiat currency to convert the market cap and volume into (e.g., VND, EUR), USD by default" example("VND")
+// @Success 200 {object} models.FormatMarketCapResponse "Successful response with market stats"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbol"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Coin not found"
+// @Failure 429 {object} models.ErrorResponseDataInternalServerError "CoinGecko rate limit"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/market-stats [get]
+func GetMarketStats(context *gin.Context) {
+	conversion, statusCode, err := fx.FromUSD(context.Query("convert"))
+	if err != nil {
+		showError(statusCode, err, context)
+		return
+	}
+
+	coinID, statusCode, err := DefaultResolver.Resolve(context.Query("symbol"))
+	if err != nil {
+		showError(statusCode, err, context)
+		return
+	}
+
+	response, status, err := DefaultPoller.Snapshot(coinID)
+	if err != nil {
+		showError(models.StatusCode(status), err, context)
+		return
+	}
+	if conversion != nil {
+		// the snapshot is shared with other clients
+		converted := *response
+		converted.MarketCap = int64(conversion.Convert(float64(response.MarketCap)))
+		converted.TotalVolume = int64(conversion.Convert(float64(response.TotalVolume)))
+		converted.Conversion = conversion.Response()
+		response = &converted
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// showError keeps a CoinGecko rate limit as 429 so that clients know to wait
+func showError(statusCode models.StatusCode, err error, context *gin.Context) {
+	if statusCode == http.StatusTooManyRequests {
+		utils.ShowError(http.StatusTooManyRequests, "Rate limit, please wait.", context)
+		return
+	}
+	utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+}
@@ -0,0 +1,74 @@
+package marketcap
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMarketStats(t *testing.T) {
+	setupCoinGecko(t)
+	resolver, poller := DefaultResolver, DefaultPoller
+	DefaultResolver, DefaultPoller = NewResolver(), NewPoller(time.Hour)
+	defer func() { DefaultResolver, DefaultPoller = resolver, poller }()
+	fx.SetDefault(&fx.StaticRateSource{FXRates: models.FXRates{Rates: map[string]float64{"USD": 1, "VND": 25000}, AsOf: 1733875200000, Source: "test"}})
+	defer fx.SetDefault(nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/market-stats", GetMarketStats)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Ticker",
+			query:          "symbol=BTC",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"bitcoin","symbol":"btc","name":"Bitcoin","market_cap":1925000000000,"24h_volume":45000000000,"last_updated":"2024-12-11T07:00:00.000Z"}`,
+		},
+		{
+			name:           "Converted into VND",
+			query:          "symbol=BTC&convert=vnd",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"bitcoin","symbol":"btc","name":"Bitcoin","market_cap":48125000000000000,"24h_volume":1125000000000000,"last_updated":"2024-12-11T07:00:00.000Z","conversion":{"from":"USD","to":"VND","rate":25000,"asOf":"2024-12-11 00:00:00","source":"test"}}`,
+		},
+		{
+			name:           "Unknown currency",
+			query:          "symbol=BTC&convert=XYZ",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Unsupported currency XYZ"}`,
+		},
+		{
+			name:           "Missing symbol",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Missing symbol"}`,
+		},
+		{
+			name:           "Unknown coin",
+			query:          "symbol=nocoin",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"Coin nocoin not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/market-stats?%s", tt.query), nil))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package marketcap
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultOverviewRefresh is how often the overview is fetched when MARKET_OVERVIEW_REFRESH is not set
+	DefaultOverviewRefresh = 5 * time.Minute
+	// minOverviewRefresh keeps the two requests of each refresh within the CoinGecko rate limit
+	minOverviewRefresh = 30 * time.Second
+	// OverviewCoins is how many of the largest coins the overview ranks
+	OverviewCoins = 100
+
+	DefaultOverviewLimit = 10
+)
+
+// MarketOverview is one fetch of the global market and of its largest coins by market cap
+type MarketOverview struct {
+	Global    models.CoinGeckoGlobal
+	Coins     []models.CoinGeckoMarket
+	FetchedAt time.Time
+}
+
+// Overview keeps the latest MarketOverview, fetched again every Refresh, and hands each new one to
+// its subscribers so that every client shares the same two CoinGecko requests
+type Overview struct {
+	Refresh time.Duration
+
+	// flight shares one refresh between the background loop and the requests arriving before the first
+	flight singleflight.Group
+
+	mutex       sync.RWMutex
+	latest      *MarketOverview
+	subscribers map[*OverviewSubscription]struct{}
+	// failedAt is the time of the last failed refresh, whose error is answered until minOverviewRefresh
+	// later while there is no overview
+	failedAt   time.Time
+	failStatus models.StatusCode
+	failErr    error
+}
+
+// OverviewSubscription receives every new overview until it is closed
+type OverviewSubscription struct {
+	C <-chan *MarketOverview
+
+	overview *Overview
+	channel  chan *MarketOverview
+}
+
+var (
+	overview      *Overview
+	overviewMutex sync.RWMutex
+)
+
+// SetOverview makes the market overview endpoints read o, nil turns them off
+func SetOverview(o *Overview) {
+	overviewMutex.Lock()
+	defer overviewMutex.Unlock()
+	overview = o
+}
+
+// CurrentOverview returns the overview set by SetOverview, nil when there is none
+func CurrentOverview() *Overview {
+	overviewMutex.RLock()
+	defer overviewMutex.RUnlock()
+	return overview
+}
+
+func NewOverview(refresh time.Duration) *Overview {
+	return &Overview{Refresh: refresh, subscribers: map[*OverviewSubscription]struct{}{}}
+}
+
+// NewOverviewFromEnv refreshes the overview every MARKET_OVERVIEW_REFRESH, a duration such as 2m,
+// DefaultOverviewRefresh when it is not set or invalid and at least every 30 seconds
+func NewOverviewFromEnv() *Overview {
+	refresh := DefaultOverviewRefresh
+	if value := os.Getenv("MARKET_OVERVIEW_REFRESH"); value != "" {
+		duration, err := time.ParseDuration(value)
+		switch {
+		case err != nil:
+			log.Printf("Invalid MARKET_OVERVIEW_REFRESH %s, refreshing every %s", value, refresh)
+		case duration < minOverviewRefresh:
+			refresh = minOverviewRefresh
+		default:
+			refresh = duration
+		}
+	}
+	return NewOverview(refresh)
+}
+
+// Start refreshes the overview in the background until ctx is done
+func (o *Overview) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(o.Refresh)
+		defer ticker.Stop()
+		for {
+			if _, _, err := o.refresh(); err != nil {
+				log.Println("Market overview refresh failed: ", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Latest returns the latest overview. While none was fetched yet, concurrent requests share one
+// refresh, and the error of a failed refresh is answered for minOverviewRefresh before trying again.
+func (o *Overview) Latest() (*MarketOverview, models.StatusCode, error) {
+	o.mutex.RLock()
+	latest, failedAt, failStatus, failErr := o.latest, o.failedAt, o.failStatus, o.failErr
+	o.mutex.RUnlock()
+	if latest != nil {
+		return latest, http.StatusOK, nil
+	}
+	if failErr != nil && time.Since(failedAt) < minOverviewRefresh {
+		return nil, failStatus, failErr
+	}
+	return o.refresh()
+}
+
+// Subscribe follows the overview, the latest one is sent right away when there is one
+func (o *Overview) Subscribe() *OverviewSubscription {
+	channel := make(chan *MarketOverview, subscriberBuffer)
+	subscription := &OverviewSubscription{C: channel, overview: o, channel: channel}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.latest != nil {
+		channel <- o.latest
+	}
+	o.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// Close stops the updates
+func (s *OverviewSubscription) Close() {
+	s.overview.mutex.Lock()
+	defer s.overview.mutex.Unlock()
+	if _, ok := s.overview.subscribers[s]; ok {
+		delete(s.overview.subscribers, s)
+		close(s.channel)
+	}
+}
+
+// overviewResult is what a refresh shared by concurrent callers returns to each of them
+type overviewResult struct {
+	overview   *MarketOverview
+	statusCode models.StatusCode
+}
+
+// refresh fetches the overview, or waits for the refresh in flight
+func (o *Overview) refresh() (*MarketOverview, models.StatusCode, error) {
+	result, err, _ := o.flight.Do("refresh", func() (interface{}, error) {
+		fetched, statusCode, err := o.fetch()
+		if err != nil {
+			o.mutex.Lock()
+			o.failedAt, o.failStatus, o.failErr = time.Now(), statusCode, err
+			o.mutex.Unlock()
+		}
+		return overviewResult{overview: fetched, statusCode: statusCode}, err
+	})
+	return result.(overviewResult).overview, result.(overviewResult).statusCode, err
+}
+
+// fetch fetches the global market and its largest coins and hands them to the subscribers
+func (o *Overview) fetch() (*MarketOverview, models.StatusCode, error) {
+	var fetched MarketOverview
+	if statusCode, err := getJSON("/global", url.Values{}, &fetched.Global); err != nil {
+		return nil, overviewStatusCode(statusCode), err
+	}
+
+	q := url.Values{}
+	q.Add("vs_currency", "usd")
+	q.Add("order", "market_cap_desc")
+	q.Add("per_page", strconv.Itoa(OverviewCoins))
+	q.Add("page", "1")
+	if statusCode, err := getJSON("/coins/markets", q, &fetched.Coins); err != nil {
+		return nil, overviewStatusCode(statusCode), err
+	}
+	fetched.FetchedAt = time.Now()
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.latest = &fetched
+	o.failErr = nil
+	for subscription := range o.subscribers {
+		select {
+		case subscription.channel <- &fetched:
+		default:
+			log.Println("Skipping market overview update of a slow subscriber")
+		}
+	}
+	return &fetched, http.StatusOK, nil
+}
+
+// overviewStatusCode turns an unreachable CoinGecko into a bad gateway
+func overviewStatusCode(statusCode int) models.StatusCode {
+	if statusCode == 0 {
+		return http.StatusBadGateway
+	}
+	return models.StatusCode(statusCode)
+}
+
+// Response ranks the limit largest coins of the overview, and the limit best and worst of them over 24 hours
+func (m *MarketOverview) Response(limit int) *models.ResponseMarketOverview {
+	var response models.ResponseMarketOverview
+	response.UpdateData(&m.Global, utils.ConvertMillisecondsToTimestamp(m.Global.Data.UpdatedAt*1000), utils.ConvertMillisecondsToTimestamp(m.FetchedAt.UnixMilli()))
+
+	coins := make([]models.MarketOverviewCoin, 0, len(m.Coins))
+	for _, market := range m.Coins {
+		coin := models.MarketOverviewCoin{
+			Rank:                  market.MarketCapRank,
+			ID:                    market.ID,
+			Symbol:                strings.ToUpper(market.Symbol),
+			Name:                  market.Name,
+			Price:                 market.CurrentPrice,
+			MarketCap:             market.MarketCap,
+			Volume24h:             market.TotalVolume,
+			PriceChangePercent24h: math.Round(market.PriceChangePercentage24h*1e4) / 1e4,
+		}
+		if response.TotalMarketCap > 0 {
+			coin.Dominance = math.Round(market.MarketCap/response.TotalMarketCap*1e6) / 1e4
+		}
+		coins = append(coins, coin)
+	}
+
+	response.TopMarketCap = coins[:min(limit, len(coins))]
+	byChange := append([]models.MarketOverviewCoin(nil), coins...)
+	sort.SliceStable(byChange, func(i, j int) bool { return byChange[i].PriceChangePercent24h > byChange[j].PriceChangePercent24h })
+	response.TopGainers = []models.MarketOverviewCoin{}
+	response.TopLosers = []models.MarketOverviewCoin{}
+	for i := 0; i < len(byChange) && len(response.TopGainers) < limit && byChange[i].PriceChangePercent24h > 0; i++ {
+		response.TopGainers = append(response.TopGainers, byChange[i])
+	}
+	for i := len(byChange) - 1; i >= 0 && len(response.TopLosers) < limit && byChange[i].PriceChangePercent24h < 0; i-- {
+		response.TopLosers = append(response.TopLosers, byChange[i])
+	}
+	return &response
+}
+
+// ParseOverviewLimit reads the length of the rank lists, DefaultOverviewLimit when value is empty
+func ParseOverviewLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultOverviewLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > OverviewCoins {
+		return 0, fmt.Errorf("limit must be between 1 and %d", OverviewCoins)
+	}
+	return limit, nil
+}
@@ -0,0 +1,165 @@
+package marketcap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupOverviewCoinGecko serves the global market and four coins, and counts the refreshes. Every
+// request waits delay first and is answered with status when it is set.
+func setupOverviewCoinGecko(t *testing.T) *coinGeckoRequests {
+	requests := &coinGeckoRequests{counts: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.mutex.Lock()
+		requests.counts[r.URL.Path]++
+		delay, status := requests.delay, requests.status
+		requests.mutex.Unlock()
+		time.Sleep(delay)
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/global":
+			w.Write([]byte(`{"data":{"active_cryptocurrencies":16231,"total_market_cap":{"usd":3400000000000,"btc":35000000},"total_volume":{"usd":150000000000},"market_cap_percentage":{"btc":56.123456,"eth":13.05},"market_cap_change_percentage_24h_usd":1.834567,"updated_at":1733900000}}`))
+		case "/coins/markets":
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			assert.Equal(t, "market_cap_desc", r.URL.Query().Get("order"))
+			w.Write([]byte(`[
+				{"id":"bitcoin","symbol":"btc","name":"Bitcoin","current_price":97102.5,"market_cap":1904000000000,"market_cap_rank":1,"total_volume":45000000000,"price_change_percentage_24h":1.27},
+				{"id":"ethereum","symbol":"eth","name":"Ethereum","current_price":3680.1,"market_cap":443700000000,"market_cap_rank":2,"total_volume":28000000000,"price_change_percentage_24h":-2.5},
+				{"id":"tether","symbol":"usdt","name":"Tether","current_price":1,"market_cap":136000000000,"market_cap_rank":3,"total_volume":90000000000,"price_change_percentage_24h":0},
+				{"id":"solana","symbol":"sol","name":"Solana","current_price":215.3,"market_cap":102000000000,"market_cap_rank":4,"total_volume":5000000000,"price_change_percentage_24h":6.123456}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Setenv("COINGECKO_BASE_URL", server.URL)
+	t.Cleanup(server.Close)
+	return requests
+}
+
+func TestOverview(t *testing.T) {
+	refreshes := setupOverviewCoinGecko(t)
+	overview := NewOverview(20 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	subscription := overview.Subscribe()
+	defer subscription.Close()
+	overview.Start(ctx)
+
+	var latest *MarketOverview
+	for i := 0; i < 2; i++ {
+		select {
+		case latest = <-subscription.C:
+		case <-time.After(2 * time.Second):
+			t.Fatal("no overview")
+		}
+	}
+	assert.GreaterOrEqual(t, refreshes.count("/global"), 2)
+
+	cached, statusCode, err := overview.Latest()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, int(statusCode))
+	assert.Len(t, cached.Coins, 4)
+
+	response := latest.Response(2)
+	assert.Equal(t, 3400000000000.0, response.TotalMarketCap)
+	assert.Equal(t, 150000000000.0, response.TotalVolume24h)
+	assert.Equal(t, 1.8346, response.MarketCapChangePercent24h)
+	assert.Equal(t, 56.1235, response.BTCDominance)
+	assert.Equal(t, 13.05, response.ETHDominance)
+	assert.Equal(t, 16231, response.ActiveCryptocurrencies)
+	assert.Equal(t, []models.MarketOverviewCoin{
+		{Rank: 1, ID: "bitcoin", Symbol: "BTC", Name: "Bitcoin", Price: 97102.5, MarketCap: 1904000000000, Volume24h: 45000000000, PriceChangePercent24h: 1.27, Dominance: 56},
+		{Rank: 2, ID: "ethereum", Symbol: "ETH", Name: "Ethereum", Price: 3680.1, MarketCap: 443700000000, Volume24h: 28000000000, PriceChangePercent24h: -2.5, Dominance: 13.05},
+	}, response.TopMarketCap)
+	assert.Equal(t, []string{"solana", "bitcoin"}, coinIDs(response.TopGainers))
+	assert.Equal(t, []string{"ethereum"}, coinIDs(response.TopLosers))
+}
+
+func TestOverviewLatestWithoutStart(t *testing.T) {
+	refreshes := setupOverviewCoinGecko(t)
+	overview := NewOverview(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		latest, _, err := overview.Latest()
+		assert.NoError(t, err)
+		assert.Len(t, latest.Coins, 4)
+	}
+	assert.Equal(t, 1, refreshes.count("/global"))
+
+	t.Setenv("COINGECKO_BASE_URL", "http://127.0.0.1:0")
+	_, statusCode, err := NewOverview(time.Hour).Latest()
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, int(statusCode))
+}
+
+func TestOverviewLatestConcurrent(t *testing.T) {
+	refreshes := setupOverviewCoinGecko(t)
+	refreshes.set(50*time.Millisecond, http.StatusServiceUnavailable)
+	overview := NewOverview(time.Hour)
+
+	// concurrent requests share one refresh
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, statusCode, err := overview.Latest()
+			assert.EqualError(t, err, "API returned status code: 503")
+			assert.Equal(t, http.StatusServiceUnavailable, int(statusCode))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, refreshes.count("/global"))
+
+	// the failure is answered until the retry delay is over
+	refreshes.set(0, 0)
+	_, statusCode, err := overview.Latest()
+	assert.EqualError(t, err, "API returned status code: 503")
+	assert.Equal(t, http.StatusServiceUnavailable, int(statusCode))
+	assert.Equal(t, 1, refreshes.count("/global"))
+
+	overview.mutex.Lock()
+	overview.failedAt = time.Now().Add(-minOverviewRefresh)
+	overview.mutex.Unlock()
+	latest, _, err := overview.Latest()
+	assert.NoError(t, err)
+	assert.Len(t, latest.Coins, 4)
+	assert.Equal(t, 2, refreshes.count("/global"))
+}
+
+func TestNewOverviewFromEnv(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", DefaultOverviewRefresh},
+		{"2m", 2 * time.Minute},
+		{"1s", minOverviewRefresh},
+		{"often", DefaultOverviewRefresh},
+	}
+	for _, tt := range tests {
+		t.Setenv("MARKET_OVERVIEW_REFRESH", tt.value)
+		assert.Equal(t, tt.expected, NewOverviewFromEnv().Refresh, tt.value)
+	}
+}
+
+func coinIDs(coins []models.MarketOverviewCoin) []string {
+	ids := []string{}
+	for _, coin := range coins {
+		ids = append(ids, coin.ID)
+	}
+	return ids
+}
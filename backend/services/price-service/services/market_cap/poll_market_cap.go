@@ -0,0 +1,187 @@
+package marketcap
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultInterval is how often a followed coin is fetched, the CoinGecko data changes slowly
+	DefaultInterval = 15 * time.Minute
+
+	// subscriberBuffer is how many updates a client may lag behind before updates are skipped
+	subscriberBuffer = 4
+)
+
+// rateLimitBackoff is how soon a coin is fetched again after CoinGecko answered 429
+var rateLimitBackoff = time.Minute
+
+// DefaultPoller is shared by the market cap socket, stream channel and REST endpoint
+var DefaultPoller = NewPoller(DefaultInterval)
+
+// Update is one fetch of a coin, Data is nil when it failed
+type Update struct {
+	Data       *models.FormatMarketCapResponse
+	StatusCode int
+	Err        error
+}
+
+// Poller fetches each followed coin once per Interval whatever the number of clients and fans the
+// result out to every subscriber, so CoinGecko sees one request per coin instead of one per client.
+// The latest market cap of each coin is kept for snapshots and for new subscribers.
+type Poller struct {
+	Interval time.Duration
+
+	// flight shares one fetch between the snapshots of a coin missing at the same time
+	flight singleflight.Group
+
+	mutex sync.Mutex
+	coins map[string]*polledCoin
+}
+
+type polledCoin struct {
+	latest    *models.FormatMarketCapResponse
+	fetchedAt time.Time
+	// done is closed when the last subscriber leaves, nil while nobody follows the coin
+	done        chan struct{}
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the updates of one coin until it is closed
+type Subscription struct {
+	C <-chan Update
+
+	poller  *Poller
+	coinID  string
+	channel chan Update
+}
+
+func NewPoller(interval time.Duration) *Poller {
+	return &Poller{Interval: interval, coins: map[string]*polledCoin{}}
+}
+
+// Subscribe follows a CoinGecko id, the latest market cap is sent right away when it is recent
+func (p *Poller) Subscribe(coinID string) *Subscription {
+	channel := make(chan Update, subscriberBuffer)
+	subscription := &Subscription{C: channel, poller: p, coinID: coinID, channel: channel}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	coin := p.coin(coinID)
+	age := time.Since(coin.fetchedAt)
+	if coin.latest != nil && age < p.Interval {
+		channel <- Update{Data: coin.latest, StatusCode: http.StatusOK}
+	}
+	coin.subscribers[subscription] = struct{}{}
+
+	if coin.done == nil {
+		coin.done = make(chan struct{})
+		delay := time.Duration(0)
+		if coin.latest != nil && age < p.Interval {
+			delay = p.Interval - age
+		}
+		go p.run(coinID, coin, coin.done, delay)
+	}
+	return subscription
+}
+
+// Close stops the updates, the coin is no longer fetched once its last subscriber leaves
+func (s *Subscription) Close() {
+	p := s.poller
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	coin := p.coins[s.coinID]
+	if _, ok := coin.subscribers[s]; !ok {
+		return
+	}
+	delete(coin.subscribers, s)
+	close(s.channel)
+	if len(coin.subscribers) == 0 {
+		close(coin.done)
+		coin.done = nil
+	}
+}
+
+// Snapshot returns the latest market cap of a coin, fetching it only when it is older than Interval.
+// Concurrent snapshots of a coin to fetch wait for the same request.
+func (p *Poller) Snapshot(coinID string) (*models.FormatMarketCapResponse, int, error) {
+	p.mutex.Lock()
+	coin := p.coin(coinID)
+	if coin.latest != nil && time.Since(coin.fetchedAt) < p.Interval {
+		latest := coin.latest
+		p.mutex.Unlock()
+		return latest, http.StatusOK, nil
+	}
+	p.mutex.Unlock()
+
+	update, _, _ := p.flight.Do(coinID, func() (interface{}, error) {
+		data, statusCode, err := FetchMarketCap(coinID)
+		if err == nil {
+			p.mutex.Lock()
+			coin.latest, coin.fetchedAt = data, time.Now()
+			p.mutex.Unlock()
+		}
+		return Update{Data: data, StatusCode: statusCode, Err: err}, nil
+	})
+	return update.(Update).Data, update.(Update).StatusCode, update.(Update).Err
+}
+
+// coin must be called with the mutex held
+func (p *Poller) coin(coinID string) *polledCoin {
+	coin, ok := p.coins[coinID]
+	if !ok {
+		coin = &polledCoin{subscribers: map[*Subscription]struct{}{}}
+		p.coins[coinID] = coin
+	}
+	return coin
+}
+
+// run fetches the coin after delay and then every Interval until done is closed
+func (p *Poller) run(coinID string, coin *polledCoin, done chan struct{}, delay time.Duration) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-timer.C:
+		}
+
+		data, statusCode, err := FetchMarketCap(coinID)
+		if err != nil {
+			log.Println(err)
+		}
+		wait := p.Interval
+		if statusCode == http.StatusTooManyRequests {
+			wait = rateLimitBackoff
+		}
+
+		p.mutex.Lock()
+		select {
+		case <-done:
+			p.mutex.Unlock()
+			return
+		default:
+		}
+		if err == nil {
+			coin.latest, coin.fetchedAt = data, time.Now()
+		}
+		for subscription := range coin.subscribers {
+			select {
+			case subscription.channel <- Update{Data: data, StatusCode: statusCode, Err: err}:
+			default:
+				log.Println("Skipping market cap update of a slow subscriber of ", coinID)
+			}
+		}
+		p.mutex.Unlock()
+
+		timer.Reset(wait)
+	}
+}
@@ -0,0 +1,100 @@
+package marketcap
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(t *testing.T, subscription *Subscription) Update {
+	select {
+	case update := <-subscription.C:
+		return update
+	case <-time.After(2 * time.Second):
+		t.Fatal("no update")
+		return Update{}
+	}
+}
+
+func TestPoller(t *testing.T) {
+	requests := setupCoinGecko(t)
+	poller := NewPoller(50 * time.Millisecond)
+
+	first := poller.Subscribe("bitcoin")
+	update := receive(t, first)
+	assert.NoError(t, update.Err)
+	assert.Equal(t, "bitcoin", update.Data.ID)
+	assert.Equal(t, "Bitcoin", update.Data.Name)
+	assert.Equal(t, int64(1925000000000), update.Data.MarketCap)
+	assert.Equal(t, "2024-12-11T07:00:00.000Z", update.Data.LastUpdated)
+
+	// a second subscriber shares the polling and gets the latest market cap right away
+	second := poller.Subscribe("bitcoin")
+	assert.Equal(t, update.Data, receive(t, second).Data)
+
+	// both get the next fetch
+	receive(t, first)
+	receive(t, second)
+	first.Close()
+	second.Close()
+	first.Close()
+
+	fetched := requests.count("/coins/bitcoin")
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, fetched, requests.count("/coins/bitcoin"), "the coin is not fetched without subscribers")
+}
+
+func TestPollerErrors(t *testing.T) {
+	setupCoinGecko(t)
+	poller := NewPoller(time.Hour)
+
+	subscription := poller.Subscribe("nocoin")
+	defer subscription.Close()
+	update := receive(t, subscription)
+	assert.Nil(t, update.Data)
+	assert.Equal(t, http.StatusNotFound, update.StatusCode)
+
+	// a rate limited coin is fetched again after rateLimitBackoff instead of Interval
+	defer func(backoff time.Duration) { rateLimitBackoff = backoff }(rateLimitBackoff)
+	rateLimitBackoff = 10 * time.Millisecond
+	limited := poller.Subscribe("limited")
+	defer limited.Close()
+	assert.Equal(t, http.StatusTooManyRequests, receive(t, limited).StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, receive(t, limited).StatusCode)
+}
+
+func TestPollerSnapshot(t *testing.T) {
+	requests := setupCoinGecko(t)
+	poller := NewPoller(time.Hour)
+
+	for i := 0; i < 3; i++ {
+		data, statusCode, err := poller.Snapshot("bitcoin")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, statusCode)
+		assert.Equal(t, "btc", data.Symbol)
+	}
+	assert.Equal(t, 1, requests.count("/coins/bitcoin"))
+
+	_, statusCode, err := poller.Snapshot("nocoin")
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.EqualError(t, err, "API returned status code: 404")
+
+	// concurrent snapshots of a coin to fetch share one request
+	requests.set(50*time.Millisecond, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, statusCode, err := poller.Snapshot("ethereum")
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+			assert.Equal(t, "ethereum", data.ID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, requests.count("/coins/ethereum"))
+}
@@ -0,0 +1,220 @@
+package marketcap
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// coinListTTL is how long the CoinGecko coin list is used before it is loaded again
+	coinListTTL = 24 * time.Hour
+	// coinListRetry is how long a failed load of the coin list is waited out before the next one,
+	// doubled at each failure in a row up to coinListMaxRetry
+	coinListRetry    = 10 * time.Second
+	coinListMaxRetry = 5 * time.Minute
+)
+
+// quoteSuffixes are stripped from trading pairs such as BTCUSDT that match no coin
+var quoteSuffixes = []string{"usdt", "usdc", "busd", "usd"}
+
+// DefaultResolver is shared by the market cap socket, stream channel and REST endpoint
+var DefaultResolver = NewResolver()
+
+// Resolver maps what users type, a CoinGecko id such as bitcoin, a ticker such as BTC, a trading pair
+// such as BTCUSDT or a name such as Bitcoin, to a CoinGecko id. Tickers shared by several coins
+// resolve to the coin with the largest market cap, which CoinGecko is asked once per ticker.
+// CoinGecko is never called with the mutex held and concurrent loads of the same data share one call.
+type Resolver struct {
+	flight singleflight.Group
+
+	mutex    sync.Mutex
+	coins    []models.CoinGeckoCoin
+	ids      map[string]bool
+	loadedAt time.Time
+	// failures counts the failed loads of the coin list in a row, the next load waits until retryAt
+	failures   int
+	retryAt    time.Time
+	loadStatus models.StatusCode
+	loadErr    error
+	// resolved caches the id of each query and ranked the id chosen among each set of coins sharing
+	// a ticker, until the coin list is loaded again
+	resolved map[string]string
+	ranked   map[string]string
+}
+
+// flightResult is what a call shared by concurrent requests returns to each of them
+type flightResult struct {
+	id         string
+	statusCode models.StatusCode
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+// Resolve returns the CoinGecko id of query
+func (r *Resolver) Resolve(query string) (string, models.StatusCode, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return "", http.StatusBadRequest, fmt.Errorf("Missing symbol")
+	}
+
+	if statusCode, err := r.load(); err != nil {
+		return "", statusCode, err
+	}
+
+	r.mutex.Lock()
+	if id, ok := r.resolved[query]; ok {
+		r.mutex.Unlock()
+		return id, http.StatusOK, nil
+	}
+	if r.ids[query] {
+		r.mutex.Unlock()
+		return query, http.StatusOK, nil
+	}
+
+	candidates := r.match(func(coin models.CoinGeckoCoin) bool { return coin.Symbol == query })
+	for _, suffix := range quoteSuffixes {
+		if len(candidates) > 0 {
+			break
+		}
+		if base := strings.TrimSuffix(query, suffix); base != query && base != "" {
+			candidates = r.match(func(coin models.CoinGeckoCoin) bool { return coin.Symbol == base })
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = r.match(func(coin models.CoinGeckoCoin) bool { return strings.ToLower(coin.Name) == query })
+	}
+	key := strings.Join(candidates, ",")
+	ranked := r.ranked[key]
+	r.mutex.Unlock()
+
+	var id string
+	switch len(candidates) {
+	case 0:
+		return "", http.StatusNotFound, fmt.Errorf("Coin %s not found", query)
+	case 1:
+		id = candidates[0]
+	default:
+		if id = ranked; id == "" {
+			result, err, _ := r.flight.Do("ranked:"+key, func() (interface{}, error) {
+				id, statusCode, err := largestMarketCap(candidates)
+				return flightResult{id: id, statusCode: statusCode}, err
+			})
+			if err != nil {
+				return "", result.(flightResult).statusCode, err
+			}
+			id = result.(flightResult).id
+		}
+	}
+
+	r.mutex.Lock()
+	if len(candidates) > 1 {
+		r.ranked[key] = id
+	}
+	r.resolved[query] = id
+	r.mutex.Unlock()
+	return id, http.StatusOK, nil
+}
+
+// load makes sure there is a coin list. A list older than coinListTTL keeps answering while it is
+// loaded again in the background. Without a list, the callers wait for the one load in flight, or get
+// the error of the last load until its retry delay is over.
+func (r *Resolver) load() (models.StatusCode, error) {
+	r.mutex.Lock()
+	loaded := r.coins != nil
+	due := (!loaded || time.Since(r.loadedAt) >= coinListTTL) && !time.Now().Before(r.retryAt)
+	statusCode, err := r.loadStatus, r.loadErr
+	r.mutex.Unlock()
+
+	switch {
+	case loaded && due:
+		r.flight.DoChan("list", r.loadList)
+	case !loaded && due:
+		r.flight.Do("list", r.loadList)
+		r.mutex.Lock()
+		loaded, statusCode, err = r.coins != nil, r.loadStatus, r.loadErr
+		r.mutex.Unlock()
+	}
+	if !loaded {
+		return statusCode, err
+	}
+	return http.StatusOK, nil
+}
+
+// loadList fetches the coin list, a stale list is kept when CoinGecko fails
+func (r *Resolver) loadList() (interface{}, error) {
+	var coins []models.CoinGeckoCoin
+	statusCode, err := getJSON("/coins/list", url.Values{}, &coins)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if err != nil {
+		if statusCode == 0 {
+			statusCode = http.StatusBadGateway
+		}
+		r.failures++
+		retry := min(coinListRetry<<min(r.failures-1, 5), coinListMaxRetry)
+		r.retryAt = time.Now().Add(retry)
+		r.loadStatus, r.loadErr = models.StatusCode(statusCode), err
+		log.Printf("CoinGecko coin list error, retrying in %s: %v", retry, err)
+		return nil, err
+	}
+
+	r.coins = coins
+	r.ids = make(map[string]bool, len(coins))
+	for _, coin := range coins {
+		r.ids[coin.ID] = true
+	}
+	r.loadedAt = time.Now()
+	r.failures, r.retryAt = 0, time.Time{}
+	r.loadStatus, r.loadErr = http.StatusOK, nil
+	r.resolved = map[string]string{}
+	r.ranked = map[string]string{}
+	return nil, nil
+}
+
+// match returns the ids of the coins of the list matching keep. It must be called with the mutex held.
+func (r *Resolver) match(keep func(coin models.CoinGeckoCoin) bool) []string {
+	var ids []string
+	for _, coin := range r.coins {
+		if keep(coin) {
+			ids = append(ids, coin.ID)
+		}
+	}
+	return ids
+}
+
+// largestMarketCap returns the id with the largest market cap, the first id when CoinGecko ranks none of them
+func largestMarketCap(ids []string) (string, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("vs_currency", "usd")
+	q.Add("ids", strings.Join(ids, ","))
+	q.Add("order", "market_cap_desc")
+
+	var markets []models.CoinGeckoMarket
+	statusCode, err := getJSON("/coins/markets", q, &markets)
+	if err != nil {
+		if statusCode == 0 {
+			statusCode = http.StatusBadGateway
+		}
+		return "", models.StatusCode(statusCode), err
+	}
+
+	id := ids[0]
+	largest := -1.0
+	for _, market := range markets {
+		if market.MarketCap > largest {
+			id, largest = market.ID, market.MarketCap
+		}
+	}
+	return id, http.StatusOK, nil
+}
@@ -0,0 +1,178 @@
+package marketcap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// coinGeckoRequests counts the requests of each path served by setupCoinGecko. Every request waits
+// delay first and is answered with status when it is set.
+type coinGeckoRequests struct {
+	mutex  sync.Mutex
+	counts map[string]int
+	delay  time.Duration
+	status int
+}
+
+func (c *coinGeckoRequests) count(path string) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.counts[path]
+}
+
+func (c *coinGeckoRequests) set(delay time.Duration, status int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.delay, c.status = delay, status
+}
+
+// setupCoinGecko serves a coin list where btc is the ticker of two coins, bitcoin having the largest
+// market cap, and counts the requests of each path
+func setupCoinGecko(t *testing.T) *coinGeckoRequests {
+	requests := &coinGeckoRequests{counts: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.mutex.Lock()
+		requests.counts[r.URL.Path]++
+		delay, status := requests.delay, requests.status
+		requests.mutex.Unlock()
+		time.Sleep(delay)
+		if status != 0 {
+			w.WriteHeader(status)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/coins/list":
+			w.Write([]byte(`[
+				{"id":"batcat","symbol":"btc","name":"batcat"},
+				{"id":"bitcoin","symbol":"btc","name":"Bitcoin"},
+				{"id":"ethereum","symbol":"eth","name":"Ethereum"},
+				{"id":"tether","symbol":"usdt","name":"Tether"}
+			]`))
+		case "/coins/markets":
+			assert.Equal(t, "batcat,bitcoin", r.URL.Query().Get("ids"))
+			w.Write([]byte(`[{"id":"bitcoin","market_cap":1925000000000},{"id":"batcat","market_cap":12000}]`))
+		case "/coins/bitcoin":
+			w.Write([]byte(`{"id":"bitcoin","symbol":"btc","name":"Bitcoin","market_data":{"market_cap":{"usd":1925000000000},"total_volume":{"usd":45000000000}},"last_updated":"2024-12-11T07:00:00.000Z"}`))
+		case "/coins/ethereum":
+			w.Write([]byte(`{"id":"ethereum","symbol":"eth","name":"Ethereum","market_data":{"market_cap":{"usd":470000000000},"total_volume":{"usd":30000000000}},"last_updated":"2024-12-11T07:00:00.000Z"}`))
+		case "/coins/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Setenv("COINGECKO_BASE_URL", server.URL)
+	t.Cleanup(server.Close)
+	return requests
+}
+
+func TestResolver(t *testing.T) {
+	requests := setupCoinGecko(t)
+	resolver := NewResolver()
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedID         string
+		expectedStatusCode models.StatusCode
+		expectedError      string
+	}{
+		{name: "CoinGecko id", query: "ethereum", expectedID: "ethereum", expectedStatusCode: http.StatusOK},
+		{name: "Ticker of one coin", query: "ETH", expectedID: "ethereum", expectedStatusCode: http.StatusOK},
+		{name: "Ticker of several coins", query: "btc", expectedID: "bitcoin", expectedStatusCode: http.StatusOK},
+		{name: "Trading pair", query: "BTCUSDT", expectedID: "bitcoin", expectedStatusCode: http.StatusOK},
+		{name: "Quote ticker", query: "usdt", expectedID: "tether", expectedStatusCode: http.StatusOK},
+		{name: "Name", query: " Bitcoin ", expectedID: "bitcoin", expectedStatusCode: http.StatusOK},
+		{name: "Unknown coin", query: "nocoin", expectedStatusCode: http.StatusNotFound, expectedError: "Coin nocoin not found"},
+		{name: "Missing symbol", query: "", expectedStatusCode: http.StatusBadRequest, expectedError: "Missing symbol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, statusCode, err := resolver.Resolve(tt.query)
+			assert.Equal(t, tt.expectedStatusCode, statusCode)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+		})
+	}
+
+	// the list is loaded once and the btc ticker ranked once
+	assert.Equal(t, 1, requests.count("/coins/list"))
+	assert.Equal(t, 1, requests.count("/coins/markets"))
+}
+
+func TestResolverConcurrent(t *testing.T) {
+	requests := setupCoinGecko(t)
+	requests.set(50*time.Millisecond, 0)
+	resolver := NewResolver()
+
+	// concurrent requests share the load of the list and the ranking of the ticker
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, statusCode, err := resolver.Resolve("btc")
+			assert.NoError(t, err)
+			assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+			assert.Equal(t, "bitcoin", id)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, requests.count("/coins/list"))
+	assert.Equal(t, 1, requests.count("/coins/markets"))
+}
+
+func TestResolverRetry(t *testing.T) {
+	requests := setupCoinGecko(t)
+	requests.set(0, http.StatusTooManyRequests)
+	resolver := NewResolver()
+
+	_, statusCode, err := resolver.Resolve("btc")
+	assert.EqualError(t, err, "API returned status code: 429")
+	assert.Equal(t, models.StatusCode(http.StatusTooManyRequests), statusCode)
+
+	// the failure is answered again until the retry delay is over
+	_, statusCode, err = resolver.Resolve("eth")
+	assert.EqualError(t, err, "API returned status code: 429")
+	assert.Equal(t, models.StatusCode(http.StatusTooManyRequests), statusCode)
+	assert.Equal(t, 1, requests.count("/coins/list"))
+
+	// the delay doubles at each failure in a row
+	resolver.mutex.Lock()
+	assert.Equal(t, 1, resolver.failures)
+	resolver.retryAt = time.Time{}
+	resolver.mutex.Unlock()
+	resolver.Resolve("eth")
+	resolver.mutex.Lock()
+	assert.Equal(t, 2, resolver.failures)
+	assert.WithinDuration(t, time.Now().Add(2*coinListRetry), resolver.retryAt, time.Second)
+	resolver.retryAt = time.Time{}
+	resolver.mutex.Unlock()
+
+	requests.set(0, 0)
+	id, statusCode, err := resolver.Resolve("eth")
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+	assert.Equal(t, "ethereum", id)
+	assert.Equal(t, 3, requests.count("/coins/list"))
+	assert.Zero(t, resolver.failures)
+}
+
+func TestResolverWithoutCoinList(t *testing.T) {
+	t.Setenv("COINGECKO_BASE_URL", "http://127.0.0.1:0")
+	_, statusCode, err := NewResolver().Resolve("btc")
+	assert.Error(t, err)
+	assert.Equal(t, models.StatusCode(http.StatusBadGateway), statusCode)
+}
@@ -0,0 +1,56 @@
+package openinterest
+
+import (
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get long/short ratio history
+// @Description Retrieves a long/short ratio series of a perpetual contract, oldest first, with the long and short shares from 0 to 1: the accounts (top-account) or positions (top-position) of the top traders, or every account (global-account), from Binance Futures or the requested exchange. Bybit only has global-account.
+// @Tags Open Interest
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param type query string false "Ratio: global-account (default), top-account or top-position" example("global-account")
+// @Param period query string false "Period: 5m, 15m, 30m, 1h (default), 2h, 4h, 6h, 12h or 1d, Bybit has no 2h, 6h and 12h" example("1h")
+// @Param startTime query int false "Time in milliseconds of the first point"
+// @Param endTime query int false "Time in milliseconds of the last point, the cursor of a previous page loads older points"
+// @Param limit query int false "Number of points, the latest of the range are kept, 30 by default and at most 500 (100 on OKX)"
+// @Param exchange query string false "Exchange: binance (default), okx or bybit" example("binance")
+// @Success 200 {object} models.ResponseLongShortRatio "Ratios oldest first"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbol or invalid parameters"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/long-short-ratio [get]
+func GetLongShortRatio(context *gin.Context) {
+	kind := context.DefaultQuery("type", models.RatioGlobalAccounts)
+	if !provider.IsValidRatio(kind) {
+		utils.ShowError(http.StatusBadRequest, "type must be top-account, top-position or global-account", context)
+		return
+	}
+	query, ok := parsePositioningQuery(context)
+	if !ok {
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	history, statusCode, err := marketData.LongShortRatio(kind, query)
+	if err != nil {
+		utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+		return
+	}
+
+	var response models.ResponseLongShortRatio
+	response.UpdateData(query.Symbol, kind, query.Period, history, utils.ConvertMilisecondToTimeFormatedRFC3339, utils.GetTimeNow())
+	if len(history) > 0 && history[0].Time > query.StartTime {
+		response.Cursor = history[0].Time - 1
+	}
+	context.JSON(http.StatusOK, response)
+}
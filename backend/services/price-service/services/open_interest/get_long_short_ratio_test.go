@@ -0,0 +1,55 @@
+package openinterest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLongShortRatio(t *testing.T) {
+	setupMockBinance(t)
+	router := setupTestRouter()
+	router.GET("/long-short-ratio", GetLongShortRatio)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedType    string
+		expectedMessage string
+	}{
+		{name: "global accounts by default", query: "symbol=BTCUSDT", expectedStatus: http.StatusOK, expectedType: models.RatioGlobalAccounts},
+		{name: "top trader positions", query: "symbol=BTCUSDT&type=top-position&period=4h", expectedStatus: http.StatusOK, expectedType: models.RatioTopPositions},
+		{name: "unknown type", query: "symbol=BTCUSDT&type=whales", expectedStatus: http.StatusBadRequest, expectedMessage: "type must be top-account, top-position or global-account"},
+		{name: "missing symbol", query: "type=top-account", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing symbol"},
+		{name: "invalid symbol", query: "symbol=NOPEUSDT", expectedStatus: http.StatusBadRequest, expectedMessage: "API returned status code: 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/long-short-ratio?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMessage, response["message"])
+				return
+			}
+
+			var response models.ResponseLongShortRatio
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedType, response.Type)
+			assert.Equal(t, []models.ResponseLongShortRatioEach{
+				{Time: "2024-12-11T07:00:00Z", LongShortRatio: 1.5, LongAccount: 0.6, ShortAccount: 0.4},
+			}, response.History)
+			assert.Equal(t, int64(1733900399999), response.Cursor)
+		})
+	}
+}
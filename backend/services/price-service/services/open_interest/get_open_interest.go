@@ -0,0 +1,60 @@
+package openinterest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get open interest
+// @Description Retrieves the current open interest of a perpetual contract in its base asset and valued at the mark price, from Binance Futures or the requested exchange
+// @Tags Open Interest
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param exchange query string false "Exchange: binance (default), okx or bybit" example("binance")
+// @Success 200 {object} models.ResponseOpenInterest "Successful response with open interest"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbol or invalid parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/open-interest [get]
+func GetOpenInterest(context *gin.Context) {
+	symbol := context.Query("symbol")
+	if symbol == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing symbol", context)
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	response, statusCode, err := GetOpenInterestData(marketData, provider.NormalizeSymbol(symbol))
+	if err != nil {
+		utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+		return
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// GetOpenInterestData reads the open interest of a symbol with its mark price
+func GetOpenInterestData(marketData provider.MarketDataProvider, symbol string) (*models.ResponseOpenInterest, models.StatusCode, error) {
+	openInterest, statusCode, err := marketData.OpenInterest(symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	premiumIndex, statusCode, err := marketData.PremiumIndex(symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	markPrice, _ := strconv.ParseFloat(premiumIndex.MarkPrice, 64)
+	var response models.ResponseOpenInterest
+	response.UpdateData(openInterest, markPrice, utils.ConvertMillisecondsToTimestamp(openInterest.Time))
+	return &response, http.StatusOK, nil
+}
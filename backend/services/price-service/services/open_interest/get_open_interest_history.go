@@ -0,0 +1,99 @@
+package openinterest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPeriod is the period of the series without the period parameter
+	DefaultPeriod = "1h"
+	// DefaultLimit and MaxLimit bound the number of points, exchanges with a lower cap return fewer
+	DefaultLimit = 30
+	MaxLimit     = 500
+)
+
+// @Summary Get open interest history
+// @Description Retrieves the open interest of a perpetual contract every period, oldest first, with times aligned to the klines of the same interval, from Binance Futures or the requested exchange. Binance keeps the last 30 days.
+// @Tags Open Interest
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param period query string false "Period: 5m, 15m, 30m, 1h (default), 2h, 4h, 6h, 12h or 1d, Bybit has no 2h, 6h and 12h" example("1h")
+// @Param startTime query int false "Time in milliseconds of the first point"
+// @Param endTime query int false "Time in milliseconds of the last point, the cursor of a previous page loads older points"
+// @Param limit query int false "Number of points, the latest of the range are kept, 30 by default and at most 500 (100 on OKX, 200 on Bybit)"
+// @Param exchange query string false "Exchange: binance (default), okx or bybit" example("binance")
+// @Success 200 {object} models.ResponseOpenInterestHistory "Open interest oldest first"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Missing symbol or invalid parameters"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/open-interest/history [get]
+func GetOpenInterestHistory(context *gin.Context) {
+	query, ok := parsePositioningQuery(context)
+	if !ok {
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	history, statusCode, err := marketData.OpenInterestHistory(query)
+	if err != nil {
+		utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+		return
+	}
+
+	var response models.ResponseOpenInterestHistory
+	response.UpdateData(query.Symbol, query.Period, history, utils.ConvertMilisecondToTimeFormatedRFC3339, utils.GetTimeNow())
+	if len(history) > 0 && history[0].Time > query.StartTime {
+		response.Cursor = history[0].Time - 1
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// parsePositioningQuery reads the symbol, period, startTime, endTime and limit parameters shared by
+// the open interest and long/short ratio series. It answers 400 and returns false when one is invalid.
+func parsePositioningQuery(context *gin.Context) (models.PositioningQuery, bool) {
+	query := models.PositioningQuery{
+		Symbol: provider.NormalizeSymbol(context.Query("symbol")),
+		Period: context.DefaultQuery("period", DefaultPeriod),
+		Limit:  DefaultLimit,
+	}
+	if query.Symbol == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing symbol", context)
+		return query, false
+	}
+	if !provider.IsValidPeriod(query.Period) {
+		utils.ShowError(http.StatusBadRequest, "Invalid period", context)
+		return query, false
+	}
+
+	var ok bool
+	if query.StartTime, ok = utils.QueryMilliseconds(context, "startTime"); !ok {
+		return query, false
+	}
+	if query.EndTime, ok = utils.QueryMilliseconds(context, "endTime"); !ok {
+		return query, false
+	}
+	if query.EndTime > 0 && query.StartTime > query.EndTime {
+		utils.ShowError(http.StatusBadRequest, "startTime must not be after endTime", context)
+		return query, false
+	}
+
+	if value := context.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			utils.ShowError(http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(MaxLimit), context)
+			return query, false
+		}
+		query.Limit = limit
+	}
+	return query, true
+}
@@ -0,0 +1,81 @@
+package openinterest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOpenInterestHistory(t *testing.T) {
+	query := setupMockBinance(t)
+	router := setupTestRouter()
+	router.GET("/open-interest/history", GetOpenInterestHistory)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedTimes   []string
+		expectedCursor  int64
+		expectedPeriod  string
+		expectedLimit   string
+		expectedMessage string
+	}{
+		{
+			name:           "latest points oldest first",
+			query:          "symbol=BTCUSDT",
+			expectedStatus: http.StatusOK,
+			expectedTimes:  []string{"2024-12-11T06:00:00Z", "2024-12-11T07:00:00Z"},
+			expectedCursor: 1733896799999,
+			expectedPeriod: "1h",
+			expectedLimit:  "30",
+		},
+		{
+			name:           "from a start time",
+			query:          "symbol=BTCUSDT&period=5m&startTime=1733896800001&limit=500",
+			expectedStatus: http.StatusOK,
+			expectedTimes:  []string{"2024-12-11T07:00:00Z"},
+			expectedCursor: 1733900399999,
+			expectedPeriod: "5m",
+			expectedLimit:  "500",
+		},
+		{name: "missing symbol", query: "period=1h", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing symbol"},
+		{name: "invalid period", query: "symbol=BTCUSDT&period=1m", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid period"},
+		{name: "invalid limit", query: "symbol=BTCUSDT&limit=501", expectedStatus: http.StatusBadRequest, expectedMessage: "limit must be between 1 and 500"},
+		{name: "invalid start time", query: "symbol=BTCUSDT&startTime=soon", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid startTime"},
+		{name: "start after end", query: "symbol=BTCUSDT&startTime=2&endTime=1", expectedStatus: http.StatusBadRequest, expectedMessage: "startTime must not be after endTime"},
+		{name: "not supported", query: "symbol=BTCUSDT&exchange=coinbase", expectedStatus: http.StatusBadRequest, expectedMessage: "coinbase futures: not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/open-interest/history?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMessage, response["message"])
+				return
+			}
+
+			var response models.ResponseOpenInterestHistory
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedPeriod, response.Period)
+			assert.Equal(t, tt.expectedCursor, response.Cursor)
+			assert.Equal(t, tt.expectedLimit, query.Get("limit"))
+			times := make([]string, 0, len(response.History))
+			for _, point := range response.History {
+				times = append(times, point.Time)
+			}
+			assert.Equal(t, tt.expectedTimes, times)
+			assert.Equal(t, 80000.5, response.History[len(response.History)-1].OpenInterest)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package openinterest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupMockBinance serves the open interest and long/short ratio data of BTCUSDT like Binance Futures
+// and returns the query of the last request
+func setupMockBinance(t *testing.T) *url.Values {
+	var query url.Values
+	binance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if query.Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/fapi/v1/openInterest":
+			w.Write([]byte(`{"openInterest":"80000.5","symbol":"BTCUSDT","time":1733900000000}`))
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"97000.00","indexPrice":"96990.00","lastFundingRate":"0.0001","nextFundingTime":1733904000000,"time":1733900000000}`))
+		case "/futures/data/openInterestHist":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","sumOpenInterest":"79000","sumOpenInterestValue":"7663000000","timestamp":1733896800000},{"symbol":"BTCUSDT","sumOpenInterest":"80000.5","sumOpenInterestValue":"7760048500","timestamp":1733900400000}]`))
+		case "/futures/data/globalLongShortAccountRatio", "/futures/data/topLongShortPositionRatio":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","longShortRatio":"1.5","longAccount":"0.6","shortAccount":"0.4","timestamp":"1733900400000"}]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	provider.SetDefault(provider.NewBinanceProvider(binance.URL, binance.URL))
+	t.Cleanup(func() {
+		provider.SetDefault(nil)
+		binance.Close()
+	})
+	return &query
+}
+
+func setupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	return gin.New()
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	setupMockBinance(t)
+	router := setupTestRouter()
+	router.GET("/open-interest", GetOpenInterest)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "valued at the mark price", query: "symbol=btc-usdt", expectedStatus: http.StatusOK},
+		{name: "missing symbol", query: "", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing symbol"},
+		{name: "unknown exchange", query: "symbol=BTCUSDT&exchange=kraken", expectedStatus: http.StatusBadRequest, expectedMessage: "exchange kraken is not supported"},
+		{name: "invalid symbol", query: "symbol=NOPEUSDT", expectedStatus: http.StatusBadRequest, expectedMessage: "API returned status code: 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/open-interest?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedMessage != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedMessage, response["message"])
+				return
+			}
+
+			var response models.ResponseOpenInterest
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "BTCUSDT", response.Symbol)
+			assert.Equal(t, 80000.5, response.OpenInterest)
+			assert.Equal(t, 97000.0, response.MarkPrice)
+			assert.Equal(t, 7760048500.0, response.OpenInterestValue)
+			assert.NotEmpty(t, response.EventTime)
+		})
+	}
+}
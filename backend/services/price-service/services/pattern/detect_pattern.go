@@ -0,0 +1,242 @@
+package pattern
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	PatternDoji               = "doji"
+	PatternHammer             = "hammer"
+	PatternShootingStar       = "shooting_star"
+	PatternBullishEngulfing   = "bullish_engulfing"
+	PatternBearishEngulfing   = "bearish_engulfing"
+	PatternMorningStar        = "morning_star"
+	PatternEveningStar        = "evening_star"
+	PatternThreeWhiteSoldiers = "three_white_soldiers"
+	PatternThreeBlackCrows    = "three_black_crows"
+
+	// trendCandles is how many candles before a pattern set the trend it reverses
+	trendCandles = 5
+	// averageCandles is how many candles before a pattern set the size of a long body
+	averageCandles = 10
+)
+
+// detector looks for a pattern ending at candle i, it returns the confidence and whether it was found
+type detector struct {
+	name      string
+	direction string
+	length    int
+	detect    func(candles []models.KLineEachData, i int) (float64, bool)
+}
+
+// detectors are in the order patterns ending on the same candle are returned
+var detectors = []detector{
+	{name: PatternDoji, direction: models.DirectionNeutral, length: 1, detect: doji},
+	{name: PatternHammer, direction: models.DirectionBullish, length: 1, detect: hammer},
+	{name: PatternShootingStar, direction: models.DirectionBearish, length: 1, detect: shootingStar},
+	{name: PatternBullishEngulfing, direction: models.DirectionBullish, length: 2, detect: engulfing(true)},
+	{name: PatternBearishEngulfing, direction: models.DirectionBearish, length: 2, detect: engulfing(false)},
+	{name: PatternMorningStar, direction: models.DirectionBullish, length: 3, detect: star(true)},
+	{name: PatternEveningStar, direction: models.DirectionBearish, length: 3, detect: star(false)},
+	{name: PatternThreeWhiteSoldiers, direction: models.DirectionBullish, length: 3, detect: threeCandles(true)},
+	{name: PatternThreeBlackCrows, direction: models.DirectionBearish, length: 3, detect: threeCandles(false)},
+}
+
+// ParsePatterns reads a comma separated list of pattern names, every pattern when value is empty
+func ParsePatterns(value string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, detector := range detectors {
+			known = known || detector.name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("Unknown pattern %s", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// Detect returns the patterns among names, or every pattern when names is empty, found over candles
+// ordered by their last candle
+func Detect(candles []models.KLineEachData, names []string) []models.CandlePattern {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	patterns := []models.CandlePattern{}
+	for i := range candles {
+		for _, detector := range detectors {
+			if len(wanted) > 0 && !wanted[detector.name] || i+1 < detector.length {
+				continue
+			}
+			confidence, ok := detector.detect(candles, i)
+			if !ok {
+				continue
+			}
+			indices := make([]int, 0, detector.length)
+			for index := i - detector.length + 1; index <= i; index++ {
+				indices = append(indices, index)
+			}
+			patterns = append(patterns, models.CandlePattern{
+				Pattern:    detector.name,
+				Direction:  detector.direction,
+				Indices:    indices,
+				Time:       candles[i].Time,
+				Confidence: math.Round(clamp(confidence)*100) / 100,
+			})
+		}
+	}
+	return patterns
+}
+
+// shape is the body and shadows of a candle
+type shape struct {
+	body, upper, lower, size float64
+	bullish, bearish         bool
+}
+
+func shapeOf(candle models.KLineEachData) shape {
+	return shape{
+		body:    math.Abs(candle.Close - candle.Open),
+		upper:   candle.High - math.Max(candle.Open, candle.Close),
+		lower:   math.Min(candle.Open, candle.Close) - candle.Low,
+		size:    candle.High - candle.Low,
+		bullish: candle.Close > candle.Open,
+		bearish: candle.Close < candle.Open,
+	}
+}
+
+// doji opens and closes at almost the same price, its body at most a tenth of its range
+func doji(candles []models.KLineEachData, i int) (float64, bool) {
+	s := shapeOf(candles[i])
+	if s.size <= 0 || s.body > 0.1*s.size {
+		return 0, false
+	}
+	return 1 - 5*s.body/s.size, true
+}
+
+// hammer ends a downtrend with a small body on top of a lower shadow at least twice as long
+func hammer(candles []models.KLineEachData, i int) (float64, bool) {
+	s := shapeOf(candles[i])
+	if s.size <= 0 || s.body > 0.35*s.size || s.lower < 2*s.body || s.upper > 0.15*s.size || priorTrend(candles, i) >= 0 {
+		return 0, false
+	}
+	return s.lower / s.size, true
+}
+
+// shootingStar ends an uptrend with a small body under an upper shadow at least twice as long
+func shootingStar(candles []models.KLineEachData, i int) (float64, bool) {
+	s := shapeOf(candles[i])
+	if s.size <= 0 || s.body > 0.35*s.size || s.upper < 2*s.body || s.lower > 0.15*s.size || priorTrend(candles, i) <= 0 {
+		return 0, false
+	}
+	return s.upper / s.size, true
+}
+
+// engulfing is a candle whose body covers the whole body of the previous one, of the other color
+func engulfing(bullish bool) func(candles []models.KLineEachData, i int) (float64, bool) {
+	return func(candles []models.KLineEachData, i int) (float64, bool) {
+		previous, current := candles[i-1], candles[i]
+		p, c := shapeOf(previous), shapeOf(current)
+		if c.body <= p.body {
+			return 0, false
+		}
+		if bullish && !(p.bearish && c.bullish && current.Open <= previous.Close && current.Close >= previous.Open) {
+			return 0, false
+		}
+		if !bullish && !(p.bullish && c.bearish && current.Open >= previous.Close && current.Close <= previous.Open) {
+			return 0, false
+		}
+		return 1 - p.body/(2*c.body), true
+	}
+}
+
+// star is a long candle, a small one past its close and a candle of the other color closing beyond the
+// middle of the first body: a morning star when the first candle falls, an evening star when it rises
+func star(morning bool) func(candles []models.KLineEachData, i int) (float64, bool) {
+	return func(candles []models.KLineEachData, i int) (float64, bool) {
+		first, second, third := candles[i-2], candles[i-1], candles[i]
+		f, s, t := shapeOf(first), shapeOf(second), shapeOf(third)
+		if f.body < averageBody(candles, i-2) || s.body > 0.3*f.body {
+			return 0, false
+		}
+		middle := (first.Open + first.Close) / 2
+		if morning {
+			if !f.bearish || !t.bullish || math.Max(second.Open, second.Close) > middle || third.Close <= middle {
+				return 0, false
+			}
+			return (third.Close - first.Close) / f.body, true
+		}
+		if !f.bullish || !t.bearish || math.Min(second.Open, second.Close) < middle || third.Close >= middle {
+			return 0, false
+		}
+		return (first.Close - third.Close) / f.body, true
+	}
+}
+
+// threeCandles is three long candles of one color, each closing further and opening within the body of
+// the previous one: three white soldiers when they rise, three black crows when they fall
+func threeCandles(rising bool) func(candles []models.KLineEachData, i int) (float64, bool) {
+	return func(candles []models.KLineEachData, i int) (float64, bool) {
+		strength := 0.0
+		for j := i - 2; j <= i; j++ {
+			candle, s := candles[j], shapeOf(candles[j])
+			if s.size <= 0 || s.body < 0.5*s.size {
+				return 0, false
+			}
+			if rising && (!s.bullish || s.upper > 0.3*s.body) || !rising && (!s.bearish || s.lower > 0.3*s.body) {
+				return 0, false
+			}
+			if j > i-2 {
+				previous := candles[j-1]
+				low, high := math.Min(previous.Open, previous.Close), math.Max(previous.Open, previous.Close)
+				if candle.Open < low || candle.Open > high {
+					return 0, false
+				}
+				if rising && candle.Close <= previous.Close || !rising && candle.Close >= previous.Close {
+					return 0, false
+				}
+			}
+			strength += s.body / s.size / 3
+		}
+		return strength, true
+	}
+}
+
+// priorTrend returns the change of the close over the trendCandles candles before candle i,
+// 0 for the first candle
+func priorTrend(candles []models.KLineEachData, i int) float64 {
+	if i < 1 {
+		return 0
+	}
+	return candles[i-1].Close - candles[max(0, i-trendCandles)].Close
+}
+
+// averageBody returns the average body of the averageCandles candles before candle i, or the body of
+// candle i itself when it is the first
+func averageBody(candles []models.KLineEachData, i int) float64 {
+	start := max(0, i-averageCandles)
+	if start == i {
+		return shapeOf(candles[i]).body
+	}
+	sum := 0.0
+	for _, candle := range candles[start:i] {
+		sum += shapeOf(candle).body
+	}
+	return sum / float64(i-start)
+}
+
+func clamp(confidence float64) float64 {
+	return math.Max(0, math.Min(1, confidence))
+}
@@ -0,0 +1,147 @@
+package pattern
+
+import (
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func candle(open, high, low, close float64) models.KLineEachData {
+	return models.KLineEachData{Open: open, High: high, Low: low, Close: close, Volume: 1}
+}
+
+// downtrend is five falling candles closing from 120 to 104, none of them a pattern
+func downtrend() []models.KLineEachData {
+	var candles []models.KLineEachData
+	for close := 120.0; close >= 104; close -= 4 {
+		candles = append(candles, candle(close+3, close+3.5, close-0.5, close))
+	}
+	return candles
+}
+
+// uptrend is five rising candles closing from 80 to 96, none of them a pattern
+func uptrend() []models.KLineEachData {
+	var candles []models.KLineEachData
+	for close := 80.0; close <= 96; close += 4 {
+		candles = append(candles, candle(close-3, close+0.5, close-3.5, close))
+	}
+	return candles
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name               string
+		candles            []models.KLineEachData
+		expectedPatterns   []string
+		expectedConfidence float64
+		expectedLength     int
+	}{
+		{name: "Doji", candles: []models.KLineEachData{candle(100, 105, 95, 100.5)}, expectedPatterns: []string{PatternDoji}, expectedConfidence: 0.75, expectedLength: 1},
+		{name: "Hammer after a downtrend", candles: append(downtrend(), candle(101, 102.2, 95, 102)), expectedPatterns: []string{PatternHammer}, expectedConfidence: 0.83, expectedLength: 1},
+		{name: "Hammer shape after an uptrend", candles: append(uptrend(), candle(101, 102.2, 95, 102))},
+		{name: "Shooting star after an uptrend", candles: append(uptrend(), candle(99, 105, 97.8, 98)), expectedPatterns: []string{PatternShootingStar}, expectedConfidence: 0.83, expectedLength: 1},
+		{name: "Shooting star shape after a downtrend", candles: append(downtrend(), candle(99, 105, 97.8, 98))},
+		{
+			name:               "Bullish engulfing",
+			candles:            []models.KLineEachData{candle(105, 106, 99, 100), candle(99.5, 106.5, 99, 106)},
+			expectedPatterns:   []string{PatternBullishEngulfing},
+			expectedConfidence: 0.62,
+			expectedLength:     2,
+		},
+		{
+			name:               "Bearish engulfing",
+			candles:            []models.KLineEachData{candle(100, 106, 99, 105), candle(105.5, 106, 98.5, 99)},
+			expectedPatterns:   []string{PatternBearishEngulfing},
+			expectedConfidence: 0.62,
+			expectedLength:     2,
+		},
+		{name: "Body inside the previous one", candles: []models.KLineEachData{candle(105, 106, 99, 100), candle(101, 104.5, 100.5, 104)}},
+		{
+			name:               "Morning star",
+			candles:            []models.KLineEachData{candle(110, 111, 99.5, 100), candle(99.5, 100, 98, 99), candle(99.5, 108.5, 99, 108)},
+			expectedPatterns:   []string{PatternMorningStar},
+			expectedConfidence: 0.8,
+			expectedLength:     3,
+		},
+		{
+			name:               "Evening star",
+			candles:            []models.KLineEachData{candle(100, 110.5, 99, 110), candle(110.5, 112, 110, 111), candle(110.5, 111, 101.5, 102)},
+			expectedPatterns:   []string{PatternEveningStar},
+			expectedConfidence: 0.8,
+			expectedLength:     3,
+		},
+		{name: "Star not closing past the middle", candles: []models.KLineEachData{candle(110, 111, 99.5, 100), candle(99.5, 100, 98, 99), candle(99.5, 104.5, 99, 104)}},
+		{
+			name:               "Three white soldiers",
+			candles:            []models.KLineEachData{candle(100, 104.5, 99.8, 104), candle(102, 107.6, 101.8, 107), candle(105, 110.5, 104.8, 110)},
+			expectedPatterns:   []string{PatternThreeWhiteSoldiers},
+			expectedConfidence: 0.86,
+			expectedLength:     3,
+		},
+		{
+			name:               "Three black crows",
+			candles:            []models.KLineEachData{candle(110, 110.2, 105.5, 106), candle(108, 108.2, 102.4, 103), candle(105, 105.2, 99.5, 100)},
+			expectedPatterns:   []string{PatternThreeBlackCrows},
+			expectedConfidence: 0.86,
+			expectedLength:     3,
+		},
+		{name: "Rising candles opening above the previous body", candles: []models.KLineEachData{candle(100, 104.5, 99.8, 104), candle(105, 109.6, 104.8, 109), candle(110, 114.5, 109.8, 114)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last := len(tt.candles) - 1
+			var found []models.CandlePattern
+			for _, pattern := range Detect(tt.candles, nil) {
+				if pattern.Indices[len(pattern.Indices)-1] == last {
+					found = append(found, pattern)
+				}
+			}
+
+			var names []string
+			for _, pattern := range found {
+				names = append(names, pattern.Pattern)
+			}
+			assert.Equal(t, tt.expectedPatterns, names)
+			if len(found) == 1 {
+				assert.Equal(t, tt.expectedConfidence, found[0].Confidence)
+				assert.Len(t, found[0].Indices, tt.expectedLength)
+				assert.Equal(t, last-tt.expectedLength+1, found[0].Indices[0])
+			}
+		})
+	}
+}
+
+func TestDetectFiltersAndOrders(t *testing.T) {
+	candles := append(downtrend(), candle(101, 102.2, 95, 102), candle(100, 105, 95, 100.5))
+	candles[6].Time = "2024-11-21T06:00:00Z"
+
+	patterns := Detect(candles, nil)
+	assert.Len(t, patterns, 2)
+	assert.Equal(t, PatternHammer, patterns[0].Pattern)
+	assert.Equal(t, []int{5}, patterns[0].Indices)
+	assert.Equal(t, models.DirectionBullish, patterns[0].Direction)
+	assert.Equal(t, PatternDoji, patterns[1].Pattern)
+	assert.Equal(t, models.DirectionNeutral, patterns[1].Direction)
+	assert.Equal(t, "2024-11-21T06:00:00Z", patterns[1].Time)
+
+	patterns = Detect(candles, []string{PatternDoji})
+	assert.Len(t, patterns, 1)
+	assert.Equal(t, PatternDoji, patterns[0].Pattern)
+
+	assert.Equal(t, []models.CandlePattern{}, Detect(nil, nil))
+}
+
+func TestParsePatterns(t *testing.T) {
+	names, err := ParsePatterns(" Hammer, morning_star ,")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{PatternHammer, PatternMorningStar}, names)
+
+	names, err = ParsePatterns("")
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	_, err = ParsePatterns("hammer,head_and_shoulders")
+	assert.EqualError(t, err, "Unknown pattern head_and_shoulders")
+}
@@ -0,0 +1,107 @@
+package pattern
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/kline"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPatternLimit is the number of candles searched when no limit is requested
+	DefaultPatternLimit = 100
+	// MaxPatternLimit bounds the candles of one search
+	MaxPatternLimit = 1000
+)
+
+// @Summary Get candlestick patterns
+// @Description Searches the Kline data of a symbol for candlestick patterns: doji, hammer, shooting star, bullish and bearish engulfing, morning and evening star, three white soldiers and three black crows. Each pattern comes with the indices of its candles in kline_data and a confidence from 0 to 1.
+// @Tags Patterns
+// @Param Authorization header string true "Authorization token"
+// @Param symbol query string true "Symbol to search (e.g., BTCUSDT)"
+// @Param interval query string true "Interval of the candles (e.g., 1m, 5m, 1h, 1d), any count of m, h, d, w or M such as 10m or 2w is built from a finer interval"
+// @Param patterns query string false "Comma separated patterns to search, every pattern by default" example("bullish_engulfing,hammer")
+// @Param timezone query string false "Timezone the day, week (from Monday) and month candles open in, e.g. UTC+7 or Asia/Ho_Chi_Minh, UTC by default"
+// @Param endTime query int false "Open time in milliseconds of the last candle, the cursor of a previous page loads older candles"
+// @Param limit query int false "Number of candles, 100 by default and at most 1000"
+// @Param market query string false "Market: futures (default) or spot" example("futures")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponseCandlePatterns "Successful response with candles and patterns"
+// @Failure 400 {object} models.ErrorResponseInputMissing "Missing data or invalid parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/vip3/patterns [get]
+func GetPatterns(context *gin.Context) {
+	query := models.KlineQuery{
+		Symbol:   context.Query("symbol"),
+		Interval: context.Query("interval"),
+		Market:   context.Query("market"),
+		Timezone: context.Query("timezone"),
+		Limit:    DefaultPatternLimit,
+	}
+	if query.Symbol == "" || query.Interval == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing data", context)
+		return
+	}
+	names, err := ParsePatterns(context.Query("patterns"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	if context.Query("endTime") != "" {
+		endTime, err := strconv.ParseInt(context.Query("endTime"), 10, 64)
+		if err != nil || endTime <= 0 {
+			utils.ShowError(http.StatusBadRequest, "Invalid endTime", context)
+			return
+		}
+		query.EndTime = endTime
+	}
+	if context.Query("limit") != "" {
+		limit, err := strconv.Atoi(context.Query("limit"))
+		if err != nil || limit < 1 || limit > MaxPatternLimit {
+			utils.ShowError(http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", MaxPatternLimit), context)
+			return
+		}
+		query.Limit = limit
+	}
+	if query.Market, err = provider.NormalizeMarket(query.Market); err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	response, statusCode, err := GetPatternsData(marketData, query, names)
+	if err != nil {
+		responseStatusCode := utils.ResponseStatusCode(statusCode)
+		if responseStatusCode == http.StatusInternalServerError {
+			utils.ShowError(http.StatusInternalServerError, "Internal server error", context)
+			return
+		}
+		utils.ShowError(int64(responseStatusCode), err.Error(), context)
+		return
+	}
+	context.JSON(http.StatusOK, response)
+}
+
+// GetPatternsData loads the candles of query and searches them for the patterns among names, every
+// pattern when names is empty
+func GetPatternsData(marketData provider.MarketDataProvider, query models.KlineQuery, names []string) (*models.ResponseCandlePatterns, models.StatusCode, error) {
+	candles, statusCode, err := kline.LoadKlines(marketData, query)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	response := &models.ResponseCandlePatterns{KlineResponse: kline.NewKlineResponse(query.Symbol, query.Interval, candles, nil)}
+	response.Patterns = Detect(response.KlineData, names)
+	return response, http.StatusOK, nil
+}
@@ -0,0 +1,104 @@
+package pattern
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const hour = int64(3600000)
+
+// hourlyCandles opens the candles hourly from 2024-11-21T00:00:00Z
+func hourlyCandles(data []models.KLineEachData) []models.Candle {
+	candles := make([]models.Candle, len(data))
+	for i, each := range data {
+		candles[i] = models.Candle{OpenTime: 1732147200000 + int64(i)*hour, Open: each.Open, High: each.High, Low: each.Low, Close: each.Close, Volume: each.Volume}
+	}
+	return candles
+}
+
+// setupTestRouter serves BTCUSDT ending with a bullish engulfing and ETHUSDT with a hammer then 5 quiet candles
+func setupTestRouter(t *testing.T) *gin.Engine {
+	quiet := make([]models.KLineEachData, 5)
+	for i := range quiet {
+		quiet[i] = candle(100, 101, 99, 100.5+float64(i)*0.1)
+	}
+	eth := append(append(downtrend(), candle(101, 102.2, 95, 102)), quiet...)
+	btc := append(downtrend(), candle(105, 106, 99, 100), candle(99.5, 106.5, 99, 106))
+	provider.SetDefault(&providertest.FakeExchange{Candles: map[string][]models.Candle{"BTCUSDT": hourlyCandles(btc), "ETHUSDT": hourlyCandles(eth)}})
+	t.Cleanup(func() { provider.SetDefault(nil) })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/patterns", GetPatterns)
+	router.GET("/patterns/scan", GetPatternScan)
+	return router
+}
+
+func TestGetPatterns(t *testing.T) {
+	router := setupTestRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/patterns?symbol=BTCUSDT&interval=1h", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ResponseCandlePatterns
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "BTCUSDT", response.Symbol)
+	assert.Len(t, response.KlineData, 7)
+	assert.Equal(t, []models.CandlePattern{{
+		Pattern:    PatternBullishEngulfing,
+		Direction:  models.DirectionBullish,
+		Indices:    []int{5, 6},
+		Time:       "2024-11-21T06:00:00Z",
+		Confidence: 0.62,
+	}}, response.Patterns)
+
+	// indices follow the candles returned
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/patterns?symbol=BTCUSDT&interval=1h&limit=2&patterns=bullish_engulfing", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.KlineData, 2)
+	assert.Equal(t, []int{0, 1}, response.Patterns[0].Indices)
+}
+
+func TestGetPatternsErrors(t *testing.T) {
+	router := setupTestRouter(t)
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{name: "Missing interval", query: "symbol=BTCUSDT", expectedStatus: http.StatusBadRequest, expectedMessage: "Missing data"},
+		{name: "Unknown pattern", query: "symbol=BTCUSDT&interval=1h&patterns=cup", expectedStatus: http.StatusBadRequest, expectedMessage: "Unknown pattern cup"},
+		{name: "Invalid limit", query: "symbol=BTCUSDT&interval=1h&limit=1001", expectedStatus: http.StatusBadRequest, expectedMessage: "limit must be between 1 and 1000"},
+		{name: "Invalid endTime", query: "symbol=BTCUSDT&interval=1h&endTime=-1", expectedStatus: http.StatusBadRequest, expectedMessage: "Invalid endTime"},
+		{name: "Unknown exchange", query: "symbol=BTCUSDT&interval=1h&exchange=kraken", expectedStatus: http.StatusBadRequest, expectedMessage: "exchange kraken is not supported"},
+		{name: "Invalid symbol", query: "symbol=NOPEUSDT&interval=1h", expectedStatus: http.StatusBadRequest, expectedMessage: "API returned status code: 400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/patterns?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
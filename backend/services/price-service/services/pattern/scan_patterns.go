@@ -0,0 +1,138 @@
+package pattern
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/kline"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultScanRecent is how many of the latest candles a scanned pattern may end on
+	DefaultScanRecent = 3
+	// MaxScanSymbols bounds the watchlist of one scan
+	MaxScanSymbols = 50
+
+	// scanCandles are loaded for each symbol, enough for the trend and body size before a pattern
+	scanCandles = 30
+	// scanWorkers bounds the symbols loaded at once
+	scanWorkers = 8
+)
+
+// @Summary Scan a watchlist for candlestick patterns
+// @Description Searches the latest closed candles of every symbol of a watchlist for candlestick patterns and returns the patterns that ended within the recent candles. A symbol that cannot be loaded is reported with its error.
+// @Tags Patterns
+// @Param Authorization header string true "Authorization token"
+// @Param symbols query string false "Comma separated symbols, PATTERN_WATCHLIST by default, at most 50" example("BTCUSDT,ETHUSDT")
+// @Param interval query string true "Interval of the candles (e.g., 15m, 1h, 4h, 1d)" example("1h")
+// @Param recent query int false "Number of latest candles a pattern may end on, 3 by default and at most 30" example(3)
+// @Param patterns query string false "Comma separated patterns to search, every pattern by default" example("morning_star,evening_star")
+// @Param market query string false "Market: futures (default) or spot" example("futures")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponsePatternScan "Successful response with the recent patterns of every symbol"
+// @Failure 400 {object} models.ErrorResponseInputMissing "Missing data or invalid parameters"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Internal server error"
+// @Router /api/v1/vip3/patterns/scan [get]
+func GetPatternScan(context *gin.Context) {
+	symbols := provider.ParseSymbols(context.QueryArray("symbols"))
+	if len(symbols) == 0 {
+		symbols = provider.ParseSymbols([]string{os.Getenv("PATTERN_WATCHLIST")})
+	}
+	interval := context.Query("interval")
+	if len(symbols) == 0 || interval == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing data", context)
+		return
+	}
+	if len(symbols) > MaxScanSymbols {
+		utils.ShowError(http.StatusBadRequest, fmt.Sprintf("At most %d symbols per scan", MaxScanSymbols), context)
+		return
+	}
+	names, err := ParsePatterns(context.Query("patterns"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	recent := DefaultScanRecent
+	if context.Query("recent") != "" {
+		recent, err = strconv.Atoi(context.Query("recent"))
+		if err != nil || recent < 1 || recent > scanCandles {
+			utils.ShowError(http.StatusBadRequest, fmt.Sprintf("recent must be between 1 and %d", scanCandles), context)
+			return
+		}
+	}
+	market, err := provider.NormalizeMarket(context.Query("market"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	// one more candle for the one still open
+	response := ScanPatterns(marketData, models.KlineQuery{Interval: interval, Market: market, Limit: scanCandles + 1}, symbols, names, recent)
+	context.JSON(http.StatusOK, response)
+}
+
+// ScanPatterns searches the closed candles of query for every symbol at once and keeps the patterns
+// ending on one of the last recent candles
+func ScanPatterns(marketData provider.MarketDataProvider, query models.KlineQuery, symbols, names []string, recent int) *models.ResponsePatternScan {
+	entries := make([]models.PatternScanEntry, len(symbols))
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, scanWorkers)
+	for i, symbol := range symbols {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(entry *models.PatternScanEntry, symbol string) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			entry.Symbol = symbol
+			entry.Patterns = []models.CandlePattern{}
+
+			symbolQuery := query
+			symbolQuery.Symbol = symbol
+			candles, _, err := kline.LoadKlines(marketData, symbolQuery)
+			if err != nil {
+				entry.Error = err.Error()
+				return
+			}
+			candles = closedCandles(candles, time.Now())
+			first := len(candles) - recent
+			for _, pattern := range Detect(kline.NewKlineResponse(symbol, query.Interval, candles, nil).KlineData, names) {
+				if pattern.Indices[len(pattern.Indices)-1] >= first {
+					entry.Patterns = append(entry.Patterns, pattern)
+				}
+			}
+		}(&entries[i], symbol)
+	}
+	wg.Wait()
+
+	return &models.ResponsePatternScan{
+		Interval:  query.Interval,
+		Recent:    recent,
+		Symbols:   entries,
+		EventTime: utils.GetTimeNow(),
+	}
+}
+
+// closedCandles drops the candles still open at now, the pattern of an open candle may be gone at its close
+func closedCandles(candles []models.Candle, now time.Time) []models.Candle {
+	end := len(candles)
+	for end > 0 && candles[end-1].CloseTime >= now.UnixMilli() {
+		end--
+	}
+	return candles[:end]
+}
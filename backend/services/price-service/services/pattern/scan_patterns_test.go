@@ -0,0 +1,111 @@
+package pattern
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider/providertest"
+	"github.com/stretchr/testify/assert"
+)
+
+func scan(t *testing.T, query string) (*httptest.ResponseRecorder, models.ResponsePatternScan) {
+	router := setupTestRouter(t)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/patterns/scan?"+query, nil)
+	router.ServeHTTP(w, req)
+
+	var response models.ResponsePatternScan
+	if w.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w, response
+}
+
+func TestGetPatternScan(t *testing.T) {
+	w, response := scan(t, "symbols=btcusdt,ETHUSDT,NOPEUSDT&interval=1h")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1h", response.Interval)
+	assert.Equal(t, DefaultScanRecent, response.Recent)
+	assert.Len(t, response.Symbols, 3)
+
+	btc := response.Symbols[0]
+	assert.Equal(t, "BTCUSDT", btc.Symbol)
+	assert.Len(t, btc.Patterns, 1)
+	assert.Equal(t, PatternBullishEngulfing, btc.Patterns[0].Pattern)
+
+	// the hammer of ETHUSDT is 5 candles old
+	eth := response.Symbols[1]
+	assert.Equal(t, "ETHUSDT", eth.Symbol)
+	assert.Empty(t, eth.Patterns)
+	assert.Empty(t, eth.Error)
+
+	nope := response.Symbols[2]
+	assert.Equal(t, "NOPEUSDT", nope.Symbol)
+	assert.Equal(t, "API returned status code: 400", nope.Error)
+	assert.Equal(t, []models.CandlePattern{}, nope.Patterns)
+
+	w, response = scan(t, "symbols=ETHUSDT&interval=1h&recent=6&patterns=hammer")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, response.Symbols[0].Patterns, 1)
+	assert.Equal(t, PatternHammer, response.Symbols[0].Patterns[0].Pattern)
+}
+
+func TestGetPatternScanWatchlist(t *testing.T) {
+	t.Setenv("PATTERN_WATCHLIST", "ETHUSDT, BTCUSDT")
+	w, response := scan(t, "interval=1h")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, response.Symbols, 2)
+	assert.Equal(t, "ETHUSDT", response.Symbols[0].Symbol)
+	assert.Equal(t, "BTCUSDT", response.Symbols[1].Symbol)
+}
+
+func TestGetPatternScanErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		query           string
+		expectedMessage string
+	}{
+		{name: "Missing symbols", query: "interval=1h", expectedMessage: "Missing data"},
+		{name: "Missing interval", query: "symbols=BTCUSDT", expectedMessage: "Missing data"},
+		{name: "Invalid recent", query: "symbols=BTCUSDT&interval=1h&recent=31", expectedMessage: "recent must be between 1 and 30"},
+		{name: "Unknown pattern", query: "symbols=BTCUSDT&interval=1h&patterns=flag", expectedMessage: "Unknown pattern flag"},
+		{name: "Invalid market", query: "symbols=BTCUSDT&interval=1h&market=options", expectedMessage: "market options is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, _ := scan(t, tt.query)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var response map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedMessage, response["message"])
+		})
+	}
+}
+
+func TestGetPatternScanClosedCandles(t *testing.T) {
+	router := setupTestRouter(t)
+	// the bullish engulfing of BTCUSDT closes in an hour
+	now := time.Now().UnixMilli()
+	candles := hourlyCandles(append(downtrend(), candle(105, 106, 99, 100), candle(99.5, 106.5, 99, 106)))
+	for i := range candles {
+		candles[i].OpenTime = now - int64(len(candles)-i)*hour + hour/2
+		candles[i].CloseTime = candles[i].OpenTime + hour - 1
+	}
+	provider.SetDefault(&providertest.FakeExchange{Candles: map[string][]models.Candle{"BTCUSDT": candles}})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/patterns/scan?symbols=BTCUSDT&interval=1h&recent=1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response models.ResponsePatternScan
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, response.Symbols[0].Patterns)
+	assert.Empty(t, response.Symbols[0].Error)
+}
@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	DefaultBinanceSpotBaseURL    = "https://api.binance.com"
+	DefaultBinanceFuturesBaseURL = "https://fapi.binance.com"
+
+	// BinanceMaxKlines is the most candles Binance futures returns for one request
+	BinanceMaxKlines = 1500
+	// binanceMaxSpotKlines is the most candles Binance spot returns for one request
+	binanceMaxSpotKlines = 1000
+	// binanceMaxDepth is the most levels of each side Binance futures returns, spot returns up to 5000
+	binanceMaxDepth = 1000
+	// binanceMaxTrades is the most aggregated trades Binance returns for one request
+	binanceMaxTrades = 1000
+	// binanceMaxFundingRates is the most funding rates Binance returns for one request
+	binanceMaxFundingRates = 1000
+	// binanceMaxPositions is the most open interest or long/short ratio points Binance returns for one request
+	binanceMaxPositions = 500
+)
+
+// binanceFuturesDepthLimits are the only depth limits Binance futures accepts
+var binanceFuturesDepthLimits = []int{5, 10, 20, 50, 100, 500, 1000}
+
+// BinanceProvider reads market data from the Binance spot and USD-M futures REST APIs
+type BinanceProvider struct {
+	SpotBaseURL    string
+	FuturesBaseURL string
+	// Client is used for every request, http.DefaultClient when nil
+	Client *http.Client
+}
+
+func NewBinanceProvider(spotBaseURL, futuresBaseURL string) *BinanceProvider {
+	return &BinanceProvider{
+		SpotBaseURL:    strings.TrimRight(spotBaseURL, "/"),
+		FuturesBaseURL: strings.TrimRight(futuresBaseURL, "/"),
+	}
+}
+
+// NewBinanceProviderFromEnv uses BINANCE_SPOT_BASE_URL and BINANCE_FUTURES_BASE_URL when they are set
+func NewBinanceProviderFromEnv() *BinanceProvider {
+	spotBaseURL := os.Getenv("BINANCE_SPOT_BASE_URL")
+	if spotBaseURL == "" {
+		spotBaseURL = DefaultBinanceSpotBaseURL
+	}
+	futuresBaseURL := os.Getenv("BINANCE_FUTURES_BASE_URL")
+	if futuresBaseURL == "" {
+		futuresBaseURL = DefaultBinanceFuturesBaseURL
+	}
+	return NewBinanceProvider(spotBaseURL, futuresBaseURL)
+}
+
+func (b *BinanceProvider) Name() string {
+	return ExchangeBinance
+}
+
+func (b *BinanceProvider) SpotTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", symbol)
+
+	var response models.ResponseBinance
+	if statusCode, err := getJSON(b.Client, b.SpotBaseURL+"/api/v3/ticker/price", q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	// spot ticker has no timestamp, use the time it was received
+	if response.Time == 0 {
+		response.Time = time.Now().UnixMilli()
+	}
+	return &response, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) FuturesTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", symbol)
+
+	var response struct {
+		Symbol    string `json:"symbol"`
+		LastPrice string `json:"lastPrice"`
+		CloseTime int64  `json:"closeTime"`
+	}
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+"/fapi/v1/ticker/24hr", q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return &models.ResponseBinance{
+		Symbol: response.Symbol,
+		Price:  response.LastPrice,
+		Time:   response.CloseTime,
+	}, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) PremiumIndex(symbol string) (*models.ResponseBinanceFuture, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", symbol)
+
+	var response models.ResponseBinanceFuture
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+"/fapi/v1/premiumIndex", q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return &response, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) Ticker24h(market, symbol string) (*models.Ticker24h, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", symbol)
+
+	endpoint := b.FuturesBaseURL + "/fapi/v1/ticker/24hr"
+	if market == MarketSpot {
+		endpoint = b.SpotBaseURL + "/api/v3/ticker/24hr"
+	}
+	var response models.Ticker24h
+	if statusCode, err := getJSON(b.Client, endpoint, q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return &response, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) Depth(market, symbol string, limit int) (*models.OrderBook, models.StatusCode, error) {
+	limit = min(limit, binanceMaxDepth)
+	q := url.Values{}
+	q.Add("symbol", symbol)
+
+	endpoint, requestLimit := b.SpotBaseURL+"/api/v3/depth", limit
+	if market != MarketSpot {
+		// futures only accept a few limits, the smallest one that holds limit is requested
+		endpoint, requestLimit = b.FuturesBaseURL+"/fapi/v1/depth", binanceMaxDepth
+		for _, accepted := range binanceFuturesDepthLimits {
+			if accepted >= limit {
+				requestLimit = accepted
+				break
+			}
+		}
+	}
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(requestLimit))
+	}
+
+	var response struct {
+		LastUpdateID int64           `json:"lastUpdateId"`
+		Time         int64           `json:"T"`
+		Bids         [][]interface{} `json:"bids"`
+		Asks         [][]interface{} `json:"asks"`
+	}
+	if statusCode, err := getJSON(b.Client, endpoint, q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	// spot depth has no timestamp, use the time it was received
+	if response.Time == 0 {
+		response.Time = time.Now().UnixMilli()
+	}
+	return orderBook(symbol, response.LastUpdateID, response.Bids, response.Asks, limit, response.Time)
+}
+
+// Trades returns aggregated trades, the fills of one taker order at one price
+func (b *BinanceProvider) Trades(market, symbol string, limit int) ([]models.Trade, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", symbol)
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(min(limit, binanceMaxTrades)))
+	}
+	endpoint := b.FuturesBaseURL + "/fapi/v1/aggTrades"
+	if market == MarketSpot {
+		endpoint = b.SpotBaseURL + "/api/v3/aggTrades"
+	}
+
+	var response []struct {
+		ID           int64  `json:"a"`
+		Price        string `json:"p"`
+		Quantity     string `json:"q"`
+		Time         int64  `json:"T"`
+		IsBuyerMaker bool   `json:"m"`
+	}
+	if statusCode, err := getJSON(b.Client, endpoint, q, &response); err != nil {
+		return nil, statusCode, err
+	}
+
+	trades := make([]models.Trade, 0, len(response))
+	for _, value := range response {
+		side := models.SideBuy
+		if value.IsBuyerMaker {
+			side = models.SideSell
+		}
+		trades = append(trades, models.Trade{
+			ID:       strconv.FormatInt(value.ID, 10),
+			Price:    toFloat(value.Price),
+			Quantity: toFloat(value.Quantity),
+			Side:     side,
+			Time:     value.Time,
+		})
+	}
+	return trades, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) SpotTickers() ([]models.ResponseBinance, models.StatusCode, error) {
+	var response []models.ResponseBinance
+	if statusCode, err := getJSON(b.Client, b.SpotBaseURL+"/api/v3/ticker/price", nil, &response); err != nil {
+		return nil, statusCode, err
+	}
+	receivedAt := time.Now().UnixMilli()
+	for i := range response {
+		response[i].Time = receivedAt
+	}
+	return response, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) PremiumIndexes() ([]models.ResponseBinanceFuture, models.StatusCode, error) {
+	var response []models.ResponseBinanceFuture
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+"/fapi/v1/premiumIndex", nil, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return response, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) Klines(query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", query.Symbol)
+	q.Add("interval", query.Interval)
+	if query.StartTime > 0 {
+		q.Add("startTime", strconv.FormatInt(query.StartTime, 10))
+	}
+	if query.EndTime > 0 {
+		q.Add("endTime", strconv.FormatInt(query.EndTime, 10))
+	}
+	endpoint, maxKlines := b.FuturesBaseURL+"/fapi/v1/klines", BinanceMaxKlines
+	if query.Market == MarketSpot {
+		endpoint, maxKlines = b.SpotBaseURL+"/api/v3/klines", binanceMaxSpotKlines
+	}
+	if query.Limit > 0 {
+		q.Add("limit", strconv.Itoa(klineLimit(query, maxKlines)))
+	}
+
+	var data [][]interface{}
+	if statusCode, err := getJSON(b.Client, endpoint, q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	candles := make([]models.Candle, 0, len(data))
+	for _, value := range data {
+		// [openTime, open, high, low, close, volume, closeTime, ...]
+		if len(value) < 6 {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to decode response: kline has %d fields", len(value))
+		}
+		candle := models.Candle{
+			OpenTime: toInt64(value[0]),
+			Open:     toFloat(value[1]),
+			High:     toFloat(value[2]),
+			Low:      toFloat(value[3]),
+			Close:    toFloat(value[4]),
+			Volume:   toFloat(value[5]),
+		}
+		if len(value) > 6 {
+			candle.CloseTime = toInt64(value[6])
+		}
+		candles = append(candles, candle)
+	}
+	return candles, http.StatusOK, nil
+}
+
+func (b *BinanceProvider) FundingInfo() ([]models.FundingRateSecond, models.StatusCode, error) {
+	var response []models.FundingRateSecond
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+"/fapi/v1/fundingInfo", nil, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return response, http.StatusOK, nil
+}
+
+// FundingRateHistory never sends startTime, Binance would return the rates following it instead of the latest
+func (b *BinanceProvider) FundingRateHistory(query models.FundingRateQuery) ([]models.FundingRateHistory, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", query.Symbol)
+	if query.Limit > 0 {
+		q.Add("limit", strconv.Itoa(min(query.Limit, binanceMaxFundingRates)))
+	}
+	if query.EndTime > 0 {
+		q.Add("endTime", strconv.FormatInt(query.EndTime, 10))
+	}
+
+	var response []models.FundingRateHistory
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+"/fapi/v1/fundingRate", q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return fundingRatesSince(response, query.StartTime), http.StatusOK, nil
+}
+
+// OpenInterest reads the open interest in the base asset only, Binance does not value it
+func (b *BinanceProvider) OpenInterest(symbol string) (*models.OpenInterest, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("symbol", symbol)
+
+	var response struct {
+		Symbol       string `json:"symbol"`
+		OpenInterest string `json:"openInterest"`
+		Time         int64  `json:"time"`
+	}
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+"/fapi/v1/openInterest", q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return &models.OpenInterest{
+		Symbol:       response.Symbol,
+		OpenInterest: toFloat(response.OpenInterest),
+		Time:         response.Time,
+	}, http.StatusOK, nil
+}
+
+// OpenInterestHistory reads the open interest statistics, which Binance keeps for the last 30 days
+func (b *BinanceProvider) OpenInterestHistory(query models.PositioningQuery) ([]models.OpenInterest, models.StatusCode, error) {
+	if !IsValidPeriod(query.Period) {
+		statusCode, err := notSupported(ExchangeBinance, "period "+query.Period)
+		return nil, statusCode, err
+	}
+
+	var response []struct {
+		Symbol               string      `json:"symbol"`
+		SumOpenInterest      string      `json:"sumOpenInterest"`
+		SumOpenInterestValue string      `json:"sumOpenInterestValue"`
+		Timestamp            interface{} `json:"timestamp"`
+	}
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+"/futures/data/openInterestHist", b.positioningQuery(query), &response); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.OpenInterest, 0, len(response))
+	for _, value := range response {
+		history = append(history, models.OpenInterest{
+			Symbol:            value.Symbol,
+			OpenInterest:      toFloat(value.SumOpenInterest),
+			OpenInterestValue: toFloat(value.SumOpenInterestValue),
+			Time:              toInt64(value.Timestamp),
+		})
+	}
+	return openInterestSince(history, query.StartTime), http.StatusOK, nil
+}
+
+func (b *BinanceProvider) LongShortRatio(kind string, query models.PositioningQuery) ([]models.LongShortRatio, models.StatusCode, error) {
+	endpoints := map[string]string{
+		models.RatioTopAccounts:    "/futures/data/topLongShortAccountRatio",
+		models.RatioTopPositions:   "/futures/data/topLongShortPositionRatio",
+		models.RatioGlobalAccounts: "/futures/data/globalLongShortAccountRatio",
+	}
+	endpoint, ok := endpoints[kind]
+	if !ok {
+		statusCode, err := notSupported(ExchangeBinance, "long/short ratio "+kind)
+		return nil, statusCode, err
+	}
+	if !IsValidPeriod(query.Period) {
+		statusCode, err := notSupported(ExchangeBinance, "period "+query.Period)
+		return nil, statusCode, err
+	}
+
+	// the position ratio also names the shares of the positions longAccount and shortAccount
+	var response []struct {
+		Symbol         string      `json:"symbol"`
+		LongShortRatio string      `json:"longShortRatio"`
+		LongAccount    string      `json:"longAccount"`
+		ShortAccount   string      `json:"shortAccount"`
+		Timestamp      interface{} `json:"timestamp"`
+	}
+	if statusCode, err := getJSON(b.Client, b.FuturesBaseURL+endpoint, b.positioningQuery(query), &response); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.LongShortRatio, 0, len(response))
+	for _, value := range response {
+		history = append(history, models.LongShortRatio{
+			Symbol:         value.Symbol,
+			LongShortRatio: toFloat(value.LongShortRatio),
+			LongAccount:    toFloat(value.LongAccount),
+			ShortAccount:   toFloat(value.ShortAccount),
+			Time:           toInt64(value.Timestamp),
+		})
+	}
+	return ratiosSince(history, query.StartTime), http.StatusOK, nil
+}
+
+// positioningQuery sends only the end time, like the funding rate history, so the latest points are returned
+func (b *BinanceProvider) positioningQuery(query models.PositioningQuery) url.Values {
+	q := url.Values{}
+	q.Add("symbol", query.Symbol)
+	q.Add("period", query.Period)
+	if query.Limit > 0 {
+		q.Add("limit", strconv.Itoa(min(query.Limit, binanceMaxPositions)))
+	}
+	if query.EndTime > 0 {
+		q.Add("endTime", strconv.FormatInt(query.EndTime, 10))
+	}
+	return q
+}
+
+func (b *BinanceProvider) ExchangeInfo() ([]models.ExchangeSymbol, models.StatusCode, error) {
+	var response struct {
+		Symbols []models.ExchangeSymbol `json:"symbols"`
+	}
+	if statusCode, err := getJSON(b.Client, b.SpotBaseURL+"/api/v3/exchangeInfo", nil, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return response.Symbols, http.StatusOK, nil
+}
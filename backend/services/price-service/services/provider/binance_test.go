@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeBinance serves canned bodies by path, like a local Binance
+func newFakeBinance(t *testing.T, routes map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		if symbol := r.URL.Query().Get("symbol"); symbol == "INVALID" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func TestBinanceProvider(t *testing.T) {
+	server := newFakeBinance(t, map[string]string{
+		"/api/v3/ticker/price":  `{"symbol":"BTCUSDT","price":"50000.00"}`,
+		"/api/v3/ticker/24hr":   `{"symbol":"BTCUSDT","priceChange":"-94.99","priceChangePercent":"-0.189","weightedAvgPrice":"50012.3","lastPrice":"50000.00","openPrice":"50094.99","highPrice":"50500.00","lowPrice":"49800.00","volume":"1234.5","quoteVolume":"61740000.1","openTime":1699913600000,"closeTime":1700000000000,"count":98765}`,
+		"/fapi/v1/ticker/24hr":  `{"symbol":"BTCUSDT","lastPrice":"50100.10","closeTime":1700000000000}`,
+		"/fapi/v1/premiumIndex": `{"symbol":"BTCUSDT","markPrice":"50050.00","indexPrice":"50040.00","lastFundingRate":"0.00010000","nextFundingTime":1700028800000,"time":1700000000000}`,
+		"/fapi/v1/klines":       `[[1689033600000,"30147.8","31040.0","29928.8","30396.9","429115.537",1689119999999,"0",1,"0","0","0"]]`,
+		"/fapi/v1/fundingInfo":  `[{"symbol":"BTCUSDT","adjustedFundingRateCap":"0.02","adjustedFundingRateFloor":"-0.02","fundingIntervalHours":8}]`,
+		"/fapi/v1/fundingRate":  `[{"symbol":"BTCUSDT","fundingRate":"0.00010000","fundingTime":1700000000000,"markPrice":"50000"}]`,
+		"/api/v3/exchangeInfo":  `{"symbols":[{"symbol":"BTCUSDT","status":"TRADING","baseAsset":"BTC","quoteAsset":"USDT"},{"symbol":"OLDUSDT","status":"BREAK"}]}`,
+	})
+	defer server.Close()
+
+	binance := NewBinanceProvider(server.URL, server.URL+"/")
+
+	t.Run("Spot ticker", func(t *testing.T) {
+		ticker, statusCode, err := binance.SpotTicker("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, models.StatusCode(http.StatusOK), statusCode)
+		assert.Equal(t, "50000.00", ticker.Price)
+		assert.NotZero(t, ticker.Time)
+	})
+
+	t.Run("Spot ticker invalid symbol", func(t *testing.T) {
+		ticker, statusCode, err := binance.SpotTicker("INVALID")
+		assert.Nil(t, ticker)
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.EqualError(t, err, "API returned status code: 400")
+	})
+
+	t.Run("Futures ticker", func(t *testing.T) {
+		ticker, _, err := binance.FuturesTicker("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, "50100.10", ticker.Price)
+		assert.Equal(t, int64(1700000000000), ticker.Time)
+	})
+
+	t.Run("24 hour ticker", func(t *testing.T) {
+		ticker, _, err := binance.Ticker24h(MarketSpot, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Ticker24h{
+			Symbol:             "BTCUSDT",
+			LastPrice:          "50000.00",
+			PriceChange:        "-94.99",
+			PriceChangePercent: "-0.189",
+			WeightedAvgPrice:   "50012.3",
+			OpenPrice:          "50094.99",
+			HighPrice:          "50500.00",
+			LowPrice:           "49800.00",
+			Volume:             "1234.5",
+			QuoteVolume:        "61740000.1",
+			OpenTime:           1699913600000,
+			CloseTime:          1700000000000,
+			TradeCount:         98765,
+		}, ticker)
+
+		ticker, _, err = binance.Ticker24h(MarketFutures, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, "50100.10", ticker.LastPrice)
+	})
+
+	t.Run("Premium index", func(t *testing.T) {
+		premiumIndex, _, err := binance.PremiumIndex("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, "50050.00", premiumIndex.MarkPrice)
+		assert.Equal(t, "0.00010000", premiumIndex.LastFundingRate)
+	})
+
+	t.Run("Klines", func(t *testing.T) {
+		candles, _, err := binance.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1d"})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Candle{{
+			OpenTime:  1689033600000,
+			CloseTime: 1689119999999,
+			Open:      30147.8,
+			High:      31040.0,
+			Low:       29928.8,
+			Close:     30396.9,
+			Volume:    429115.537,
+		}}, candles)
+	})
+
+	t.Run("Funding info and history", func(t *testing.T) {
+		info, _, err := binance.FundingInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, 8, info[0].FundingIntervalHours)
+
+		history, _, err := binance.FundingRateHistory(models.FundingRateQuery{Symbol: "BTCUSDT", Limit: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, "0.00010000", history[0].FundingRate)
+	})
+
+	t.Run("Exchange info", func(t *testing.T) {
+		symbols, _, err := binance.ExchangeInfo()
+		assert.NoError(t, err)
+		assert.Len(t, symbols, 2)
+		assert.Equal(t, "BREAK", symbols[1].Status)
+	})
+}
+
+func TestBinanceProviderKlinesRange(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	binance := NewBinanceProvider(server.URL, server.URL)
+	_, _, err := binance.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", StartTime: 1700000000000, EndTime: 1800000000000, Limit: 5000})
+	assert.NoError(t, err)
+	assert.Equal(t, "1700000000000", query.Get("startTime"))
+	assert.Equal(t, "1800000000000", query.Get("endTime"))
+	assert.Equal(t, "1500", query.Get("limit"))
+
+	_, _, err = binance.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h"})
+	assert.NoError(t, err)
+	assert.Empty(t, query.Get("startTime"))
+	assert.Empty(t, query.Get("limit"))
+}
+
+func TestBinanceProviderAllSymbols(t *testing.T) {
+	server := newFakeBinance(t, map[string]string{
+		"/api/v3/ticker/price":  `[{"symbol":"BTCUSDT","price":"50000.00"},{"symbol":"ETHUSDT","price":"3000.00"}]`,
+		"/fapi/v1/premiumIndex": `[{"symbol":"BTCUSDT","markPrice":"50050.00","indexPrice":"50040.00","lastFundingRate":"0.00010000","nextFundingTime":1700028800000,"time":1700000000000}]`,
+	})
+	defer server.Close()
+	binance := NewBinanceProvider(server.URL, server.URL)
+
+	tickers, _, err := binance.SpotTickers()
+	assert.NoError(t, err)
+	assert.Len(t, tickers, 2)
+	assert.Equal(t, "ETHUSDT", tickers[1].Symbol)
+	assert.Equal(t, "3000.00", tickers[1].Price)
+	assert.NotZero(t, tickers[1].Time)
+
+	indexes, _, err := binance.PremiumIndexes()
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ResponseBinanceFuture{{
+		Symbol:          "BTCUSDT",
+		MarkPrice:       "50050.00",
+		IndexPrice:      "50040.00",
+		LastFundingRate: "0.00010000",
+		NextFundingTime: 1700028800000,
+		Time:            1700000000000,
+	}}, indexes)
+}
+
+func TestBinanceProviderSpotKlines(t *testing.T) {
+	var path string
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	binance := NewBinanceProvider(server.URL, server.URL)
+	_, _, err := binance.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1s", Market: MarketSpot, Limit: 5000})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v3/klines", path)
+	assert.Equal(t, "1000", query.Get("limit"))
+
+	_, _, err = binance.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/fapi/v1/klines", path)
+}
+
+func TestBinanceProviderDepth(t *testing.T) {
+	var path string
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		w.Write([]byte(`{"lastUpdateId":1027024,"E":1700000000100,"T":1700000000090,"bids":[["50000.00","1.5"],["49999.90","0.2"],["49999.00","3"]],"asks":[["50000.10","0.7"],["50001.00","2.25"],["50002.00","1"]]}`))
+	}))
+	defer server.Close()
+
+	binance := NewBinanceProvider(server.URL, server.URL)
+
+	book, _, err := binance.Depth(MarketFutures, "BTCUSDT", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "/fapi/v1/depth", path)
+	assert.Equal(t, "5", query.Get("limit"))
+	assert.Equal(t, &models.OrderBook{
+		Symbol:       "BTCUSDT",
+		LastUpdateID: 1027024,
+		Bids:         []models.PriceLevel{{Price: 50000, Quantity: 1.5}, {Price: 49999.9, Quantity: 0.2}},
+		Asks:         []models.PriceLevel{{Price: 50000.1, Quantity: 0.7}, {Price: 50001, Quantity: 2.25}},
+		Time:         1700000000090,
+	}, book)
+
+	book, _, err = binance.Depth(MarketSpot, "BTCUSDT", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v3/depth", path)
+	assert.Equal(t, "3", query.Get("limit"))
+	assert.Len(t, book.Bids, 3)
+
+	_, _, err = binance.Depth(MarketFutures, "BTCUSDT", 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", query.Get("limit"))
+}
+
+func TestBinanceProviderTrades(t *testing.T) {
+	var path string
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		w.Write([]byte(`[{"a":26129,"p":"50000.10","q":"0.5","f":27781,"l":27781,"T":1700000000100,"m":false},{"a":26130,"p":"50000.00","q":"1.25","f":27782,"l":27783,"T":1700000000200,"m":true}]`))
+	}))
+	defer server.Close()
+
+	binance := NewBinanceProvider(server.URL, server.URL)
+
+	trades, _, err := binance.Trades(MarketSpot, "BTCUSDT", 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v3/aggTrades", path)
+	assert.Equal(t, "1000", query.Get("limit"))
+	assert.Equal(t, []models.Trade{
+		{ID: "26129", Price: 50000.1, Quantity: 0.5, Side: models.SideBuy, Time: 1700000000100},
+		{ID: "26130", Price: 50000, Quantity: 1.25, Side: models.SideSell, Time: 1700000000200},
+	}, trades)
+
+	_, _, err = binance.Trades(MarketFutures, "BTCUSDT", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "/fapi/v1/aggTrades", path)
+}
+
+func TestBinanceProviderFundingRateHistory(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`[{"symbol":"BTCUSDT","fundingRate":"0.0001","fundingTime":1700000000000},{"symbol":"BTCUSDT","fundingRate":"-0.0002","fundingTime":1700028800000}]`))
+	}))
+	defer server.Close()
+
+	binance := NewBinanceProvider(server.URL, server.URL)
+
+	history, _, err := binance.FundingRateHistory(models.FundingRateQuery{Symbol: "BTCUSDT", StartTime: 1700000000001, EndTime: 1700028800000, Limit: 5000})
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", query.Get("limit"))
+	assert.Equal(t, "1700028800000", query.Get("endTime"))
+	assert.Empty(t, query.Get("startTime"))
+	assert.Equal(t, []models.FundingRateHistory{{Symbol: "BTCUSDT", FundingRate: "-0.0002", FundingTime: 1700028800000}}, history)
+}
+
+func TestBinanceProviderPositioning(t *testing.T) {
+	var query url.Values
+	server := newFakeBinance(t, map[string]string{
+		"/fapi/v1/openInterest":                     `{"openInterest":"81234.567","symbol":"BTCUSDT","time":1700000000000}`,
+		"/futures/data/openInterestHist":            `[{"symbol":"BTCUSDT","sumOpenInterest":"81000.5","sumOpenInterestValue":"4050025000.5","CMCCirculatingSupply":"19000000","timestamp":1699996400000},{"symbol":"BTCUSDT","sumOpenInterest":"81234.567","sumOpenInterestValue":"4061728350","timestamp":1700000000000}]`,
+		"/futures/data/globalLongShortAccountRatio": `[{"symbol":"BTCUSDT","longShortRatio":"1.8123","longAccount":"0.6444","shortAccount":"0.3556","timestamp":"1700000000000"}]`,
+		"/futures/data/topLongShortPositionRatio":   `[{"symbol":"BTCUSDT","longShortRatio":"1.2","longAccount":"0.5455","shortAccount":"0.4545","timestamp":1700000000000}]`,
+	})
+	defer server.Close()
+	recorder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer recorder.Close()
+
+	binance := NewBinanceProvider(recorder.URL, recorder.URL)
+
+	t.Run("Open interest", func(t *testing.T) {
+		openInterest, _, err := binance.OpenInterest("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OpenInterest{Symbol: "BTCUSDT", OpenInterest: 81234.567, Time: 1700000000000}, openInterest)
+	})
+
+	t.Run("Open interest history of a range", func(t *testing.T) {
+		history, _, err := binance.OpenInterestHistory(models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h", StartTime: 1699996400001, EndTime: 1700000000000, Limit: 1000})
+		assert.NoError(t, err)
+		assert.Equal(t, "1h", query.Get("period"))
+		assert.Equal(t, "500", query.Get("limit"))
+		assert.Equal(t, "1700000000000", query.Get("endTime"))
+		assert.Empty(t, query.Get("startTime"))
+		assert.Equal(t, []models.OpenInterest{{Symbol: "BTCUSDT", OpenInterest: 81234.567, OpenInterestValue: 4061728350, Time: 1700000000000}}, history)
+	})
+
+	t.Run("Long/short ratios", func(t *testing.T) {
+		history, _, err := binance.LongShortRatio(models.RatioGlobalAccounts, models.PositioningQuery{Symbol: "BTCUSDT", Period: "5m"})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.LongShortRatio{{Symbol: "BTCUSDT", LongShortRatio: 1.8123, LongAccount: 0.6444, ShortAccount: 0.3556, Time: 1700000000000}}, history)
+
+		history, _, err = binance.LongShortRatio(models.RatioTopPositions, models.PositioningQuery{Symbol: "BTCUSDT", Period: "5m"})
+		assert.NoError(t, err)
+		assert.Equal(t, 0.5455, history[0].LongAccount)
+	})
+
+	t.Run("Unsupported period and type", func(t *testing.T) {
+		_, statusCode, err := binance.OpenInterestHistory(models.PositioningQuery{Symbol: "BTCUSDT", Period: "1m"})
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.EqualError(t, err, "binance period 1m: not supported")
+
+		_, _, err = binance.LongShortRatio("whales", models.PositioningQuery{Symbol: "BTCUSDT", Period: "5m"})
+		assert.EqualError(t, err, "binance long/short ratio whales: not supported")
+	})
+}
+
+func TestBinanceProviderDecodeError(t *testing.T) {
+	server := newFakeBinance(t, map[string]string{"/api/v3/ticker/price": "invalid json"})
+	defer server.Close()
+
+	_, statusCode, err := NewBinanceProvider(server.URL, server.URL).SpotTicker("BTCUSDT")
+	assert.Equal(t, models.StatusCode(http.StatusInternalServerError), statusCode)
+	assert.Contains(t, err.Error(), "failed to decode response")
+}
+
+func TestNewBinanceProviderFromEnv(t *testing.T) {
+	t.Setenv("BINANCE_SPOT_BASE_URL", "http://localhost:9000/")
+	t.Setenv("BINANCE_FUTURES_BASE_URL", "")
+
+	binance := NewBinanceProviderFromEnv()
+	assert.Equal(t, "http://localhost:9000", binance.SpotBaseURL)
+	assert.Equal(t, DefaultBinanceFuturesBaseURL, binance.FuturesBaseURL)
+}
+
+func TestSetDefault(t *testing.T) {
+	fake := NewBinanceProvider("http://fake", "http://fake")
+	SetDefault(fake)
+	defer SetDefault(nil)
+
+	assert.Same(t, fake, Default())
+	p, _ := Get("")
+	assert.Same(t, fake, p)
+}
+
+func TestFundingIntervals(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`[{"symbol":"BTCUSDT","fundingIntervalHours":4},{"symbol":"ETHUSDT","fundingIntervalHours":0}]`))
+	}))
+	defer server.Close()
+
+	binance := NewBinanceProvider(server.URL, server.URL)
+	assert.Equal(t, map[string]int{"BTCUSDT": 4}, FundingIntervals(binance))
+	// the funding info is read once an hour
+	assert.Equal(t, map[string]int{"BTCUSDT": 4}, FundingIntervals(binance))
+	assert.Equal(t, 1, requests)
+}
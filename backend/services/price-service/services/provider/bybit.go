@@ -0,0 +1,533 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	DefaultBybitBaseURL = "https://api.bybit.com"
+
+	// bybitMaxKlines is the most candles Bybit returns for one request
+	bybitMaxKlines = 1000
+	// bybitMaxSpotDepth and bybitMaxDepth are the most levels of each side of one spot and linear order book request
+	bybitMaxSpotDepth = 200
+	bybitMaxDepth     = 500
+	// bybitMaxSpotTrades and bybitMaxTrades are the most trades of one spot and linear recent trades request
+	bybitMaxSpotTrades = 60
+	bybitMaxTrades     = 1000
+	// bybitMaxFundingRates is the most funding rates of one funding history request
+	bybitMaxFundingRates = 200
+	// bybitMaxOpenInterest and bybitMaxRatios are the most points of one open interest and one account ratio request
+	bybitMaxOpenInterest = 200
+	bybitMaxRatios       = 500
+)
+
+// bybitIntervals maps Binance kline intervals to Bybit intervals
+var bybitIntervals = map[string]string{
+	"1m": "1", "3m": "3", "5m": "5", "15m": "15", "30m": "30",
+	"1h": "60", "2h": "120", "4h": "240", "6h": "360", "12h": "720",
+	"1d": "D", "1w": "W", "1M": "M",
+}
+
+// bybitPeriods maps Binance open interest and long/short ratio periods to Bybit periods
+var bybitPeriods = map[string]string{
+	"5m": "5min", "15m": "15min", "30m": "30min", "1h": "1h", "4h": "4h", "1d": "1d",
+}
+
+// BybitProvider reads market data from the Bybit v5 REST API, futures are the USDT linear perpetuals
+type BybitProvider struct {
+	BaseURL string
+	// Client is used for every request, http.DefaultClient when nil
+	Client *http.Client
+}
+
+func NewBybitProvider(baseURL string) *BybitProvider {
+	return &BybitProvider{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewBybitProviderFromEnv uses BYBIT_BASE_URL when it is set
+func NewBybitProviderFromEnv() *BybitProvider {
+	baseURL := os.Getenv("BYBIT_BASE_URL")
+	if baseURL == "" {
+		baseURL = DefaultBybitBaseURL
+	}
+	return NewBybitProvider(baseURL)
+}
+
+type bybitTicker struct {
+	Symbol          string `json:"symbol"`
+	LastPrice       string `json:"lastPrice"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	FundingRate     string `json:"fundingRate"`
+	NextFundingTime string `json:"nextFundingTime"`
+	PrevPrice24h    string `json:"prevPrice24h"`
+	HighPrice24h    string `json:"highPrice24h"`
+	LowPrice24h     string `json:"lowPrice24h"`
+	Volume24h       string `json:"volume24h"`
+	Turnover24h     string `json:"turnover24h"`
+	// OpenInterest and OpenInterestValue are only set on linear tickers
+	OpenInterest      string `json:"openInterest"`
+	OpenInterestValue string `json:"openInterestValue"`
+}
+
+func (b *BybitProvider) Name() string {
+	return ExchangeBybit
+}
+
+func (b *BybitProvider) SpotTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	ticker, eventTime, statusCode, err := b.ticker("spot", symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &models.ResponseBinance{Symbol: ticker.Symbol, Price: ticker.LastPrice, Time: eventTime}, http.StatusOK, nil
+}
+
+func (b *BybitProvider) FuturesTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	ticker, eventTime, statusCode, err := b.ticker("linear", symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &models.ResponseBinance{Symbol: ticker.Symbol, Price: ticker.LastPrice, Time: eventTime}, http.StatusOK, nil
+}
+
+func (b *BybitProvider) PremiumIndex(symbol string) (*models.ResponseBinanceFuture, models.StatusCode, error) {
+	ticker, eventTime, statusCode, err := b.ticker("linear", symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &models.ResponseBinanceFuture{
+		Symbol:          ticker.Symbol,
+		MarkPrice:       ticker.MarkPrice,
+		IndexPrice:      ticker.IndexPrice,
+		LastFundingRate: ticker.FundingRate,
+		NextFundingTime: toInt64(ticker.NextFundingTime),
+		Time:            eventTime,
+	}, http.StatusOK, nil
+}
+
+func (b *BybitProvider) Ticker24h(market, symbol string) (*models.Ticker24h, models.StatusCode, error) {
+	category := "linear"
+	if market == MarketSpot {
+		category = "spot"
+	}
+	ticker, eventTime, statusCode, err := b.ticker(category, symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return rollingTicker(ticker.Symbol, ticker.LastPrice, ticker.PrevPrice24h, ticker.HighPrice24h, ticker.LowPrice24h,
+		ticker.Volume24h, ticker.Turnover24h, eventTime), http.StatusOK, nil
+}
+
+func (b *BybitProvider) Depth(market, symbol string, limit int) (*models.OrderBook, models.StatusCode, error) {
+	category, maxDepth := "linear", bybitMaxDepth
+	if market == MarketSpot {
+		category, maxDepth = "spot", bybitMaxSpotDepth
+	}
+	limit = min(limit, maxDepth)
+	q := url.Values{}
+	q.Add("category", category)
+	q.Add("symbol", NormalizeSymbol(symbol))
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(limit))
+	}
+
+	var result struct {
+		Symbol   string          `json:"s"`
+		Bids     [][]interface{} `json:"b"`
+		Asks     [][]interface{} `json:"a"`
+		Ts       int64           `json:"ts"`
+		UpdateID int64           `json:"u"`
+	}
+	if _, statusCode, err := b.get("/v5/market/orderbook", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+	if result.Symbol == "" {
+		return nil, http.StatusNotFound, symbolNotFound(ExchangeBybit, symbol)
+	}
+	return orderBook(result.Symbol, result.UpdateID, result.Bids, result.Asks, limit, result.Ts)
+}
+
+func (b *BybitProvider) Trades(market, symbol string, limit int) ([]models.Trade, models.StatusCode, error) {
+	category, maxTrades := "linear", bybitMaxTrades
+	if market == MarketSpot {
+		category, maxTrades = "spot", bybitMaxSpotTrades
+	}
+	q := url.Values{}
+	q.Add("category", category)
+	q.Add("symbol", NormalizeSymbol(symbol))
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(min(limit, maxTrades)))
+	}
+
+	// newest first, side is the taker side
+	var result struct {
+		List []struct {
+			ExecID string `json:"execId"`
+			Price  string `json:"price"`
+			Size   string `json:"size"`
+			Side   string `json:"side"`
+			Time   string `json:"time"`
+		} `json:"list"`
+	}
+	if _, statusCode, err := b.get("/v5/market/recent-trade", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+
+	trades := make([]models.Trade, 0, len(result.List))
+	for i := len(result.List) - 1; i >= 0; i-- {
+		value := result.List[i]
+		trades = append(trades, models.Trade{
+			ID:       value.ExecID,
+			Price:    toFloat(value.Price),
+			Quantity: toFloat(value.Size),
+			Side:     strings.ToLower(value.Side),
+			Time:     toInt64(value.Time),
+		})
+	}
+	return trades, http.StatusOK, nil
+}
+
+func (b *BybitProvider) SpotTickers() ([]models.ResponseBinance, models.StatusCode, error) {
+	list, eventTime, statusCode, err := b.tickers("spot")
+	if err != nil {
+		return nil, statusCode, err
+	}
+	tickers := make([]models.ResponseBinance, 0, len(list))
+	for _, ticker := range list {
+		tickers = append(tickers, models.ResponseBinance{Symbol: ticker.Symbol, Price: ticker.LastPrice, Time: eventTime})
+	}
+	return tickers, http.StatusOK, nil
+}
+
+func (b *BybitProvider) PremiumIndexes() ([]models.ResponseBinanceFuture, models.StatusCode, error) {
+	list, eventTime, statusCode, err := b.tickers("linear")
+	if err != nil {
+		return nil, statusCode, err
+	}
+	indexes := make([]models.ResponseBinanceFuture, 0, len(list))
+	for _, ticker := range list {
+		indexes = append(indexes, models.ResponseBinanceFuture{
+			Symbol:          ticker.Symbol,
+			MarkPrice:       ticker.MarkPrice,
+			IndexPrice:      ticker.IndexPrice,
+			LastFundingRate: ticker.FundingRate,
+			NextFundingTime: toInt64(ticker.NextFundingTime),
+			Time:            eventTime,
+		})
+	}
+	return indexes, http.StatusOK, nil
+}
+
+// tickers returns every ticker of a category (spot or linear) and the response time
+func (b *BybitProvider) tickers(category string) ([]bybitTicker, int64, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("category", category)
+
+	var result struct {
+		List []bybitTicker `json:"list"`
+	}
+	eventTime, statusCode, err := b.get("/v5/market/tickers", q, &result)
+	if err != nil {
+		return nil, 0, statusCode, err
+	}
+	return result.List, eventTime, http.StatusOK, nil
+}
+
+// ticker returns the ticker of a symbol in a category (spot or linear) and the response time
+func (b *BybitProvider) ticker(category, symbol string) (*bybitTicker, int64, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("category", category)
+	q.Add("symbol", NormalizeSymbol(symbol))
+
+	var result struct {
+		List []bybitTicker `json:"list"`
+	}
+	eventTime, statusCode, err := b.get("/v5/market/tickers", q, &result)
+	if err != nil {
+		return nil, 0, statusCode, err
+	}
+	if len(result.List) == 0 {
+		return nil, 0, http.StatusNotFound, symbolNotFound(ExchangeBybit, symbol)
+	}
+	return &result.List[0], eventTime, http.StatusOK, nil
+}
+
+func (b *BybitProvider) Klines(query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	interval, ok := bybitIntervals[query.Interval]
+	if !ok {
+		statusCode, err := notSupported(ExchangeBybit, "interval "+query.Interval)
+		return nil, statusCode, err
+	}
+	category := "linear"
+	if query.Market == MarketSpot {
+		category = "spot"
+	}
+	q := url.Values{}
+	q.Add("category", category)
+	q.Add("symbol", NormalizeSymbol(query.Symbol))
+	q.Add("interval", interval)
+	limit := klineLimit(query, bybitMaxKlines)
+	q.Add("limit", strconv.Itoa(limit))
+	// Bybit returns the latest candles of the window, so the window must not hold more than limit
+	startTime, endTime := klineWindow(query, limit)
+	if startTime > 0 {
+		q.Add("start", strconv.FormatInt(startTime, 10))
+	}
+	if endTime > 0 {
+		q.Add("end", strconv.FormatInt(endTime, 10))
+	}
+
+	// [startTime, open, high, low, close, volume, turnover], newest first
+	var result struct {
+		List [][]string `json:"list"`
+	}
+	if _, statusCode, err := b.get("/v5/market/kline", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+
+	candles := make([]models.Candle, 0, len(result.List))
+	for i := len(result.List) - 1; i >= 0; i-- {
+		value := result.List[i]
+		if len(value) < 6 {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to decode response: kline has %d fields", len(value))
+		}
+		openTime := toInt64(value[0])
+		candles = append(candles, models.Candle{
+			OpenTime:  openTime,
+			CloseTime: CandleCloseTime(openTime, query.Interval),
+			Open:      toFloat(value[1]),
+			High:      toFloat(value[2]),
+			Low:       toFloat(value[3]),
+			Close:     toFloat(value[4]),
+			Volume:    toFloat(value[5]),
+		})
+	}
+	return candles, http.StatusOK, nil
+}
+
+func (b *BybitProvider) FundingInfo() ([]models.FundingRateSecond, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("category", "linear")
+	q.Add("limit", "1000")
+
+	var result struct {
+		List []struct {
+			Symbol           string `json:"symbol"`
+			FundingInterval  int    `json:"fundingInterval"`
+			UpperFundingRate string `json:"upperFundingRate"`
+			LowerFundingRate string `json:"lowerFundingRate"`
+		} `json:"list"`
+	}
+	if _, statusCode, err := b.get("/v5/market/instruments-info", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+
+	info := make([]models.FundingRateSecond, 0, len(result.List))
+	for _, value := range result.List {
+		info = append(info, models.FundingRateSecond{
+			Symbol:                   value.Symbol,
+			AdjustedFundingRateCap:   value.UpperFundingRate,
+			AdjustedFundingRateFloor: value.LowerFundingRate,
+			// fundingInterval is in minutes
+			FundingIntervalHours: value.FundingInterval / 60,
+		})
+	}
+	return info, http.StatusOK, nil
+}
+
+// FundingRateHistory never sends startTime, Bybit rejects it without endTime and would not return the latest rates
+func (b *BybitProvider) FundingRateHistory(query models.FundingRateQuery) ([]models.FundingRateHistory, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("category", "linear")
+	q.Add("symbol", NormalizeSymbol(query.Symbol))
+	if query.Limit > 0 {
+		q.Add("limit", strconv.Itoa(min(query.Limit, bybitMaxFundingRates)))
+	}
+	if query.EndTime > 0 {
+		q.Add("endTime", strconv.FormatInt(query.EndTime, 10))
+	}
+
+	// newest first
+	var result struct {
+		List []struct {
+			Symbol               string `json:"symbol"`
+			FundingRate          string `json:"fundingRate"`
+			FundingRateTimestamp string `json:"fundingRateTimestamp"`
+		} `json:"list"`
+	}
+	if _, statusCode, err := b.get("/v5/market/funding/history", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.FundingRateHistory, 0, len(result.List))
+	for i := len(result.List) - 1; i >= 0; i-- {
+		history = append(history, models.FundingRateHistory{
+			Symbol:      result.List[i].Symbol,
+			FundingRate: result.List[i].FundingRate,
+			FundingTime: toInt64(result.List[i].FundingRateTimestamp),
+		})
+	}
+	return fundingRatesSince(history, query.StartTime), http.StatusOK, nil
+}
+
+// OpenInterest reads the open interest and its value from the linear ticker
+func (b *BybitProvider) OpenInterest(symbol string) (*models.OpenInterest, models.StatusCode, error) {
+	ticker, eventTime, statusCode, err := b.ticker("linear", symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &models.OpenInterest{
+		Symbol:            ticker.Symbol,
+		OpenInterest:      toFloat(ticker.OpenInterest),
+		OpenInterestValue: toFloat(ticker.OpenInterestValue),
+		Time:              eventTime,
+	}, http.StatusOK, nil
+}
+
+// OpenInterestHistory reads the open interest in the base asset only, Bybit does not value it
+func (b *BybitProvider) OpenInterestHistory(query models.PositioningQuery) ([]models.OpenInterest, models.StatusCode, error) {
+	period, ok := bybitPeriods[query.Period]
+	if !ok {
+		statusCode, err := notSupported(ExchangeBybit, "period "+query.Period)
+		return nil, statusCode, err
+	}
+	q := b.positioningQuery(query, bybitMaxOpenInterest)
+	q.Add("intervalTime", period)
+
+	// newest first
+	var result struct {
+		Symbol string `json:"symbol"`
+		List   []struct {
+			OpenInterest string `json:"openInterest"`
+			Timestamp    string `json:"timestamp"`
+		} `json:"list"`
+	}
+	if _, statusCode, err := b.get("/v5/market/open-interest", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.OpenInterest, 0, len(result.List))
+	for i := len(result.List) - 1; i >= 0; i-- {
+		history = append(history, models.OpenInterest{
+			Symbol:       result.Symbol,
+			OpenInterest: toFloat(result.List[i].OpenInterest),
+			Time:         toInt64(result.List[i].Timestamp),
+		})
+	}
+	return openInterestSince(history, query.StartTime), http.StatusOK, nil
+}
+
+// LongShortRatio reads the ratio of every account, Bybit publishes no ratio of its top traders
+func (b *BybitProvider) LongShortRatio(kind string, query models.PositioningQuery) ([]models.LongShortRatio, models.StatusCode, error) {
+	if kind != models.RatioGlobalAccounts {
+		statusCode, err := notSupported(ExchangeBybit, "long/short ratio "+kind)
+		return nil, statusCode, err
+	}
+	period, ok := bybitPeriods[query.Period]
+	if !ok {
+		statusCode, err := notSupported(ExchangeBybit, "period "+query.Period)
+		return nil, statusCode, err
+	}
+	q := b.positioningQuery(query, bybitMaxRatios)
+	q.Add("period", period)
+
+	// newest first
+	var result struct {
+		List []struct {
+			Symbol    string `json:"symbol"`
+			BuyRatio  string `json:"buyRatio"`
+			SellRatio string `json:"sellRatio"`
+			Timestamp string `json:"timestamp"`
+		} `json:"list"`
+	}
+	if _, statusCode, err := b.get("/v5/market/account-ratio", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.LongShortRatio, 0, len(result.List))
+	for i := len(result.List) - 1; i >= 0; i-- {
+		value := result.List[i]
+		long, short := toFloat(value.BuyRatio), toFloat(value.SellRatio)
+		ratio := 0.0
+		if short > 0 {
+			ratio = math.Round(long/short*1e4) / 1e4
+		}
+		history = append(history, models.LongShortRatio{
+			Symbol:         value.Symbol,
+			LongShortRatio: ratio,
+			LongAccount:    long,
+			ShortAccount:   short,
+			Time:           toInt64(value.Timestamp),
+		})
+	}
+	return ratiosSince(history, query.StartTime), http.StatusOK, nil
+}
+
+func (b *BybitProvider) positioningQuery(query models.PositioningQuery, maxLimit int) url.Values {
+	q := url.Values{}
+	q.Add("category", "linear")
+	q.Add("symbol", NormalizeSymbol(query.Symbol))
+	if query.Limit > 0 {
+		q.Add("limit", strconv.Itoa(min(query.Limit, maxLimit)))
+	}
+	if query.EndTime > 0 {
+		q.Add("endTime", strconv.FormatInt(query.EndTime, 10))
+	}
+	return q
+}
+
+func (b *BybitProvider) ExchangeInfo() ([]models.ExchangeSymbol, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("category", "spot")
+
+	var result struct {
+		List []struct {
+			Symbol    string `json:"symbol"`
+			BaseCoin  string `json:"baseCoin"`
+			QuoteCoin string `json:"quoteCoin"`
+			Status    string `json:"status"`
+		} `json:"list"`
+	}
+	if _, statusCode, err := b.get("/v5/market/instruments-info", q, &result); err != nil {
+		return nil, statusCode, err
+	}
+
+	symbols := make([]models.ExchangeSymbol, 0, len(result.List))
+	for _, value := range result.List {
+		symbols = append(symbols, models.ExchangeSymbol{
+			Symbol:     value.Symbol,
+			Status:     strings.ToUpper(value.Status),
+			BaseAsset:  value.BaseCoin,
+			QuoteAsset: value.QuoteCoin,
+		})
+	}
+	return symbols, http.StatusOK, nil
+}
+
+// get unwraps the {"retCode","retMsg","result","time"} envelope of Bybit and returns its time,
+// a non zero retCode is a bad request
+func (b *BybitProvider) get(path string, query url.Values, result interface{}) (int64, models.StatusCode, error) {
+	var envelope struct {
+		RetCode int         `json:"retCode"`
+		RetMsg  string      `json:"retMsg"`
+		Result  interface{} `json:"result"`
+		Time    int64       `json:"time"`
+	}
+	envelope.Result = result
+	if statusCode, err := getJSON(b.Client, b.BaseURL+path, query, &envelope); err != nil {
+		return 0, statusCode, err
+	}
+	if envelope.RetCode != 0 {
+		return 0, http.StatusBadRequest, fmt.Errorf("bybit error %d: %s", envelope.RetCode, envelope.RetMsg)
+	}
+	return envelope.Time, http.StatusOK, nil
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBybitFixtureProvider(t *testing.T) *BybitProvider {
+	server := newFixtureServer(t, []fixture{
+		{path: "/v5/market/tickers", query: "symbol=NOPEUSDT", file: "bybit_error.json"},
+		{path: "/v5/market/recent-trade", query: "category=linear&limit=50", file: "bybit_recent_trade.json"},
+		{path: "/v5/market/orderbook", query: "symbol=NOPEUSDT", file: "bybit_orderbook_empty.json"},
+		{path: "/v5/market/orderbook", query: "category=spot&limit=200", file: "bybit_orderbook.json"},
+		{path: "/v5/market/tickers", query: "category=spot", file: "bybit_tickers_spot.json"},
+		{path: "/v5/market/tickers", query: "category=linear", file: "bybit_tickers_linear.json"},
+		{path: "/v5/market/kline", query: "start=1733900340000&end=1733900459999&limit=2", file: "bybit_kline.json"},
+		{path: "/v5/market/kline", query: "interval=1&limit=1000", file: "bybit_kline.json"},
+		{path: "/v5/market/kline", query: "category=spot&interval=60", file: "bybit_kline.json"},
+		{path: "/v5/market/instruments-info", query: "category=linear", file: "bybit_instruments_linear.json"},
+		{path: "/v5/market/instruments-info", query: "category=spot", file: "bybit_instruments_spot.json"},
+		{path: "/v5/market/funding/history", file: "bybit_funding_history.json"},
+		{path: "/v5/market/open-interest", query: "intervalTime=1h&limit=2", file: "bybit_open_interest.json"},
+		{path: "/v5/market/account-ratio", query: "period=1h&endTime=1733900400000", file: "bybit_account_ratio.json"},
+	})
+	return NewBybitProvider(server.URL)
+}
+
+func TestBybitProvider(t *testing.T) {
+	bybit := newBybitFixtureProvider(t)
+
+	t.Run("Spot ticker", func(t *testing.T) {
+		ticker, _, err := bybit.SpotTicker("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResponseBinance{Symbol: "BTCUSDT", Price: "97110.6", Time: 1733900001000}, ticker)
+	})
+
+	t.Run("Futures ticker", func(t *testing.T) {
+		ticker, _, err := bybit.FuturesTicker("BTC/USDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResponseBinance{Symbol: "BTCUSDT", Price: "97160.40", Time: 1733900001200}, ticker)
+	})
+
+	t.Run("Invalid symbol", func(t *testing.T) {
+		ticker, statusCode, err := bybit.SpotTicker("NOPEUSDT")
+		assert.Nil(t, ticker)
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.EqualError(t, err, "bybit error 10001: params error: symbol invalid")
+	})
+
+	t.Run("Premium index", func(t *testing.T) {
+		premiumIndex, _, err := bybit.PremiumIndex("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResponseBinanceFuture{
+			Symbol:          "BTCUSDT",
+			MarkPrice:       "97158.90",
+			IndexPrice:      "97140.12",
+			LastFundingRate: "0.0001",
+			NextFundingTime: 1733904000000,
+			Time:            1733900001200,
+		}, premiumIndex)
+	})
+
+	t.Run("Klines oldest first", func(t *testing.T) {
+		candles, _, err := bybit.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1m"})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Candle{
+			{OpenTime: 1733900340000, CloseTime: 1733900399999, Open: 97140, High: 97175.4, Low: 97130.2, Close: 97170.1, Volume: 23.1},
+			{OpenTime: 1733900400000, CloseTime: 1733900459999, Open: 97170.1, High: 97190, Low: 97160.3, Close: 97180.1, Volume: 12.54},
+		}, candles)
+	})
+
+	t.Run("Klines from a start time", func(t *testing.T) {
+		candles, _, err := bybit.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1m", StartTime: 1733900340000, Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, candles, 2)
+		assert.Equal(t, int64(1733900340000), candles[0].OpenTime)
+	})
+
+	t.Run("Depth", func(t *testing.T) {
+		book, _, err := bybit.Depth(MarketSpot, "BTCUSDT", 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OrderBook{
+			Symbol:       "BTCUSDT",
+			LastUpdateID: 4412345,
+			Bids:         []models.PriceLevel{{Price: 97110.5, Quantity: 0.52}, {Price: 97110.1, Quantity: 1.4}},
+			Asks:         []models.PriceLevel{{Price: 97110.6, Quantity: 0.31}, {Price: 97111, Quantity: 2.05}},
+			Time:         1733900001100,
+		}, book)
+
+		_, statusCode, err := bybit.Depth(MarketSpot, "NOPEUSDT", 10)
+		assert.Equal(t, models.StatusCode(http.StatusNotFound), statusCode)
+		assert.EqualError(t, err, "bybit has no symbol NOPEUSDT")
+	})
+
+	t.Run("Trades oldest first", func(t *testing.T) {
+		trades, _, err := bybit.Trades(MarketFutures, "BTCUSDT", 50)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Trade{
+			{ID: "0c7e31d2-9f2b-5a1e-9b7c-3a1d2e4f5b6c", Price: 97160.3, Quantity: 1.5, Side: models.SideSell, Time: 1733900001020},
+			{ID: "b9d0a8e4-2c1f-5f57-8d55-6f2a1f3e9c01", Price: 97160.4, Quantity: 0.12, Side: models.SideBuy, Time: 1733900001150},
+		}, trades)
+	})
+
+	t.Run("Every spot ticker", func(t *testing.T) {
+		tickers, _, err := bybit.SpotTickers()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ResponseBinance{{Symbol: "BTCUSDT", Price: "97110.6", Time: 1733900001000}}, tickers)
+	})
+
+	t.Run("Every premium index", func(t *testing.T) {
+		indexes, _, err := bybit.PremiumIndexes()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ResponseBinanceFuture{{
+			Symbol:          "BTCUSDT",
+			MarkPrice:       "97158.90",
+			IndexPrice:      "97140.12",
+			LastFundingRate: "0.0001",
+			NextFundingTime: 1733904000000,
+			Time:            1733900001200,
+		}}, indexes)
+	})
+
+	t.Run("24 hour ticker", func(t *testing.T) {
+		ticker, _, err := bybit.Ticker24h(MarketSpot, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Ticker24h{
+			Symbol:             "BTCUSDT",
+			LastPrice:          "97110.6",
+			PriceChange:        "1109.4",
+			PriceChangePercent: "1.156",
+			OpenPrice:          "96001.2",
+			HighPrice:          "97790",
+			LowPrice:           "95490.1",
+			Volume:             "8423.12",
+			QuoteVolume:        "812345678.12",
+			OpenTime:           1733813601000,
+			CloseTime:          1733900001000,
+		}, ticker)
+
+		ticker, _, err = bybit.Ticker24h(MarketFutures, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, "1140.30", ticker.PriceChange)
+		assert.Equal(t, "95432.123", ticker.Volume)
+	})
+
+	t.Run("Spot klines", func(t *testing.T) {
+		candles, _, err := bybit.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", Market: MarketSpot})
+		assert.NoError(t, err)
+		assert.Len(t, candles, 2)
+	})
+
+	t.Run("Unsupported interval", func(t *testing.T) {
+		_, _, err := bybit.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "3d"})
+		assert.True(t, errors.Is(err, ErrNotSupported))
+	})
+
+	t.Run("Funding info", func(t *testing.T) {
+		info, _, err := bybit.FundingInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.FundingRateSecond{
+			{Symbol: "BTCUSDT", AdjustedFundingRateCap: "0.00375", AdjustedFundingRateFloor: "-0.00375", FundingIntervalHours: 8},
+			{Symbol: "1000PEPEUSDT", AdjustedFundingRateCap: "0.02", AdjustedFundingRateFloor: "-0.02", FundingIntervalHours: 4},
+		}, info)
+	})
+
+	t.Run("Funding rate history oldest first", func(t *testing.T) {
+		history, _, err := bybit.FundingRateHistory(models.FundingRateQuery{Symbol: "BTCUSDT", Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.FundingRateHistory{
+			{Symbol: "BTCUSDT", FundingRate: "0.000087", FundingTime: 1733846400000},
+			{Symbol: "BTCUSDT", FundingRate: "0.0001", FundingTime: 1733875200000},
+		}, history)
+	})
+
+	t.Run("Open interest", func(t *testing.T) {
+		openInterest, _, err := bybit.OpenInterest("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OpenInterest{Symbol: "BTCUSDT", OpenInterest: 56012.345, OpenInterestValue: 5442331212.12, Time: 1733900001200}, openInterest)
+	})
+
+	t.Run("Open interest history oldest first", func(t *testing.T) {
+		history, _, err := bybit.OpenInterestHistory(models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h", Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.OpenInterest{
+			{Symbol: "BTCUSDT", OpenInterest: 55890.12, Time: 1733896800000},
+			{Symbol: "BTCUSDT", OpenInterest: 56012.345, Time: 1733900400000},
+		}, history)
+
+		_, _, err = bybit.OpenInterestHistory(models.PositioningQuery{Symbol: "BTCUSDT", Period: "2h"})
+		assert.True(t, errors.Is(err, ErrNotSupported))
+	})
+
+	t.Run("Long/short ratio of a range", func(t *testing.T) {
+		history, _, err := bybit.LongShortRatio(models.RatioGlobalAccounts, models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h", StartTime: 1733900000000, EndTime: 1733900400000})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.LongShortRatio{
+			{Symbol: "BTCUSDT", LongShortRatio: 1.5, LongAccount: 0.6, ShortAccount: 0.4, Time: 1733900400000},
+		}, history)
+
+		_, statusCode, err := bybit.LongShortRatio(models.RatioTopPositions, models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h"})
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.EqualError(t, err, "bybit long/short ratio top-position: not supported")
+	})
+
+	t.Run("Exchange info", func(t *testing.T) {
+		symbols, _, err := bybit.ExchangeInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ExchangeSymbol{
+			{Symbol: "BTCUSDT", Status: "TRADING", BaseAsset: "BTC", QuoteAsset: "USDT"},
+			{Symbol: "OLDUSDT", Status: "CLOSED", BaseAsset: "OLD", QuoteAsset: "USDT"},
+		}, symbols)
+	})
+}
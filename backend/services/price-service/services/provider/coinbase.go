@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	DefaultCoinbaseBaseURL = "https://api.exchange.coinbase.com"
+
+	// coinbaseMaxKlines is the most candles Coinbase returns for one request
+	coinbaseMaxKlines = 300
+	// coinbaseMaxTrades is the most trades Coinbase returns for one request
+	coinbaseMaxTrades = 1000
+)
+
+// coinbaseGranularities maps Binance kline intervals to Coinbase candle granularities in seconds
+var coinbaseGranularities = map[string]int{
+	"1m": 60, "5m": 300, "15m": 900, "1h": 3600, "6h": 21600, "1d": 86400,
+}
+
+// CoinbaseProvider reads market data from the Coinbase Exchange REST API.
+// Coinbase has no perpetual futures, so futures and funding are not supported
+// and klines come from the spot market.
+type CoinbaseProvider struct {
+	BaseURL string
+	// Client is used for every request, http.DefaultClient when nil
+	Client *http.Client
+}
+
+func NewCoinbaseProvider(baseURL string) *CoinbaseProvider {
+	return &CoinbaseProvider{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewCoinbaseProviderFromEnv uses COINBASE_BASE_URL when it is set
+func NewCoinbaseProviderFromEnv() *CoinbaseProvider {
+	baseURL := os.Getenv("COINBASE_BASE_URL")
+	if baseURL == "" {
+		baseURL = DefaultCoinbaseBaseURL
+	}
+	return NewCoinbaseProvider(baseURL)
+}
+
+func (c *CoinbaseProvider) Name() string {
+	return ExchangeCoinbase
+}
+
+func (c *CoinbaseProvider) SpotTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	productID, err := coinbaseProduct(symbol)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var response struct {
+		Price string    `json:"price"`
+		Time  time.Time `json:"time"`
+	}
+	if statusCode, err := getJSON(c.Client, c.BaseURL+"/products/"+productID+"/ticker", nil, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return &models.ResponseBinance{
+		Symbol: NormalizeSymbol(symbol),
+		Price:  response.Price,
+		Time:   response.Time.UnixMilli(),
+	}, http.StatusOK, nil
+}
+
+func (c *CoinbaseProvider) FuturesTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "futures")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) PremiumIndex(symbol string) (*models.ResponseBinanceFuture, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "futures")
+	return nil, statusCode, err
+}
+
+// Ticker24h reads the stats of a product, which have neither a quote volume nor a timestamp
+func (c *CoinbaseProvider) Ticker24h(market, symbol string) (*models.Ticker24h, models.StatusCode, error) {
+	if market != MarketSpot {
+		statusCode, err := notSupported(ExchangeCoinbase, "futures")
+		return nil, statusCode, err
+	}
+	productID, err := coinbaseProduct(symbol)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var response struct {
+		Open   string `json:"open"`
+		High   string `json:"high"`
+		Low    string `json:"low"`
+		Last   string `json:"last"`
+		Volume string `json:"volume"`
+	}
+	if statusCode, err := getJSON(c.Client, c.BaseURL+"/products/"+productID+"/stats", nil, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return rollingTicker(NormalizeSymbol(symbol), response.Last, response.Open, response.High, response.Low,
+		response.Volume, "", time.Now().UnixMilli()), http.StatusOK, nil
+}
+
+// Depth reads the aggregated level 2 book, which Coinbase only returns whole
+func (c *CoinbaseProvider) Depth(market, symbol string, limit int) (*models.OrderBook, models.StatusCode, error) {
+	if market != MarketSpot {
+		statusCode, err := notSupported(ExchangeCoinbase, "futures")
+		return nil, statusCode, err
+	}
+	productID, err := coinbaseProduct(symbol)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("level", "2")
+
+	// levels are [price, size, orders]
+	var response struct {
+		Bids     [][]interface{} `json:"bids"`
+		Asks     [][]interface{} `json:"asks"`
+		Sequence int64           `json:"sequence"`
+		Time     time.Time       `json:"time"`
+	}
+	if statusCode, err := getJSON(c.Client, c.BaseURL+"/products/"+productID+"/book", q, &response); err != nil {
+		return nil, statusCode, err
+	}
+	return orderBook(NormalizeSymbol(symbol), response.Sequence, response.Bids, response.Asks, limit, response.Time.UnixMilli())
+}
+
+func (c *CoinbaseProvider) Trades(market, symbol string, limit int) ([]models.Trade, models.StatusCode, error) {
+	if market != MarketSpot {
+		statusCode, err := notSupported(ExchangeCoinbase, "futures")
+		return nil, statusCode, err
+	}
+	productID, err := coinbaseProduct(symbol)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(min(limit, coinbaseMaxTrades)))
+	}
+
+	// newest first, side is the side of the maker order
+	var response []struct {
+		TradeID int64     `json:"trade_id"`
+		Price   string    `json:"price"`
+		Size    string    `json:"size"`
+		Side    string    `json:"side"`
+		Time    time.Time `json:"time"`
+	}
+	if statusCode, err := getJSON(c.Client, c.BaseURL+"/products/"+productID+"/trades", q, &response); err != nil {
+		return nil, statusCode, err
+	}
+
+	trades := make([]models.Trade, 0, len(response))
+	for i := len(response) - 1; i >= 0; i-- {
+		side := models.SideBuy
+		if response[i].Side == models.SideBuy {
+			side = models.SideSell
+		}
+		trades = append(trades, models.Trade{
+			ID:       strconv.FormatInt(response[i].TradeID, 10),
+			Price:    toFloat(response[i].Price),
+			Quantity: toFloat(response[i].Size),
+			Side:     side,
+			Time:     response[i].Time.UnixMilli(),
+		})
+	}
+	return trades, http.StatusOK, nil
+}
+
+// SpotTickers is not supported, Coinbase has no request for the ticker of every product
+func (c *CoinbaseProvider) SpotTickers() ([]models.ResponseBinance, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "tickers of every product")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) PremiumIndexes() ([]models.ResponseBinanceFuture, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "futures")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) Klines(query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	granularity, ok := coinbaseGranularities[query.Interval]
+	if !ok {
+		statusCode, err := notSupported(ExchangeCoinbase, "interval "+query.Interval)
+		return nil, statusCode, err
+	}
+	productID, err := coinbaseProduct(query.Symbol)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("granularity", strconv.Itoa(granularity))
+	// Coinbase ignores the window unless both start and end are given and rejects windows over its cap
+	limit := klineLimit(query, coinbaseMaxKlines)
+	startTime, endTime := klineWindow(query, limit)
+	if startTime == 0 && endTime > 0 {
+		startTime = RangeStart(endTime, query.Interval, limit)
+	}
+	if startTime > 0 {
+		if endTime == 0 {
+			endTime = RangeEnd(startTime, query.Interval, limit)
+		}
+		q.Add("start", time.UnixMilli(startTime).UTC().Format(time.RFC3339))
+		q.Add("end", time.UnixMilli(endTime).UTC().Format(time.RFC3339))
+	}
+
+	// [time in seconds, low, high, open, close, volume], newest first
+	var data [][]float64
+	if statusCode, err := getJSON(c.Client, c.BaseURL+"/products/"+productID+"/candles", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	candles := make([]models.Candle, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		value := data[i]
+		if len(value) < 6 {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to decode response: candle has %d fields", len(value))
+		}
+		openTime := int64(value[0]) * 1000
+		candles = append(candles, models.Candle{
+			OpenTime:  openTime,
+			CloseTime: CandleCloseTime(openTime, query.Interval),
+			Open:      value[3],
+			High:      value[2],
+			Low:       value[1],
+			Close:     value[4],
+			Volume:    value[5],
+		})
+	}
+
+	// Coinbase has no limit parameter
+	if len(candles) > limit {
+		if query.StartTime > 0 {
+			candles = candles[:limit]
+		} else {
+			candles = candles[len(candles)-limit:]
+		}
+	}
+	return candles, http.StatusOK, nil
+}
+
+func (c *CoinbaseProvider) FundingInfo() ([]models.FundingRateSecond, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "funding")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) FundingRateHistory(query models.FundingRateQuery) ([]models.FundingRateHistory, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "funding")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) OpenInterest(symbol string) (*models.OpenInterest, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "futures")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) OpenInterestHistory(query models.PositioningQuery) ([]models.OpenInterest, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "futures")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) LongShortRatio(kind string, query models.PositioningQuery) ([]models.LongShortRatio, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeCoinbase, "futures")
+	return nil, statusCode, err
+}
+
+func (c *CoinbaseProvider) ExchangeInfo() ([]models.ExchangeSymbol, models.StatusCode, error) {
+	var data []struct {
+		BaseCurrency    string `json:"base_currency"`
+		QuoteCurrency   string `json:"quote_currency"`
+		Status          string `json:"status"`
+		TradingDisabled bool   `json:"trading_disabled"`
+	}
+	if statusCode, err := getJSON(c.Client, c.BaseURL+"/products", nil, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	symbols := make([]models.ExchangeSymbol, 0, len(data))
+	for _, value := range data {
+		status := strings.ToUpper(value.Status)
+		if value.Status == "online" && !value.TradingDisabled {
+			status = "TRADING"
+		}
+		symbols = append(symbols, models.ExchangeSymbol{
+			Symbol:     value.BaseCurrency + value.QuoteCurrency,
+			Status:     status,
+			BaseAsset:  value.BaseCurrency,
+			QuoteAsset: value.QuoteCurrency,
+		})
+	}
+	return symbols, http.StatusOK, nil
+}
+
+// coinbaseProduct turns BTCUSDT into BTC-USDT
+func coinbaseProduct(symbol string) (string, error) {
+	base, quote, err := SplitSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	return base + "-" + quote, nil
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCoinbaseFixtureProvider(t *testing.T) *CoinbaseProvider {
+	server := newFixtureServer(t, []fixture{
+		{path: "/products/BTC-USDT/ticker", file: "coinbase_ticker.json"},
+		{path: "/products/BTC-USDT/stats", file: "coinbase_stats.json"},
+		{path: "/products/BTC-USDT/book", query: "level=2", file: "coinbase_book.json"},
+		{path: "/products/BTC-USDT/trades", query: "limit=2", file: "coinbase_trades.json"},
+		{path: "/products/NOPE-USD/ticker", statusCode: http.StatusNotFound, file: "coinbase_not_found.json"},
+		{path: "/products/BTC-USDT/candles", query: "granularity=60&start=2024-12-11T07:00:00Z&end=2024-12-11T07:00:59Z", file: "coinbase_candles.json"},
+		{path: "/products/BTC-USDT/candles", query: "granularity=60", file: "coinbase_candles.json"},
+		{path: "/products", file: "coinbase_products.json"},
+	})
+	return NewCoinbaseProvider(server.URL)
+}
+
+func TestCoinbaseProvider(t *testing.T) {
+	coinbase := newCoinbaseFixtureProvider(t)
+
+	t.Run("Spot ticker", func(t *testing.T) {
+		ticker, _, err := coinbase.SpotTicker("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResponseBinance{Symbol: "BTCUSDT", Price: "97102.00", Time: 1733900000123}, ticker)
+	})
+
+	t.Run("24 hour ticker", func(t *testing.T) {
+		ticker, _, err := coinbase.Ticker24h(MarketSpot, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, "BTCUSDT", ticker.Symbol)
+		assert.Equal(t, "1221.50", ticker.PriceChange)
+		assert.Equal(t, "1.274", ticker.PriceChangePercent)
+		assert.Equal(t, "12345.67891234", ticker.Volume)
+		assert.Equal(t, int64(24*60*60*1000), ticker.CloseTime-ticker.OpenTime)
+	})
+
+	t.Run("Depth", func(t *testing.T) {
+		book, _, err := coinbase.Depth(MarketSpot, "BTCUSDT", 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OrderBook{
+			Symbol:       "BTCUSDT",
+			LastUpdateID: 91234567890,
+			Bids:         []models.PriceLevel{{Price: 97101.95, Quantity: 0.25}},
+			Asks:         []models.PriceLevel{{Price: 97102.11, Quantity: 0.4}},
+			Time:         1733900000456,
+		}, book)
+	})
+
+	t.Run("Trades with the taker side", func(t *testing.T) {
+		trades, _, err := coinbase.Trades(MarketSpot, "BTCUSDT", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Trade{
+			{ID: "734512345", Price: 97101.95, Quantity: 0.5, Side: models.SideSell, Time: 1733900000123},
+			{ID: "734512346", Price: 97102.01, Quantity: 0.0102, Side: models.SideBuy, Time: 1733900000300},
+		}, trades)
+	})
+
+	t.Run("Unknown product", func(t *testing.T) {
+		_, statusCode, err := coinbase.SpotTicker("NOPEUSD")
+		assert.Equal(t, models.StatusCode(http.StatusNotFound), statusCode)
+		assert.EqualError(t, err, "API returned status code: 404")
+	})
+
+	t.Run("Klines oldest first", func(t *testing.T) {
+		candles, _, err := coinbase.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1m"})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Candle{
+			{OpenTime: 1733900340000, CloseTime: 1733900399999, Open: 97140, High: 97175.4, Low: 97130.2, Close: 97170.1, Volume: 23.1},
+			{OpenTime: 1733900400000, CloseTime: 1733900459999, Open: 97170.1, High: 97190, Low: 97160.3, Close: 97180.1, Volume: 12.54},
+		}, candles)
+	})
+
+	t.Run("Klines up to an end time", func(t *testing.T) {
+		candles, _, err := coinbase.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1m", EndTime: 1733900459999, Limit: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Candle{
+			{OpenTime: 1733900400000, CloseTime: 1733900459999, Open: 97170.1, High: 97190, Low: 97160.3, Close: 97180.1, Volume: 12.54},
+		}, candles)
+	})
+
+	t.Run("Unsupported interval", func(t *testing.T) {
+		_, statusCode, err := coinbase.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "4h"})
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.True(t, errors.Is(err, ErrNotSupported))
+	})
+
+	t.Run("Futures and funding not supported", func(t *testing.T) {
+		_, statusCode, err := coinbase.FuturesTicker("BTCUSDT")
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.EqualError(t, err, "coinbase futures: not supported")
+
+		_, _, err = coinbase.PremiumIndex("BTCUSDT")
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.PremiumIndexes()
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.Trades(MarketFutures, "BTCUSDT", 10)
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.Depth(MarketFutures, "BTCUSDT", 10)
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.Ticker24h(MarketFutures, "BTCUSDT")
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.SpotTickers()
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.FundingInfo()
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.FundingRateHistory(models.FundingRateQuery{Symbol: "BTCUSDT", Limit: 1})
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.OpenInterest("BTCUSDT")
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.OpenInterestHistory(models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h"})
+		assert.True(t, errors.Is(err, ErrNotSupported))
+		_, _, err = coinbase.LongShortRatio(models.RatioGlobalAccounts, models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h"})
+		assert.True(t, errors.Is(err, ErrNotSupported))
+	})
+
+	t.Run("Exchange info", func(t *testing.T) {
+		symbols, _, err := coinbase.ExchangeInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ExchangeSymbol{
+			{Symbol: "BTCUSDT", Status: "TRADING", BaseAsset: "BTC", QuoteAsset: "USDT"},
+			{Symbol: "OLDUSD", Status: "DELISTED", BaseAsset: "OLD", QuoteAsset: "USD"},
+		}, symbols)
+	})
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// priceLevels decodes the [price, quantity, ...] levels of an exchange, keeping at most limit of them
+func priceLevels(levels [][]interface{}, limit int) ([]models.PriceLevel, error) {
+	if limit > 0 && len(levels) > limit {
+		levels = levels[:limit]
+	}
+	result := make([]models.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("failed to decode response: price level has %d fields", len(level))
+		}
+		result = append(result, models.PriceLevel{Price: toFloat(level[0]), Quantity: toFloat(level[1])})
+	}
+	return result, nil
+}
+
+// orderBook builds the book of a symbol from the decoded levels of both sides
+func orderBook(symbol string, lastUpdateID int64, bids, asks [][]interface{}, limit int, eventTime int64) (*models.OrderBook, models.StatusCode, error) {
+	bidLevels, err := priceLevels(bids, limit)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	askLevels, err := priceLevels(asks, limit)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return &models.OrderBook{
+		Symbol:       symbol,
+		LastUpdateID: lastUpdateID,
+		Bids:         bidLevels,
+		Asks:         askLevels,
+		Time:         eventTime,
+	}, http.StatusOK, nil
+}
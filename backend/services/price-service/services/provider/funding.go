@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// fundingIntervalsTTL is how long the funding intervals of an exchange are reused, they rarely change
+const fundingIntervalsTTL = time.Hour
+
+type cachedFundingIntervals struct {
+	intervals map[string]int
+	expires   time.Time
+}
+
+var (
+	// fundingIntervalsCache is keyed by provider rather than by name, so that providers of the same
+	// exchange on other URLs do not share their intervals
+	fundingIntervalsCache      = map[MarketDataProvider]cachedFundingIntervals{}
+	fundingIntervalsCacheMutex sync.Mutex
+)
+
+// fundingRatesSince drops the rates, oldest first, settled before startTime when it is set
+func fundingRatesSince(history []models.FundingRateHistory, startTime int64) []models.FundingRateHistory {
+	for len(history) > 0 && startTime > 0 && history[0].FundingTime < startTime {
+		history = history[1:]
+	}
+	return history
+}
+
+// FundingIntervals returns the funding interval in hours of the futures symbols the exchange lists in its
+// funding info, empty when the exchange has none. The other symbols fund every 8 hours. The funding
+// info is cached for an hour, the map returned is shared and must not be modified.
+func FundingIntervals(p MarketDataProvider) map[string]int {
+	now := time.Now()
+	fundingIntervalsCacheMutex.Lock()
+	cached, ok := fundingIntervalsCache[p]
+	fundingIntervalsCacheMutex.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.intervals
+	}
+
+	intervals := map[string]int{}
+	info, _, err := p.FundingInfo()
+	if err != nil {
+		return intervals
+	}
+	for _, value := range info {
+		if value.FundingIntervalHours > 0 {
+			intervals[value.Symbol] = value.FundingIntervalHours
+		}
+	}
+	fundingIntervalsCacheMutex.Lock()
+	fundingIntervalsCache[p] = cachedFundingIntervals{intervals: intervals, expires: now.Add(fundingIntervalsTTL)}
+	fundingIntervalsCacheMutex.Unlock()
+	return intervals
+}
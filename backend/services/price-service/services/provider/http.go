@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// getJSON sends a GET request and decodes the JSON body into out, client is http.DefaultClient when nil.
+// On failure it returns the upstream status code, or 500 when there is none.
+func getJSON(client *http.Client, endpoint string, query url.Values, out interface{}) (models.StatusCode, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to create request: %v", err)
+	}
+	if query != nil {
+		req.URL.RawQuery = query.Encode()
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.StatusCode(resp.StatusCode), fmt.Errorf("API returned status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return http.StatusOK, nil
+}
+
+// notSupported builds the error returned for data an exchange does not offer
+func notSupported(exchange, what string) (models.StatusCode, error) {
+	return http.StatusBadRequest, fmt.Errorf("%s %s: %w", exchange, what, ErrNotSupported)
+}
+
+func symbolNotFound(exchange, symbol string) error {
+	return fmt.Errorf("%s has no symbol %s", exchange, symbol)
+}
+
+func toFloat(data interface{}) float64 {
+	switch value := data.(type) {
+	case string:
+		result, _ := strconv.ParseFloat(value, 64)
+		return result
+	case float64:
+		return value
+	}
+	return 0.0
+}
+
+func toInt64(data interface{}) int64 {
+	switch value := data.(type) {
+	case float64:
+		return int64(value)
+	case string:
+		result, _ := strconv.ParseInt(value, 10, 64)
+		return result
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// intervalDurations are the kline intervals of Binance futures, 1M is handled separately
+var intervalDurations = map[string]time.Duration{
+	"1m":  time.Minute,
+	"3m":  3 * time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"6h":  6 * time.Hour,
+	"8h":  8 * time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+	"3d":  3 * 24 * time.Hour,
+	"1w":  7 * 24 * time.Hour,
+}
+
+// IsValidInterval reports whether interval is a kline interval of Binance futures
+func IsValidInterval(interval string) bool {
+	_, ok := intervalDurations[interval]
+	return ok || interval == "1M"
+}
+
+// CandleCloseTime returns the last millisecond of a candle opened at openTime
+func CandleCloseTime(openTime int64, interval string) int64 {
+	if interval == "1M" {
+		return time.UnixMilli(openTime).UTC().AddDate(0, 1, 0).UnixMilli() - 1
+	}
+	return openTime + intervalDurations[interval].Milliseconds() - 1
+}
+
+// RangeEnd returns the last millisecond of count candles opened from startTime on
+func RangeEnd(startTime int64, interval string, count int) int64 {
+	if interval == "1M" {
+		return time.UnixMilli(startTime).UTC().AddDate(0, count, 0).UnixMilli() - 1
+	}
+	return startTime + int64(count)*intervalDurations[interval].Milliseconds() - 1
+}
+
+// RangeStart returns the open time of the first of count candles ending at endTime
+func RangeStart(endTime int64, interval string, count int) int64 {
+	if interval == "1M" {
+		return time.UnixMilli(endTime+1).UTC().AddDate(0, -count, 0).UnixMilli()
+	}
+	return endTime - int64(count)*intervalDurations[interval].Milliseconds() + 1
+}
+
+// klineWindow returns the time window holding count candles of query for exchanges that take
+// a window rather than a start time and a limit. Zero times stay unset.
+func klineWindow(query models.KlineQuery, count int) (startTime, endTime int64) {
+	if query.StartTime > 0 {
+		endTime = RangeEnd(query.StartTime, query.Interval, count)
+		if query.EndTime > 0 && query.EndTime < endTime {
+			endTime = query.EndTime
+		}
+		return query.StartTime, endTime
+	}
+	return 0, query.EndTime
+}
+
+// klineLimit returns the number of candles to request from an exchange capped at max
+func klineLimit(query models.KlineQuery, max int) int {
+	if query.Limit <= 0 || query.Limit > max {
+		return max
+	}
+	return query.Limit
+}
@@ -0,0 +1,566 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	DefaultOKXBaseURL = "https://www.okx.com"
+
+	// okxMaxKlines and okxMaxHistoryKlines are the most candles of one request to the
+	// recent candles and the history candles endpoints
+	okxMaxKlines        = 300
+	okxMaxHistoryKlines = 100
+	// okxMaxDepth is the most levels of each side of one order book request
+	okxMaxDepth = 400
+	// okxMaxTrades is the most trades of one recent trades request
+	okxMaxTrades = 500
+	// okxMaxFundingRates is the most funding rates of one funding rate history request
+	okxMaxFundingRates = 100
+	// okxMaxPositions is the most points of one open interest or long/short ratio request
+	okxMaxPositions = 100
+)
+
+// okxBars maps Binance kline intervals to OKX bars, daily and longer bars are aligned to UTC
+var okxBars = map[string]string{
+	"1m": "1m", "3m": "3m", "5m": "5m", "15m": "15m", "30m": "30m",
+	"1h": "1H", "2h": "2H", "4h": "4H", "6h": "6Hutc", "12h": "12Hutc",
+	"1d": "1Dutc", "1w": "1Wutc", "1M": "1Mutc",
+}
+
+// okxPeriods maps Binance open interest and long/short ratio periods to OKX periods
+var okxPeriods = map[string]string{
+	"5m": "5m", "15m": "15m", "30m": "30m", "1h": "1H", "2h": "2H",
+	"4h": "4H", "6h": "6H", "12h": "12H", "1d": "1D",
+}
+
+// OKXProvider reads market data from the OKX v5 REST API, futures are the USDT-margined perpetual swaps
+type OKXProvider struct {
+	BaseURL string
+	// Client is used for every request, http.DefaultClient when nil
+	Client *http.Client
+}
+
+func NewOKXProvider(baseURL string) *OKXProvider {
+	return &OKXProvider{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewOKXProviderFromEnv uses OKX_BASE_URL when it is set
+func NewOKXProviderFromEnv() *OKXProvider {
+	baseURL := os.Getenv("OKX_BASE_URL")
+	if baseURL == "" {
+		baseURL = DefaultOKXBaseURL
+	}
+	return NewOKXProvider(baseURL)
+}
+
+func (o *OKXProvider) Name() string {
+	return ExchangeOKX
+}
+
+func (o *OKXProvider) SpotTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	return o.ticker(symbol, false)
+}
+
+func (o *OKXProvider) FuturesTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error) {
+	return o.ticker(symbol, true)
+}
+
+func (o *OKXProvider) ticker(symbol string, swap bool) (*models.ResponseBinance, models.StatusCode, error) {
+	ticker, statusCode, err := o.fetchTicker(symbol, swap)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	return &models.ResponseBinance{
+		Symbol: NormalizeSymbol(symbol),
+		Price:  ticker.Last,
+		Time:   toInt64(ticker.Ts),
+	}, http.StatusOK, nil
+}
+
+// Ticker24h has no weighted average price nor trade count, and no quote volume for futures
+// since OKX gives the swap volume in contracts and in the base asset
+func (o *OKXProvider) Ticker24h(market, symbol string) (*models.Ticker24h, models.StatusCode, error) {
+	ticker, statusCode, err := o.fetchTicker(symbol, market != MarketSpot)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	volume, quoteVolume := ticker.Vol24h, ticker.VolCcy24h
+	if market != MarketSpot {
+		volume, quoteVolume = ticker.VolCcy24h, ""
+	}
+	return rollingTicker(NormalizeSymbol(symbol), ticker.Last, ticker.Open24h, ticker.High24h, ticker.Low24h,
+		volume, quoteVolume, toInt64(ticker.Ts)), http.StatusOK, nil
+}
+
+type okxTicker struct {
+	Last      string `json:"last"`
+	Open24h   string `json:"open24h"`
+	High24h   string `json:"high24h"`
+	Low24h    string `json:"low24h"`
+	Vol24h    string `json:"vol24h"`
+	VolCcy24h string `json:"volCcy24h"`
+	Ts        string `json:"ts"`
+}
+
+func (o *OKXProvider) fetchTicker(symbol string, swap bool) (*okxTicker, models.StatusCode, error) {
+	instID, err := okxInstrument(symbol, swap)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("instId", instID)
+
+	var data []okxTicker
+	if statusCode, err := o.get("/api/v5/market/ticker", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+	if len(data) == 0 {
+		return nil, http.StatusNotFound, symbolNotFound(ExchangeOKX, symbol)
+	}
+	return &data[0], http.StatusOK, nil
+}
+
+// PremiumIndex combines the mark price, index price and funding rate endpoints of OKX
+func (o *OKXProvider) PremiumIndex(symbol string) (*models.ResponseBinanceFuture, models.StatusCode, error) {
+	swapID, err := okxInstrument(symbol, true)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	indexID, _ := okxInstrument(symbol, false)
+
+	q := url.Values{}
+	q.Add("instType", "SWAP")
+	q.Add("instId", swapID)
+	var mark []struct {
+		MarkPx string `json:"markPx"`
+		Ts     string `json:"ts"`
+	}
+	if statusCode, err := o.get("/api/v5/public/mark-price", q, &mark); err != nil {
+		return nil, statusCode, err
+	}
+
+	q = url.Values{}
+	q.Add("instId", indexID)
+	var index []struct {
+		IdxPx string `json:"idxPx"`
+	}
+	if statusCode, err := o.get("/api/v5/market/index-tickers", q, &index); err != nil {
+		return nil, statusCode, err
+	}
+
+	q = url.Values{}
+	q.Add("instId", swapID)
+	var funding []struct {
+		FundingRate string `json:"fundingRate"`
+		FundingTime string `json:"fundingTime"`
+	}
+	if statusCode, err := o.get("/api/v5/public/funding-rate", q, &funding); err != nil {
+		return nil, statusCode, err
+	}
+	if len(mark) == 0 || len(index) == 0 || len(funding) == 0 {
+		return nil, http.StatusNotFound, symbolNotFound(ExchangeOKX, symbol)
+	}
+
+	return &models.ResponseBinanceFuture{
+		Symbol:          NormalizeSymbol(symbol),
+		MarkPrice:       mark[0].MarkPx,
+		IndexPrice:      index[0].IdxPx,
+		LastFundingRate: funding[0].FundingRate,
+		NextFundingTime: toInt64(funding[0].FundingTime),
+		Time:            toInt64(mark[0].Ts),
+	}, http.StatusOK, nil
+}
+
+func (o *OKXProvider) Depth(market, symbol string, limit int) (*models.OrderBook, models.StatusCode, error) {
+	instID, err := okxInstrument(symbol, market != MarketSpot)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	limit = min(limit, okxMaxDepth)
+	q := url.Values{}
+	q.Add("instId", instID)
+	if limit > 0 {
+		q.Add("sz", strconv.Itoa(limit))
+	}
+
+	// levels are [price, size, deprecated, orders], swap sizes are in contracts
+	var data []struct {
+		Asks  [][]interface{} `json:"asks"`
+		Bids  [][]interface{} `json:"bids"`
+		Ts    string          `json:"ts"`
+		SeqID int64           `json:"seqId"`
+	}
+	if statusCode, err := o.get("/api/v5/market/books", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+	if len(data) == 0 {
+		return nil, http.StatusNotFound, symbolNotFound(ExchangeOKX, symbol)
+	}
+	return orderBook(NormalizeSymbol(symbol), data[0].SeqID, data[0].Bids, data[0].Asks, limit, toInt64(data[0].Ts))
+}
+
+// Trades of futures are in contracts, see Ticker24h
+func (o *OKXProvider) Trades(market, symbol string, limit int) ([]models.Trade, models.StatusCode, error) {
+	instID, err := okxInstrument(symbol, market != MarketSpot)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("instId", instID)
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(min(limit, okxMaxTrades)))
+	}
+
+	// newest first, side is the taker side
+	var data []struct {
+		TradeID string `json:"tradeId"`
+		Px      string `json:"px"`
+		Sz      string `json:"sz"`
+		Side    string `json:"side"`
+		Ts      string `json:"ts"`
+	}
+	if statusCode, err := o.get("/api/v5/market/trades", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	trades := make([]models.Trade, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		trades = append(trades, models.Trade{
+			ID:       data[i].TradeID,
+			Price:    toFloat(data[i].Px),
+			Quantity: toFloat(data[i].Sz),
+			Side:     data[i].Side,
+			Time:     toInt64(data[i].Ts),
+		})
+	}
+	return trades, http.StatusOK, nil
+}
+
+func (o *OKXProvider) SpotTickers() ([]models.ResponseBinance, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("instType", "SPOT")
+	var data []struct {
+		InstID string `json:"instId"`
+		Last   string `json:"last"`
+		Ts     string `json:"ts"`
+	}
+	if statusCode, err := o.get("/api/v5/market/tickers", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	tickers := make([]models.ResponseBinance, 0, len(data))
+	for _, value := range data {
+		tickers = append(tickers, models.ResponseBinance{
+			Symbol: NormalizeSymbol(value.InstID),
+			Price:  value.Last,
+			Time:   toInt64(value.Ts),
+		})
+	}
+	return tickers, http.StatusOK, nil
+}
+
+// PremiumIndexes only has the mark prices, OKX has no request for every index price and funding rate
+func (o *OKXProvider) PremiumIndexes() ([]models.ResponseBinanceFuture, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("instType", "SWAP")
+	var data []struct {
+		InstID string `json:"instId"`
+		MarkPx string `json:"markPx"`
+		Ts     string `json:"ts"`
+	}
+	if statusCode, err := o.get("/api/v5/public/mark-price", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	indexes := make([]models.ResponseBinanceFuture, 0, len(data))
+	for _, value := range data {
+		indexes = append(indexes, models.ResponseBinanceFuture{
+			Symbol:    NormalizeSymbol(strings.TrimSuffix(value.InstID, "-SWAP")),
+			MarkPrice: value.MarkPx,
+			Time:      toInt64(value.Ts),
+		})
+	}
+	return indexes, http.StatusOK, nil
+}
+
+func (o *OKXProvider) Klines(query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	bar, ok := okxBars[query.Interval]
+	if !ok {
+		statusCode, err := notSupported(ExchangeOKX, "interval "+query.Interval)
+		return nil, statusCode, err
+	}
+	instID, err := okxInstrument(query.Symbol, query.Market != MarketSpot)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("instId", instID)
+	q.Add("bar", bar)
+
+	// the recent candles endpoint only covers the last 1440 candles
+	path, limit := "/api/v5/market/candles", klineLimit(query, okxMaxKlines)
+	if query.StartTime > 0 || query.EndTime > 0 {
+		path, limit = "/api/v5/market/history-candles", klineLimit(query, okxMaxHistoryKlines)
+	}
+	q.Add("limit", strconv.Itoa(limit))
+	// after and before select candles opened strictly earlier and strictly later than a time
+	startTime, endTime := klineWindow(query, limit)
+	if startTime > 0 {
+		q.Add("before", strconv.FormatInt(startTime-1, 10))
+	}
+	if endTime > 0 {
+		q.Add("after", strconv.FormatInt(endTime+1, 10))
+	}
+
+	// [ts, o, h, l, c, vol, volCcy, volCcyQuote, confirm], newest first
+	var data [][]string
+	if statusCode, err := o.get(path, q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	candles := make([]models.Candle, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		value := data[i]
+		if len(value) < 6 {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to decode response: candle has %d fields", len(value))
+		}
+		openTime := toInt64(value[0])
+		candles = append(candles, models.Candle{
+			OpenTime:  openTime,
+			CloseTime: CandleCloseTime(openTime, query.Interval),
+			Open:      toFloat(value[1]),
+			High:      toFloat(value[2]),
+			Low:       toFloat(value[3]),
+			Close:     toFloat(value[4]),
+			Volume:    toFloat(value[5]),
+		})
+	}
+	return candles, http.StatusOK, nil
+}
+
+func (o *OKXProvider) FundingInfo() ([]models.FundingRateSecond, models.StatusCode, error) {
+	statusCode, err := notSupported(ExchangeOKX, "funding info")
+	return nil, statusCode, err
+}
+
+func (o *OKXProvider) FundingRateHistory(query models.FundingRateQuery) ([]models.FundingRateHistory, models.StatusCode, error) {
+	instID, err := okxInstrument(query.Symbol, true)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("instId", instID)
+	if query.Limit > 0 {
+		q.Add("limit", strconv.Itoa(min(query.Limit, okxMaxFundingRates)))
+	}
+	// after pages to the rates older than a funding time
+	if query.EndTime > 0 {
+		q.Add("after", strconv.FormatInt(query.EndTime+1, 10))
+	}
+
+	// newest first
+	var data []struct {
+		FundingRate string `json:"fundingRate"`
+		FundingTime string `json:"fundingTime"`
+	}
+	if statusCode, err := o.get("/api/v5/public/funding-rate-history", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.FundingRateHistory, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		history = append(history, models.FundingRateHistory{
+			Symbol:      NormalizeSymbol(query.Symbol),
+			FundingRate: data[i].FundingRate,
+			FundingTime: toInt64(data[i].FundingTime),
+		})
+	}
+	return fundingRatesSince(history, query.StartTime), http.StatusOK, nil
+}
+
+// OpenInterest reads the open interest of the swap in coins and its value in USD
+func (o *OKXProvider) OpenInterest(symbol string) (*models.OpenInterest, models.StatusCode, error) {
+	instID, err := okxInstrument(symbol, true)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("instType", "SWAP")
+	q.Add("instId", instID)
+
+	var data []struct {
+		OiCcy string `json:"oiCcy"`
+		OiUsd string `json:"oiUsd"`
+		Ts    string `json:"ts"`
+	}
+	if statusCode, err := o.get("/api/v5/public/open-interest", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+	if len(data) == 0 {
+		return nil, http.StatusNotFound, symbolNotFound(ExchangeOKX, symbol)
+	}
+	return &models.OpenInterest{
+		Symbol:            NormalizeSymbol(symbol),
+		OpenInterest:      toFloat(data[0].OiCcy),
+		OpenInterestValue: toFloat(data[0].OiUsd),
+		Time:              toInt64(data[0].Ts),
+	}, http.StatusOK, nil
+}
+
+func (o *OKXProvider) OpenInterestHistory(query models.PositioningQuery) ([]models.OpenInterest, models.StatusCode, error) {
+	q, statusCode, err := o.positioningQuery(query)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	// [ts, oi in contracts, oi in coins, oi in USD], newest first
+	var data [][]string
+	if statusCode, err := o.get("/api/v5/rubik/stat/contracts/open-interest-history", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.OpenInterest, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		value := data[i]
+		if len(value) < 4 {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to decode response: open interest has %d fields", len(value))
+		}
+		history = append(history, models.OpenInterest{
+			Symbol:            NormalizeSymbol(query.Symbol),
+			OpenInterest:      toFloat(value[2]),
+			OpenInterestValue: toFloat(value[3]),
+			Time:              toInt64(value[0]),
+		})
+	}
+	return openInterestSince(history, query.StartTime), http.StatusOK, nil
+}
+
+// LongShortRatio reads series that only give the ratio, the long and short shares are derived from it
+func (o *OKXProvider) LongShortRatio(kind string, query models.PositioningQuery) ([]models.LongShortRatio, models.StatusCode, error) {
+	endpoints := map[string]string{
+		models.RatioTopAccounts:    "/api/v5/rubik/stat/contracts/long-short-account-ratio-contract-top-trader",
+		models.RatioTopPositions:   "/api/v5/rubik/stat/contracts/long-short-position-ratio-contract-top-trader",
+		models.RatioGlobalAccounts: "/api/v5/rubik/stat/contracts/long-short-account-ratio-contract",
+	}
+	endpoint, ok := endpoints[kind]
+	if !ok {
+		statusCode, err := notSupported(ExchangeOKX, "long/short ratio "+kind)
+		return nil, statusCode, err
+	}
+	q, statusCode, err := o.positioningQuery(query)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	// [ts, ratio], newest first
+	var data [][]string
+	if statusCode, err := o.get(endpoint, q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	history := make([]models.LongShortRatio, 0, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		value := data[i]
+		if len(value) < 2 {
+			return nil, http.StatusInternalServerError, fmt.Errorf("failed to decode response: long/short ratio has %d fields", len(value))
+		}
+		ratio := toFloat(value[1])
+		long, short := ratioShares(ratio)
+		history = append(history, models.LongShortRatio{
+			Symbol:         NormalizeSymbol(query.Symbol),
+			LongShortRatio: ratio,
+			LongAccount:    long,
+			ShortAccount:   short,
+			Time:           toInt64(value[0]),
+		})
+	}
+	return ratiosSince(history, query.StartTime), http.StatusOK, nil
+}
+
+func (o *OKXProvider) positioningQuery(query models.PositioningQuery) (url.Values, models.StatusCode, error) {
+	period, ok := okxPeriods[query.Period]
+	if !ok {
+		statusCode, err := notSupported(ExchangeOKX, "period "+query.Period)
+		return nil, statusCode, err
+	}
+	instID, err := okxInstrument(query.Symbol, true)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	q := url.Values{}
+	q.Add("instId", instID)
+	q.Add("period", period)
+	if query.Limit > 0 {
+		q.Add("limit", strconv.Itoa(min(query.Limit, okxMaxPositions)))
+	}
+	if query.EndTime > 0 {
+		q.Add("end", strconv.FormatInt(query.EndTime, 10))
+	}
+	return q, http.StatusOK, nil
+}
+
+func (o *OKXProvider) ExchangeInfo() ([]models.ExchangeSymbol, models.StatusCode, error) {
+	q := url.Values{}
+	q.Add("instType", "SPOT")
+
+	var data []struct {
+		BaseCcy  string `json:"baseCcy"`
+		QuoteCcy string `json:"quoteCcy"`
+		State    string `json:"state"`
+	}
+	if statusCode, err := o.get("/api/v5/public/instruments", q, &data); err != nil {
+		return nil, statusCode, err
+	}
+
+	symbols := make([]models.ExchangeSymbol, 0, len(data))
+	for _, value := range data {
+		status := strings.ToUpper(value.State)
+		if value.State == "live" {
+			status = "TRADING"
+		}
+		symbols = append(symbols, models.ExchangeSymbol{
+			Symbol:     value.BaseCcy + value.QuoteCcy,
+			Status:     status,
+			BaseAsset:  value.BaseCcy,
+			QuoteAsset: value.QuoteCcy,
+		})
+	}
+	return symbols, http.StatusOK, nil
+}
+
+// get unwraps the {"code","msg","data"} envelope of OKX, a non zero code is a bad request
+func (o *OKXProvider) get(path string, query url.Values, data interface{}) (models.StatusCode, error) {
+	var envelope struct {
+		Code string      `json:"code"`
+		Msg  string      `json:"msg"`
+		Data interface{} `json:"data"`
+	}
+	envelope.Data = data
+	if statusCode, err := getJSON(o.Client, o.BaseURL+path, query, &envelope); err != nil {
+		return statusCode, err
+	}
+	if envelope.Code != "0" {
+		return http.StatusBadRequest, fmt.Errorf("okx error %s: %s", envelope.Code, envelope.Msg)
+	}
+	return http.StatusOK, nil
+}
+
+// okxInstrument turns BTCUSDT into BTC-USDT, or BTC-USDT-SWAP for the perpetual swap
+func okxInstrument(symbol string, swap bool) (string, error) {
+	base, quote, err := SplitSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	if swap {
+		return base + "-" + quote + "-SWAP", nil
+	}
+	return base + "-" + quote, nil
+}
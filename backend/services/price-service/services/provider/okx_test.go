@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOKXFixtureProvider(t *testing.T) *OKXProvider {
+	server := newFixtureServer(t, []fixture{
+		{path: "/api/v5/market/ticker", query: "instId=BTC-USDT", file: "okx_ticker_spot.json"},
+		{path: "/api/v5/market/ticker", query: "instId=BTC-USDT-SWAP", file: "okx_ticker_swap.json"},
+		{path: "/api/v5/market/ticker", file: "okx_error.json"},
+		{path: "/api/v5/public/mark-price", query: "instId=BTC-USDT-SWAP", file: "okx_mark_price.json"},
+		{path: "/api/v5/public/mark-price", query: "instType=SWAP", file: "okx_mark_prices.json"},
+		{path: "/api/v5/market/tickers", query: "instType=SPOT", file: "okx_tickers_spot.json"},
+		{path: "/api/v5/market/trades", query: "instId=BTC-USDT&limit=500", file: "okx_trades.json"},
+		{path: "/api/v5/market/books", query: "instId=BTC-USDT-SWAP&sz=2", file: "okx_books.json"},
+		{path: "/api/v5/market/index-tickers", query: "instId=BTC-USDT", file: "okx_index_tickers.json"},
+		{path: "/api/v5/public/funding-rate", query: "instId=BTC-USDT-SWAP", file: "okx_funding_rate.json"},
+		{path: "/api/v5/market/candles", query: "bar=1m&limit=300", file: "okx_candles.json"},
+		{path: "/api/v5/market/candles", query: "instId=BTC-USDT&bar=1H", file: "okx_candles.json"},
+		{path: "/api/v5/market/history-candles", query: "before=1733900339999&after=1733900460000&limit=2", file: "okx_candles.json"},
+		{path: "/api/v5/public/funding-rate-history", query: "after=1733900000001&limit=", file: "okx_funding_rate_history.json"},
+		{path: "/api/v5/public/funding-rate-history", query: "limit=2", file: "okx_funding_rate_history.json"},
+		{path: "/api/v5/public/instruments", query: "instType=SPOT", file: "okx_instruments.json"},
+		{path: "/api/v5/public/open-interest", query: "instType=SWAP&instId=BTC-USDT-SWAP", file: "okx_open_interest.json"},
+		{path: "/api/v5/rubik/stat/contracts/open-interest-history", query: "instId=BTC-USDT-SWAP&period=1H", file: "okx_open_interest_history.json"},
+		{path: "/api/v5/rubik/stat/contracts/long-short-account-ratio-contract", query: "period=5m&limit=2", file: "okx_long_short_ratio.json"},
+		{path: "/api/v5/rubik/stat/contracts/long-short-position-ratio-contract-top-trader", query: "end=1733900400000", file: "okx_long_short_ratio.json"},
+	})
+	return NewOKXProvider(server.URL)
+}
+
+func TestOKXProvider(t *testing.T) {
+	okx := newOKXFixtureProvider(t)
+
+	t.Run("Spot ticker", func(t *testing.T) {
+		ticker, _, err := okx.SpotTicker("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResponseBinance{Symbol: "BTCUSDT", Price: "97123.4", Time: 1733900000123}, ticker)
+	})
+
+	t.Run("Futures ticker", func(t *testing.T) {
+		ticker, _, err := okx.FuturesTicker("btc-usdt")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResponseBinance{Symbol: "BTCUSDT", Price: "97180.1", Time: 1733900000456}, ticker)
+	})
+
+	t.Run("Unknown instrument", func(t *testing.T) {
+		ticker, statusCode, err := okx.SpotTicker("NOPEUSDT")
+		assert.Nil(t, ticker)
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.EqualError(t, err, "okx error 51001: Instrument ID does not exist")
+	})
+
+	t.Run("Unknown quote asset", func(t *testing.T) {
+		_, statusCode, err := okx.SpotTicker("BTCXYZ")
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.Error(t, err)
+	})
+
+	t.Run("Premium index", func(t *testing.T) {
+		premiumIndex, _, err := okx.PremiumIndex("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.ResponseBinanceFuture{
+			Symbol:          "BTCUSDT",
+			MarkPrice:       "97175.6",
+			IndexPrice:      "97150.2",
+			LastFundingRate: "0.0001032",
+			NextFundingTime: 1733904000000,
+			Time:            1733900000500,
+		}, premiumIndex)
+	})
+
+	t.Run("Klines oldest first", func(t *testing.T) {
+		candles, _, err := okx.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1m"})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Candle{
+			{OpenTime: 1733900340000, CloseTime: 1733900399999, Open: 97140, High: 97175.4, Low: 97130.2, Close: 97170.1, Volume: 2310},
+			{OpenTime: 1733900400000, CloseTime: 1733900459999, Open: 97170.1, High: 97190, Low: 97160.3, Close: 97180.1, Volume: 1254},
+		}, candles)
+	})
+
+	t.Run("Klines from a start time", func(t *testing.T) {
+		candles, _, err := okx.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1m", StartTime: 1733900340000, Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, candles, 2)
+		assert.Equal(t, int64(1733900340000), candles[0].OpenTime)
+	})
+
+	t.Run("Depth", func(t *testing.T) {
+		book, _, err := okx.Depth(MarketFutures, "BTCUSDT", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OrderBook{
+			Symbol:       "BTCUSDT",
+			LastUpdateID: 34512876,
+			Bids:         []models.PriceLevel{{Price: 97123.4, Quantity: 0.8}, {Price: 97122.9, Quantity: 2.15}},
+			Asks:         []models.PriceLevel{{Price: 97123.5, Quantity: 1.2}, {Price: 97124, Quantity: 0.5}},
+			Time:         1733900000789,
+		}, book)
+	})
+
+	t.Run("Trades oldest first", func(t *testing.T) {
+		trades, _, err := okx.Trades(MarketSpot, "BTCUSDT", 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.Trade{
+			{ID: "584210371", Price: 97123.5, Quantity: 0.2, Side: models.SideBuy, Time: 1733900000100},
+			{ID: "584210372", Price: 97123.4, Quantity: 0.015, Side: models.SideSell, Time: 1733900000200},
+		}, trades)
+	})
+
+	t.Run("Every spot ticker", func(t *testing.T) {
+		tickers, _, err := okx.SpotTickers()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ResponseBinance{
+			{Symbol: "BTCUSDT", Price: "97123.4", Time: 1733900000123},
+			{Symbol: "ETHUSDT", Price: "3890.12", Time: 1733900000124},
+		}, tickers)
+	})
+
+	t.Run("Every mark price", func(t *testing.T) {
+		indexes, _, err := okx.PremiumIndexes()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ResponseBinanceFuture{
+			{Symbol: "BTCUSDT", MarkPrice: "97175.6", Time: 1733900000500},
+			{Symbol: "ETHUSDT", MarkPrice: "3891.05", Time: 1733900000500},
+		}, indexes)
+	})
+
+	t.Run("24 hour ticker", func(t *testing.T) {
+		ticker, _, err := okx.Ticker24h(MarketSpot, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.Ticker24h{
+			Symbol:             "BTCUSDT",
+			LastPrice:          "97123.4",
+			PriceChange:        "1111.3",
+			PriceChangePercent: "1.157",
+			OpenPrice:          "96012.1",
+			HighPrice:          "97800",
+			LowPrice:           "95500.2",
+			Volume:             "10823.45",
+			QuoteVolume:        "1045234567.12",
+			OpenTime:           1733813600123,
+			CloseTime:          1733900000123,
+		}, ticker)
+
+		ticker, _, err = okx.Ticker24h(MarketFutures, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, "1130.1", ticker.PriceChange)
+		assert.Equal(t, "1.177", ticker.PriceChangePercent)
+		assert.Equal(t, "98234.12", ticker.Volume)
+		assert.Empty(t, ticker.QuoteVolume)
+	})
+
+	t.Run("Spot klines", func(t *testing.T) {
+		candles, _, err := okx.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "1h", Market: MarketSpot})
+		assert.NoError(t, err)
+		assert.Len(t, candles, 2)
+	})
+
+	t.Run("Unsupported interval", func(t *testing.T) {
+		_, statusCode, err := okx.Klines(models.KlineQuery{Symbol: "BTCUSDT", Interval: "8h"})
+		assert.Equal(t, models.StatusCode(http.StatusBadRequest), statusCode)
+		assert.True(t, errors.Is(err, ErrNotSupported))
+	})
+
+	t.Run("Funding rate history oldest first", func(t *testing.T) {
+		history, _, err := okx.FundingRateHistory(models.FundingRateQuery{Symbol: "BTCUSDT", Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.FundingRateHistory{
+			{Symbol: "BTCUSDT", FundingRate: "0.0000872", FundingTime: 1733846400000},
+			{Symbol: "BTCUSDT", FundingRate: "0.0001032", FundingTime: 1733875200000},
+		}, history)
+	})
+
+	t.Run("Funding rate history of a range", func(t *testing.T) {
+		history, _, err := okx.FundingRateHistory(models.FundingRateQuery{Symbol: "BTCUSDT", StartTime: 1733875200000, EndTime: 1733900000000})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.FundingRateHistory{
+			{Symbol: "BTCUSDT", FundingRate: "0.0001032", FundingTime: 1733875200000},
+		}, history)
+	})
+
+	t.Run("Funding info not supported", func(t *testing.T) {
+		_, _, err := okx.FundingInfo()
+		assert.True(t, errors.Is(err, ErrNotSupported))
+	})
+
+	t.Run("Open interest", func(t *testing.T) {
+		openInterest, _, err := okx.OpenInterest("BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OpenInterest{Symbol: "BTCUSDT", OpenInterest: 26012.345, OpenInterestValue: 2527663420.12, Time: 1733900000500}, openInterest)
+	})
+
+	t.Run("Open interest history oldest first", func(t *testing.T) {
+		history, _, err := okx.OpenInterestHistory(models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h"})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.OpenInterest{
+			{Symbol: "BTCUSDT", OpenInterest: 25987.651, OpenInterestValue: 2522312345.67, Time: 1733896800000},
+			{Symbol: "BTCUSDT", OpenInterest: 26012.345, OpenInterestValue: 2527663420.12, Time: 1733900400000},
+		}, history)
+	})
+
+	t.Run("Long/short ratio shares from the ratio", func(t *testing.T) {
+		history, _, err := okx.LongShortRatio(models.RatioGlobalAccounts, models.PositioningQuery{Symbol: "BTCUSDT", Period: "5m", Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []models.LongShortRatio{
+			{Symbol: "BTCUSDT", LongShortRatio: 0.8, LongAccount: 0.4444, ShortAccount: 0.5556, Time: 1733896800000},
+			{Symbol: "BTCUSDT", LongShortRatio: 1.5, LongAccount: 0.6, ShortAccount: 0.4, Time: 1733900400000},
+		}, history)
+	})
+
+	t.Run("Top trader position ratio of a range", func(t *testing.T) {
+		history, _, err := okx.LongShortRatio(models.RatioTopPositions, models.PositioningQuery{Symbol: "BTCUSDT", Period: "1h", StartTime: 1733900000000, EndTime: 1733900400000})
+		assert.NoError(t, err)
+		assert.Len(t, history, 1)
+		assert.Equal(t, int64(1733900400000), history[0].Time)
+
+		_, _, err = okx.LongShortRatio(models.RatioTopPositions, models.PositioningQuery{Symbol: "BTCUSDT", Period: "3d"})
+		assert.True(t, errors.Is(err, ErrNotSupported))
+	})
+
+	t.Run("Exchange info", func(t *testing.T) {
+		symbols, _, err := okx.ExchangeInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, []models.ExchangeSymbol{
+			{Symbol: "BTCUSDT", Status: "TRADING", BaseAsset: "BTC", QuoteAsset: "USDT"},
+			{Symbol: "LUNCUSDT", Status: "SUSPEND", BaseAsset: "LUNC", QuoteAsset: "USDT"},
+		}, symbols)
+	})
+}
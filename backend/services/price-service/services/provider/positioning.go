@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"math"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// positioningPeriods are the periods of the open interest and long/short ratio series of Binance futures
+var positioningPeriods = map[string]bool{
+	"5m": true, "15m": true, "30m": true, "1h": true, "2h": true,
+	"4h": true, "6h": true, "12h": true, "1d": true,
+}
+
+// IsValidPeriod reports whether period is a period of the open interest and long/short ratio series
+func IsValidPeriod(period string) bool {
+	return positioningPeriods[period]
+}
+
+// IsValidRatio reports whether kind is models.RatioTopAccounts, models.RatioTopPositions or models.RatioGlobalAccounts
+func IsValidRatio(kind string) bool {
+	switch kind {
+	case models.RatioTopAccounts, models.RatioTopPositions, models.RatioGlobalAccounts:
+		return true
+	}
+	return false
+}
+
+// openInterestSince drops the points, oldest first, taken before startTime when it is set
+func openInterestSince(history []models.OpenInterest, startTime int64) []models.OpenInterest {
+	for len(history) > 0 && startTime > 0 && history[0].Time < startTime {
+		history = history[1:]
+	}
+	return history
+}
+
+// ratiosSince drops the points, oldest first, taken before startTime when it is set
+func ratiosSince(history []models.LongShortRatio, startTime int64) []models.LongShortRatio {
+	for len(history) > 0 && startTime > 0 && history[0].Time < startTime {
+		history = history[1:]
+	}
+	return history
+}
+
+// ratioShares splits a long/short ratio into the long and short shares, to 4 decimals like Binance,
+// for exchanges that only give the ratio
+func ratioShares(ratio float64) (long, short float64) {
+	if ratio <= 0 {
+		return 0, 0
+	}
+	long = math.Round(ratio/(1+ratio)*1e4) / 1e4
+	return long, math.Round((1-long)*1e4) / 1e4
+}
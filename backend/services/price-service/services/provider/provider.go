@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	ExchangeBinance  = "binance"
+	ExchangeOKX      = "okx"
+	ExchangeBybit    = "bybit"
+	ExchangeCoinbase = "coinbase"
+
+	MarketSpot    = "spot"
+	MarketFutures = "futures"
+)
+
+// ErrNotSupported is returned, with status 400, for data an exchange does not offer
+var ErrNotSupported = errors.New("not supported")
+
+// MarketDataProvider is the exchange client shared by price-service and trigger-service.
+// Symbols are given and returned in Binance form (BTCUSDT) whatever the exchange.
+// Every method returns the status code the caller should answer with when err is not nil.
+type MarketDataProvider interface {
+	// Name returns the exchange name used in the exchange query parameter
+	Name() string
+	// SpotTicker returns the latest spot price of a symbol
+	SpotTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error)
+	// FuturesTicker returns the last traded futures price of a symbol
+	FuturesTicker(symbol string) (*models.ResponseBinance, models.StatusCode, error)
+	// PremiumIndex returns mark price, index price and current funding of a futures symbol
+	PremiumIndex(symbol string) (*models.ResponseBinanceFuture, models.StatusCode, error)
+	// Ticker24h returns the rolling 24 hour statistics of a symbol on a market (spot or futures)
+	Ticker24h(market, symbol string) (*models.Ticker24h, models.StatusCode, error)
+	// Depth returns at most limit of the best bids and asks of a symbol on a market (spot or futures),
+	// or fewer when the exchange caps one request lower
+	Depth(market, symbol string, limit int) (*models.OrderBook, models.StatusCode, error)
+	// Trades returns at most limit of the latest trades of a symbol on a market (spot or futures),
+	// oldest first, or fewer when the exchange caps one request lower
+	Trades(market, symbol string, limit int) ([]models.Trade, models.StatusCode, error)
+	// SpotTickers returns the latest spot price of every symbol in one request
+	SpotTickers() ([]models.ResponseBinance, models.StatusCode, error)
+	// PremiumIndexes returns the premium index of every futures symbol in one request,
+	// exchanges without such a request leave the index price and funding fields empty
+	PremiumIndexes() ([]models.ResponseBinanceFuture, models.StatusCode, error)
+	// Klines returns the candles of a symbol on query.Market, oldest first. It returns at most query.Limit
+	// candles, or fewer when the exchange caps one request lower, see models.KlineQuery for the range.
+	Klines(query models.KlineQuery) ([]models.Candle, models.StatusCode, error)
+	// FundingInfo returns funding cap, floor and interval of every futures symbol with adjusted funding
+	FundingInfo() ([]models.FundingRateSecond, models.StatusCode, error)
+	// FundingRateHistory returns the settled funding rates of a symbol, oldest first. It returns at most
+	// query.Limit rates, or fewer when the exchange caps one request lower, see models.FundingRateQuery for the range.
+	FundingRateHistory(query models.FundingRateQuery) ([]models.FundingRateHistory, models.StatusCode, error)
+	// OpenInterest returns the current open interest of a futures symbol, exchanges that do not value it
+	// leave OpenInterestValue zero
+	OpenInterest(symbol string) (*models.OpenInterest, models.StatusCode, error)
+	// OpenInterestHistory returns the open interest of a futures symbol every query.Period, oldest first.
+	// It returns at most query.Limit points, or fewer when the exchange caps one request lower.
+	OpenInterestHistory(query models.PositioningQuery) ([]models.OpenInterest, models.StatusCode, error)
+	// LongShortRatio returns a long/short ratio series (models.RatioTopAccounts, models.RatioTopPositions
+	// or models.RatioGlobalAccounts) of a futures symbol, oldest first, limited like OpenInterestHistory
+	LongShortRatio(kind string, query models.PositioningQuery) ([]models.LongShortRatio, models.StatusCode, error)
+	// ExchangeInfo returns every spot symbol with its trading status
+	ExchangeInfo() ([]models.ExchangeSymbol, models.StatusCode, error)
+}
+
+var (
+	providers     = map[string]MarketDataProvider{}
+	providerMutex sync.Mutex
+)
+
+// Get returns the provider of an exchange, created from the environment on first use.
+// An empty exchange means Binance.
+func Get(exchange string) (MarketDataProvider, error) {
+	exchange = strings.ToLower(strings.TrimSpace(exchange))
+	if exchange == "" {
+		exchange = ExchangeBinance
+	}
+
+	providerMutex.Lock()
+	defer providerMutex.Unlock()
+	if p, ok := providers[exchange]; ok {
+		return p, nil
+	}
+
+	var p MarketDataProvider
+	switch exchange {
+	case ExchangeBinance:
+		p = NewBinanceProviderFromEnv()
+	case ExchangeOKX:
+		p = NewOKXProviderFromEnv()
+	case ExchangeBybit:
+		p = NewBybitProviderFromEnv()
+	case ExchangeCoinbase:
+		p = NewCoinbaseProviderFromEnv()
+	default:
+		return nil, fmt.Errorf("exchange %s is not supported", exchange)
+	}
+	providers[exchange] = p
+	return p, nil
+}
+
+// Register replaces the provider of an exchange, e.g. with a fake exchange in tests.
+// A nil provider restores the one created from the environment.
+func Register(exchange string, p MarketDataProvider) {
+	providerMutex.Lock()
+	defer providerMutex.Unlock()
+	if p == nil {
+		delete(providers, exchange)
+		return
+	}
+	providers[exchange] = p
+}
+
+// NormalizeMarket returns MarketSpot or MarketFutures, futures for an empty market
+func NormalizeMarket(market string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(market)) {
+	case "", MarketFutures:
+		return MarketFutures, nil
+	case MarketSpot:
+		return MarketSpot, nil
+	}
+	return "", fmt.Errorf("market %s is not supported", market)
+}
+
+// Default returns the Binance provider used when no exchange is requested
+func Default() MarketDataProvider {
+	p, _ := Get(ExchangeBinance)
+	return p
+}
+
+// SetDefault replaces the Binance provider, e.g. with a fake exchange in tests
+func SetDefault(p MarketDataProvider) {
+	Register(ExchangeBinance, p)
+}
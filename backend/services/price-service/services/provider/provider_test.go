@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixture is a recorded exchange response served for a path and, when query is set, the query parameters in it
+type fixture struct {
+	path       string
+	query      string
+	statusCode int
+	file       string
+}
+
+// newFixtureServer serves recorded responses from testdata, unknown requests fail the test
+func newFixtureServer(t *testing.T, fixtures []fixture) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, f := range fixtures {
+			if f.path != r.URL.Path {
+				continue
+			}
+			if !matchQuery(r.URL.Query(), f.query) {
+				continue
+			}
+			body, err := os.ReadFile(filepath.Join("testdata", f.file))
+			if err != nil {
+				t.Fatalf("read fixture %s: %v", f.file, err)
+			}
+			if f.statusCode != 0 {
+				w.WriteHeader(f.statusCode)
+			}
+			w.Write(body)
+			return
+		}
+		t.Errorf("no fixture for %s?%s", r.URL.Path, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func matchQuery(actual url.Values, query string) bool {
+	expected, _ := url.ParseQuery(query)
+	for key := range expected {
+		if actual.Get(key) != expected.Get(key) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		exchange     string
+		expectedName string
+		expectError  bool
+	}{
+		{exchange: "", expectedName: ExchangeBinance},
+		{exchange: "Binance", expectedName: ExchangeBinance},
+		{exchange: "okx", expectedName: ExchangeOKX},
+		{exchange: "bybit", expectedName: ExchangeBybit},
+		{exchange: " coinbase ", expectedName: ExchangeCoinbase},
+		{exchange: "kraken", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exchange, func(t *testing.T) {
+			p, err := Get(tt.exchange)
+			if tt.expectError {
+				assert.EqualError(t, err, "exchange kraken is not supported")
+				assert.Nil(t, p)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedName, p.Name())
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	fake := NewOKXProvider("http://fake")
+	Register(ExchangeOKX, fake)
+
+	p, _ := Get(ExchangeOKX)
+	assert.Same(t, fake, p)
+
+	Register(ExchangeOKX, nil)
+	p, _ = Get(ExchangeOKX)
+	assert.NotSame(t, fake, p)
+	assert.Equal(t, DefaultOKXBaseURL, p.(*OKXProvider).BaseURL)
+}
+
+func TestNormalizeMarket(t *testing.T) {
+	for market, expected := range map[string]string{"": MarketFutures, "futures": MarketFutures, " Spot ": MarketSpot} {
+		actual, err := NormalizeMarket(market)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	_, err := NormalizeMarket("options")
+	assert.EqualError(t, err, "market options is not supported")
+}
+
+func TestParseSymbols(t *testing.T) {
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}, ParseSymbols([]string{"btcusdt, ETH-USDT", "SOL/USDT,BTCUSDT", ""}))
+	assert.Nil(t, ParseSymbols(nil))
+}
+
+func TestSplitSymbol(t *testing.T) {
+	tests := []struct {
+		symbol        string
+		expectedBase  string
+		expectedQuote string
+		expectError   bool
+	}{
+		{symbol: "BTCUSDT", expectedBase: "BTC", expectedQuote: "USDT"},
+		{symbol: "eth-usdc", expectedBase: "ETH", expectedQuote: "USDC"},
+		{symbol: "BTC/FDUSD", expectedBase: "BTC", expectedQuote: "FDUSD"},
+		{symbol: "ETHBTC", expectedBase: "ETH", expectedQuote: "BTC"},
+		{symbol: "BTCUSD", expectedBase: "BTC", expectedQuote: "USD"},
+		{symbol: "USDT", expectError: true},
+		{symbol: "BTCXYZ", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			base, quote, err := SplitSymbol(tt.symbol)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBase, base)
+			assert.Equal(t, tt.expectedQuote, quote)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package providertest provides a market data provider serving canned data for the tests of the
+// services built on the provider package
+package providertest
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+)
+
+// FakeExchange serves canned candles and order books by symbol and records the kline queries it
+// answers. Unknown symbols fail as the exchanges answer them, the other requests are not supported.
+type FakeExchange struct {
+	provider.MarketDataProvider
+	// ExchangeName is the name of the exchange, fake when empty
+	ExchangeName string
+	// Candles are sorted by open time
+	Candles map[string][]models.Candle
+	Books   map[string]models.OrderBook
+	// PageSize caps the candles of a request when positive
+	PageSize int
+	// SpotOnly rejects futures order books
+	SpotOnly bool
+
+	mutex   sync.Mutex
+	queries []models.KlineQuery
+}
+
+func (f *FakeExchange) Name() string {
+	if f.ExchangeName == "" {
+		return "fake"
+	}
+	return f.ExchangeName
+}
+
+// Klines returns the candles from the start time forward when it is set, the latest ones up to the
+// end time otherwise, at most the limit of the query
+func (f *FakeExchange) Klines(query models.KlineQuery) ([]models.Candle, models.StatusCode, error) {
+	f.mutex.Lock()
+	f.queries = append(f.queries, query)
+	f.mutex.Unlock()
+
+	candles, ok := f.Candles[query.Symbol]
+	if !ok {
+		return nil, http.StatusBadRequest, errors.New("API returned status code: 400")
+	}
+	limit := query.Limit
+	if f.PageSize > 0 {
+		limit = min(limit, f.PageSize)
+	}
+
+	if query.EndTime > 0 {
+		end := sort.Search(len(candles), func(i int) bool { return candles[i].OpenTime > query.EndTime })
+		candles = candles[:end]
+	}
+	if query.StartTime > 0 {
+		start := sort.Search(len(candles), func(i int) bool { return candles[i].OpenTime >= query.StartTime })
+		candles = candles[start:]
+		return candles[:min(len(candles), limit)], http.StatusOK, nil
+	}
+	return candles[max(0, len(candles)-limit):], http.StatusOK, nil
+}
+
+// Depth returns the book of the symbol whatever the limit
+func (f *FakeExchange) Depth(market, symbol string, limit int) (*models.OrderBook, models.StatusCode, error) {
+	if market == provider.MarketFutures && f.SpotOnly {
+		return nil, http.StatusBadRequest, provider.ErrNotSupported
+	}
+	book, ok := f.Books[symbol]
+	if !ok {
+		return nil, http.StatusBadRequest, errors.New("API returned status code: 400")
+	}
+	return &book, http.StatusOK, nil
+}
+
+// Queries returns the kline queries answered so far
+func (f *FakeExchange) Queries() []models.KlineQuery {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]models.KlineQuery(nil), f.queries...)
+}
+
+// ResetQueries forgets the kline queries answered so far
+func (f *FakeExchange) ResetQueries() {
+	f.mutex.Lock()
+	f.queries = nil
+	f.mutex.Unlock()
+}
+
+// HourlyCandles returns count candles of one hour from first, each with a volume of 1
+func HourlyCandles(first int64, count int) []models.Candle {
+	const hour = int64(3600000)
+	candles := make([]models.Candle, count)
+	for i := range candles {
+		candles[i] = models.Candle{OpenTime: first + int64(i)*hour, Volume: 1}
+	}
+	return candles
+}
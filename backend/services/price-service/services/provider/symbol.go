@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// quoteAssets are checked longest first so that e.g. FDUSD wins over USD
+var quoteAssets = []string{"FDUSD", "USDT", "USDC", "BUSD", "TUSD", "EUR", "TRY", "BRL", "USD", "BTC", "ETH", "BNB"}
+
+// NormalizeSymbol turns BTC-USDT, btc/usdt or btc_usdt into BTCUSDT
+func NormalizeSymbol(symbol string) string {
+	replacer := strings.NewReplacer("-", "", "/", "", "_", "", " ", "")
+	return strings.ToUpper(replacer.Replace(symbol))
+}
+
+// ParseSymbols normalizes the symbols of repeated or comma separated query values, without duplicates
+func ParseSymbols(values []string) []string {
+	var symbols []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		for _, symbol := range strings.Split(value, ",") {
+			symbol = NormalizeSymbol(symbol)
+			if symbol != "" && !seen[symbol] {
+				seen[symbol] = true
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	return symbols
+}
+
+// SplitSymbol splits a symbol such as BTCUSDT into its base and quote asset
+func SplitSymbol(symbol string) (string, string, error) {
+	symbol = NormalizeSymbol(symbol)
+	for _, quote := range quoteAssets {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return strings.TrimSuffix(symbol, quote), quote, nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown quote asset in symbol %s", symbol)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+// tickerWindow is the length in milliseconds of the rolling window of the 24 hour statistics
+const tickerWindow = 24 * 60 * 60 * 1000
+
+// rollingTicker builds the 24 hour statistics of exchanges that only give the price 24 hours ago,
+// the change keeps the precision of the prices and the percentage has 3 decimals like Binance
+func rollingTicker(symbol, last, open, high, low, volume, quoteVolume string, closeTime int64) *models.Ticker24h {
+	ticker := &models.Ticker24h{
+		Symbol:      symbol,
+		LastPrice:   last,
+		OpenPrice:   open,
+		HighPrice:   high,
+		LowPrice:    low,
+		Volume:      volume,
+		QuoteVolume: quoteVolume,
+		OpenTime:    closeTime - tickerWindow,
+		CloseTime:   closeTime,
+	}
+	lastPrice, openPrice := toFloat(last), toFloat(open)
+	if openPrice != 0 {
+		change := lastPrice - openPrice
+		ticker.PriceChange = strconv.FormatFloat(change, 'f', max(decimals(last), decimals(open)), 64)
+		ticker.PriceChangePercent = strconv.FormatFloat(change/openPrice*100, 'f', 3, 64)
+	}
+	return ticker
+}
+
+// decimals counts the digits after the decimal point of a number
+func decimals(number string) int {
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		return len(number) - i - 1
+	}
+	return 0
+}
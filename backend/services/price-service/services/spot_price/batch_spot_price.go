@@ -0,0 +1,85 @@
+package spot_price
+
+import (
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get spot prices of several symbols
+// @Description Retrieves the spot price of every requested trading pair, or of every pair when none is given, with one request to Binance Spot or the requested exchange
+// @Tags Spot price
+// @Produce json
+// @Param symbols query string false "Comma separated trading pairs, every pair when empty (e.g., BTCUSDT,ETHUSDT)" example("BTCUSDT,ETHUSDT")
+// @Param exchange query string false "Exchange: binance (default), okx or bybit" example("binance")
+// @Param convert query string false "Fiat currency to convert the prices into (e.g., VND, USD, EUR), USD stablecoins are taken at par" example("VND")
+// @Success 200 {object} models.ResponseSpotPriceBatch "Prices by symbol, unknown symbols and symbols whose quote cannot be converted are listed in errors"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid request parameters"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Failed to fetch prices"
+// @Router /api/v1/spot-price/batch [get]
+func GetSpotPriceBatch(ctx *gin.Context) {
+	marketData, err := provider.Get(ctx.Query("exchange"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// rejects an unknown currency before asking the exchange
+	currency := ctx.Query("convert")
+	if _, statusCode, err := fx.FromUSD(currency); err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+
+	tickers, statusCode, err := marketData.SpotTickers()
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+
+	bySymbol := make(map[string]models.ResponseBinance, len(tickers))
+	for _, ticker := range tickers {
+		bySymbol[ticker.Symbol] = ticker
+	}
+
+	symbols := provider.ParseSymbols(ctx.QueryArray("symbols"))
+	if len(symbols) == 0 {
+		for symbol := range bySymbol {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	response := &models.ResponseSpotPriceBatch{Prices: make(map[string]models.ResponseSpotPrice, len(symbols))}
+	for _, symbol := range symbols {
+		ticker, ok := bySymbol[symbol]
+		if !ok {
+			if response.Errors == nil {
+				response.Errors = map[string]string{}
+			}
+			response.Errors[symbol] = "Symbol not found"
+			continue
+		}
+		conversion, _, err := fx.ForSymbol(ticker.Symbol, currency)
+		if err != nil {
+			if response.Errors == nil {
+				response.Errors = map[string]string{}
+			}
+			response.Errors[symbol] = err.Error()
+			continue
+		}
+		var price models.ResponseSpotPrice
+		price.UpdateData(ticker.Symbol, ticker.Price, utils.ConvertMillisecondsToTimestamp(ticker.Time))
+		if conversion != nil {
+			price.Price = conversion.ConvertPrice(price.Price)
+			price.Conversion = conversion.Response()
+		}
+		response.Prices[symbol] = price
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
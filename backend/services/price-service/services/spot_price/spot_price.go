@@ -1,21 +1,23 @@
 package spot_price
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 // @Summary Get real-time spot price data
-// @Description Retrieves current spot price information for a specified trading pair from Binance Spot
+// @Description Retrieves current spot price information for a specified trading pair from Binance Spot or the requested exchange
 // @Tags Spot price
 // @Produce json
 // @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Param convert query string false "Fiat currency to convert the price into (e.g., VND, USD, EUR), USD stablecoins are taken at par" example("VND")
 // @Success 200 {object} models.ResponseSpotPrice "Successful response with spot price data"
 // @Failure 400 {object} models.ErrorResponseDataMissing "Invalid symbol or request parameters"
 // @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
@@ -28,38 +30,38 @@ func GetSpotPrice(ctx *gin.Context) {
 		return
 	}
 
-	// Construct the Binance API URL
-	url := fmt.Sprintf("https://fapi.binance.com/fapi/v2/ticker/price?symbol=%s", symbol)
-
-	// Make the HTTP request
-	resp, err := http.Get(url)
+	marketData, err := provider.Get(ctx.Query("exchange"))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to fetch price: %v", err)})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	defer resp.Body.Close()
 
-	// Check if the response status is not OK
-	if resp.StatusCode != http.StatusOK {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("API returned status code: %d", resp.StatusCode)})
+	ticker, statusCode, err := marketData.SpotTicker(symbol)
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Decode the Binance response
-	var binanceResp models.ResponseBinance
-	if err := json.NewDecoder(resp.Body).Decode(&binanceResp); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to decode response: %v", err)})
+	// Convert the price into the requested fiat currency
+	conversion, statusCode, err := fx.ForSymbol(ticker.Symbol, ctx.Query("convert"))
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
 		return
 	}
+	price := ticker.Price
+	if conversion != nil {
+		price = conversion.ConvertPrice(price)
+	}
 
 	// Convert timestamp to formatted date string
-	eventTime := time.Unix(binanceResp.Time/1000, 0).Format("2006-01-02 15:04:05")
+	eventTime := utils.ConvertMillisecondsToTimestamp(ticker.Time)
 
 	// Create our response structure
 	response := &models.ResponseSpotPrice{
-		EventTime: eventTime,
-		Price:     binanceResp.Price,
-		Symbol:    binanceResp.Symbol,
+		EventTime:  eventTime,
+		Price:      price,
+		Symbol:     ticker.Symbol,
+		Conversion: conversion.Response(),
 	}
 
 	ctx.JSON(http.StatusOK, response)
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/fx"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -138,3 +141,48 @@ func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// Use the mock server's client to do the actual request
 	return t.mockServer.Client().Do(newReq)
 }
+
+func TestGetSpotPriceConvert(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"symbol":"BTCUSDT","price":"50000.00","time":1677721200000}`))
+	}))
+	defer mockServer.Close()
+	provider.SetDefault(provider.NewBinanceProvider(mockServer.URL, mockServer.URL))
+	defer provider.SetDefault(nil)
+	fx.SetDefault(&fx.StaticRateSource{FXRates: models.FXRates{Rates: map[string]float64{"USD": 1, "VND": 25400, "EUR": 0.95}, AsOf: 1733875200000, Source: "test"}})
+	defer fx.SetDefault(nil)
+
+	tests := []struct {
+		name           string
+		convert        string
+		expectedStatus int
+		expectedPrice  string
+		expectedError  string
+	}{
+		{name: "into VND", convert: "vnd", expectedStatus: http.StatusOK, expectedPrice: "1270000000"},
+		{name: "into EUR", convert: "EUR", expectedStatus: http.StatusOK, expectedPrice: "47500"},
+		{name: "unknown currency", convert: "XYZ", expectedStatus: http.StatusBadRequest, expectedError: "Unsupported currency XYZ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/?symbol=BTCUSDT&convert="+tt.convert, nil)
+			GetSpotPrice(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				assert.JSONEq(t, `{"error":"`+tt.expectedError+`"}`, w.Body.String())
+				return
+			}
+			var response models.ResponseSpotPrice
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedPrice, response.Price)
+			assert.Equal(t, "USDT", response.Conversion.From)
+			assert.Equal(t, strings.ToUpper(tt.convert), response.Conversion.To)
+			assert.NotEmpty(t, response.Conversion.AsOf)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package tape
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+)
+
+const (
+	// DefaultWindows are the rolling windows of clients that choose none
+	DefaultWindows = "1m,5m,15m"
+	// DefaultLargeTrade is the quote quantity from which a trade is large, 100000 USDT on USDT pairs
+	DefaultLargeTrade = 100000.0
+	// MaxWindow bounds the windows, a tape keeps every trade of its longest window
+	MaxWindow = time.Hour
+	// maxWindows bounds the windows of one tape
+	maxWindows = 5
+)
+
+// Window is a rolling window of a tape, Label is the window as the client wrote it, such as 5m
+type Window struct {
+	Label    string
+	Duration time.Duration
+}
+
+// ParseWindows reads comma separated windows such as 30s,1m,1h, DefaultWindows when value is empty
+func ParseWindows(value string) ([]Window, error) {
+	if strings.TrimSpace(value) == "" {
+		value = DefaultWindows
+	}
+	var windows []Window
+	for _, label := range strings.Split(value, ",") {
+		label = strings.TrimSpace(label)
+		duration, err := time.ParseDuration(label)
+		if err != nil || duration < time.Second || duration > MaxWindow {
+			return nil, fmt.Errorf("Invalid window %s, windows go from 1s to %s", label, MaxWindow)
+		}
+		windows = append(windows, Window{Label: label, Duration: duration})
+	}
+	if len(windows) > maxWindows {
+		return nil, fmt.Errorf("At most %d windows", maxWindows)
+	}
+	return windows, nil
+}
+
+// ParseLargeTrade reads the quote quantity from which a trade is large, DefaultLargeTrade when value is empty
+func ParseLargeTrade(value string) (float64, error) {
+	if value == "" {
+		return DefaultLargeTrade, nil
+	}
+	largeTrade, err := strconv.ParseFloat(value, 64)
+	if err != nil || largeTrade <= 0 {
+		return 0, fmt.Errorf("Invalid largeTrade %s", value)
+	}
+	return largeTrade, nil
+}
+
+// Tape sums the trades of a symbol over rolling windows ending at its last trade.
+// It keeps the trades of its longest window, trades must be added oldest first.
+type Tape struct {
+	windows    []*window
+	largeTrade float64
+	trades     []models.Trade
+}
+
+type window struct {
+	Window
+	// start is the index of the first trade of the window in the trades of the tape
+	start       int
+	volume      float64
+	quoteVolume float64
+	buyVolume   float64
+	sellVolume  float64
+	largeCount  int
+}
+
+func New(windows []Window, largeTrade float64) *Tape {
+	t := &Tape{largeTrade: largeTrade}
+	for _, w := range windows {
+		t.windows = append(t.windows, &window{Window: w})
+	}
+	return t
+}
+
+// IsLarge tells whether the quote quantity of a trade reaches the large trade threshold
+func (t *Tape) IsLarge(trade *models.Trade) bool {
+	return trade.Price*trade.Quantity >= t.largeTrade
+}
+
+// Add sums a trade into every window and drops the trades that left all of them
+func (t *Tape) Add(trade models.Trade) {
+	t.trades = append(t.trades, trade)
+	for _, w := range t.windows {
+		w.sum(&trade, 1, t.IsLarge(&trade))
+		for w.start < len(t.trades) && t.trades[w.start].Time <= trade.Time-w.Duration.Milliseconds() {
+			w.sum(&t.trades[w.start], -1, t.IsLarge(&t.trades[w.start]))
+			w.start++
+		}
+		if w.start == len(t.trades)-1 {
+			// only this trade is left, start again from it to drop the rounding of the subtractions
+			*w = window{Window: w.Window, start: w.start}
+			w.sum(&trade, 1, t.IsLarge(&trade))
+		}
+	}
+
+	dropped := len(t.trades)
+	for _, w := range t.windows {
+		dropped = min(dropped, w.start)
+	}
+	if dropped > 0 {
+		t.trades = t.trades[dropped:]
+		for _, w := range t.windows {
+			w.start -= dropped
+		}
+	}
+}
+
+// Stats returns the statistics of every window
+func (t *Tape) Stats() []models.TradeWindowStats {
+	stats := make([]models.TradeWindowStats, 0, len(t.windows))
+	for _, w := range t.windows {
+		var vwap float64
+		if w.volume > 0 {
+			vwap = w.quoteVolume / w.volume
+		}
+		stats = append(stats, models.TradeWindowStats{
+			Window:          w.Label,
+			VWAP:            vwap,
+			Volume:          w.volume,
+			QuoteVolume:     w.quoteVolume,
+			BuyVolume:       w.buyVolume,
+			SellVolume:      w.sellVolume,
+			TradeCount:      len(t.trades) - w.start,
+			LargeTradeCount: w.largeCount,
+		})
+	}
+	return stats
+}
+
+// sum adds a trade to the window with sign 1 and removes it with sign -1
+func (w *window) sum(trade *models.Trade, sign float64, isLarge bool) {
+	w.volume += sign * trade.Quantity
+	w.quoteVolume += sign * trade.Price * trade.Quantity
+	if trade.Side == models.SideSell {
+		w.sellVolume += sign * trade.Quantity
+	} else {
+		w.buyVolume += sign * trade.Quantity
+	}
+	if isLarge {
+		w.largeCount += int(sign)
+	}
+}
@@ -0,0 +1,80 @@
+package tape
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWindows(t *testing.T) {
+	windows, err := ParseWindows("")
+	assert.NoError(t, err)
+	assert.Equal(t, []Window{{"1m", time.Minute}, {"5m", 5 * time.Minute}, {"15m", 15 * time.Minute}}, windows)
+
+	windows, err = ParseWindows("30s, 1h")
+	assert.NoError(t, err)
+	assert.Equal(t, []Window{{"30s", 30 * time.Second}, {"1h", time.Hour}}, windows)
+
+	for _, value := range []string{"2h", "100ms", "5x", "1m,,5m"} {
+		_, err := ParseWindows(value)
+		assert.Error(t, err, value)
+	}
+	_, err = ParseWindows("1s,2s,3s,4s,5s,6s")
+	assert.EqualError(t, err, "At most 5 windows")
+}
+
+func TestParseLargeTrade(t *testing.T) {
+	largeTrade, err := ParseLargeTrade("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLargeTrade, largeTrade)
+
+	largeTrade, err = ParseLargeTrade("2500.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 2500.5, largeTrade)
+
+	_, err = ParseLargeTrade("-1")
+	assert.EqualError(t, err, "Invalid largeTrade -1")
+}
+
+func TestTape(t *testing.T) {
+	tape := New([]Window{{"1s", time.Second}, {"3s", 3 * time.Second}}, 1000)
+
+	trades := []models.Trade{
+		{ID: "1", Price: 100, Quantity: 2, Side: models.SideBuy, Time: 1000},
+		{ID: "2", Price: 110, Quantity: 10, Side: models.SideSell, Time: 1500},
+		{ID: "3", Price: 105, Quantity: 4, Side: models.SideBuy, Time: 2400},
+		{ID: "4", Price: 100, Quantity: 1, Side: models.SideSell, Time: 4000},
+	}
+	for _, trade := range trades[:3] {
+		tape.Add(trade)
+	}
+	assert.True(t, tape.IsLarge(&trades[1]))
+	assert.False(t, tape.IsLarge(&trades[0]))
+
+	// the 1s window holds trades 2 and 3, the 3s window all three
+	assert.Equal(t, []models.TradeWindowStats{
+		{Window: "1s", VWAP: (1100.0 + 420) / 14, Volume: 14, QuoteVolume: 1520, BuyVolume: 4, SellVolume: 10, TradeCount: 2, LargeTradeCount: 1},
+		{Window: "3s", VWAP: (200.0 + 1100 + 420) / 16, Volume: 16, QuoteVolume: 1720, BuyVolume: 6, SellVolume: 10, TradeCount: 3, LargeTradeCount: 1},
+	}, tape.Stats())
+
+	tape.Add(trades[3])
+	stats := tape.Stats()
+	assert.Equal(t, models.TradeWindowStats{Window: "1s", VWAP: 100, Volume: 1, QuoteVolume: 100, SellVolume: 1, TradeCount: 1}, stats[0])
+	assert.Equal(t, 3, stats[1].TradeCount)
+	assert.Equal(t, 15.0, stats[1].Volume)
+	assert.Len(t, tape.trades, 3)
+}
+
+func TestTapeEmptyWindow(t *testing.T) {
+	tape := New([]Window{{"1s", time.Second}}, DefaultLargeTrade)
+	assert.Equal(t, []models.TradeWindowStats{{Window: "1s"}}, tape.Stats())
+
+	tape.Add(models.Trade{ID: "1", Price: 0.1, Quantity: 3, Side: models.SideBuy, Time: 1000})
+	tape.Add(models.Trade{ID: "2", Price: 0.2, Quantity: 7, Side: models.SideBuy, Time: 5000})
+	stats := tape.Stats()[0]
+	assert.Equal(t, 1, stats.TradeCount)
+	assert.Equal(t, 7.0, stats.Volume)
+	assert.InDelta(t, 0.2, stats.VWAP, 1e-12)
+}
@@ -0,0 +1,52 @@
+package ticker
+
+import (
+	"net/http"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get 24 hour ticker statistics
+// @Description Retrieves the rolling 24 hour price change, high, low and volume of a trading pair from Binance or the requested exchange
+// @Tags Ticker
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param market query string false "Market: spot (default) or futures" example("spot")
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponseTicker24h "Rolling 24 hour statistics, fields the exchange does not give are omitted"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid symbol or request parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Failed to fetch ticker"
+// @Router /api/v1/ticker/24h [get]
+func GetTicker24h(ctx *gin.Context) {
+	symbol := ctx.Query("symbol")
+	if symbol == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "symbol cannot be empty"})
+		return
+	}
+
+	market, err := provider.NormalizeMarket(ctx.DefaultQuery("market", provider.MarketSpot))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	marketData, err := provider.Get(ctx.Query("exchange"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ticker, statusCode, err := marketData.Ticker24h(market, provider.NormalizeSymbol(symbol))
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+
+	var response models.ResponseTicker24h
+	response.UpdateData(market, ticker, utils.ConvertMillisecondsToTimestamp(ticker.OpenTime), utils.ConvertMillisecondsToTimestamp(ticker.CloseTime))
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,111 @@
+package ticker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTicker24h(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	binance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		lastPrice := "50000.00"
+		if r.URL.Path == "/fapi/v1/ticker/24hr" {
+			lastPrice = "50010.00"
+		}
+		w.Write([]byte(`{"symbol":"BTCUSDT","priceChange":"-94.99","priceChangePercent":"-0.189","weightedAvgPrice":"50012.3","lastPrice":"` + lastPrice +
+			`","openPrice":"50094.99","highPrice":"50500.00","lowPrice":"49800.00","volume":"1234.5","quoteVolume":"61740000.1","openTime":1699913600000,"closeTime":1700000000000,"count":98765}`))
+	}))
+	defer binance.Close()
+	provider.SetDefault(provider.NewBinanceProvider(binance.URL, binance.URL))
+	defer provider.SetDefault(nil)
+
+	tests := []struct {
+		name              string
+		query             string
+		expectedStatus    int
+		expectedMarket    string
+		expectedLastPrice string
+		expectedError     string
+	}{
+		{
+			name:              "spot by default",
+			query:             "symbol=btc-usdt",
+			expectedStatus:    http.StatusOK,
+			expectedMarket:    "spot",
+			expectedLastPrice: "50000.00",
+		},
+		{
+			name:              "futures",
+			query:             "symbol=BTCUSDT&market=futures",
+			expectedStatus:    http.StatusOK,
+			expectedMarket:    "futures",
+			expectedLastPrice: "50010.00",
+		},
+		{
+			name:           "empty symbol",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "symbol cannot be empty",
+		},
+		{
+			name:           "unknown market",
+			query:          "symbol=BTCUSDT&market=options",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "market options is not supported",
+		},
+		{
+			name:           "unknown exchange",
+			query:          "symbol=BTCUSDT&exchange=kraken",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "exchange kraken is not supported",
+		},
+		{
+			name:           "invalid symbol",
+			query:          "symbol=NOPEUSDT",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "API returned status code: 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/ticker/24h?"+tt.query, nil)
+
+			GetTicker24h(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response["error"])
+				return
+			}
+
+			var response models.ResponseTicker24h
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "BTCUSDT", response.Symbol)
+			assert.Equal(t, tt.expectedMarket, response.Market)
+			assert.Equal(t, tt.expectedLastPrice, response.LastPrice)
+			assert.Equal(t, "-0.189", response.PriceChangePercent)
+			assert.Equal(t, "61740000.1", response.QuoteVolume)
+			assert.Equal(t, int64(98765), response.TradeCount)
+			assert.NotEmpty(t, response.OpenTime)
+			assert.NotEmpty(t, response.EventTime)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package trades
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/tape"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultLimit is the number of trades returned when the client asks for none
+	DefaultLimit = 100
+	// MaxLimit bounds the returned trades, it is also how many trades the windows are computed from
+	MaxLimit = 1000
+)
+
+// @Summary Get recent trades
+// @Description Retrieves the recent trades of a trading pair, oldest first, with the VWAP, volumes and large trades of rolling windows ending at the last trade.
+// @Description Symbols recorded from the Binance aggregated trade stream are served from memory, others from Binance or the requested exchange.
+// @Tags Trades
+// @Produce json
+// @Param symbol query string true "Trading pair symbol (e.g., BTCUSDT)" example("BTCUSDT")
+// @Param market query string false "Market: spot (default) or futures" example("spot")
+// @Param limit query int false "Number of trades, 100 by default and at most 1000, exchanges may return fewer"
+// @Param windows query string false "Comma separated rolling windows from 1s to 1h, 1m,5m,15m by default" example("1m,5m,15m")
+// @Param largeTrade query number false "Quote quantity from which a trade is large, 100000 by default" example(100000)
+// @Param exchange query string false "Exchange: binance (default), okx, bybit or coinbase" example("binance")
+// @Success 200 {object} models.ResponseTrades "Trades oldest first with the statistics of each window"
+// @Failure 400 {object} models.ErrorResponseDataMissing "Invalid symbol or request parameters"
+// @Failure 404 {object} models.ErrorResponseDataNotFound "Symbol not found"
+// @Failure 500 {object} models.ErrorResponseDataInternalServerError "Failed to fetch trades"
+// @Router /api/v1/trades [get]
+func GetTrades(ctx *gin.Context) {
+	symbol := ctx.Query("symbol")
+	if symbol == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "symbol cannot be empty"})
+		return
+	}
+	symbol = provider.NormalizeSymbol(symbol)
+
+	market, err := provider.NormalizeMarket(ctx.DefaultQuery("market", provider.MarketSpot))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit, err := ParseLimit(ctx.Query("limit"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	windows, err := tape.ParseWindows(ctx.Query("windows"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	largeTrade, err := tape.ParseLargeTrade(ctx.Query("largeTrade"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	marketData, err := provider.Get(ctx.Query("exchange"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	trades, statusCode, err := recentTrades(marketData, market, symbol)
+	if err != nil {
+		ctx.JSON(utils.ResponseStatusCode(statusCode), gin.H{"error": err.Error()})
+		return
+	}
+
+	tradeTape := tape.New(windows, largeTrade)
+	for _, trade := range trades {
+		tradeTape.Add(trade)
+	}
+
+	response := models.ResponseTrades{
+		Symbol:    symbol,
+		Market:    market,
+		Trades:    make([]models.ResponseTrade, 0, min(limit, len(trades))),
+		Stats:     tradeTape.Stats(),
+		EventTime: utils.ConvertMillisecondsToTimestamp(time.Now().UnixMilli()),
+	}
+	for i := max(len(trades)-limit, 0); i < len(trades); i++ {
+		var trade models.ResponseTrade
+		trade.UpdateData(&trades[i], tradeTape.IsLarge(&trades[i]), utils.ConvertMillisecondsToTimestamp(trades[i].Time))
+		response.Trades = append(response.Trades, trade)
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// recentTrades reads the trades of the recorder when it follows the symbol, the exchange otherwise
+func recentTrades(marketData provider.MarketDataProvider, market, symbol string) ([]models.Trade, models.StatusCode, error) {
+	if r := getRecorder(); r != nil && marketData.Name() == provider.ExchangeBinance {
+		if trades, ok := r.Recent(market, symbol); ok && len(trades) > 0 {
+			return trades, http.StatusOK, nil
+		}
+	}
+	return marketData.Trades(market, symbol, MaxLimit)
+}
+
+// ParseLimit reads the number of trades, DefaultLimit when value is empty
+func ParseLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+	return limit, nil
+}
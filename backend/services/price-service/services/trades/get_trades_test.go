@@ -0,0 +1,153 @@
+package trades
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrades(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	binance := newFakeBinance(t)
+	provider.SetDefault(provider.NewBinanceProvider(binance.URL, binance.URL))
+	defer provider.SetDefault(nil)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedIDs    []string
+		expectedStats  []models.TradeWindowStats
+		expectedError  string
+	}{
+		{
+			name:           "default windows",
+			query:          "symbol=btcusdt",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{"100", "101"},
+			expectedStats: []models.TradeWindowStats{
+				{Window: "1m", VWAP: 50007.5, Volume: 4, QuoteVolume: 200030, BuyVolume: 1, SellVolume: 3, TradeCount: 2, LargeTradeCount: 1},
+				{Window: "5m", VWAP: 50007.5, Volume: 4, QuoteVolume: 200030, BuyVolume: 1, SellVolume: 3, TradeCount: 2, LargeTradeCount: 1},
+				{Window: "15m", VWAP: 50007.5, Volume: 4, QuoteVolume: 200030, BuyVolume: 1, SellVolume: 3, TradeCount: 2, LargeTradeCount: 1},
+			},
+		},
+		{
+			name:           "limit and custom windows",
+			query:          "symbol=BTCUSDT&limit=1&windows=10s&largeTrade=10000",
+			expectedStatus: http.StatusOK,
+			expectedIDs:    []string{"101"},
+			expectedStats: []models.TradeWindowStats{
+				{Window: "10s", VWAP: 50010, Volume: 3, QuoteVolume: 150030, SellVolume: 3, TradeCount: 1, LargeTradeCount: 1},
+			},
+		},
+		{
+			name:           "empty symbol",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "symbol cannot be empty",
+		},
+		{
+			name:           "invalid limit",
+			query:          "symbol=BTCUSDT&limit=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "limit must be between 1 and 1000",
+		},
+		{
+			name:           "invalid window",
+			query:          "symbol=BTCUSDT&windows=2h",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid window 2h, windows go from 1s to 1h0m0s",
+		},
+		{
+			name:           "invalid large trade",
+			query:          "symbol=BTCUSDT&largeTrade=-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid largeTrade -1",
+		},
+		{
+			name:           "unknown exchange",
+			query:          "symbol=BTCUSDT&exchange=kraken",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "exchange kraken is not supported",
+		},
+		{
+			name:           "invalid symbol",
+			query:          "symbol=NOPEUSDT",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "API returned status code: 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/trades?"+tt.query, nil)
+
+			GetTrades(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response map[string]string
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response["error"])
+				return
+			}
+
+			var response models.ResponseTrades
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "BTCUSDT", response.Symbol)
+			assert.Equal(t, provider.MarketSpot, response.Market)
+			var ids []string
+			for _, trade := range response.Trades {
+				ids = append(ids, trade.ID)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Equal(t, tt.expectedStats, response.Stats)
+		})
+	}
+}
+
+func TestGetTradesFromRecorder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// the exchange is never asked for the trades of a recorded symbol
+	binance := newFakeBinance(t)
+	provider.SetDefault(provider.NewBinanceProvider(binance.URL+"/unused", binance.URL+"/unused"))
+	defer provider.SetDefault(nil)
+
+	recorder := &Recorder{Market: provider.MarketSpot, History: DefaultRecorderHistory, trades: map[string][]models.Trade{"ETHUSDT": nil}}
+	recorder.record("ETHUSDT", []models.Trade{
+		{ID: "7", Price: 3000, Quantity: 50, Side: models.SideBuy, Time: 1700000000000},
+	})
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/trades?symbol=ETHUSDT", nil)
+
+	GetTrades(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.ResponseTrades
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Trades, 1)
+	assert.Equal(t, models.ResponseTrade{
+		ID:            "7",
+		Price:         3000,
+		Quantity:      50,
+		QuoteQuantity: 150000,
+		Side:          models.SideBuy,
+		IsLarge:       true,
+		Time:          response.Trades[0].Time,
+	}, response.Trades[0])
+	assert.Equal(t, 50.0, response.Stats[0].BuyVolume)
+}
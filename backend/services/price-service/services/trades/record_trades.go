@@ -0,0 +1,165 @@
+package trades
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
+)
+
+// DefaultRecorderHistory is how many trades the recorder keeps for each symbol
+const DefaultRecorderHistory = 10000
+
+// Recorder keeps the latest aggregated trades of a set of Binance symbols in memory. It loads the
+// recent trades from the REST API, then adds every trade of the aggTrade stream, loading the recent
+// trades again whenever the stream reconnects.
+type Recorder struct {
+	MarketData provider.MarketDataProvider
+	Hub        *websocket.Hub
+	Market     string
+	Symbols    []string
+	History    int
+
+	mutex  sync.RWMutex
+	trades map[string][]models.Trade
+}
+
+var (
+	recorder      *Recorder
+	recorderMutex sync.RWMutex
+)
+
+// SetRecorder makes trade requests of the recorded symbols read the recorder, nil turns it off
+func SetRecorder(r *Recorder) {
+	recorderMutex.Lock()
+	defer recorderMutex.Unlock()
+	recorder = r
+}
+
+func getRecorder() *Recorder {
+	recorderMutex.RLock()
+	defer recorderMutex.RUnlock()
+	return recorder
+}
+
+// NewRecorderFromEnv records the symbols of TRADE_SYMBOLS, a comma separated list such as
+// BTCUSDT,ETHUSDT, on the market of TRADE_MARKET, spot when it is not set
+func NewRecorderFromEnv() *Recorder {
+	market, err := provider.NormalizeMarket(os.Getenv("TRADE_MARKET"))
+	if err != nil || os.Getenv("TRADE_MARKET") == "" {
+		market = provider.MarketSpot
+	}
+	var symbols []string
+	for _, symbol := range strings.Split(os.Getenv("TRADE_SYMBOLS"), ",") {
+		if symbol = strings.TrimSpace(symbol); symbol != "" {
+			symbols = append(symbols, provider.NormalizeSymbol(symbol))
+		}
+	}
+
+	return &Recorder{
+		MarketData: provider.Default(),
+		Hub:        websocket.DefaultHub,
+		Market:     market,
+		Symbols:    symbols,
+		History:    DefaultRecorderHistory,
+	}
+}
+
+// Start records every symbol in the background until ctx is done
+func (r *Recorder) Start(ctx context.Context) {
+	r.mutex.Lock()
+	r.trades = make(map[string][]models.Trade, len(r.Symbols))
+	for _, symbol := range r.Symbols {
+		r.trades[symbol] = nil
+	}
+	r.mutex.Unlock()
+
+	for _, symbol := range r.Symbols {
+		go r.follow(ctx, symbol)
+	}
+}
+
+// Recent returns the recorded trades of a symbol oldest first, ok is false when the symbol is not recorded
+func (r *Recorder) Recent(market, symbol string) (trades []models.Trade, ok bool) {
+	if market != r.Market {
+		return nil, false
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	recorded, ok := r.trades[symbol]
+	// up to twice History trades are kept between two trims
+	recorded = recorded[max(0, len(recorded)-r.History):]
+	return append([]models.Trade(nil), recorded...), ok
+}
+
+func (r *Recorder) follow(ctx context.Context, symbol string) {
+	url := websocket.AggTradeStreamURL(symbol, r.Market)
+
+	subscription := r.Hub.Subscribe(url)
+	defer func() { subscription.Close() }()
+	r.fill(symbol)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case message, ok := <-subscription.C:
+			if !ok {
+				// the hub dropped this subscriber, trades may have been missed meanwhile
+				subscription = r.Hub.Subscribe(url)
+				r.fill(symbol)
+				continue
+			}
+			if message.Status == websocket.StatusConnected {
+				r.fill(symbol)
+				continue
+			}
+			if message.Status != "" {
+				continue
+			}
+
+			var event models.AggTradeWebsocket
+			if err := json.Unmarshal(message.Data, &event); err != nil {
+				log.Println("JSON unmarshal error: ", err)
+				continue
+			}
+			r.record(symbol, []models.Trade{event.Trade()})
+		}
+	}
+}
+
+// fill records the recent trades of the REST API that came after the last recorded one
+func (r *Recorder) fill(symbol string) {
+	trades, _, err := r.MarketData.Trades(r.Market, symbol, r.History)
+	if err != nil {
+		log.Println("Trade recorder error: ", err)
+		return
+	}
+	r.record(symbol, trades)
+}
+
+// record appends the trades that came after the last recorded one. It keeps the last History trades
+// once there are twice as many, so that the trades are copied once every History trades rather than at
+// every trade.
+func (r *Recorder) record(symbol string, trades []models.Trade) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	recorded := r.trades[symbol]
+	for _, trade := range trades {
+		if len(recorded) == 0 || trade.After(&recorded[len(recorded)-1]) {
+			recorded = append(recorded, trade)
+		}
+	}
+	if len(recorded) > 2*r.History {
+		recorded = append([]models.Trade(nil), recorded[len(recorded)-r.History:]...)
+	}
+	r.trades[symbol] = recorded
+}
@@ -0,0 +1,118 @@
+package trades
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/websocket"
+	gorilla "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// aggTrades are the recent trades served by newFakeBinance, the buyer is the maker of the second one
+const aggTrades = `[{"a":100,"p":"50000","q":"1","f":1,"l":1,"T":1700000000000,"m":false},` +
+	`{"a":101,"p":"50010","q":"3","f":2,"l":3,"T":1700000030000,"m":true}]`
+
+// newFakeBinance serves aggTrades for BTCUSDT and an invalid symbol error otherwise
+func newFakeBinance(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		w.Write([]byte(aggTrades))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newMockAggTradeStream repeats the last recent trade then sends a new one
+func newMockAggTradeStream(t *testing.T) *httptest.Server {
+	upgrader := gorilla.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		conn.WriteMessage(gorilla.TextMessage, []byte(`{"e":"aggTrade","E":1700000030001,"s":"BTCUSDT","a":101,"p":"50010","q":"3","f":2,"l":3,"T":1700000030000,"m":true}`))
+		conn.WriteMessage(gorilla.TextMessage, []byte(`{"e":"aggTrade","E":1700000040001,"s":"BTCUSDT","a":102,"p":"50020","q":"2","f":4,"l":4,"T":1700000040000,"m":false}`))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecorder(t *testing.T) {
+	binance := newFakeBinance(t)
+	stream := newMockAggTradeStream(t)
+	t.Setenv("BINANCE_SPOT_WS_URL", "ws"+strings.TrimPrefix(stream.URL, "http"))
+
+	recorder := &Recorder{
+		MarketData: provider.NewBinanceProvider(binance.URL, binance.URL),
+		Hub:        websocket.NewHub(),
+		Market:     provider.MarketSpot,
+		Symbols:    []string{"BTCUSDT"},
+		History:    2,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	recorder.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		trades, _ := recorder.Recent(provider.MarketSpot, "BTCUSDT")
+		return len(trades) == 2 && trades[1].ID == "102"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// the repeated trade is recorded once and only the last History trades are kept
+	trades, ok := recorder.Recent(provider.MarketSpot, "BTCUSDT")
+	assert.True(t, ok)
+	assert.Equal(t, []models.Trade{
+		{ID: "101", Price: 50010, Quantity: 3, Side: models.SideSell, Time: 1700000030000},
+		{ID: "102", Price: 50020, Quantity: 2, Side: models.SideBuy, Time: 1700000040000},
+	}, trades)
+
+	_, ok = recorder.Recent(provider.MarketSpot, "ETHUSDT")
+	assert.False(t, ok)
+	_, ok = recorder.Recent(provider.MarketFutures, "BTCUSDT")
+	assert.False(t, ok)
+}
+
+func TestRecorderTrim(t *testing.T) {
+	recorder := &Recorder{Market: provider.MarketSpot, History: 2, trades: map[string][]models.Trade{"BTCUSDT": nil}}
+
+	for i := 1; i <= 5; i++ {
+		recorder.record("BTCUSDT", []models.Trade{{ID: strconv.Itoa(i), Time: int64(i)}})
+		trades, _ := recorder.Recent(provider.MarketSpot, "BTCUSDT")
+		assert.Len(t, trades, min(i, 2))
+		assert.Equal(t, strconv.Itoa(i), trades[len(trades)-1].ID)
+	}
+	// trimmed to History once there were more than twice History trades
+	assert.Len(t, recorder.trades["BTCUSDT"], 2)
+}
+
+func TestNewRecorderFromEnv(t *testing.T) {
+	t.Setenv("TRADE_SYMBOLS", "btcusdt, ETH-USDT,")
+	t.Setenv("TRADE_MARKET", "")
+
+	recorder := NewRecorderFromEnv()
+	assert.Equal(t, []string{"BTCUSDT", "ETHUSDT"}, recorder.Symbols)
+	assert.Equal(t, provider.MarketSpot, recorder.Market)
+	assert.Equal(t, DefaultRecorderHistory, recorder.History)
+
+	t.Setenv("TRADE_MARKET", "futures")
+	assert.Equal(t, provider.MarketFutures, NewRecorderFromEnv().Market)
+}
@@ -0,0 +1,73 @@
+package websocket
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/arbitrage"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// arbitrageInterval is how often the arbitrage socket and channel quote the exchanges
+var arbitrageInterval = 5 * time.Second
+
+// ArbitrageSocket quotes an asset across exchanges every 5 seconds, with the parameters of the
+// arbitrage endpoint
+func ArbitrageSocket(context *gin.Context) {
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	query, err := arbitrage.ParseQuery(context)
+	if err != nil {
+		ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, err.Error()))
+		return
+	}
+
+	// done chan to check if the main go routine is continue or not
+	done := make(chan struct{})
+	// exit chan to check if the go func is continue or not (check for stop loop)
+	exit := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(arbitrageInterval)
+		defer ticker.Stop()
+		for {
+			response, statusCode, err := arbitrage.GetArbitrageData(query)
+			switch {
+			case err != nil && statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+				ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, err.Error()))
+				return
+			case err != nil:
+				log.Println("Arbitrage poll error: ", err)
+			default:
+				if err := ws.WriteJSON(response); err != nil {
+					log.Println("Write error to client: ", err)
+					return
+				}
+			}
+
+			select {
+			case <-exit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil || string(msg) == "disconnect" {
+			close(exit)
+			break
+		}
+	}
+
+	<-done
+}
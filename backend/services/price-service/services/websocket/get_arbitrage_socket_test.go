@@ -0,0 +1,141 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/arbitrage"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// depthExchange quotes BTC on one exchange, its bid rising by step at every request
+type depthExchange struct {
+	provider.MarketDataProvider
+	name     string
+	bid      float64
+	step     float64
+	requests int32
+}
+
+func (d *depthExchange) Name() string {
+	return d.name
+}
+
+func (d *depthExchange) Depth(market, symbol string, limit int) (*models.OrderBook, models.StatusCode, error) {
+	if !strings.HasPrefix(symbol, "BTC") {
+		return nil, http.StatusBadRequest, errors.New("API returned status code: 400")
+	}
+	bid := d.bid + d.step*float64(atomic.AddInt32(&d.requests, 1))
+	return &models.OrderBook{
+		Symbol: symbol,
+		Bids:   []models.PriceLevel{{Price: bid, Quantity: 1}},
+		Asks:   []models.PriceLevel{{Price: bid + 1, Quantity: 1}},
+	}, http.StatusOK, nil
+}
+
+// setupArbitrageTest registers Binance quoting BTC at 97000 and OKX at 97100 rising by 10, quoted every
+// 50 milliseconds. Bybit and Coinbase are left out of the arbitrage config.
+func setupArbitrageTest(t *testing.T) {
+	arbitrage.SetConfig(&arbitrage.Config{Exchanges: []string{provider.ExchangeBinance, provider.ExchangeOKX}, TakerFees: arbitrage.DefaultTakerFees})
+	provider.Register(provider.ExchangeBinance, &depthExchange{name: provider.ExchangeBinance, bid: 97000})
+	provider.Register(provider.ExchangeOKX, &depthExchange{name: provider.ExchangeOKX, bid: 97100, step: 10})
+
+	interval := arbitrageInterval
+	arbitrageInterval = 50 * time.Millisecond
+	t.Cleanup(func() {
+		arbitrageInterval = interval
+		arbitrage.SetConfig(nil)
+		provider.Register(provider.ExchangeBinance, nil)
+		provider.Register(provider.ExchangeOKX, nil)
+	})
+}
+
+func TestArbitrageSocket(t *testing.T) {
+	setupArbitrageTest(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws/arbitrage", ArbitrageSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/arbitrage"
+
+	t.Run("Spreads", func(t *testing.T) {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+"?asset=btc&fees=binance=0,okx=0", nil)
+		assert.NoError(t, err)
+		defer ws.Close()
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		var first, second models.ResponseArbitrage
+		assert.NoError(t, ws.ReadJSON(&first))
+		assert.NoError(t, ws.ReadJSON(&second))
+
+		assert.Equal(t, "BTC", first.Asset)
+		assert.Equal(t, "binance", first.BuyExchange)
+		assert.Equal(t, "okx", first.SellExchange)
+		assert.Greater(t, second.SellPrice, first.SellPrice)
+		assert.Equal(t, 10.0, second.GrossSpread-first.GrossSpread)
+		assert.True(t, second.Profitable)
+
+		assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte("disconnect")))
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+"?asset=BTC&exchanges=binance", nil)
+		assert.NoError(t, err)
+		defer ws.Close()
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		_, _, err = ws.ReadMessage()
+		closeErr, ok := err.(*websocket.CloseError)
+		assert.True(t, ok)
+		assert.Equal(t, "At least two exchanges are needed", closeErr.Text)
+	})
+
+	t.Run("Asset not listed", func(t *testing.T) {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+"?asset=NOPE", nil)
+		assert.NoError(t, err)
+		defer ws.Close()
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		_, _, err = ws.ReadMessage()
+		closeErr, ok := err.(*websocket.CloseError)
+		assert.True(t, ok)
+		assert.Equal(t, "NOPE is not quoted on any exchange", closeErr.Text)
+	})
+}
+
+func TestStreamArbitrageChannel(t *testing.T) {
+	setupArbitrageTest(t)
+	url := setupStreamTest(t)
+	c := dialStream(t, url, nil)
+
+	assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelArbitrage, Symbols: []string{"btc", "NOPE"}}))
+	assert.True(t, readAck(t, c).Success)
+
+	messages := map[string]map[string]interface{}{}
+	for len(messages) < 2 {
+		message := readStreamMessage(t, c)
+		if _, ok := messages[message["symbol"].(string)]; !ok {
+			messages[message["symbol"].(string)] = message
+		}
+	}
+	data, err := json.Marshal(messages["BTC"]["data"])
+	assert.NoError(t, err)
+	var response models.ResponseArbitrage
+	assert.NoError(t, json.Unmarshal(data, &response))
+	assert.Equal(t, "BTC", response.Asset)
+	assert.Equal(t, provider.MarketSpot, response.Market)
+	assert.Len(t, response.Venues, 2)
+	assert.Equal(t, "Symbol error", messages["NOPE"]["error"])
+}
@@ -0,0 +1,111 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/depth"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// depthRetryDelay spaces the snapshot requests of a book while Binance fails to return one
+var depthRetryDelay = time.Second
+
+// DepthSocket streams the best levels of the order book of a symbol on a market, spot by default,
+// with spread and mid price. The book is loaded from a REST snapshot and kept up to date by the
+// diff depth stream, it is loaded again whenever the stream misses an update.
+func DepthSocket(context *gin.Context) {
+	symbol := provider.NormalizeSymbol(context.Query("symbol"))
+	market, err := provider.NormalizeMarket(context.DefaultQuery("market", provider.MarketSpot))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	limit, err := depth.ParseLimit(context.Query("limit"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	relay := &depthRelay{book: depth.NewBook(market, symbol), limit: limit}
+	relayStream(ws, depthStreamURL(symbol, market), relay.update, nil)
+}
+
+// depthStreamURL returns the Binance diff depth stream of a symbol on the spot or futures market
+func depthStreamURL(symbol, market string) string {
+	baseURL := spotStreamURL()
+	if market == provider.MarketFutures {
+		baseURL = futuresStreamURL()
+	}
+	return fmt.Sprintf("%s/ws/%s@depth@100ms", baseURL, strings.ToLower(symbol))
+}
+
+// depthRelay keeps the book of one client, the hub shares the stream but not the book
+type depthRelay struct {
+	book  *depth.Book
+	limit int
+	// retryAt is when the next snapshot may be requested after a failed one
+	retryAt time.Time
+}
+
+// update applies a depth event and returns the top of the book, or nil while the event
+// is older than the snapshot
+func (d *depthRelay) update(message []byte) (interface{}, error) {
+	var event models.DepthEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return nil, err
+	}
+
+	if !d.book.Loaded() {
+		if err := d.loadSnapshot(); err != nil {
+			return nil, err
+		}
+	}
+	result, err := d.book.Apply(&event)
+	if err != nil {
+		return nil, err
+	}
+	if result == depth.Gap {
+		log.Printf("Depth stream of %s missed updates, loading a new snapshot", d.book.Symbol)
+		if err := d.loadSnapshot(); err != nil {
+			return nil, err
+		}
+		if result, err = d.book.Apply(&event); err != nil {
+			return nil, err
+		}
+	}
+	if result != depth.Applied {
+		return nil, nil
+	}
+
+	var response models.ResponseDepth
+	response.UpdateData(d.book.Market, d.book.Top(d.limit), utils.ConvertMillisecondsToTimestamp(event.EventTime))
+	return response, nil
+}
+
+func (d *depthRelay) loadSnapshot() error {
+	if time.Now().Before(d.retryAt) {
+		return fmt.Errorf("depth snapshot of %s failed, retrying in %s", d.book.Symbol, depthRetryDelay)
+	}
+	snapshot, _, err := provider.Default().Depth(d.book.Market, d.book.Symbol, depth.MaxLimit)
+	if err != nil {
+		d.retryAt = time.Now().Add(depthRetryDelay)
+		return err
+	}
+	d.book.Reset(snapshot)
+	return nil
+}
@@ -0,0 +1,111 @@
+package websocket
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDepthSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// the second snapshot is loaded after the stream skips update 102 to 104
+	var snapshots int32
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/depth", r.URL.Path)
+		assert.Equal(t, "1000", r.URL.Query().Get("limit"))
+		if atomic.AddInt32(&snapshots, 1) == 1 {
+			w.Write([]byte(`{"lastUpdateId":100,"bids":[["99","1"]],"asks":[["101","1"]]}`))
+			return
+		}
+		w.Write([]byte(`{"lastUpdateId":106,"bids":[["98","5"]],"asks":[["101","1"]]}`))
+	}))
+	defer rest.Close()
+	provider.SetDefault(provider.NewBinanceProvider(rest.URL, rest.URL))
+	defer provider.SetDefault(nil)
+
+	events := []string{
+		`{"U":95,"u":100,"b":[["99","7"]],"a":[]}`,
+		`{"U":101,"u":101,"b":[["99.5","2"]],"a":[]}`,
+		`{"U":105,"u":106,"b":[],"a":[]}`,
+		`{"U":107,"u":107,"b":[],"a":[["100.5","3"]]}`,
+	}
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	stream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ws/btcusdt@depth@100ms", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for i, event := range events {
+			event = fmt.Sprintf(`{"e":"depthUpdate","E":%d,"s":"BTCUSDT",`, 1700000000000+int64(i)) + event[1:]
+			conn.WriteMessage(websocket.TextMessage, []byte(event))
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer stream.Close()
+	t.Setenv("BINANCE_SPOT_WS_URL", "ws"+strings.TrimPrefix(stream.URL, "http"))
+
+	router := gin.New()
+	router.GET("/depth", DepthSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/depth?symbol=BTCUSDT&limit=1", nil)
+	assert.NoError(t, err)
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(6 * time.Second))
+
+	var first, second models.ResponseDepth
+	assert.NoError(t, c.ReadJSON(&first))
+	assert.Equal(t, []models.PriceLevel{{Price: 99.5, Quantity: 2}}, first.Bids)
+	assert.Equal(t, []models.PriceLevel{{Price: 101, Quantity: 1}}, first.Asks)
+	assert.Equal(t, 1.5, first.Spread)
+	assert.Equal(t, 100.25, first.MidPrice)
+	assert.Equal(t, int64(101), first.LastUpdateID)
+
+	assert.NoError(t, c.ReadJSON(&second))
+	assert.Equal(t, []models.PriceLevel{{Price: 98, Quantity: 5}}, second.Bids)
+	assert.Equal(t, []models.PriceLevel{{Price: 100.5, Quantity: 3}}, second.Asks)
+	assert.Equal(t, int64(107), second.LastUpdateID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&snapshots))
+}
+
+func TestDepthSocketParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/depth", DepthSocket)
+
+	tests := []struct {
+		name            string
+		queryParams     string
+		expectedMessage string
+	}{
+		{"Unknown market", "symbol=BTCUSDT&market=options", "market options is not supported"},
+		{"Invalid limit", "symbol=BTCUSDT&limit=0", "limit must be between 1 and 1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/depth?"+tt.queryParams, nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, fmt.Sprintf(`{"message":%q}`, tt.expectedMessage), w.Body.String())
+		})
+	}
+}
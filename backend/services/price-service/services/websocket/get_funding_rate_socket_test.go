@@ -46,8 +46,11 @@ func NewMockBinanceServer() *MockBinanceServer {
 			},
 		}
 
-		mockJSON, _ := json.Marshal(mockData)
-		conn.WriteMessage(websocket.TextMessage, mockJSON)
+		// Binance keeps the connection open but sends nothing for an unknown symbol
+		if strings.Contains(r.URL.String(), "btcusdt") {
+			mockJSON, _ := json.Marshal(mockData)
+			conn.WriteMessage(websocket.TextMessage, mockJSON)
+		}
 
 		// Keep connection alive
 		for {
@@ -71,11 +74,12 @@ func setupTest(t *testing.T) (*gin.Engine, *httptest.Server, *MockBinanceServer)
 
 	// Setup mock Binance server
 	mockBinance := NewMockBinanceServer()
+	// Override the Binance WebSocket URL with our mock server
+	t.Setenv("BINANCE_FUTURES_WS_URL", mockBinance.URL)
 
 	// Create a test router
 	router := gin.New()
 	router.GET("/ws", func(c *gin.Context) {
-		// Override the Binance WebSocket URL with our mock server
 		originalFunc := FundingRateSocket
 		originalFunc(c)
 	})
@@ -49,8 +49,11 @@ func NewMockFutureBinanceServer() *MockFutureBinanceServer {
 			},
 		}
 
-		mockJSON, _ := json.Marshal(mockData)
-		conn.WriteMessage(websocket.TextMessage, mockJSON)
+		// Binance keeps the connection open but sends nothing for an unknown symbol
+		if strings.Contains(r.URL.String(), "btcusdt") {
+			mockJSON, _ := json.Marshal(mockData)
+			conn.WriteMessage(websocket.TextMessage, mockJSON)
+		}
 
 		// Keep connection alive
 		for {
@@ -74,11 +77,12 @@ func setupFutureTest(t *testing.T) (*gin.Engine, *httptest.Server, *MockFutureBi
 
 	// Setup mock Binance server
 	mockBinance := NewMockFutureBinanceServer()
+	// Override the Binance WebSocket URL with our mock server
+	t.Setenv("BINANCE_SPOT_WS_URL", mockBinance.URL)
 
 	// Create a test router
 	router := gin.New()
 	router.GET("/ws", func(c *gin.Context) {
-		// Override the Binance WebSocket URL with our mock server
 		originalFunc := FuturePriceSocket
 		originalFunc(c)
 	})
@@ -5,16 +5,59 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/gorilla/websocket"
 )
 
+const (
+	// klineChannelMarket and klineChannelInterval are the candles of the kline channel of the
+	// multiplexed stream
+	klineChannelMarket   = provider.MarketSpot
+	klineChannelInterval = "1s"
+
+	// maxKlineReplay bounds the candles replayed from startTime before the live ones
+	maxKlineReplay = 1000
+)
+
+// KlineSocket streams the candles of a symbol on a market, futures by default like the kline API, at an
+// interval. Every frame is the candle in progress, isFinal is set on its last frame once it closes.
+// Clients continuing a chart loaded from the kline API pass the open time of its last candle as
+// startTime: the candles from it on are sent first, so the live candle follows the history. A startTime
+// more than maxKlineReplay candles ago is refused rather than leaving a gap before the live candles.
 func KlineSocket(context *gin.Context) {
+	symbol := provider.NormalizeSymbol(context.Query("symbol"))
+	interval := context.Query("interval")
+	if symbol == "" || interval == "" {
+		utils.ShowError(http.StatusBadRequest, "Missing data", context)
+		return
+	}
+	market, err := provider.NormalizeMarket(context.Query("market"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	if !isStreamInterval(market, interval) {
+		utils.ShowError(http.StatusBadRequest, "Invalid interval", context)
+		return
+	}
+	var startTime int64
+	if context.Query("startTime") != "" {
+		startTime, err = strconv.ParseInt(context.Query("startTime"), 10, 64)
+		if err != nil || startTime <= 0 {
+			utils.ShowError(http.StatusBadRequest, "Invalid startTime", context)
+			return
+		}
+		if startTime < replayStart(interval, time.Now().UnixMilli()) {
+			utils.ShowError(http.StatusBadRequest, fmt.Sprintf("startTime must be within the last %d candles, older candles are in the kline API", maxKlineReplay), context)
+			return
+		}
+	}
 
 	// Create websocket
 	ws, err := Upgrade(context.Writer, context.Request)
@@ -24,104 +67,102 @@ func KlineSocket(context *gin.Context) {
 	}
 	defer ws.Close()
 
-	symbol := strings.ToLower(context.Query("symbol"))
-	wsURL := fmt.Sprintf("wss://stream.binance.com/stream?streams=%s@kline_1s", symbol)
+	seed := func() []interface{} {
+		return klineSeed(models.KlineQuery{Symbol: symbol, Interval: interval, Market: market, StartTime: startTime})
+	}
+	relayStream(ws, KlineStreamURL(symbol, market, interval), klineMessage, seed)
+}
 
-	headers := http.Header{}
-	headers.Add("method", "SUBSCRIBE")
+// isStreamInterval reports whether Binance streams klines of interval on market, 1s is spot only
+func isStreamInterval(market, interval string) bool {
+	return provider.IsValidInterval(interval) || interval == "1s" && market == provider.MarketSpot
+}
 
-	conn, _, err := websocket.DefaultDialer.Dial(wsURL, headers)
-	if err != nil {
-		log.Fatal("Connection error: ", err)
+// replayStart returns the open time of the oldest of the maxKlineReplay candles ending at now
+func replayStart(interval string, now int64) int64 {
+	if interval == "1s" {
+		return now - maxKlineReplay*time.Second.Milliseconds() + 1
 	}
-	defer conn.Close()
-
-	done := make(chan struct{})
-	// handle symbol error
-	isReceivedMessage := make(chan bool)
-	timeoutDuration := 5 * time.Second
-
-	go func() {
-		defer close(done)
-
-		for {
-			_, message, err := conn.ReadMessage()
-			if err != nil {
-				log.Println("Read error: ", err)
-				return
-			}
-
-			// alert get message (symbol not error)
-			isReceivedMessage <- true
-
-			var KlineResponse models.KlineWebsocket
-
-			if err = json.Unmarshal(message, &KlineResponse); err != nil {
-				log.Println("JSON unmarshal error: ", err)
-				continue
-			}
-
-			response := processKlineResponse(&KlineResponse)
-
-			responseJSON, err := json.Marshal(&response)
-			if err != nil {
-				errorMsg := fmt.Sprintf("JSON marshal error: %s", err.Error())
-				utils.ShowErrorSocket(ws, errorMsg)
-				continue
-			}
-
-			if err = ws.WriteMessage(websocket.TextMessage, []byte(responseJSON)); err != nil {
-				errorMsg := fmt.Sprintf("Write error to client %s", err.Error())
-				utils.ShowErrorSocket(ws, errorMsg)
-				return
-			}
-		}
-	}()
-
-	// after 5 seconds, if not response
-	go func() {
-		for {
-			select {
-			case <-isReceivedMessage:
-				continue
-			case <-time.After(timeoutDuration):
-				// close connect socket with binance server
-				conn.Close()
-				// close socket with user
-				errorMSG := "Symbol error"
-				ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseProtocolError, errorMSG))
-				return
-			}
-		}
-	}()
+	return provider.RangeStart(now, interval, maxKlineReplay)
+}
 
-	for {
-		_, msg, err := ws.ReadMessage()
-		if err != nil {
-			log.Println("Error reading message: ", err)
-			break
-		}
-		if string(msg) == "disconnect" {
-			log.Println("Disconnecting from Websocket")
-			break
-		}
+// klineSeed returns the candles from query.StartTime on, or the last closed and the current candle
+// without it, as frames of the stream
+func klineSeed(query models.KlineQuery) []interface{} {
+	query.Limit = 2
+	if query.StartTime > 0 {
+		query.Limit = maxKlineReplay
+	}
+	candles, _, err := provider.Default().Klines(query)
+	if err != nil {
+		log.Println("Kline seed error: ", err)
+		return nil
 	}
 
-	<-done
+	now := time.Now().UnixMilli()
+	frames := make([]interface{}, 0, len(candles))
+	for _, candle := range candles {
+		frames = append(frames, candleFrame(query.Symbol, query.Interval, candle, candle.CloseTime < now))
+	}
+	return frames
 }
 
 func processKlineResponse(KlineResponse *models.KlineWebsocket) map[string]interface{} {
 	return map[string]interface{}{
 		"symbol":              KlineResponse.Data.Symbol,
+		"interval":            KlineResponse.Data.KData.Interval,
 		"eventTime":           utils.ConvertMillisecondsToTimestamp(KlineResponse.Data.EventTime),
+		"time":                utils.ConvertMilisecondToTimeFormatedRFC3339(KlineResponse.Data.KData.StartTime),
 		"startTime":           utils.ConvertMillisecondsToTimestamp(KlineResponse.Data.KData.StartTime),
 		"closeTime":           utils.ConvertMillisecondsToTimestamp(KlineResponse.Data.KData.CloseTime),
 		"openPrice":           KlineResponse.Data.KData.OpenPrice,
 		"highPrice":           KlineResponse.Data.KData.HighPrice,
 		"lowPrice":            KlineResponse.Data.KData.LowPrice,
+		"closePrice":          KlineResponse.Data.KData.ClosePrice,
 		"baseAssetVolume":     KlineResponse.Data.KData.BaseAssetVolume,
 		"quoteAssetVolume":    KlineResponse.Data.KData.QuoteAssetVolume,
 		"takerBuyBaseVolume":  KlineResponse.Data.KData.TakerBuyBaseVolume,
 		"takerBuyQuoteVolume": KlineResponse.Data.KData.TakerBuyQuoteVolume,
+		"isFinal":             KlineResponse.Data.KData.IsFinal,
+	}
+}
+
+// candleFrame formats a candle of the REST API like a stream frame, without the quote and taker volumes
+func candleFrame(symbol, interval string, candle models.Candle, final bool) map[string]interface{} {
+	return map[string]interface{}{
+		"symbol":          symbol,
+		"interval":        interval,
+		"eventTime":       utils.GetTimeNow(),
+		"time":            utils.ConvertMilisecondToTimeFormatedRFC3339(candle.OpenTime),
+		"startTime":       utils.ConvertMillisecondsToTimestamp(candle.OpenTime),
+		"closeTime":       utils.ConvertMillisecondsToTimestamp(candle.CloseTime),
+		"openPrice":       strconv.FormatFloat(candle.Open, 'f', -1, 64),
+		"highPrice":       strconv.FormatFloat(candle.High, 'f', -1, 64),
+		"lowPrice":        strconv.FormatFloat(candle.Low, 'f', -1, 64),
+		"closePrice":      strconv.FormatFloat(candle.Close, 'f', -1, 64),
+		"baseAssetVolume": strconv.FormatFloat(candle.Volume, 'f', -1, 64),
+		"isFinal":         final,
+	}
+}
+
+// KlineStreamURL returns the Binance kline stream of a symbol on the spot or futures market
+func KlineStreamURL(symbol, market, interval string) string {
+	baseURL := spotStreamURL()
+	if market == provider.MarketFutures {
+		baseURL = futuresStreamURL()
+	}
+	return fmt.Sprintf("%s/stream?streams=%s@kline_%s", baseURL, strings.ToLower(symbol), interval)
+}
+
+// klineSecondStreamURL returns the 1s spot kline stream of the kline channel of the multiplexed stream
+func klineSecondStreamURL(symbol string) string {
+	return KlineStreamURL(symbol, klineChannelMarket, klineChannelInterval)
+}
+
+func klineMessage(message []byte) (interface{}, error) {
+	var KlineResponse models.KlineWebsocket
+	if err := json.Unmarshal(message, &KlineResponse); err != nil {
+		return nil, err
 	}
+	return processKlineResponse(&KlineResponse), nil
 }
@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,8 @@ func newMockWebsocketServer() *MockWebsocketServer {
 					QuoteAssetVolume    string `json:"q"`
 					TakerBuyBaseVolume  string `json:"V"`
 					TakerBuyQuoteVolume string `json:"Q"`
+					Interval            string `json:"i"`
+					IsFinal             bool   `json:"x"`
 				} `json:"k"`
 			}{
 				EventType: "kline",
@@ -70,6 +74,8 @@ func newMockWebsocketServer() *MockWebsocketServer {
 					QuoteAssetVolume    string `json:"q"`
 					TakerBuyBaseVolume  string `json:"V"`
 					TakerBuyQuoteVolume string `json:"Q"`
+					Interval            string `json:"i"`
+					IsFinal             bool   `json:"x"`
 				}{
 					StartTime:           time.Now().UnixMilli(),
 					CloseTime:           time.Now().Add(time.Second).UnixMilli(),
@@ -114,7 +120,7 @@ func TestKlineSocket(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 
 	// Mock HTTP request with query parameters
-	req := httptest.NewRequest("GET", "/?symbol=btcusdt", nil)
+	req := httptest.NewRequest("GET", "/?symbol=btcusdt&market=spot&interval=1s", nil)
 	c.Request = req
 
 	// Create a done channel to signal test completion
@@ -148,7 +154,7 @@ func TestKlineSocketWithInvalidSymbol(t *testing.T) {
 	c, _ := gin.CreateTestContext(w)
 
 	// Mock HTTP request with invalid symbol
-	req := httptest.NewRequest("GET", "/?symbol=invalid", nil)
+	req := httptest.NewRequest("GET", "/?symbol=invalid&market=spot&interval=1s", nil)
 	c.Request = req
 
 	// Create a done channel to signal test completion
@@ -190,6 +196,8 @@ func TestProcessKlineResponse(t *testing.T) {
 					QuoteAssetVolume    string `json:"q"`
 					TakerBuyBaseVolume  string `json:"V"`
 					TakerBuyQuoteVolume string `json:"Q"`
+					Interval            string `json:"i"`
+					IsFinal             bool   `json:"x"`
 				} `json:"k"`
 			}{
 				Symbol:    "BTCUSDT",
@@ -206,21 +214,29 @@ func TestProcessKlineResponse(t *testing.T) {
 					QuoteAssetVolume    string `json:"q"`
 					TakerBuyBaseVolume  string `json:"V"`
 					TakerBuyQuoteVolume string `json:"Q"`
+					Interval            string `json:"i"`
+					IsFinal             bool   `json:"x"`
 				}{
 					StartTime:           time.Now().UnixMilli(),
 					CloseTime:           time.Now().Add(time.Second).UnixMilli(),
 					OpenPrice:           "50000.00",
 					HighPrice:           "50200.00",
 					LowPrice:            "49900.00",
+					ClosePrice:          "50100.00",
 					BaseAssetVolume:     "10.5",
 					QuoteAssetVolume:    "525000.00",
 					TakerBuyBaseVolume:  "6.3",
 					TakerBuyQuoteVolume: "315000.00",
+					Interval:            "1h",
+					IsFinal:             true,
 				},
 			},
 		}
 
 		result := processKlineResponse(input)
+		assert.Equal(t, "1h", result["interval"])
+		assert.Equal(t, "50100.00", result["closePrice"])
+		assert.Equal(t, true, result["isFinal"])
 
 		assert.Equal(t, "BTCUSDT", result["symbol"])
 		assert.Equal(t, "50000.00", result["openPrice"])
@@ -232,3 +248,100 @@ func TestProcessKlineResponse(t *testing.T) {
 		assert.Equal(t, "315000.00", result["takerBuyQuoteVolume"])
 	})
 }
+
+func TestKlineSocketParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/kline", KlineSocket)
+
+	tests := []struct {
+		name            string
+		queryParams     string
+		expectedMessage string
+	}{
+		{"Missing interval", "symbol=BTCUSDT", "Missing data"},
+		{"Unknown market", "symbol=BTCUSDT&market=options&interval=1h", "market options is not supported"},
+		{"Unknown interval", "symbol=BTCUSDT&interval=7m", "Invalid interval"},
+		{"Seconds on futures", "symbol=BTCUSDT&market=futures&interval=1s", "Invalid interval"},
+		// futures by default, like the kline API
+		{"Seconds by default", "symbol=BTCUSDT&interval=1s", "Invalid interval"},
+		{"Invalid start time", "symbol=BTCUSDT&interval=1h&startTime=yesterday", "Invalid startTime"},
+		{"Start time before the replay", "symbol=BTCUSDT&interval=1h&startTime=1700000000000", "startTime must be within the last 1000 candles, older candles are in the kline API"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/kline?"+tt.queryParams, nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, fmt.Sprintf(`{"message":%q}`, tt.expectedMessage), w.Body.String())
+		})
+	}
+}
+
+func TestKlineSocketContinuesHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hour := int64(time.Hour / time.Millisecond)
+	current := time.Now().UnixMilli() / hour * hour
+
+	// the futures stream sends the candle in progress
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	stream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		event := fmt.Sprintf(`{"stream":"btcusdt@kline_1h","data":{"e":"kline","s":"BTCUSDT","k":{"t":%d,"T":%d,"i":"1h","o":"101","c":"103","h":"104","l":"100","v":"7","x":false}}}`,
+			current, current+hour-1)
+		conn.WriteMessage(websocket.TextMessage, []byte(event))
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer stream.Close()
+	t.Setenv("BINANCE_FUTURES_WS_URL", "ws"+strings.TrimPrefix(stream.URL, "http"))
+
+	// the REST API has the candles from startTime on
+	var path, startTime string
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, startTime = r.URL.Path, r.URL.Query().Get("startTime")
+		fmt.Fprintf(w, `[[%d,"100","102","99","101","5",%d],[%d,"101","102","100","102","3",%d]]`,
+			current-hour, current-1, current, current+hour-1)
+	}))
+	defer rest.Close()
+	provider.SetDefault(provider.NewBinanceProvider(rest.URL, rest.URL))
+	defer provider.SetDefault(nil)
+
+	router := gin.New()
+	router.GET("/kline", KlineSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	url := fmt.Sprintf("ws%s/kline?symbol=btcusdt&market=futures&interval=1h&startTime=%d", strings.TrimPrefix(server.URL, "http"), current-hour)
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var frames []map[string]interface{}
+	for i := 0; i < 3; i++ {
+		var frame map[string]interface{}
+		assert.NoError(t, c.ReadJSON(&frame))
+		frames = append(frames, frame)
+	}
+	assert.Equal(t, "/fapi/v1/klines", path)
+	assert.Equal(t, fmt.Sprint(current-hour), startTime)
+
+	// the closed candle, then the one in progress from the REST API and from the stream
+	assert.Equal(t, true, frames[0]["isFinal"])
+	assert.Equal(t, "101", frames[0]["closePrice"])
+	assert.Equal(t, false, frames[1]["isFinal"])
+	assert.Equal(t, false, frames[2]["isFinal"])
+	assert.Equal(t, "103", frames[2]["closePrice"])
+	assert.Equal(t, "1h", frames[2]["interval"])
+	assert.Equal(t, frames[1]["time"], frames[2]["time"])
+}
@@ -0,0 +1,106 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// LiquidationSocket streams the liquidations of Binance futures as they happen, of every symbol or of
+// the requested symbols, with a notional of at least minNotional. Unlike the other sockets it has no
+// symbol timeout, a symbol may go long without liquidations.
+func LiquidationSocket(context *gin.Context) {
+	symbols := map[string]bool{}
+	for _, symbol := range provider.ParseSymbols(context.QueryArray("symbols")) {
+		symbols[symbol] = true
+	}
+	var minNotional float64
+	if value := context.Query("minNotional"); value != "" {
+		var err error
+		minNotional, err = strconv.ParseFloat(value, 64)
+		if err != nil || minNotional < 0 {
+			utils.ShowError(http.StatusBadRequest, "minNotional must be a number of at least 0", context)
+			return
+		}
+	}
+
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	subscription := DefaultHub.Subscribe(ForceOrderStreamURL())
+	defer subscription.Close()
+
+	disconnected := make(chan struct{})
+	keepAlive(ws, disconnected)
+	go func() {
+		defer close(disconnected)
+		for {
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				log.Println("Error reading message: ", err)
+				return
+			}
+			ws.SetReadDeadline(time.Now().Add(pongWait))
+			if string(msg) == "disconnect" {
+				log.Println("Disconnecting from WebSocket")
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+
+		case message, ok := <-subscription.C:
+			if !ok {
+				// this client fell too far behind
+				ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "Stream closed, please reconnect"))
+				return
+			}
+
+			var frame interface{}
+			if message.Status != "" {
+				frame = models.StreamStatus{Status: message.Status, Message: statusMessages[message.Status]}
+			} else {
+				var event models.ForceOrderWebsocket
+				if err := json.Unmarshal(message.Data, &event); err != nil {
+					log.Println("JSON unmarshal error: ", err)
+					continue
+				}
+				liquidation := event.Liquidation()
+				if (len(symbols) > 0 && !symbols[liquidation.Symbol]) || liquidation.Notional < minNotional {
+					continue
+				}
+				var response models.ResponseLiquidation
+				response.UpdateData(&liquidation, utils.ConvertMillisecondsToTimestamp(liquidation.Time))
+				frame = response
+			}
+
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := ws.WriteJSON(frame); err != nil {
+				log.Println("Write error to client: ", err)
+				return
+			}
+		}
+	}
+}
+
+// ForceOrderStreamURL returns the Binance futures stream of the liquidations of every symbol
+func ForceOrderStreamURL() string {
+	return fmt.Sprintf("%s/ws/!forceOrder@arr", futuresStreamURL())
+}
@@ -0,0 +1,77 @@
+package websocket
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiquidationSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// a small BTC liquidation, an ETH one and a large BTC one
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	stream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ws/!forceOrder@arr", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, order := range []string{
+			`"s":"BTCUSDT","S":"SELL","q":"0.01","p":"96900","ap":"97000","z":"0.01","T":1733900000000`,
+			`"s":"ETHUSDT","S":"BUY","q":"10","p":"3900","ap":"3950","z":"10","T":1733900001000`,
+			`"s":"BTCUSDT","S":"BUY","q":"2","p":"97100","ap":"97050","z":"2","T":1733900002000`,
+		} {
+			conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"e":"forceOrder","E":1733900002100,"o":{%s,"o":"LIMIT","f":"IOC","X":"FILLED"}}`, order)))
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer stream.Close()
+	t.Setenv("BINANCE_FUTURES_WS_URL", "ws"+strings.TrimPrefix(stream.URL, "http"))
+
+	router := gin.New()
+	router.GET("/liquidations", LiquidationSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/liquidations?symbols=btcusdt&minNotional=10000", nil)
+	assert.NoError(t, err)
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var frame models.ResponseLiquidation
+	assert.NoError(t, c.ReadJSON(&frame))
+	assert.Equal(t, "BTCUSDT", frame.Symbol)
+	assert.Equal(t, models.PositionShort, frame.Side)
+	assert.Equal(t, 97050.0, frame.Price)
+	assert.Equal(t, 2.0, frame.Quantity)
+	assert.Equal(t, 194100.0, frame.Notional)
+}
+
+func TestLiquidationSocketParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/liquidations", LiquidationSocket)
+
+	for _, minNotional := range []string{"-1", "large"} {
+		t.Run(minNotional, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/liquidations?minNotional="+minNotional, nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"message":"minNotional must be a number of at least 0"}`, w.Body.String())
+		})
+	}
+}
@@ -1,20 +1,29 @@
 package websocket
 
 import (
-	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
-	"net/url"
-	"strings"
 	"time"
 
-	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	marketcap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
 
+// MarketCapSocket streams the CoinGecko market cap of a coin, given as a CoinGecko id, a ticker, a
+// trading pair or a name. Every client of a coin shares the polling of marketcap.DefaultPoller.
 func MarketCapSocket(context *gin.Context) {
+	coinID, statusCode, err := marketcap.DefaultResolver.Resolve(context.Query("symbol"))
+	if err != nil {
+		if statusCode == http.StatusTooManyRequests {
+			utils.ShowError(http.StatusTooManyRequests, "Rate limit, please wait.", context)
+		} else {
+			utils.ShowError(int64(utils.ResponseStatusCode(statusCode)), err.Error(), context)
+		}
+		return
+	}
+
 	// Create websocket
 	ws, err := Upgrade(context.Writer, context.Request)
 	if err != nil {
@@ -23,112 +32,49 @@ func MarketCapSocket(context *gin.Context) {
 	}
 	defer ws.Close()
 
-	symbol := strings.ToLower(context.Query("symbol"))
-	urlMarketCap := fmt.Sprintf("https://api.coingecko.com/api/v3/coins/%s", symbol)
-
-	// done chan to check if the main go routine is continue or not
-	done := make(chan struct{})
-	// exit chan to check if the go func is continue or not (check for stop loop)
-	exit := make(chan struct{})
+	subscription := marketcap.DefaultPoller.Subscribe(coinID)
+	defer subscription.Close()
 
+	disconnected := make(chan struct{})
+	keepAlive(ws, disconnected)
 	go func() {
-		defer close(done)
-		ticker := time.NewTicker(15 * time.Minute)
-		defer ticker.Stop()
-		isContinue := processMarketCapSocket(urlMarketCap, ws)
-		if !isContinue {
-			return
-		}
+		defer close(disconnected)
 		for {
-			select {
-			case <-exit:
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				log.Println("Error reading message: ", err)
 				return
-			case <-ticker.C:
-				isContinue := processMarketCapSocket(urlMarketCap, ws)
-				if isContinue {
-					continue
-				} else {
-					return
-				}
-			case <-done:
+			}
+			ws.SetReadDeadline(time.Now().Add(pongWait))
+			if string(msg) == "disconnect" {
+				log.Println("Disconnecting from WebSocket")
 				return
 			}
 		}
 	}()
 
 	for {
-		_, msg, err := ws.ReadMessage()
-		if err != nil {
-			close(exit)
-			break
-		}
-		if string(msg) == "disconnect" {
-			close(exit)
-			break
-		}
-	}
-
-	<-done
-}
-
-func processMarketCapSocket(urlMarketCap string, ws *websocket.Conn) bool {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", urlMarketCap, nil)
-	if err != nil {
-		log.Println("ERROR Request")
-		return true
-	}
-
-	q := url.Values{}
-	q.Add("localization", "false")
-	q.Add("tickers", "false")
-	q.Add("community_data", "false")
-	req.URL.RawQuery = q.Encode()
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Println("Error http request")
-		return true
-	}
-
-	// Get status code
-	statusCode := resp.StatusCode
-	if statusCode == http.StatusTooManyRequests {
-		ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "Rate limit, please wait."))
-		return false
-
-	} else if statusCode != http.StatusOK {
-		ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "Symbol missing or invalid"))
-		return false
-	}
-
-	// get data response
-	var marketCapResponse models.MarketCapResponse
-	err = json.NewDecoder(resp.Body).Decode(&marketCapResponse)
-	resp.Body.Close()
-	if err != nil {
-		log.Println("Error from getting response, error: ", err)
-		return true
-	}
-
-	// format response
-	dataResponse := models.CreateReponseFormat(
-		marketCapResponse.Symbol,
-		marketCapResponse.MarketData.MarketCap.USD,
-		marketCapResponse.MarketData.TotalVolume.USD,
-	)
+		select {
+		case <-disconnected:
+			return
 
-	responseJSON, err := json.Marshal(&dataResponse)
-	if err != nil {
-		errorMsg := fmt.Sprintf("JSON marshal error: %s", err.Error())
-		log.Println(errorMsg)
-		return true
-	}
+		case update := <-subscription.C:
+			switch {
+			case update.StatusCode == http.StatusTooManyRequests:
+				// the poller tries again sooner, the client keeps its connection
+				continue
+			case update.StatusCode != 0 && update.StatusCode != http.StatusOK:
+				ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "Symbol missing or invalid"))
+				return
+			case update.Err != nil:
+				continue
+			}
 
-	// return message response to client
-	if err = ws.WriteMessage(websocket.TextMessage, []byte(responseJSON)); err != nil {
-		errorMsg := fmt.Sprintf("Write error to client %s", err.Error())
-		log.Println(errorMsg)
-		return false
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := ws.WriteJSON(update.Data); err != nil {
+				log.Println("Write error to client: ", err)
+				return
+			}
+		}
 	}
-	return true
 }
@@ -2,17 +2,47 @@ package websocket
 
 import (
 	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	marketcap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 )
 
+// setupMockCoinGecko replaces CoinGecko by a mock server knowing bitcoin and gives the market cap
+// sockets a fresh resolver and poller, it returns how many times the bitcoin market cap was fetched
+func setupMockCoinGecko(t *testing.T) *int {
+	fetches := 0
+	coinGecko := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/coins/list":
+			w.Write([]byte(`[{"id":"bitcoin","symbol":"btc","name":"Bitcoin"},{"id":"ethereum","symbol":"eth","name":"Ethereum"}]`))
+		case "/coins/bitcoin":
+			fetches++
+			w.Write([]byte(`{"id":"bitcoin","symbol":"btc","name":"Bitcoin","market_data":{"market_cap":{"usd":1925000000000},"total_volume":{"usd":45000000000}},"last_updated":"2024-12-11T07:00:00.000Z"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Setenv("COINGECKO_BASE_URL", coinGecko.URL)
+
+	resolver, poller := marketcap.DefaultResolver, marketcap.DefaultPoller
+	marketcap.DefaultResolver, marketcap.DefaultPoller = marketcap.NewResolver(), marketcap.NewPoller(marketcap.DefaultInterval)
+	t.Cleanup(func() {
+		marketcap.DefaultResolver, marketcap.DefaultPoller = resolver, poller
+		coinGecko.Close()
+	})
+	return &fetches
+}
+
 func TestMarketCapSocket(t *testing.T) {
+	fetches := setupMockCoinGecko(t)
+
 	// Setup Gin router
 	router := gin.Default()
 	router.GET("/ws/market-cap", MarketCapSocket)
@@ -22,30 +52,42 @@ func TestMarketCapSocket(t *testing.T) {
 	defer server.Close()
 
 	// Convert http://... to ws://...
-	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/market-cap?symbol=bitcoin"
-
-	// Connect to websocket
-	ws, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
-	assert.NoError(t, err)
-	defer ws.Close()
-
-	// Read the first message
-	_, msg, err := ws.ReadMessage()
-	assert.NoError(t, err)
-
-	// Parse response
-	var response models.FormatMarketCapResponse
-	err = json.Unmarshal(msg, &response)
-	assert.NoError(t, err)
-
-	// Assert response structure
-	assert.Equal(t, "btc", response.Symbol)
-	assert.Greater(t, response.MarketCap, int64(0))
-	assert.Greater(t, response.TotalVolume, int64(0))
-
-	// Test disconnect
-	err = ws.WriteMessage(websocket.TextMessage, []byte("disconnect"))
-	assert.NoError(t, err)
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/market-cap?symbol="
+
+	// Two clients of the same coin, one by id and one by ticker
+	for _, symbol := range []string{"bitcoin", "BTC"} {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+symbol, nil)
+		assert.NoError(t, err)
+		defer ws.Close()
+
+		// Read the first message
+		_, msg, err := ws.ReadMessage()
+		assert.NoError(t, err)
+
+		// Parse response
+		var response models.FormatMarketCapResponse
+		err = json.Unmarshal(msg, &response)
+		assert.NoError(t, err)
+
+		// Assert response structure
+		assert.Equal(t, "bitcoin", response.ID)
+		assert.Equal(t, "btc", response.Symbol)
+		assert.Greater(t, response.MarketCap, int64(0))
+		assert.Greater(t, response.TotalVolume, int64(0))
+	}
+	// the second client got the result of the shared poller
+	assert.Equal(t, 1, *fetches)
+}
+
+func TestMarketCapSocketUnknownCoin(t *testing.T) {
+	setupMockCoinGecko(t)
+	router := gin.New()
+	router.GET("/ws/market-cap", MarketCapSocket)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/ws/market-cap?symbol=nocoin", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"message":"Coin nocoin not found"}`, w.Body.String())
 }
 
 func TestCreateResponseFormat(t *testing.T) {
@@ -0,0 +1,68 @@
+package websocket
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	marketcap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MarketOverviewSocket streams the global market overview, the latest one on connection and then
+// every refresh of the shared overview
+func MarketOverviewSocket(context *gin.Context) {
+	limit, err := marketcap.ParseOverviewLimit(context.Query("limit"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	overview := marketcap.CurrentOverview()
+	if overview == nil {
+		utils.ShowError(http.StatusServiceUnavailable, "Market overview is not available", context)
+		return
+	}
+
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	subscription := overview.Subscribe()
+	defer subscription.Close()
+
+	disconnected := make(chan struct{})
+	keepAlive(ws, disconnected)
+	go func() {
+		defer close(disconnected)
+		for {
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				log.Println("Error reading message: ", err)
+				return
+			}
+			ws.SetReadDeadline(time.Now().Add(pongWait))
+			if string(msg) == "disconnect" {
+				log.Println("Disconnecting from WebSocket")
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+
+		case latest := <-subscription.C:
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := ws.WriteJSON(latest.Response(limit)); err != nil {
+				log.Println("Write error to client: ", err)
+				return
+			}
+		}
+	}
+}
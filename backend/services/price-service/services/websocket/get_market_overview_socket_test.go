@@ -0,0 +1,57 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	marketcap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarketOverviewSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	coinGecko := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/global":
+			w.Write([]byte(`{"data":{"active_cryptocurrencies":16231,"total_market_cap":{"usd":3400000000000},"total_volume":{"usd":150000000000},"market_cap_percentage":{"btc":56,"eth":13},"updated_at":1733900000}}`))
+		case "/coins/markets":
+			w.Write([]byte(`[{"id":"bitcoin","symbol":"btc","name":"Bitcoin","market_cap":1904000000000,"market_cap_rank":1,"price_change_percentage_24h":1.27},{"id":"ethereum","symbol":"eth","name":"Ethereum","market_cap":443700000000,"market_cap_rank":2,"price_change_percentage_24h":-2.5}]`))
+		}
+	}))
+	defer coinGecko.Close()
+	t.Setenv("COINGECKO_BASE_URL", coinGecko.URL)
+
+	overview := marketcap.NewOverview(time.Hour)
+	_, _, err := overview.Latest()
+	assert.NoError(t, err)
+	marketcap.SetOverview(overview)
+	defer marketcap.SetOverview(nil)
+
+	router := gin.New()
+	router.GET("/market-overview", MarketOverviewSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/market-overview?limit=1", nil)
+	assert.NoError(t, err)
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var frame models.ResponseMarketOverview
+	assert.NoError(t, c.ReadJSON(&frame))
+	assert.Equal(t, 56.0, frame.BTCDominance)
+	assert.Len(t, frame.TopMarketCap, 1)
+	assert.Equal(t, "BTC", frame.TopMarketCap[0].Symbol)
+	assert.Equal(t, "ethereum", frame.TopLosers[0].ID)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/market-overview?limit=0", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"message":"limit must be between 1 and 100"}`, w.Body.String())
+}
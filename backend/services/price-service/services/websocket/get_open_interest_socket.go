@@ -0,0 +1,127 @@
+package websocket
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	openinterest "github.com/dath-241/coin-price-be-go/services/price-service/services/open_interest"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// openInterestInterval is how often the open interest socket and channel poll the exchange
+	openInterestInterval = 10 * time.Second
+	// longShortRatioInterval is how often they poll the long/short ratios, which change every 5 minutes
+	longShortRatioInterval = 5 * time.Minute
+)
+
+// OpenInterestSocket polls the open interest of a perpetual contract every 10 seconds and its latest
+// global account and top trader position long/short ratios every 5 minutes, from Binance Futures or
+// the requested exchange
+func OpenInterestSocket(context *gin.Context) {
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	marketData, err := provider.Get(context.Query("exchange"))
+	if err != nil {
+		ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, err.Error()))
+		return
+	}
+	poll := &openInterestPoll{marketData: marketData, symbol: provider.NormalizeSymbol(context.Query("symbol"))}
+
+	// done chan to check if the main go routine is continue or not
+	done := make(chan struct{})
+	// exit chan to check if the go func is continue or not (check for stop loop)
+	exit := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(openInterestInterval)
+		defer ticker.Stop()
+		for {
+			frame, statusCode, err := poll.next()
+			switch {
+			case err != nil && statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+				ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "Symbol missing or invalid"))
+				return
+			case err != nil:
+				log.Println("Open interest poll error: ", err)
+			default:
+				if err := ws.WriteJSON(frame); err != nil {
+					log.Println("Write error to client: ", err)
+					return
+				}
+			}
+
+			select {
+			case <-exit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil || string(msg) == "disconnect" {
+			close(exit)
+			break
+		}
+	}
+
+	<-done
+}
+
+// openInterestPoll keeps what one open interest subscription needs between two polls
+type openInterestPoll struct {
+	marketData provider.MarketDataProvider
+	symbol     string
+
+	previous    float64
+	ratiosTime  time.Time
+	globalRatio *models.LongShortRatio
+	topRatio    *models.LongShortRatio
+}
+
+// next reads the open interest, and the ratios when they are due. A ratio the exchange does not
+// publish is left out of the frame rather than failing the poll.
+func (p *openInterestPoll) next() (*models.ResponseOpenInterestFrame, models.StatusCode, error) {
+	openInterest, statusCode, err := openinterest.GetOpenInterestData(p.marketData, p.symbol)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	if time.Since(p.ratiosTime) >= longShortRatioInterval {
+		p.ratiosTime = time.Now()
+		p.globalRatio = p.latestRatio(models.RatioGlobalAccounts)
+		p.topRatio = p.latestRatio(models.RatioTopPositions)
+	}
+
+	frame := &models.ResponseOpenInterestFrame{
+		ResponseOpenInterest: *openInterest,
+		GlobalAccountRatio:   p.globalRatio,
+		TopPositionRatio:     p.topRatio,
+	}
+	if p.previous > 0 {
+		frame.Change = math.Round((openInterest.OpenInterest-p.previous)*1e8) / 1e8
+	}
+	p.previous = openInterest.OpenInterest
+	return frame, http.StatusOK, nil
+}
+
+func (p *openInterestPoll) latestRatio(kind string) *models.LongShortRatio {
+	ratios, _, err := p.marketData.LongShortRatio(kind, models.PositioningQuery{Symbol: p.symbol, Period: "5m", Limit: 1})
+	if err != nil || len(ratios) == 0 {
+		return nil
+	}
+	return &ratios[len(ratios)-1]
+}
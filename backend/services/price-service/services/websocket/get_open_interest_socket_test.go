@@ -0,0 +1,141 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupOpenInterestTest polls a mock Binance Futures whose open interest grows by 10 at every request,
+// every 50 milliseconds, and counts the ratio requests
+func setupOpenInterestTest(t *testing.T) *int32 {
+	var openInterestRequests, ratioRequests int32
+	binance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbol") != "BTCUSDT" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1121,"msg":"Invalid symbol."}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/fapi/v1/openInterest":
+			requests := atomic.AddInt32(&openInterestRequests, 1)
+			fmt.Fprintf(w, `{"openInterest":"%d","symbol":"BTCUSDT","time":1733900000000}`, 80000+10*requests)
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"100000.00","time":1733900000000}`))
+		case "/futures/data/globalLongShortAccountRatio":
+			atomic.AddInt32(&ratioRequests, 1)
+			w.Write([]byte(`[{"symbol":"BTCUSDT","longShortRatio":"1.5","longAccount":"0.6","shortAccount":"0.4","timestamp":1733900400000}]`))
+		case "/futures/data/topLongShortPositionRatio":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	provider.SetDefault(provider.NewBinanceProvider(binance.URL, binance.URL))
+
+	interval := openInterestInterval
+	openInterestInterval = 50 * time.Millisecond
+	t.Cleanup(func() {
+		openInterestInterval = interval
+		provider.SetDefault(nil)
+		binance.Close()
+	})
+	return &ratioRequests
+}
+
+func TestOpenInterestSocket(t *testing.T) {
+	ratioRequests := setupOpenInterestTest(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws/open-interest", OpenInterestSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/open-interest"
+
+	t.Run("Open interest and ratios", func(t *testing.T) {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+"?symbol=btcusdt", nil)
+		assert.NoError(t, err)
+		defer ws.Close()
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		var first, second models.ResponseOpenInterestFrame
+		assert.NoError(t, ws.ReadJSON(&first))
+		assert.NoError(t, ws.ReadJSON(&second))
+
+		assert.Equal(t, "BTCUSDT", first.Symbol)
+		assert.Equal(t, 80010.0, first.OpenInterest)
+		assert.Equal(t, 8001000000.0, first.OpenInterestValue)
+		assert.Zero(t, first.Change)
+		assert.Equal(t, &models.LongShortRatio{Symbol: "BTCUSDT", LongShortRatio: 1.5, LongAccount: 0.6, ShortAccount: 0.4, Time: 1733900400000}, first.GlobalAccountRatio)
+		// the top trader ratio failed and is left out
+		assert.Nil(t, first.TopPositionRatio)
+
+		assert.Equal(t, 80020.0, second.OpenInterest)
+		assert.Equal(t, 10.0, second.Change)
+		assert.Equal(t, first.GlobalAccountRatio, second.GlobalAccountRatio)
+		// the ratios are only polled every 5 minutes
+		assert.Equal(t, int32(1), atomic.LoadInt32(ratioRequests))
+
+		assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte("disconnect")))
+	})
+
+	t.Run("Invalid symbol", func(t *testing.T) {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+"?symbol=NOPEUSDT", nil)
+		assert.NoError(t, err)
+		defer ws.Close()
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		_, _, err = ws.ReadMessage()
+		closeErr, ok := err.(*websocket.CloseError)
+		assert.True(t, ok)
+		assert.Equal(t, "Symbol missing or invalid", closeErr.Text)
+	})
+
+	t.Run("Unknown exchange", func(t *testing.T) {
+		ws, _, err := websocket.DefaultDialer.Dial(wsURL+"?symbol=BTCUSDT&exchange=kraken", nil)
+		assert.NoError(t, err)
+		defer ws.Close()
+		ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+		_, _, err = ws.ReadMessage()
+		closeErr, ok := err.(*websocket.CloseError)
+		assert.True(t, ok)
+		assert.Equal(t, "exchange kraken is not supported", closeErr.Text)
+	})
+}
+
+func TestStreamOpenInterestChannel(t *testing.T) {
+	setupOpenInterestTest(t)
+	url := setupStreamTest(t)
+	c := dialStream(t, url, nil)
+
+	assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelOpenInterest, Symbols: []string{"btcusdt", "NOPEUSDT"}}))
+	assert.True(t, readAck(t, c).Success)
+
+	messages := map[string]map[string]interface{}{}
+	for len(messages) < 2 {
+		message := readStreamMessage(t, c)
+		if _, ok := messages[message["symbol"].(string)]; !ok {
+			messages[message["symbol"].(string)] = message
+		}
+	}
+	data, err := json.Marshal(messages["BTCUSDT"]["data"])
+	assert.NoError(t, err)
+	var frame models.ResponseOpenInterestFrame
+	assert.NoError(t, json.Unmarshal(data, &frame))
+	assert.Equal(t, "BTCUSDT", frame.Symbol)
+	assert.Equal(t, 100000.0, frame.MarkPrice)
+	assert.Equal(t, "Symbol error", messages["NOPEUSDT"]["error"])
+}
@@ -0,0 +1,129 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/future_price"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PremiumSocket streams the mark, index and last price of a perpetual contract with its basis versus
+// the spot price every second. The first frame comes from the REST API, the spot price from the spot
+// ticker stream.
+func PremiumSocket(context *gin.Context) {
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	symbol := provider.NormalizeSymbol(context.Query("symbol"))
+	relay := &premiumRelay{symbol: symbol}
+
+	spot := DefaultHub.Subscribe(spotPriceStreamURL(symbol))
+	defer spot.Close()
+	go relay.followSpot(spot.C)
+
+	relayStream(ws, premiumStreamURL(symbol), relay.update, relay.seed)
+}
+
+// premiumStreamURL returns the Binance futures mark price and ticker streams of a symbol combined
+func premiumStreamURL(symbol string) string {
+	symbol = strings.ToLower(symbol)
+	return fmt.Sprintf("%s/stream?streams=%s@markPrice@1s/%s@ticker", futuresStreamURL(), symbol, symbol)
+}
+
+// premiumRelay keeps the prices a mark price event lacks
+type premiumRelay struct {
+	symbol string
+
+	mutex     sync.Mutex
+	lastPrice float64
+	spotPrice float64
+	// interestRate and the funding interval are only in the REST API
+	interestRate         string
+	fundingIntervalHours int
+}
+
+// seed sends the premium of the REST API
+func (r *premiumRelay) seed() []interface{} {
+	premium, _, err := future_price.GetPremiumData(provider.Default(), r.symbol)
+	if err != nil {
+		log.Println("Premium seed error: ", err)
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.spotPrice == 0 {
+		r.spotPrice = premium.SpotPrice
+	}
+	r.lastPrice = premium.LastPrice
+	r.interestRate = strconv.FormatFloat(premium.InterestRate, 'f', -1, 64)
+	r.fundingIntervalHours = premium.FundingIntervalHours
+	return []interface{}{premium}
+}
+
+// followSpot keeps the spot price until the subscription is closed
+func (r *premiumRelay) followSpot(messages <-chan Message) {
+	for message := range messages {
+		if message.Status != "" {
+			continue
+		}
+		var ticker models.SpotTickerWebSocket
+		if err := json.Unmarshal(message.Data, &ticker); err != nil {
+			log.Println("JSON unmarshal error: ", err)
+			continue
+		}
+		if price, err := strconv.ParseFloat(ticker.LastPrice, 64); err == nil {
+			r.mutex.Lock()
+			r.spotPrice = price
+			r.mutex.Unlock()
+		}
+	}
+}
+
+// update keeps the last price of a ticker event and answers a mark price event with the premium
+func (r *premiumRelay) update(message []byte) (interface{}, error) {
+	var event struct {
+		Stream string          `json:"stream"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message, &event); err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if strings.HasSuffix(event.Stream, "@ticker") {
+		var ticker models.SpotTickerWebSocket
+		if err := json.Unmarshal(event.Data, &ticker); err != nil {
+			return nil, err
+		}
+		r.lastPrice, _ = strconv.ParseFloat(ticker.LastPrice, 64)
+		return nil, nil
+	}
+
+	var markPrice models.MarkPriceWebsocket
+	if err := json.Unmarshal(event.Data, &markPrice); err != nil {
+		return nil, err
+	}
+	premiumIndex := markPrice.PremiumIndex()
+	premiumIndex.InterestRate = r.interestRate
+
+	var response models.ResponsePremium
+	response.UpdateData(premiumIndex, r.lastPrice, r.spotPrice, r.fundingIntervalHours,
+		utils.ConvertMillisecondsToTimestamp(premiumIndex.NextFundingTime),
+		utils.ConvertMillisecondsToTimestamp(premiumIndex.Time))
+	return response, nil
+}
@@ -0,0 +1,100 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockPremiumStream serves the given frames once then repeats the last one every 50ms
+func newMockPremiumStream(t *testing.T, path string, frames ...string) *httptest.Server {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, path, r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, frame := range frames {
+			conn.WriteMessage(websocket.TextMessage, []byte(frame))
+		}
+		for {
+			time.Sleep(50 * time.Millisecond)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(frames[len(frames)-1])); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPremiumSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/fundingInfo":
+			w.Write([]byte(`[{"symbol":"BNBUSDT","adjustedFundingRateCap":"0.02","adjustedFundingRateFloor":"-0.02","fundingIntervalHours":4}]`))
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BNBUSDT","markPrice":"600.10","indexPrice":"600.00","lastFundingRate":"0.0001","interestRate":"0.0001","nextFundingTime":1733904000000,"time":1733900000000}`))
+		case "/fapi/v1/ticker/24hr":
+			w.Write([]byte(`{"symbol":"BNBUSDT","lastPrice":"600.30","closeTime":1733900000000}`))
+		case "/api/v3/ticker/price":
+			w.Write([]byte(`{"symbol":"BNBUSDT","price":"600.00"}`))
+		}
+	}))
+	defer rest.Close()
+	provider.SetDefault(provider.NewBinanceProvider(rest.URL, rest.URL))
+	defer provider.SetDefault(nil)
+
+	spot := newMockPremiumStream(t, "/ws/bnbusdt@ticker", `{"e":"24hrTicker","E":1733900001000,"s":"BNBUSDT","c":"600.50"}`)
+	t.Setenv("BINANCE_SPOT_WS_URL", "ws"+strings.TrimPrefix(spot.URL, "http"))
+	futures := newMockPremiumStream(t, "/stream",
+		`{"stream":"bnbusdt@ticker","data":{"e":"24hrTicker","E":1733900001000,"s":"BNBUSDT","c":"601.00"}}`,
+		`{"stream":"bnbusdt@markPrice@1s","data":{"e":"markPriceUpdate","E":1733900001000,"s":"BNBUSDT","p":"600.90","i":"600.40","P":"600.45","r":"0.0002","T":1733904000000}}`)
+	t.Setenv("BINANCE_FUTURES_WS_URL", "ws"+strings.TrimPrefix(futures.URL, "http"))
+
+	router := gin.New()
+	router.GET("/premium", PremiumSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/premium?symbol=bnbusdt", nil)
+	assert.NoError(t, err)
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(6 * time.Second))
+
+	// the first frame comes from the REST API
+	var premium models.ResponsePremium
+	assert.NoError(t, c.ReadJSON(&premium))
+	assert.Equal(t, 600.1, premium.MarkPrice)
+	assert.Equal(t, 0.3, premium.Basis)
+	assert.Equal(t, 0.05, premium.BasisPercent)
+
+	// then every mark price event, once the spot stream is read
+	for i := 0; i < 50 && premium.SpotPrice != 600.5; i++ {
+		premium = models.ResponsePremium{}
+		assert.NoError(t, c.ReadJSON(&premium))
+	}
+	assert.Equal(t, "BNBUSDT", premium.Symbol)
+	assert.Equal(t, 600.9, premium.MarkPrice)
+	assert.Equal(t, 600.4, premium.IndexPrice)
+	assert.Equal(t, 601.0, premium.LastPrice)
+	assert.Equal(t, 600.5, premium.SpotPrice)
+	assert.Equal(t, 0.5, premium.Basis)
+	assert.Equal(t, 0.0002, premium.FundingRate)
+	assert.Equal(t, 0.0001, premium.InterestRate)
+	// the funding interval of the REST API, every 4 hours
+	assert.Equal(t, 4, premium.FundingIntervalHours)
+	assert.Equal(t, 43.8, premium.AnnualizedFundingRate)
+}
@@ -40,10 +40,20 @@ func NewMockSpotBinanceServer() *MockSpotBinanceServer {
 			"s": "BTCUSDT",                                       // symbol
 			"E": time.Now().UnixNano() / int64(time.Millisecond), // event time
 			"c": "30000.00",                                      // last price
+			"p": "-150.50",                                       // price change
+			"P": "-0.499",                                        // price change percent
+			"o": "30150.50",                                      // open price
+			"h": "30420.00",                                      // high price
+			"l": "29810.10",                                      // low price
+			"v": "1520.25",                                       // base asset volume
+			"q": "45700000.12",                                   // quote asset volume
 		}
 
-		mockJSON, _ := json.Marshal(mockData)
-		conn.WriteMessage(websocket.TextMessage, mockJSON)
+		// Binance keeps the connection open but sends nothing for an unknown symbol
+		if strings.Contains(r.URL.String(), "btcusdt") {
+			mockJSON, _ := json.Marshal(mockData)
+			conn.WriteMessage(websocket.TextMessage, mockJSON)
+		}
 
 		// Keep connection alive
 		for {
@@ -67,11 +77,12 @@ func setupSpotTest(t *testing.T) (*gin.Engine, *httptest.Server, *MockSpotBinanc
 
 	// Setup mock Binance server
 	mockBinance := NewMockSpotBinanceServer()
+	// Override the Binance WebSocket URL with our mock server
+	t.Setenv("BINANCE_SPOT_WS_URL", mockBinance.URL)
 
 	// Create a test router
 	router := gin.New()
 	router.GET("/ws", func(c *gin.Context) {
-		// Override the Binance WebSocket URL with our mock server
 		originalFunc := SpotPriceSocket
 		originalFunc(c)
 	})
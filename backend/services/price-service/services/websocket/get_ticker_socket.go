@@ -0,0 +1,59 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// TickerSocket streams the rolling 24 hour statistics of a symbol on a market, spot by default
+func TickerSocket(context *gin.Context) {
+	symbol := provider.NormalizeSymbol(context.Query("symbol"))
+	market, err := provider.NormalizeMarket(context.DefaultQuery("market", provider.MarketSpot))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	streamURL := spotPriceStreamURL(symbol)
+	if market == provider.MarketFutures {
+		streamURL = futuresTickerStreamURL(symbol)
+	}
+	relayStream(ws, streamURL, tickerMessage(market), nil)
+}
+
+// futuresTickerStreamURL returns the Binance futures ticker stream of a symbol,
+// the spot ticker stream is the one of the spot price
+func futuresTickerStreamURL(symbol string) string {
+	return fmt.Sprintf("%s/ws/%s@ticker", futuresStreamURL(), strings.ToLower(symbol))
+}
+
+// tickerMessage returns the format of the ticker stream events of a market
+func tickerMessage(market string) func(message []byte) (interface{}, error) {
+	return func(message []byte) (interface{}, error) {
+		var tickerResponse models.SpotTickerWebSocket
+		if err := json.Unmarshal(message, &tickerResponse); err != nil {
+			return nil, err
+		}
+
+		var response models.ResponseTicker24h
+		response.UpdateData(market, tickerResponse.Ticker24h(),
+			utils.ConvertMillisecondsToTimestamp(tickerResponse.OpenTime),
+			utils.ConvertMillisecondsToTimestamp(tickerResponse.EventTime))
+		return response, nil
+	}
+}
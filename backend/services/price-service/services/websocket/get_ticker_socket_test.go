@@ -0,0 +1,68 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTickerSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// the futures ticker event has the fields of the spot one, so both markets are served by the spot mock
+	mockSpot := NewMockSpotBinanceServer()
+	defer mockSpot.Close()
+	t.Setenv("BINANCE_SPOT_WS_URL", mockSpot.URL)
+	mockFutures := NewMockSpotBinanceServer()
+	defer mockFutures.Close()
+	t.Setenv("BINANCE_FUTURES_WS_URL", mockFutures.URL)
+
+	router := gin.New()
+	router.GET("/ticker", TickerSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedMarket string
+	}{
+		{"Spot by default", "symbol=btcusdt", "spot"},
+		{"Futures", "symbol=BTCUSDT&market=futures", "futures"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ticker?" + tt.queryParams
+			c, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+			assert.NoError(t, err)
+			defer c.Close()
+			c.SetReadDeadline(time.Now().Add(6 * time.Second))
+
+			var response map[string]interface{}
+			assert.NoError(t, c.ReadJSON(&response))
+			assert.Equal(t, "BTCUSDT", response["symbol"])
+			assert.Equal(t, tt.expectedMarket, response["market"])
+			assert.Equal(t, "30000.00", response["lastPrice"])
+			assert.Equal(t, "-150.50", response["priceChange"])
+			assert.Equal(t, "-0.499", response["priceChangePercent"])
+			assert.Equal(t, "30420.00", response["highPrice"])
+			assert.Equal(t, "29810.10", response["lowPrice"])
+			assert.Equal(t, "1520.25", response["volume"])
+			assert.Contains(t, response, "eventTime")
+		})
+	}
+
+	t.Run("Unknown market", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/ticker?symbol=BTCUSDT&market=options", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"message":"market options is not supported"}`, w.Body.String())
+	})
+}
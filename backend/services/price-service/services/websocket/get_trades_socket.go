@@ -0,0 +1,117 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/tape"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// tradeSeedLimit is how many recent trades fill the windows of a new client
+const tradeSeedLimit = 1000
+
+// TradesSocket streams every aggregated trade of a symbol on a market, spot by default, flagged when
+// its quote quantity reaches largeTrade and sent with the VWAP and buy and sell volumes of each window.
+// The windows start from the recent trades of the REST API, as far back as they go.
+func TradesSocket(context *gin.Context) {
+	symbol := provider.NormalizeSymbol(context.Query("symbol"))
+	market, err := provider.NormalizeMarket(context.DefaultQuery("market", provider.MarketSpot))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	windows, err := tape.ParseWindows(context.Query("windows"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+	largeTrade, err := tape.ParseLargeTrade(context.Query("largeTrade"))
+	if err != nil {
+		utils.ShowError(http.StatusBadRequest, err.Error(), context)
+		return
+	}
+
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	relay := &tradeRelay{market: market, symbol: symbol, tape: tape.New(windows, largeTrade)}
+	relayStream(ws, AggTradeStreamURL(symbol, market), relay.update, relay.seed)
+}
+
+// AggTradeStreamURL returns the Binance aggregated trade stream of a symbol on the spot or futures market
+func AggTradeStreamURL(symbol, market string) string {
+	baseURL := spotStreamURL()
+	if market == provider.MarketFutures {
+		baseURL = futuresStreamURL()
+	}
+	return fmt.Sprintf("%s/ws/%s@aggTrade", baseURL, strings.ToLower(symbol))
+}
+
+// tradeRelay keeps the tape of one client
+type tradeRelay struct {
+	market string
+	symbol string
+	tape   *tape.Tape
+	// last is the last trade added to the tape, the stream repeats the trades of the seed
+	last *models.Trade
+}
+
+// seed fills the windows with the recent trades, it sends nothing
+func (r *tradeRelay) seed() []interface{} {
+	trades, _, err := provider.Default().Trades(r.market, r.symbol, tradeSeedLimit)
+	if err != nil {
+		log.Println("Trade seed error: ", err)
+		return nil
+	}
+	for _, trade := range trades {
+		r.add(trade)
+	}
+	return nil
+}
+
+// update adds the trade of an aggTrade event and returns it with the statistics of every window
+func (r *tradeRelay) update(message []byte) (interface{}, error) {
+	var event models.AggTradeWebsocket
+	if err := json.Unmarshal(message, &event); err != nil {
+		return nil, err
+	}
+	trade := event.Trade()
+	if r.last != nil && !trade.After(r.last) {
+		return nil, nil
+	}
+	r.add(trade)
+
+	frame := models.ResponseTradeFrame{Symbol: event.Symbol, Market: r.market, Stats: r.tape.Stats()}
+	frame.Trade.UpdateData(&trade, r.tape.IsLarge(&trade), utils.ConvertMillisecondsToTimestamp(trade.Time))
+	return frame, nil
+}
+
+func (r *tradeRelay) add(trade models.Trade) {
+	r.tape.Add(trade)
+	r.last = &trade
+}
+
+// tradeChannelFormat returns the format of one subscription of the trades channel, on the spot
+// market with the default windows and large trade threshold
+func tradeChannelFormat(symbol string) func(message []byte) (interface{}, error) {
+	windows, _ := tape.ParseWindows(tape.DefaultWindows)
+	relay := &tradeRelay{market: provider.MarketSpot, symbol: symbol, tape: tape.New(windows, tape.DefaultLargeTrade)}
+	relay.seed()
+	return relay.update
+}
+
+// tradeChannelStreamURL returns the spot aggregated trade stream of the trades channel
+func tradeChannelStreamURL(symbol string) string {
+	return AggTradeStreamURL(symbol, provider.MarketSpot)
+}
@@ -0,0 +1,98 @@
+package websocket
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTradesSocket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/fapi/v1/aggTrades", r.URL.Path)
+		assert.Equal(t, "1000", r.URL.Query().Get("limit"))
+		w.Write([]byte(`[{"a":100,"p":"100","q":"10","f":1,"l":1,"T":1700000000000,"m":false},{"a":101,"p":"110","q":"20","f":2,"l":2,"T":1700000050000,"m":true}]`))
+	}))
+	defer rest.Close()
+	provider.SetDefault(provider.NewBinanceProvider(rest.URL, rest.URL))
+	defer provider.SetDefault(nil)
+
+	// the stream repeats the last trade of the seed before a new one
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	stream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ws/ethusdt@aggTrade", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for _, trade := range []string{`"a":101,"p":"110","q":"20","T":1700000050000,"m":true`, `"a":102,"p":"120","q":"30","T":1700000055000,"m":false`} {
+			conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"e":"aggTrade","E":1700000080000,"s":"ETHUSDT",%s}`, trade)))
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer stream.Close()
+	t.Setenv("BINANCE_FUTURES_WS_URL", "ws"+strings.TrimPrefix(stream.URL, "http"))
+
+	router := gin.New()
+	router.GET("/trades", TradesSocket)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/trades?symbol=ETHUSDT&market=futures&windows=30s,1m&largeTrade=3000", nil)
+	assert.NoError(t, err)
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(6 * time.Second))
+
+	var frame models.ResponseTradeFrame
+	assert.NoError(t, c.ReadJSON(&frame))
+	assert.Equal(t, "ETHUSDT", frame.Symbol)
+	assert.Equal(t, provider.MarketFutures, frame.Market)
+	assert.Equal(t, "102", frame.Trade.ID)
+	assert.Equal(t, models.SideBuy, frame.Trade.Side)
+	assert.Equal(t, 3600.0, frame.Trade.QuoteQuantity)
+	assert.True(t, frame.Trade.IsLarge)
+	assert.Equal(t, []models.TradeWindowStats{
+		{Window: "30s", VWAP: 116, Volume: 50, QuoteVolume: 5800, BuyVolume: 30, SellVolume: 20, TradeCount: 2, LargeTradeCount: 1},
+		{Window: "1m", VWAP: 113.33333333333333, Volume: 60, QuoteVolume: 6800, BuyVolume: 40, SellVolume: 20, TradeCount: 3, LargeTradeCount: 1},
+	}, frame.Stats)
+}
+
+func TestTradesSocketParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/trades", TradesSocket)
+
+	tests := []struct {
+		name            string
+		queryParams     string
+		expectedMessage string
+	}{
+		{"Unknown market", "symbol=BTCUSDT&market=options", "market options is not supported"},
+		{"Invalid window", "symbol=BTCUSDT&windows=0s", "Invalid window 0s, windows go from 1s to 1h0m0s"},
+		{"Invalid large trade", "symbol=BTCUSDT&largeTrade=big", "Invalid largeTrade big"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/trades?"+tt.queryParams, nil))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, fmt.Sprintf(`{"message":%q}`, tt.expectedMessage), w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package websocket
+
+import (
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	DefaultBinanceSpotStreamURL    = "wss://stream.binance.com"
+	DefaultBinanceFuturesStreamURL = "wss://fstream.binance.com"
+
+	// StatusReconnecting is sent to subscribers when the upstream connection is lost and dialed again
+	StatusReconnecting = "reconnecting"
+	// StatusConnected is sent to subscribers once the upstream connection is back
+	StatusConnected = "connected"
+
+	// subscriberBuffer is how many upstream messages a client may lag behind before it is dropped
+	subscriberBuffer = 64
+)
+
+// DefaultHub is shared by every socket handler of price-service
+var DefaultHub = NewHub()
+
+// Hub keeps one upstream connection per stream URL and fans its messages out to every subscriber.
+// The upstream connection is opened by the first subscriber, dialed again with exponential backoff
+// when it drops and closed when the last subscriber leaves.
+type Hub struct {
+	// Dialer opens upstream connections, websocket.DefaultDialer when nil
+	Dialer *websocket.Dialer
+	// MinBackoff and MaxBackoff bound the wait between two failed dials
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// MaxAge renews an upstream connection before Binance drops it, Binance closes them after 24 hours
+	MaxAge time.Duration
+
+	mutex   sync.Mutex
+	streams map[string]*hubStream
+}
+
+type hubStream struct {
+	url  string
+	conn *websocket.Conn
+	// degraded is set while the upstream connection is being dialed again
+	degraded    bool
+	closed      bool
+	done        chan struct{}
+	subscribers map[*Subscription]struct{}
+}
+
+// Message is one upstream message, or a change of the upstream connection when Status is set
+type Message struct {
+	Data   []byte
+	Status string
+}
+
+// Subscription receives the messages of one upstream stream.
+// C is closed when the subscriber is too slow to keep up.
+type Subscription struct {
+	C <-chan Message
+
+	hub     *Hub
+	stream  *hubStream
+	channel chan Message
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		MinBackoff: time.Second,
+		MaxBackoff: 30 * time.Second,
+		MaxAge:     23*time.Hour + 30*time.Minute,
+		streams:    map[string]*hubStream{},
+	}
+}
+
+// Subscribe joins the stream at url, dialing it in the background if nobody listens to it yet
+func (h *Hub) Subscribe(url string) *Subscription {
+	channel := make(chan Message, subscriberBuffer)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	stream, ok := h.streams[url]
+	if !ok {
+		stream = &hubStream{url: url, done: make(chan struct{}), subscribers: map[*Subscription]struct{}{}}
+		h.streams[url] = stream
+		go h.run(stream)
+	}
+	if stream.degraded {
+		channel <- Message{Status: StatusReconnecting}
+	}
+	subscription := &Subscription{C: channel, hub: h, stream: stream, channel: channel}
+	stream.subscribers[subscription] = struct{}{}
+	return subscription
+}
+
+// Close leaves the stream, the upstream connection is closed with its last subscriber
+func (s *Subscription) Close() {
+	s.hub.mutex.Lock()
+	defer s.hub.mutex.Unlock()
+	s.hub.remove(s)
+}
+
+// Subscribers returns how many clients listen to the stream at url
+func (h *Hub) Subscribers(url string) int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if stream, ok := h.streams[url]; ok {
+		return len(stream.subscribers)
+	}
+	return 0
+}
+
+// run dials the stream until its last subscriber leaves. Dialing the same URL again subscribes
+// to the same Binance streams, so nothing has to be replayed after a reconnect.
+func (h *Hub) run(stream *hubStream) {
+	dialer := h.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+
+	backoff := h.MinBackoff
+	for {
+		conn, _, err := dialer.Dial(stream.url, nil)
+		if err != nil {
+			log.Println("Connection error: ", err)
+			if !h.setStatus(stream, StatusReconnecting) || !h.wait(stream, backoff) {
+				return
+			}
+			backoff *= 2
+			if backoff > h.MaxBackoff {
+				backoff = h.MaxBackoff
+			}
+			continue
+		}
+
+		if !h.attach(stream, conn) {
+			// every subscriber left while dialing
+			conn.Close()
+			return
+		}
+		if !h.setStatus(stream, StatusConnected) {
+			return
+		}
+
+		expired, received, err := h.read(stream, conn)
+		if !h.setStatus(stream, "") {
+			return
+		}
+		if expired {
+			log.Println("Renewing upstream connection of ", stream.url)
+			continue
+		}
+		log.Println("Read error: ", err)
+		if received {
+			backoff = h.MinBackoff
+		}
+		// a connection that drops before its first message backs off like a failed dial
+		if !h.setStatus(stream, StatusReconnecting) || !h.wait(stream, backoff) {
+			return
+		}
+		if !received {
+			backoff *= 2
+			if backoff > h.MaxBackoff {
+				backoff = h.MaxBackoff
+			}
+		}
+	}
+}
+
+// read broadcasts the messages of conn until it fails or reaches MaxAge, which reports expired.
+// received tells whether any message came through.
+func (h *Hub) read(stream *hubStream, conn *websocket.Conn) (expired bool, received bool, err error) {
+	done := make(chan struct{})
+	defer close(done)
+	keepAlive(conn, done)
+
+	var maxAgeReached atomic.Bool
+	timer := time.AfterFunc(h.MaxAge, func() {
+		maxAgeReached.Store(true)
+		conn.Close()
+	})
+	defer timer.Stop()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			conn.Close()
+			return maxAgeReached.Load(), received, err
+		}
+		received = true
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		h.broadcast(stream, Message{Data: message})
+	}
+}
+
+func (h *Hub) attach(stream *hubStream, conn *websocket.Conn) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if stream.closed {
+		return false
+	}
+	stream.conn = conn
+	return true
+}
+
+// setStatus records the upstream state of the stream and tells the subscribers when it degrades or
+// recovers, an empty status only detaches the connection. It returns false once the stream is closed.
+func (h *Hub) setStatus(stream *hubStream, status string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if stream.closed {
+		return false
+	}
+
+	switch status {
+	case "":
+		stream.conn = nil
+		return true
+	case StatusReconnecting:
+		if stream.degraded {
+			return true
+		}
+		stream.degraded = true
+	case StatusConnected:
+		if !stream.degraded {
+			return true
+		}
+		stream.degraded = false
+	}
+	h.deliver(stream, Message{Status: status})
+	return !stream.closed
+}
+
+// wait sleeps for d and returns false when the stream is closed meanwhile
+func (h *Hub) wait(stream *hubStream, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stream.done:
+		return false
+	}
+}
+
+func (h *Hub) broadcast(stream *hubStream, message Message) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.deliver(stream, message)
+}
+
+// deliver never blocks on a client, a subscriber whose buffer is full is dropped.
+// It must be called with the mutex held.
+func (h *Hub) deliver(stream *hubStream, message Message) {
+	for subscription := range stream.subscribers {
+		select {
+		case subscription.channel <- message:
+		default:
+			log.Println("Dropping slow subscriber of ", stream.url)
+			h.remove(subscription)
+		}
+	}
+}
+
+// remove must be called with the mutex held
+func (h *Hub) remove(subscription *Subscription) {
+	stream := subscription.stream
+	if _, ok := stream.subscribers[subscription]; !ok {
+		return
+	}
+	delete(stream.subscribers, subscription)
+	close(subscription.channel)
+	if len(stream.subscribers) == 0 {
+		h.teardown(stream)
+	}
+}
+
+// teardown must be called with the mutex held
+func (h *Hub) teardown(stream *hubStream) {
+	if stream.closed {
+		return
+	}
+	stream.closed = true
+	close(stream.done)
+	if h.streams[stream.url] == stream {
+		delete(h.streams, stream.url)
+	}
+	if stream.conn != nil {
+		stream.conn.Close()
+	}
+}
+
+// spotStreamURL returns the Binance spot stream base URL, BINANCE_SPOT_WS_URL when it is set
+func spotStreamURL() string {
+	return baseURLFromEnv("BINANCE_SPOT_WS_URL", DefaultBinanceSpotStreamURL)
+}
+
+// futuresStreamURL returns the Binance futures stream base URL, BINANCE_FUTURES_WS_URL when it is set
+func futuresStreamURL() string {
+	return baseURLFromEnv("BINANCE_FUTURES_WS_URL", DefaultBinanceFuturesStreamURL)
+}
+
+func baseURLFromEnv(key, fallback string) string {
+	baseURL := os.Getenv(key)
+	if baseURL == "" {
+		baseURL = fallback
+	}
+	return strings.TrimRight(baseURL, "/")
+}
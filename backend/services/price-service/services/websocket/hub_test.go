@@ -0,0 +1,192 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockUpstream hands every accepted connection to the test so it can write to or drop it
+type mockUpstream struct {
+	URL         string
+	connections int32
+	open        int32
+	conns       chan *websocket.Conn
+}
+
+func newMockUpstream(t *testing.T) *mockUpstream {
+	upstream := &mockUpstream{conns: make(chan *websocket.Conn, 16)}
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		atomic.AddInt32(&upstream.connections, 1)
+		atomic.AddInt32(&upstream.open, 1)
+		defer atomic.AddInt32(&upstream.open, -1)
+		upstream.conns <- conn
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	upstream.URL = "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/btcusdt@ticker"
+	return upstream
+}
+
+func (u *mockUpstream) accept(t *testing.T) *websocket.Conn {
+	select {
+	case conn := <-u.conns:
+		return conn
+	case <-time.After(2 * time.Second):
+		t.Fatal("Test timed out")
+		return nil
+	}
+}
+
+func receive(t *testing.T, subscription *Subscription) (Message, bool) {
+	select {
+	case message, ok := <-subscription.C:
+		return message, ok
+	case <-time.After(2 * time.Second):
+		t.Fatal("Test timed out")
+		return Message{}, false
+	}
+}
+
+func newTestHub() *Hub {
+	hub := NewHub()
+	hub.MinBackoff = 10 * time.Millisecond
+	hub.MaxBackoff = 50 * time.Millisecond
+	return hub
+}
+
+func TestHubFanOut(t *testing.T) {
+	upstream := newMockUpstream(t)
+	hub := newTestHub()
+
+	first := hub.Subscribe(upstream.URL)
+	second := hub.Subscribe(upstream.URL)
+	assert.Equal(t, 2, hub.Subscribers(upstream.URL))
+
+	upstream.accept(t).WriteMessage(websocket.TextMessage, []byte("tick"))
+
+	message, ok := receive(t, first)
+	assert.True(t, ok)
+	assert.Equal(t, Message{Data: []byte("tick")}, message)
+	message, ok = receive(t, second)
+	assert.True(t, ok)
+	assert.Equal(t, Message{Data: []byte("tick")}, message)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.connections))
+
+	// the upstream connection stays open until the last subscriber leaves
+	first.Close()
+	first.Close()
+	assert.Equal(t, 1, hub.Subscribers(upstream.URL))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.open))
+
+	second.Close()
+	assert.Equal(t, 0, hub.Subscribers(upstream.URL))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&upstream.open) == 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// a new subscriber opens a new upstream connection
+	third := hub.Subscribe(upstream.URL)
+	defer third.Close()
+	upstream.accept(t)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&upstream.connections))
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	upstream := newMockUpstream(t)
+	hub := newTestHub()
+
+	slow := hub.Subscribe(upstream.URL)
+	fast := hub.Subscribe(upstream.URL)
+	defer fast.Close()
+	conn := upstream.accept(t)
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		conn.WriteMessage(websocket.TextMessage, []byte("tick"))
+		_, ok := receive(t, fast)
+		assert.True(t, ok)
+	}
+
+	assert.Eventually(t, func() bool { return hub.Subscribers(upstream.URL) == 1 }, 2*time.Second, 10*time.Millisecond)
+	for range slow.C {
+	}
+	slow.Close()
+	assert.Equal(t, 1, hub.Subscribers(upstream.URL))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&upstream.open))
+}
+
+func TestHubReconnect(t *testing.T) {
+	upstream := newMockUpstream(t)
+	hub := newTestHub()
+
+	subscription := hub.Subscribe(upstream.URL)
+	defer subscription.Close()
+
+	conn := upstream.accept(t)
+	conn.WriteMessage(websocket.TextMessage, []byte("before"))
+	message, _ := receive(t, subscription)
+	assert.Equal(t, Message{Data: []byte("before")}, message)
+
+	// Binance drops the connection
+	conn.Close()
+	message, _ = receive(t, subscription)
+	assert.Equal(t, Message{Status: StatusReconnecting}, message)
+	message, _ = receive(t, subscription)
+	assert.Equal(t, Message{Status: StatusConnected}, message)
+
+	upstream.accept(t).WriteMessage(websocket.TextMessage, []byte("after"))
+	message, _ = receive(t, subscription)
+	assert.Equal(t, Message{Data: []byte("after")}, message)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&upstream.connections))
+}
+
+func TestHubDialError(t *testing.T) {
+	url := "ws://127.0.0.1:1/ws/btcusdt@ticker"
+	hub := newTestHub()
+
+	first := hub.Subscribe(url)
+	message, ok := receive(t, first)
+	assert.True(t, ok)
+	assert.Equal(t, Message{Status: StatusReconnecting}, message)
+
+	// the stream keeps its subscribers while dialing again, a new one learns it is degraded
+	second := hub.Subscribe(url)
+	message, _ = receive(t, second)
+	assert.Equal(t, Message{Status: StatusReconnecting}, message)
+	assert.Equal(t, 2, hub.Subscribers(url))
+
+	first.Close()
+	second.Close()
+	assert.Equal(t, 0, hub.Subscribers(url))
+}
+
+func TestHubMaxAge(t *testing.T) {
+	upstream := newMockUpstream(t)
+	hub := newTestHub()
+	hub.MaxAge = 100 * time.Millisecond
+
+	subscription := hub.Subscribe(upstream.URL)
+	defer subscription.Close()
+	upstream.accept(t)
+
+	// the connection is renewed without telling the subscribers
+	upstream.accept(t).WriteMessage(websocket.TextMessage, []byte("renewed"))
+	message, _ := receive(t, subscription)
+	assert.Equal(t, Message{Data: []byte("renewed")}, message)
+}
@@ -0,0 +1,120 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/utils"
+	"github.com/gorilla/websocket"
+)
+
+// symbolTimeout closes a client whose stream sent nothing for this long, Binance is silent on unknown symbols
+const symbolTimeout = 5 * time.Second
+
+// statusMessages explain the upstream status frames sent to clients
+var statusMessages = map[string]string{
+	StatusReconnecting: "Upstream feed is degraded, reconnecting",
+	StatusConnected:    "Upstream feed recovered",
+}
+
+// relayStream subscribes a client to the upstream stream at url through the hub and writes every
+// message as returned by format, which returns nil for a message that sends nothing, until the
+// client disconnects or the stream ends. The frames of seed,
+// when it is not nil, are written first, once subscribed so that no update falls in between.
+func relayStream(ws *websocket.Conn, url string, format func(message []byte) (interface{}, error), seed func() []interface{}) {
+	subscription := DefaultHub.Subscribe(url)
+	defer subscription.Close()
+
+	if seed != nil {
+		for _, frame := range seed() {
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := ws.WriteJSON(frame); err != nil {
+				log.Println("Write error to client: ", err)
+				return
+			}
+		}
+	}
+
+	// handle error with websocket
+	disconnected := make(chan struct{})
+	keepAlive(ws, disconnected)
+	go func() {
+		defer close(disconnected)
+		for {
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				log.Println("Error reading message: ", err)
+				return
+			}
+			ws.SetReadDeadline(time.Now().Add(pongWait))
+			if string(msg) == "disconnect" {
+				log.Println("Disconnecting from WebSocket")
+				return
+			}
+		}
+	}()
+
+	// handle symbol error
+	timeout := time.NewTimer(symbolTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+
+		case <-timeout.C:
+			errorMSG := "Symbol error"
+			ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseProtocolError, errorMSG))
+			return
+
+		case message, ok := <-subscription.C:
+			if !ok {
+				// this client fell too far behind
+				errorMSG := "Stream closed, please reconnect"
+				ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, errorMSG))
+				return
+			}
+			if message.Status != "" {
+				if message.Status == StatusReconnecting {
+					// the silence while reconnecting is not a symbol error
+					timeout.Stop()
+				} else {
+					timeout.Reset(symbolTimeout)
+				}
+				ws.SetWriteDeadline(time.Now().Add(writeWait))
+				if err := ws.WriteJSON(models.StreamStatus{Status: message.Status, Message: statusMessages[message.Status]}); err != nil {
+					log.Println("Write error to client: ", err)
+					return
+				}
+				continue
+			}
+			timeout.Reset(symbolTimeout)
+
+			response, err := format(message.Data)
+			if err != nil {
+				log.Println("JSON unmarshal error: ", err)
+				continue
+			}
+			if response == nil {
+				continue
+			}
+
+			responseJSON, err := json.Marshal(&response)
+			if err != nil {
+				errorMsg := fmt.Sprintf("JSON marshal error: %s", err.Error())
+				utils.ShowErrorSocket(ws, errorMsg)
+				continue
+			}
+
+			ws.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := ws.WriteMessage(websocket.TextMessage, responseJSON); err != nil {
+				log.Println("Write error to client: ", err)
+				return
+			}
+		}
+	}
+}
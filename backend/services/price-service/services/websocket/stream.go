@@ -0,0 +1,414 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/admin_service/middlewares"
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/arbitrage"
+	marketcap "github.com/dath-241/coin-price-be-go/services/price-service/services/market_cap"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	ChannelSpot          = "spot"
+	ChannelFutures       = "futures"
+	ChannelTicker        = "ticker"
+	ChannelFuturesTicker = "futures-ticker"
+	ChannelTrades        = "trades"
+	ChannelKline         = "kline"
+	ChannelFunding       = "funding"
+	ChannelMarketCap     = "market-cap"
+	ChannelOpenInterest  = "open-interest"
+	ChannelArbitrage     = "arbitrage"
+
+	OpSubscribe   = "subscribe"
+	OpUnsubscribe = "unsubscribe"
+
+	// maxStreamSubscriptions bounds the channel and symbol pairs of one connection
+	maxStreamSubscriptions = 50
+)
+
+// streamChannel describes where a channel of the multiplexed stream reads its data
+type streamChannel struct {
+	// streamURL returns the upstream Binance stream of a symbol, nil for polled channels
+	streamURL func(symbol string) string
+	// poll sends the updates of a polled channel until stop is closed
+	poll   func(s *streamSession, name, symbol string, stop chan struct{})
+	format func(message []byte) (interface{}, error)
+	// newFormat, when set, builds the format of each subscription for channels that keep state
+	newFormat func(symbol string) func(message []byte) (interface{}, error)
+	// vip channels need a VIP-1, VIP-2 or VIP-3 token in the Authorization header
+	vip bool
+}
+
+var streamChannels = map[string]streamChannel{
+	ChannelSpot:          {streamURL: spotPriceStreamURL, format: spotPriceMessage},
+	ChannelFutures:       {streamURL: futurePriceStreamURL, format: futurePriceMessage},
+	ChannelTicker:        {streamURL: spotPriceStreamURL, format: tickerMessage(provider.MarketSpot)},
+	ChannelFuturesTicker: {streamURL: futuresTickerStreamURL, format: tickerMessage(provider.MarketFutures)},
+	ChannelTrades:        {streamURL: tradeChannelStreamURL, newFormat: tradeChannelFormat},
+	ChannelKline:         {streamURL: klineSecondStreamURL, format: klineMessage, vip: true},
+	ChannelFunding:       {streamURL: fundingRateStreamURL, format: fundingRateMessage},
+	ChannelMarketCap:     {poll: (*streamSession).pollMarketCap},
+	ChannelOpenInterest:  {poll: (*streamSession).pollOpenInterest},
+	ChannelArbitrage:     {poll: (*streamSession).pollArbitrage},
+}
+
+// streamSession is one client of the multiplexed stream
+type streamSession struct {
+	ws  *websocket.Conn
+	vip bool
+
+	writeMutex sync.Mutex
+
+	mutex sync.Mutex
+	// subscriptions maps channel:symbol to the channel closed on unsubscribe
+	subscriptions map[string]chan struct{}
+}
+
+// StreamSocket serves every channel on one connection. The client sends
+// {"op":"subscribe","channel":"spot","symbols":["BTCUSDT"]} or "unsubscribe" frames,
+// each frame is answered with an ack and every update comes as {"channel","symbol","data"}.
+func StreamSocket(context *gin.Context) {
+	ws, err := Upgrade(context.Writer, context.Request)
+	if err != nil {
+		log.Println("Upgrade error: ", err)
+		return
+	}
+	defer ws.Close()
+
+	session := &streamSession{
+		ws:            ws,
+		vip:           isVIP(context.GetHeader("Authorization")),
+		subscriptions: map[string]chan struct{}{},
+	}
+	defer session.unsubscribeAll()
+
+	done := make(chan struct{})
+	defer close(done)
+	keepAlive(ws, done)
+
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil {
+			log.Println("Error reading message: ", err)
+			return
+		}
+		ws.SetReadDeadline(time.Now().Add(pongWait))
+		if string(msg) == "disconnect" {
+			log.Println("Disconnecting from WebSocket")
+			return
+		}
+
+		var request models.StreamRequest
+		if err := json.Unmarshal(msg, &request); err != nil {
+			session.write(models.StreamAck{Error: "Invalid request"})
+			continue
+		}
+		session.handle(request)
+	}
+}
+
+func (s *streamSession) handle(request models.StreamRequest) {
+	ack := models.StreamAck{Op: request.Op, Channel: request.Channel, Symbols: request.Symbols}
+	channel, ok := streamChannels[request.Channel]
+
+	switch {
+	case request.Op != OpSubscribe && request.Op != OpUnsubscribe:
+		ack.Error = fmt.Sprintf("Unknown op %q", request.Op)
+	case !ok:
+		ack.Error = fmt.Sprintf("Unknown channel %q", request.Channel)
+	case len(request.Symbols) == 0:
+		ack.Error = "Missing symbols"
+	case request.Op == OpSubscribe && channel.vip && !s.vip:
+		ack.Error = fmt.Sprintf("Channel %s requires VIP-1 or above", request.Channel)
+	}
+	if ack.Error != "" {
+		s.write(ack)
+		return
+	}
+
+	ack.Symbols = make([]string, 0, len(request.Symbols))
+	for _, symbol := range request.Symbols {
+		if request.Channel == ChannelMarketCap {
+			// market-cap symbols are CoinGecko ids, tickers or names, resolved when the coin is polled
+			ack.Symbols = append(ack.Symbols, strings.ToLower(strings.TrimSpace(symbol)))
+		} else {
+			ack.Symbols = append(ack.Symbols, provider.NormalizeSymbol(symbol))
+		}
+	}
+
+	if request.Op == OpUnsubscribe {
+		s.unsubscribe(request.Channel, ack.Symbols)
+		ack.Success = true
+		s.write(ack)
+		return
+	}
+
+	started, err := s.subscribe(request.Channel, ack.Symbols)
+	if err != nil {
+		ack.Error = err.Error()
+		s.write(ack)
+		return
+	}
+	ack.Success = true
+	// the ack goes out before the first update of the new subscriptions
+	s.write(ack)
+	for symbol, stop := range started {
+		if channel.poll != nil {
+			go channel.poll(s, request.Channel, symbol, stop)
+		} else {
+			go s.relay(request.Channel, channel, symbol, stop)
+		}
+	}
+}
+
+// subscribe registers the symbols that are not subscribed yet and returns them with their stop channel,
+// nothing is registered when the connection would exceed maxStreamSubscriptions
+func (s *streamSession) subscribe(channel string, symbols []string) (map[string]chan struct{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	started := map[string]chan struct{}{}
+	for _, symbol := range symbols {
+		if _, ok := s.subscriptions[streamKey(channel, symbol)]; !ok {
+			started[symbol] = make(chan struct{})
+		}
+	}
+	if len(s.subscriptions)+len(started) > maxStreamSubscriptions {
+		return nil, fmt.Errorf("At most %d subscriptions per connection", maxStreamSubscriptions)
+	}
+	for symbol, stop := range started {
+		s.subscriptions[streamKey(channel, symbol)] = stop
+	}
+	return started, nil
+}
+
+func (s *streamSession) unsubscribe(channel string, symbols []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, symbol := range symbols {
+		key := streamKey(channel, symbol)
+		if stop, ok := s.subscriptions[key]; ok {
+			close(stop)
+			delete(s.subscriptions, key)
+		}
+	}
+}
+
+func (s *streamSession) unsubscribeAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for key, stop := range s.subscriptions {
+		close(stop)
+		delete(s.subscriptions, key)
+	}
+}
+
+// end drops a subscription that failed and tells the client why, unless it was unsubscribed meanwhile
+func (s *streamSession) end(channel, symbol string, stop chan struct{}, reason string) {
+	key := streamKey(channel, symbol)
+	s.mutex.Lock()
+	current, ok := s.subscriptions[key]
+	if ok && current == stop {
+		delete(s.subscriptions, key)
+	}
+	s.mutex.Unlock()
+
+	if ok && current == stop {
+		s.write(models.StreamMessage{Channel: channel, Symbol: symbol, Error: reason})
+	}
+}
+
+// relay forwards one upstream Binance stream through the hub until stop is closed
+func (s *streamSession) relay(name string, channel streamChannel, symbol string, stop chan struct{}) {
+	subscription := DefaultHub.Subscribe(channel.streamURL(symbol))
+	defer subscription.Close()
+
+	format := channel.format
+	if channel.newFormat != nil {
+		format = channel.newFormat(symbol)
+	}
+
+	// handle symbol error
+	timeout := time.NewTimer(symbolTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+
+		case <-timeout.C:
+			s.end(name, symbol, stop, "Symbol error")
+			return
+
+		case message, ok := <-subscription.C:
+			if !ok {
+				// this client fell too far behind
+				s.end(name, symbol, stop, "Stream closed, please subscribe again")
+				return
+			}
+			if message.Status != "" {
+				if message.Status == StatusReconnecting {
+					// the silence while reconnecting is not a symbol error
+					timeout.Stop()
+				} else {
+					timeout.Reset(symbolTimeout)
+				}
+				s.write(models.StreamMessage{Channel: name, Symbol: symbol, Status: message.Status})
+				continue
+			}
+			timeout.Reset(symbolTimeout)
+
+			data, err := format(message.Data)
+			if err != nil {
+				log.Println("JSON unmarshal error: ", err)
+				continue
+			}
+			if data == nil {
+				continue
+			}
+			s.write(models.StreamMessage{Channel: name, Symbol: symbol, Data: data})
+		}
+	}
+}
+
+// pollMarketCap sends the CoinGecko market cap of a coin on every update of the shared poller until stop is closed
+func (s *streamSession) pollMarketCap(name, symbol string, stop chan struct{}) {
+	coinID, statusCode, err := marketcap.DefaultResolver.Resolve(symbol)
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		s.end(name, symbol, stop, "Rate limit, please wait.")
+		return
+	case err != nil:
+		s.end(name, symbol, stop, "Symbol missing or invalid")
+		return
+	}
+
+	subscription := marketcap.DefaultPoller.Subscribe(coinID)
+	defer subscription.Close()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case update := <-subscription.C:
+			switch {
+			case update.StatusCode == http.StatusTooManyRequests:
+				// the poller tries again sooner
+			case update.StatusCode != 0 && update.StatusCode != http.StatusOK:
+				s.end(name, symbol, stop, "Symbol missing or invalid")
+				return
+			case update.Err == nil:
+				s.write(models.StreamMessage{Channel: name, Symbol: symbol, Data: update.Data})
+			}
+		}
+	}
+}
+
+// pollOpenInterest sends the Binance open interest of a symbol every openInterestInterval until stop is closed
+func (s *streamSession) pollOpenInterest(name, symbol string, stop chan struct{}) {
+	ticker := time.NewTicker(openInterestInterval)
+	defer ticker.Stop()
+
+	poll := &openInterestPoll{marketData: provider.Default(), symbol: symbol}
+	for {
+		data, statusCode, err := poll.next()
+		switch {
+		case err != nil && statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+			s.end(name, symbol, stop, "Symbol error")
+			return
+		case err != nil:
+			log.Println(err)
+		default:
+			s.write(models.StreamMessage{Channel: name, Symbol: symbol, Data: data})
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollArbitrage quotes a spot asset, the symbol of the subscription, across the exchanges of
+// the arbitrage config every arbitrageInterval until stop is closed
+func (s *streamSession) pollArbitrage(name, symbol string, stop chan struct{}) {
+	ticker := time.NewTicker(arbitrageInterval)
+	defer ticker.Stop()
+
+	query, err := arbitrage.NewQuery(symbol, provider.MarketSpot)
+	if err != nil {
+		s.end(name, symbol, stop, "Symbol error")
+		return
+	}
+	for {
+		data, statusCode, err := arbitrage.GetArbitrageData(query)
+		switch {
+		case err != nil && statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+			s.end(name, symbol, stop, "Symbol error")
+			return
+		case err != nil:
+			log.Println(err)
+		default:
+			s.write(models.StreamMessage{Channel: name, Symbol: symbol, Data: data})
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// write sends a frame to the client, a client that cannot be written to is closed
+func (s *streamSession) write(frame interface{}) {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	s.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := s.ws.WriteJSON(frame); err != nil {
+		log.Println("Write error to client: ", err)
+		s.ws.Close()
+	}
+}
+
+func streamKey(channel, symbol string) string {
+	return channel + ":" + symbol
+}
+
+// isVIP tells whether the Authorization header holds a valid VIP token
+func isVIP(tokenString string) bool {
+	if tokenString == "" {
+		return false
+	}
+
+	middlewares.BlacklistedTokensMutex.Lock()
+	expTime, found := middlewares.BlacklistedTokens[tokenString]
+	middlewares.BlacklistedTokensMutex.Unlock()
+	if found && time.Now().Before(expTime) {
+		return false
+	}
+
+	claims, err := middlewares.VerifyJWT(tokenString)
+	if err != nil {
+		return false
+	}
+	switch claims.Role {
+	case "VIP-1", "VIP-2", "VIP-3":
+		return true
+	}
+	return false
+}
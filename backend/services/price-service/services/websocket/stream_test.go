@@ -0,0 +1,160 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dath-241/coin-price-be-go/services/admin_service/middlewares"
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupStreamTest serves StreamSocket with Binance and CoinGecko replaced by mock servers
+func setupStreamTest(t *testing.T) string {
+	gin.SetMode(gin.TestMode)
+
+	mockBinance := NewMockSpotBinanceServer()
+	t.Cleanup(mockBinance.Close)
+	t.Setenv("BINANCE_SPOT_WS_URL", mockBinance.URL)
+
+	setupMockCoinGecko(t)
+
+	router := gin.New()
+	router.GET("/stream", StreamSocket)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/stream"
+}
+
+func dialStream(t *testing.T, url string, header http.Header) *websocket.Conn {
+	c, _, err := websocket.DefaultDialer.Dial(url, header)
+	assert.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	c.SetReadDeadline(time.Now().Add(6 * time.Second))
+	return c
+}
+
+func readAck(t *testing.T, c *websocket.Conn) models.StreamAck {
+	var ack models.StreamAck
+	assert.NoError(t, c.ReadJSON(&ack))
+	return ack
+}
+
+func readStreamMessage(t *testing.T, c *websocket.Conn) map[string]interface{} {
+	var message map[string]interface{}
+	assert.NoError(t, c.ReadJSON(&message))
+	return message
+}
+
+func TestStreamSocket(t *testing.T) {
+	url := setupStreamTest(t)
+
+	t.Run("Subscribe and unsubscribe", func(t *testing.T) {
+		c := dialStream(t, url, nil)
+
+		assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelSpot, Symbols: []string{"btcusdt"}}))
+		assert.Equal(t, models.StreamAck{Op: OpSubscribe, Channel: ChannelSpot, Symbols: []string{"BTCUSDT"}, Success: true}, readAck(t, c))
+
+		message := readStreamMessage(t, c)
+		assert.Equal(t, ChannelSpot, message["channel"])
+		assert.Equal(t, "BTCUSDT", message["symbol"])
+		assert.Equal(t, "30000.00", message["data"].(map[string]interface{})["price"])
+
+		assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpUnsubscribe, Channel: ChannelSpot, Symbols: []string{"BTCUSDT"}}))
+		assert.Equal(t, models.StreamAck{Op: OpUnsubscribe, Channel: ChannelSpot, Symbols: []string{"BTCUSDT"}, Success: true}, readAck(t, c))
+	})
+
+	t.Run("Ticker channel", func(t *testing.T) {
+		c := dialStream(t, url, nil)
+
+		assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelTicker, Symbols: []string{"BTCUSDT"}}))
+		assert.True(t, readAck(t, c).Success)
+
+		message := readStreamMessage(t, c)
+		assert.Equal(t, ChannelTicker, message["channel"])
+		data := message["data"].(map[string]interface{})
+		assert.Equal(t, "spot", data["market"])
+		assert.Equal(t, "-0.499", data["priceChangePercent"])
+		assert.Equal(t, "45700000.12", data["quoteVolume"])
+	})
+
+	t.Run("Market cap channel", func(t *testing.T) {
+		c := dialStream(t, url, nil)
+
+		assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelMarketCap, Symbols: []string{"Bitcoin", "nocoin"}}))
+		assert.True(t, readAck(t, c).Success)
+
+		messages := map[string]map[string]interface{}{}
+		for i := 0; i < 2; i++ {
+			message := readStreamMessage(t, c)
+			messages[message["symbol"].(string)] = message
+		}
+		assert.Equal(t, "btc", messages["bitcoin"]["data"].(map[string]interface{})["symbol"])
+		assert.Equal(t, "Symbol missing or invalid", messages["nocoin"]["error"])
+	})
+
+	t.Run("Rejected requests", func(t *testing.T) {
+		c := dialStream(t, url, nil)
+
+		tests := []struct {
+			name     string
+			request  string
+			expected string
+		}{
+			{"Invalid JSON", `{"op":`, "Invalid request"},
+			{"Unknown op", `{"op":"list","channel":"spot","symbols":["BTCUSDT"]}`, `Unknown op "list"`},
+			{"Unknown channel", `{"op":"subscribe","channel":"options","symbols":["BTCUSDT"]}`, `Unknown channel "options"`},
+			{"Missing symbols", `{"op":"subscribe","channel":"spot"}`, "Missing symbols"},
+			{"Kline without VIP", `{"op":"subscribe","channel":"kline","symbols":["BTCUSDT"]}`, "Channel kline requires VIP-1 or above"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.NoError(t, c.WriteMessage(websocket.TextMessage, []byte(tt.request)))
+				ack := readAck(t, c)
+				assert.False(t, ack.Success)
+				assert.Equal(t, tt.expected, ack.Error)
+			})
+		}
+	})
+
+	t.Run("Kline with VIP token", func(t *testing.T) {
+		t.Setenv("JWT_SECRET", "stream-test-secret")
+		t.Setenv("JWT_TOKEN_TTL", "60")
+		token, err := middlewares.GenerateToken("user-1", "VIP-1")
+		assert.NoError(t, err)
+
+		c := dialStream(t, url, http.Header{"Authorization": []string{token}})
+		assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelKline, Symbols: []string{"BTCUSDT"}}))
+		assert.True(t, readAck(t, c).Success)
+	})
+
+	t.Run("Too many subscriptions", func(t *testing.T) {
+		c := dialStream(t, url, nil)
+
+		symbols := make([]string, maxStreamSubscriptions+1)
+		for i := range symbols {
+			symbols[i] = strings.Repeat("X", i+1) + "USDT"
+		}
+		assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelSpot, Symbols: symbols}))
+		ack := readAck(t, c)
+		assert.False(t, ack.Success)
+		assert.Equal(t, "At most 50 subscriptions per connection", ack.Error)
+	})
+
+	t.Run("Invalid symbol", func(t *testing.T) {
+		c := dialStream(t, url, nil)
+		assert.NoError(t, c.WriteJSON(models.StreamRequest{Op: OpSubscribe, Channel: ChannelSpot, Symbols: []string{"INVALIDUSDT"}}))
+		assert.True(t, readAck(t, c).Success)
+
+		message := readStreamMessage(t, c)
+		assert.Equal(t, "INVALIDUSDT", message["symbol"])
+		assert.Equal(t, "Symbol error", message["error"])
+	})
+}
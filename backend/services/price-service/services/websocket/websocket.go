@@ -2,11 +2,22 @@ package websocket
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+const (
+	// pongWait is how long a connection may stay silent, pings and pongs included, before it is dropped
+	pongWait = 60 * time.Second
+	// pingPeriod must be shorter than pongWait
+	pingPeriod = 25 * time.Second
+	// writeWait is how long a write to a connection may block
+	writeWait = 10 * time.Second
+)
+
 // Set up websocket
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
@@ -24,3 +35,36 @@ func Upgrade(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
 
 	return ws, nil
 }
+
+// keepAlive pings conn every pingPeriod until done is closed and fails its reads when nothing,
+// not even a ping or a pong, arrived for pongWait. The reader should push the read deadline
+// forward after every message.
+func keepAlive(conn *websocket.Conn, done <-chan struct{}) {
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	conn.SetPingHandler(func(appData string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		err := conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(writeWait))
+		if netErr, ok := err.(net.Error); err == websocket.ErrCloseSent || (ok && netErr.Timeout()) {
+			return nil
+		}
+		return err
+	})
+
+	go func() {
+		ticker := time.NewTicker(pingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+const timestampLayout = "2006-01-02 15:04:05"
+
+// ConvertMillisecondsToTimestamp formats a unix time in milliseconds as "2006-01-02 15:04:05"
+func ConvertMillisecondsToTimestamp(milliseconds int64) string {
+	return time.UnixMilli(milliseconds).Format(timestampLayout)
+}
+
+// ConvertMillisecondsToHHMMSS formats a duration in milliseconds as "HH:MM:SS"
+func ConvertMillisecondsToHHMMSS(milliseconds int64) string {
+	if milliseconds < 0 {
+		milliseconds = 0
+	}
+	totalSeconds := milliseconds / 1000
+	hours := totalSeconds / 3600
+	minutes := (totalSeconds % 3600) / 60
+	seconds := totalSeconds % 60
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
+
+// ConvertMilisecondToTimeFormatedRFC3339 formats a unix time in milliseconds as RFC3339 in UTC
+func ConvertMilisecondToTimeFormatedRFC3339(milliseconds int64) string {
+	return time.UnixMilli(milliseconds).UTC().Format(time.RFC3339)
+}
+
+// GetTimeNow returns the current time formatted as "2006-01-02 15:04:05"
+func GetTimeNow() string {
+	return time.Now().Format(timestampLayout)
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryMilliseconds reads an optional unix milliseconds parameter, 0 when it is missing.
+// It answers 400 and returns false when the parameter is not a positive integer.
+func QueryMilliseconds(context *gin.Context, key string) (int64, bool) {
+	if context.Query(key) == "" {
+		return 0, true
+	}
+	milliseconds, err := strconv.ParseInt(context.Query(key), 10, 64)
+	if err != nil || milliseconds <= 0 {
+		ShowError(http.StatusBadRequest, "Invalid "+key, context)
+		return 0, false
+	}
+	return milliseconds, true
+}
+
+// QueryInt reads an optional integer parameter from min to max, fallback when it is missing.
+// It answers 400 and returns false when the parameter is out of range.
+func QueryInt(context *gin.Context, key string, fallback, min, max int) (int, bool) {
+	if context.Query(key) == "" {
+		return fallback, true
+	}
+	value, err := strconv.Atoi(context.Query(key))
+	if err != nil || value < min || value > max {
+		ShowError(http.StatusBadRequest, fmt.Sprintf("%s must be between %d and %d", key, min, max), context)
+		return 0, false
+	}
+	return value, true
+}
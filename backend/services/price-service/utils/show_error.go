@@ -2,7 +2,9 @@ package utils
 
 import (
 	"fmt"
+	"net/http"
 
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
@@ -17,3 +19,11 @@ func ShowErrorSocket(ws *websocket.Conn, message string) {
 	`, message)
 	ws.WriteMessage(websocket.TextMessage, []byte(errorMsg))
 }
+
+// ResponseStatusCode keeps 400 and 404 from an upstream API and turns every other failure into 500
+func ResponseStatusCode(statusCode models.StatusCode) int {
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusNotFound {
+		return int(statusCode)
+	}
+	return http.StatusInternalServerError
+}
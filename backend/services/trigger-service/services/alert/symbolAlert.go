@@ -1,46 +1,31 @@
 package services
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
-	"net/http"
 	"strconv"
 	"time"
 
-	models "github.com/dath-241/coin-price-be-go/services/trigger-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/models"
+	"github.com/dath-241/coin-price-be-go/services/price-service/services/provider"
 )
 
 // fetchSymbolsFromBinance fetches symbols from Binance's API
 func FetchSymbolsFromBinance() ([]string, []string, error) {
-	url := "https://api.binance.com/api/v3/exchangeInfo"
-	resp, err := http.Get(url)
+	symbols, _, err := provider.Default().ExchangeInfo()
 	if err != nil {
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("binance API returned status %d", resp.StatusCode)
-	}
-
-	var data struct {
-		Symbols []models.Symbol `json:"symbols"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, nil, err
-	}
 
 	newSymbols := []string{}
-	for _, s := range data.Symbols {
+	for _, s := range symbols {
 		if s.Status == "TRADING" {
 			newSymbols = append(newSymbols, s.Symbol)
 		}
 	}
 
 	delistedSymbols := []string{}
-	for _, s := range data.Symbols {
+	for _, s := range symbols {
 		if s.Status != "TRADING" {
 			delistedSymbols = append(delistedSymbols, s.Symbol)
 		}
@@ -49,40 +34,12 @@ func FetchSymbolsFromBinance() ([]string, []string, error) {
 	return newSymbols, delistedSymbols, nil
 }
 
-type PriceResponse struct {
-	Price string `json:"price"`
-}
-
-type FundingRateResponse struct {
-	FundingRate string `json:"fundingRate"`
-	FundingTime int64  `json:"fundingTime"`
-}
-
-type FuturePriceResponse struct {
-	LastPrice string `json:"lastPrice"`
-}
-
-
-type FundingInfoResponse struct {
-    Symbol                  string  `json:"symbol"`
-    AdjustedFundingRateCap  string  `json:"adjustedFundingRateCap"`
-    AdjustedFundingRateFloor string `json:"adjustedFundingRateFloor"`
-    FundingIntervalHours    int     `json:"fundingIntervalHours"`
-    Disclaimer              bool    `json:"disclaimer"` // This field will be ignored
-}
 // Hàm lấy giá Spot
 func GetSpotPrice(symbol string) (float64, error) {
-	url := fmt.Sprintf("https://api.binance.com/api/v3/ticker/price?symbol=%s", symbol)
-	resp, err := http.Get(url)
+	result, _, err := provider.Default().SpotTicker(symbol)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	var result PriceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, err
-	}
 
 	price, err := strconv.ParseFloat(result.Price, 64)
 	if err != nil {
@@ -95,23 +52,16 @@ func GetSpotPrice(symbol string) (float64, error) {
 
 // Hàm lấy Funding Rate
 func GetFundingRate(symbol string) (float64, error) {
-	url := fmt.Sprintf("https://fapi.binance.com/fapi/v1/fundingRate?symbol=%s&limit=1", symbol)
-	resp, err := http.Get(url)
+	results, _, err := provider.Default().FundingRateHistory(models.FundingRateQuery{Symbol: symbol, Limit: 1})
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	var results []FundingRateResponse
-	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-		return 0, err
-	}
 
 	if len(results) == 0 {
 		return 0, fmt.Errorf("no funding rate data available")
 	}
 
-	fundingRate, err := strconv.ParseFloat(results[0].FundingRate, 64)
+	fundingRate, err := strconv.ParseFloat(results[len(results)-1].FundingRate, 64)
 	if err != nil {
 		return 0, fmt.Errorf("failed to parse funding rate: %v", err)
 	}
@@ -122,19 +72,12 @@ func GetFundingRate(symbol string) (float64, error) {
 
 // Hàm lấy giá Future
 func GetFuturePrice(symbol string) (float64, error) {
-	url := fmt.Sprintf("https://fapi.binance.com/fapi/v1/ticker/24hr?symbol=%s", symbol)
-	resp, err := http.Get(url)
+	result, _, err := provider.Default().FuturesTicker(symbol)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	var result FuturePriceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, err
-	}
 
-	price, err := strconv.ParseFloat(result.LastPrice, 64)
+	price, err := strconv.ParseFloat(result.Price, 64)
 	if err != nil {
 		return 0, fmt.Errorf("failed to parse price: %v", err)
 	}
@@ -144,29 +87,22 @@ func GetFuturePrice(symbol string) (float64, error) {
 }
 
 func GetFundingRateInterval(symbol string) (string, error) {
-    url := fmt.Sprintf("https://fapi.binance.com/fapi/v1/fundingInfo?symbol=%s", symbol)
-    resp, err := http.Get(url)
-    if err != nil {
-        return "", err
-    }
-    defer resp.Body.Close()
-
-    var results []FundingInfoResponse
-    if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-        return "", err
-    }
-
-    for _, result := range results {
-        if result.Symbol == symbol {
-            fundingIntervalHours := result.FundingIntervalHours
-            intervalDuration := time.Duration(fundingIntervalHours) * time.Hour
-            interval := fmt.Sprintf("%v", intervalDuration)
-            log.Println("Funding rate interval:", interval)
-            return interval, nil
-        }
-    }
-
-    return "", fmt.Errorf("symbol %s not found in funding info response", symbol)
+	results, _, err := provider.Default().FundingInfo()
+	if err != nil {
+		return "", err
+	}
+
+	for _, result := range results {
+		if result.Symbol == symbol {
+			fundingIntervalHours := result.FundingIntervalHours
+			intervalDuration := time.Duration(fundingIntervalHours) * time.Hour
+			interval := fmt.Sprintf("%v", intervalDuration)
+			log.Println("Funding rate interval:", interval)
+			return interval, nil
+		}
+	}
+
+	return "", fmt.Errorf("symbol %s not found in funding info response", symbol)
 }
 
 func GetPriceDifference(symbol string) (float64, error) {